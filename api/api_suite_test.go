@@ -59,6 +59,7 @@ var (
 	drain                         chan struct{}
 	expire                        time.Duration
 	isTLSEnabled                  bool
+	logSearchEnabled              bool
 	cliDownloadsDir               string
 	logger                        *lagertest.TestLogger
 
@@ -140,6 +141,7 @@ var _ = BeforeEach(func() {
 	expire = 24 * time.Hour
 
 	isTLSEnabled = false
+	logSearchEnabled = true
 
 	build = new(dbfakes.FakeBuild)
 
@@ -194,6 +196,8 @@ var _ = BeforeEach(func() {
 
 		isTLSEnabled,
 
+		logSearchEnabled,
+
 		cliDownloadsDir,
 		"1.2.3",
 		"4.5.6",
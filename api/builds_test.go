@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"sync"
@@ -19,14 +20,18 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
 )
 
 var _ = Describe("Builds API", func() {
 	Describe("POST /api/v1/builds", func() {
 		var plan atc.Plan
+		var query string
 		var response *http.Response
 
 		BeforeEach(func() {
+			query = ""
 			plan = atc.Plan{
 				Task: &atc.TaskPlan{
 					Config: &atc.TaskConfig{
@@ -42,7 +47,7 @@ var _ = Describe("Builds API", func() {
 			reqPayload, err := json.Marshal(plan)
 			Expect(err).NotTo(HaveOccurred())
 
-			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds", bytes.NewBuffer(reqPayload))
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds"+query, bytes.NewBuffer(reqPayload))
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -132,6 +137,37 @@ var _ = Describe("Builds API", func() {
 
 						<-resumed
 					})
+
+					It("does not set a hold on failure", func() {
+						Expect(build.SetHoldContainersOnFailureCallCount()).To(BeZero())
+					})
+
+					Context("when holding containers on failure", func() {
+						BeforeEach(func() {
+							query = "?hold_containers_on_failure=2h"
+						})
+
+						It("sets the hold on the build before running it", func() {
+							Expect(build.SetHoldContainersOnFailureCallCount()).To(Equal(1))
+							Expect(build.SetHoldContainersOnFailureArgsForCall(0)).To(Equal(2 * time.Hour))
+
+							<-resumed
+						})
+					})
+				})
+
+				Context("when the hold on failure is invalid", func() {
+					BeforeEach(func() {
+						query = "?hold_containers_on_failure=48h"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not create a build", func() {
+						Expect(dbTeam.CreateOneOffBuildCallCount()).To(BeZero())
+					})
 				})
 
 				Context("and building fails", func() {
@@ -299,6 +335,129 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 					})
+
+					Context("when the build has metadata", func() {
+						BeforeEach(func() {
+							build.MetadataReturns(db.ResourceMetadataFields{
+								{Name: "url", Value: "https://example.com"},
+							})
+						})
+
+						Context("when authorized for another team", func() {
+							var fakeJob *dbfakes.FakeJob
+
+							presentedMetadata := func() []atc.MetadataField {
+								var presented atc.Build
+								err := json.NewDecoder(response.Body).Decode(&presented)
+								Expect(err).NotTo(HaveOccurred())
+								return presented.Metadata
+							}
+
+							BeforeEach(func() {
+								userContextReader.GetTeamReturns("some-other-team", false, true)
+
+								fakeJob = new(dbfakes.FakeJob)
+								fakePipeline.PublicReturns(true)
+								fakePipeline.JobReturns(fakeJob, true, nil)
+								build.PipelineReturns(fakePipeline, true, nil)
+							})
+
+							Context("and the job is private", func() {
+								BeforeEach(func() {
+									fakeJob.ConfigReturns(atc.JobConfig{Name: "job1", Public: false})
+								})
+
+								It("does not present the metadata", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(presentedMetadata()).To(BeEmpty())
+								})
+							})
+
+							Context("and the job is public", func() {
+								BeforeEach(func() {
+									fakeJob.ConfigReturns(atc.JobConfig{Name: "job1", Public: true})
+								})
+
+								It("presents the metadata", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(presentedMetadata()).To(Equal([]atc.MetadataField{
+										{Name: "url", Value: "https://example.com"},
+									}))
+								})
+							})
+
+							Context("and looking up the job fails", func() {
+								BeforeEach(func() {
+									fakePipeline.JobReturns(nil, false, errors.New("disaster"))
+								})
+
+								It("returns 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+							})
+						})
+
+						Context("when authorized for the build's team", func() {
+							BeforeEach(func() {
+								userContextReader.GetTeamReturns("some-team", false, true)
+							})
+
+							It("presents the metadata", func() {
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(body).To(MatchJSON(`{
+								"id": 1,
+								"name": "1",
+								"status": "succeeded",
+								"job_name": "job1",
+								"pipeline_name": "pipeline1",
+								"team_name": "some-team",
+								"url": "/teams/some-team/pipelines/pipeline1/jobs/job1/builds/1",
+								"api_url": "/api/v1/builds/1",
+								"start_time": 1,
+								"end_time": 100,
+								"reap_time": 200,
+								"metadata": [
+									{"name": "url", "value": "https://example.com"}
+								]
+							}`))
+							})
+						})
+					})
+
+					Context("when the build is awaiting approval", func() {
+						BeforeEach(func() {
+							build.StatusReturns(db.BuildStatusPending)
+							build.EndTimeReturns(time.Time{})
+							build.ReapTimeReturns(time.Time{})
+							build.ApprovalStatusReturns(atc.ApprovalStatusAwaiting)
+							build.ApproversReturns([]string{"some-approvers"})
+							build.ApprovalDeadlineReturns(time.Unix(300, 0))
+						})
+
+						It("presents the build as awaiting approval", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+							"id": 1,
+							"name": "1",
+							"status": "awaiting_approval",
+							"job_name": "job1",
+							"pipeline_name": "pipeline1",
+							"team_name": "some-team",
+							"url": "/teams/some-team/pipelines/pipeline1/jobs/job1/builds/1",
+							"api_url": "/api/v1/builds/1",
+							"start_time": 1,
+							"approval": {
+								"status": "awaiting",
+								"approvers": ["some-approvers"],
+								"deadline": 300
+							}
+						}`))
+						})
+					})
 				})
 			})
 		})
@@ -1127,212 +1286,899 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
-	Describe("GET /api/v1/builds/:build_id/preparation", func() {
-		var response *http.Response
+	Describe("PUT /api/v1/builds/:build_id/hold-containers", func() {
+		var (
+			duration string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			duration = "1h"
+		})
 
 		JustBeforeEach(func() {
-			var err error
-			response, err = http.Get(server.URL + "/api/v1/builds/42/preparation")
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/hold-containers?duration="+duration, nil)
 			Expect(err).NotTo(HaveOccurred())
-		})
 
-		Context("when the build is found", func() {
-			var buildPrep db.BuildPreparation
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
 
+		Context("when authenticated", func() {
 			BeforeEach(func() {
-				buildPrep = db.BuildPreparation{
-					BuildID:          42,
-					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
-					PausedJob:        db.BuildPreparationStatusNotBlocking,
-					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
-					Inputs: map[string]db.BuildPreparationStatus{
-						"foo": db.BuildPreparationStatusUnknown,
-						"bar": db.BuildPreparationStatusBlocking,
-					},
-					InputsSatisfied:     db.BuildPreparationStatusBlocking,
-					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
-				}
-				dbBuildFactory.BuildReturns(build, true, nil)
-				build.JobNameReturns("job1")
+				jwtValidator.IsAuthenticatedReturns(true)
+				build.IDReturns(128)
+				build.NameReturns("1")
 				build.TeamNameReturns("some-team")
-				build.PreparationReturns(buildPrep, true, nil)
-			})
-
-			Context("when authenticated, but not authorized", func() {
-				BeforeEach(func() {
-					jwtValidator.IsAuthenticatedReturns(true)
-					build.PipelineReturns(fakePipeline, true, nil)
-					userContextReader.GetTeamReturns("some-other-team", false, true)
-				})
-
-				It("returns 403", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
-				})
+				dbBuildFactory.BuildReturns(build, true, nil)
 			})
 
-			Context("when not authenticated", func() {
+			Context("when accessing same team's build", func() {
 				BeforeEach(func() {
-					jwtValidator.IsAuthenticatedReturns(false)
+					userContextReader.GetTeamReturns("some-team", false, true)
 				})
 
-				Context("and build is one off", func() {
+				Context("when the containers are held", func() {
 					BeforeEach(func() {
-						build.PipelineReturns(nil, false, nil)
+						build.HoldContainersReturns(true, nil)
+						build.ContainersHeldUntilReturns(time.Unix(3600, 0))
 					})
 
-					It("returns 401", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					It("holds them for the given duration", func() {
+						Expect(build.HoldContainersCallCount()).To(Equal(1))
+						Expect(build.HoldContainersArgsForCall(0)).To(Equal(time.Hour))
 					})
-				})
 
-				Context("and the pipeline is private", func() {
-					BeforeEach(func() {
-						build.PipelineReturns(fakePipeline, true, nil)
-						fakePipeline.PublicReturns(false)
-					})
+					It("returns the build with when the hold expires", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
 
-					It("returns 401", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						var presented atc.Build
+						Expect(json.NewDecoder(response.Body).Decode(&presented)).To(Succeed())
+						Expect(presented.ContainersHeldUntil).To(Equal(int64(3600)))
 					})
 				})
 
-				Context("and the pipeline is public", func() {
+				Context("when the team's held containers quota is reached", func() {
 					BeforeEach(func() {
-						build.PipelineReturns(fakePipeline, true, nil)
-						fakePipeline.PublicReturns(true)
+						build.HoldContainersReturns(false, nil)
 					})
 
-					Context("when the job is found", func() {
-						var fakeJob *dbfakes.FakeJob
-						BeforeEach(func() {
-							fakeJob = new(dbfakes.FakeJob)
-							fakePipeline.JobReturns(fakeJob, true, nil)
-						})
-
-						Context("when job is private", func() {
-							BeforeEach(func() {
-								fakeJob.ConfigReturns(atc.JobConfig{
-									Name:   "job1",
-									Public: false,
-								})
-							})
-
-							It("returns 401", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
-							})
-						})
-
-						Context("when job is public", func() {
-							BeforeEach(func() {
-								fakeJob.ConfigReturns(atc.JobConfig{
-									Name:   "job1",
-									Public: true,
-								})
-							})
-
-							It("returns 200", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusOK))
-							})
-						})
+					It("returns 409 Conflict", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
 					})
+				})
 
-					Context("when finding the job fails", func() {
-						BeforeEach(func() {
-							fakePipeline.JobReturns(nil, false, errors.New("nope"))
-						})
-
-						It("returns Internal Server Error", func() {
-							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
-						})
+				Context("when holding the containers fails", func() {
+					BeforeEach(func() {
+						build.HoldContainersReturns(false, errors.New("nope"))
 					})
 
-					Context("when the job cannot be found", func() {
-						BeforeEach(func() {
-							fakePipeline.JobReturns(nil, false, nil)
-						})
-
-						It("returns Not Found", func() {
-							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
-						})
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
-			})
-
-			Context("when authenticated", func() {
-				BeforeEach(func() {
-					jwtValidator.IsAuthenticatedReturns(true)
-					userContextReader.GetTeamReturns("some-team", false, true)
-				})
-
-				It("fetches data from the db", func() {
-					Expect(build.PreparationCallCount()).To(Equal(1))
-				})
-
-				It("returns OK", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusOK))
-				})
-
-				It("returns the build preparation", func() {
-					body, err := ioutil.ReadAll(response.Body)
-					Expect(err).NotTo(HaveOccurred())
-
-					Expect(body).To(MatchJSON(`{
-					"build_id": 42,
-					"paused_pipeline": "not_blocking",
-					"paused_job": "not_blocking",
-					"max_running_builds": "blocking",
-					"inputs": {
-						"foo": "unknown",
-						"bar": "blocking"
-					},
-					"inputs_satisfied": "blocking",
-					"missing_input_reasons": {
-						"some-input": "some-reason"
-					}
-				}`))
-				})
 
-				Context("when the build preparation is not found", func() {
+				Context("when the duration is longer than the maximum hold", func() {
 					BeforeEach(func() {
-						dbBuildFactory.BuildReturns(build, true, nil)
-						build.PreparationReturns(db.BuildPreparation{}, false, nil)
+						duration = "25h"
 					})
 
-					It("returns Not Found", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(build.HoldContainersCallCount()).To(BeZero())
 					})
 				})
 
-				Context("when looking up the build preparation fails", func() {
+				Context("when the duration is malformed", func() {
 					BeforeEach(func() {
-						dbBuildFactory.BuildReturns(build, true, nil)
-						build.PreparationReturns(db.BuildPreparation{}, false, errors.New("ho ho ho merry festivus"))
+						duration = "forever"
 					})
 
-					It("returns 500 Internal Server Error", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
 					})
 				})
 			})
+
+			Context("when accessing other team's build", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("some-other-team", false, true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(build.HoldContainersCallCount()).To(BeZero())
+				})
+			})
 		})
 
-		Context("when looking up the build fails", func() {
+		Context("when not authenticated", func() {
 			BeforeEach(func() {
-				dbBuildFactory.BuildReturns(nil, false, errors.New("ho ho ho merry festivus"))
+				jwtValidator.IsAuthenticatedReturns(false)
 			})
 
-			It("returns 500 Internal Server Error", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 			})
 		})
+	})
 
-		Context("when build is not found", func() {
-			BeforeEach(func() {
-				dbBuildFactory.BuildReturns(nil, false, nil)
-			})
+	Describe("PUT /api/v1/builds/:build_id/approve", func() {
+		var (
+			body     io.Reader
+			response *http.Response
+		)
 
-			It("returns 404", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+		BeforeEach(func() {
+			body = bytes.NewBufferString(`{"comment":"ship it"}`)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/approve", body)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not look up the build", func() {
+				Expect(dbBuildFactory.BuildCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the build cannot be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when looking up the build fails", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				It("looks up the requested build", func() {
+					Expect(dbBuildFactory.BuildArgsForCall(0)).To(Equal(128))
+				})
+
+				Context("when the build has no approvers", func() {
+					Context("when the team owns the build", func() {
+						BeforeEach(func() {
+							build.ResolveApprovalReturns(true, nil)
+						})
+
+						It("approves the build on behalf of the team with the comment", func() {
+							Expect(build.ResolveApprovalCallCount()).To(Equal(1))
+							status, approver, comment := build.ResolveApprovalArgsForCall(0)
+							Expect(status).To(Equal(atc.ApprovalStatusApproved))
+							Expect(approver).To(Equal("some-team"))
+							Expect(comment).To(Equal("ship it"))
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+					})
+
+					Context("when the team does not own the build", func() {
+						BeforeEach(func() {
+							build.TeamNameReturns("some-other-team")
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							Expect(build.ResolveApprovalCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the build has approvers", func() {
+					BeforeEach(func() {
+						build.ApproversReturns([]string{"release-managers"})
+					})
+
+					Context("when the team is not an approver", func() {
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							Expect(build.ResolveApprovalCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the team is an approver", func() {
+						BeforeEach(func() {
+							userContextReader.GetTeamReturns("release-managers", false, true)
+							build.ResolveApprovalReturns(true, nil)
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+					})
+				})
+
+				Context("when no comment is given", func() {
+					BeforeEach(func() {
+						body = nil
+						build.ResolveApprovalReturns(true, nil)
+					})
+
+					It("approves the build without a comment", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						_, _, comment := build.ResolveApprovalArgsForCall(0)
+						Expect(comment).To(BeEmpty())
+					})
+				})
+
+				Context("when the request body is malformed", func() {
+					BeforeEach(func() {
+						body = bytes.NewBufferString(`{`)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the build is not awaiting approval", func() {
+					BeforeEach(func() {
+						build.ResolveApprovalReturns(false, nil)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when resolving the approval fails", func() {
+					BeforeEach(func() {
+						build.ResolveApprovalReturns(false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/reject", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/reject", bytes.NewBufferString(`{"comment":"not today"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as the owning team", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+				build.TeamNameReturns("some-team")
+				build.ResolveApprovalReturns(true, nil)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			It("rejects the build", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(build.ResolveApprovalCallCount()).To(Equal(1))
+				status, approver, comment := build.ResolveApprovalArgsForCall(0)
+				Expect(status).To(Equal(atc.ApprovalStatusRejected))
+				Expect(approver).To(Equal("some-team"))
+				Expect(comment).To(Equal("not today"))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/preparation", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/preparation")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			var buildPrep db.BuildPreparation
+
+			BeforeEach(func() {
+				buildPrep = db.BuildPreparation{
+					BuildID:          42,
+					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
+					PausedJob:        db.BuildPreparationStatusNotBlocking,
+					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
+					TeamQuota:        db.BuildPreparationStatusNotBlocking,
+					Approval:         db.BuildPreparationStatusBlocking,
+					Inputs: map[string]db.BuildPreparationStatus{
+						"foo": db.BuildPreparationStatusUnknown,
+						"bar": db.BuildPreparationStatusBlocking,
+					},
+					InputsSatisfied:     db.BuildPreparationStatusBlocking,
+					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+				}
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.PreparationReturns(buildPrep, true, nil)
+			})
+
+			Context("when authenticated, but not authorized", func() {
+				BeforeEach(func() {
+					jwtValidator.IsAuthenticatedReturns(true)
+					build.PipelineReturns(fakePipeline, true, nil)
+					userContextReader.GetTeamReturns("some-other-team", false, true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					jwtValidator.IsAuthenticatedReturns(false)
+				})
+
+				Context("and build is one off", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(nil, false, nil)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				Context("and the pipeline is private", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(fakePipeline, true, nil)
+						fakePipeline.PublicReturns(false)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				Context("and the pipeline is public", func() {
+					BeforeEach(func() {
+						build.PipelineReturns(fakePipeline, true, nil)
+						fakePipeline.PublicReturns(true)
+					})
+
+					Context("when the job is found", func() {
+						var fakeJob *dbfakes.FakeJob
+						BeforeEach(func() {
+							fakeJob = new(dbfakes.FakeJob)
+							fakePipeline.JobReturns(fakeJob, true, nil)
+						})
+
+						Context("when job is private", func() {
+							BeforeEach(func() {
+								fakeJob.ConfigReturns(atc.JobConfig{
+									Name:   "job1",
+									Public: false,
+								})
+							})
+
+							It("returns 401", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+							})
+						})
+
+						Context("when job is public", func() {
+							BeforeEach(func() {
+								fakeJob.ConfigReturns(atc.JobConfig{
+									Name:   "job1",
+									Public: true,
+								})
+							})
+
+							It("returns 200", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+							})
+						})
+					})
+
+					Context("when finding the job fails", func() {
+						BeforeEach(func() {
+							fakePipeline.JobReturns(nil, false, errors.New("nope"))
+						})
+
+						It("returns Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the job cannot be found", func() {
+						BeforeEach(func() {
+							fakePipeline.JobReturns(nil, false, nil)
+						})
+
+						It("returns Not Found", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					jwtValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", false, true)
+				})
+
+				It("fetches data from the db", func() {
+					Expect(build.PreparationCallCount()).To(Equal(1))
+				})
+
+				It("returns OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the build preparation", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+					"build_id": 42,
+					"paused_pipeline": "not_blocking",
+					"paused_job": "not_blocking",
+					"max_running_builds": "blocking",
+					"team_quota": "not_blocking",
+					"approval": "blocking",
+					"inputs": {
+						"foo": "unknown",
+						"bar": "blocking"
+					},
+					"inputs_satisfied": "blocking",
+					"missing_input_reasons": {
+						"some-input": "some-reason"
+					}
+				}`))
+				})
+
+				Context("when the build preparation is not found", func() {
+					BeforeEach(func() {
+						dbBuildFactory.BuildReturns(build, true, nil)
+						build.PreparationReturns(db.BuildPreparation{}, false, nil)
+					})
+
+					It("returns Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when looking up the build preparation fails", func() {
+					BeforeEach(func() {
+						dbBuildFactory.BuildReturns(build, true, nil)
+						build.PreparationReturns(db.BuildPreparation{}, false, errors.New("ho ho ho merry festivus"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when looking up the build fails", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, errors.New("ho ho ho merry festivus"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/artifacts", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/artifacts")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the artifacts are found", func() {
+				BeforeEach(func() {
+					build.ArtifactsReturns([]db.BuildArtifact{
+						{
+							Name:         "some-artifact",
+							CreatedAt:    time.Unix(1, 0),
+							WorkerName:   "some-worker",
+							VolumeHandle: "some-handle",
+						},
+						{
+							Name:         "some-other-artifact",
+							CreatedAt:    time.Unix(2, 0),
+							WorkerName:   "some-worker",
+							VolumeHandle: "some-other-handle",
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the artifacts", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{"name": "some-artifact", "created_at": 1},
+						{"name": "some-other-artifact", "created_at": 2}
+					]`))
+				})
+			})
+
+			Context("when looking up the artifacts fails", func() {
+				BeforeEach(func() {
+					build.ArtifactsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated and the build is one off", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.PipelineReturns(nil, false, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/artifacts/:artifact_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/artifacts/some-artifact")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the artifact is found", func() {
+				var fakeWorker *workerfakes.FakeWorker
+
+				BeforeEach(func() {
+					build.ArtifactReturns(db.BuildArtifact{
+						Name:         "some-artifact",
+						CreatedAt:    time.Unix(1, 0),
+						WorkerName:   "some-worker",
+						VolumeHandle: "some-handle",
+					}, true, nil)
+
+					fakeWorker = new(workerfakes.FakeWorker)
+					fakeWorker.NameReturns("some-worker")
+
+					otherWorker := new(workerfakes.FakeWorker)
+					otherWorker.NameReturns("some-other-worker")
+
+					fakeWorkerClient.RunningWorkersReturns([]worker.Worker{otherWorker, fakeWorker}, nil)
+				})
+
+				It("looks up the artifact by name", func() {
+					Expect(build.ArtifactCallCount()).To(Equal(1))
+					Expect(build.ArtifactArgsForCall(0)).To(Equal("some-artifact"))
+				})
+
+				Context("when the volume is found", func() {
+					var fakeVolume *workerfakes.FakeVolume
+
+					BeforeEach(func() {
+						fakeVolume = new(workerfakes.FakeVolume)
+						fakeVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewBufferString("some-tar")), nil)
+						fakeWorker.LookupVolumeReturns(fakeVolume, true, nil)
+					})
+
+					It("streams out the volume as a tarball", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/x-tar"))
+						Expect(response.Header.Get("Content-Disposition")).To(Equal("attachment; filename=some-artifact.tar"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("some-tar"))
+
+						_, handle := fakeWorker.LookupVolumeArgsForCall(0)
+						Expect(handle).To(Equal("some-handle"))
+						Expect(fakeVolume.StreamOutArgsForCall(0)).To(Equal("."))
+					})
+				})
+
+				Context("when the volume is not found", func() {
+					BeforeEach(func() {
+						fakeWorker.LookupVolumeReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the worker is not running", func() {
+					BeforeEach(func() {
+						fakeWorkerClient.RunningWorkersReturns([]worker.Worker{}, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+
+			Context("when the artifact is not found", func() {
+				BeforeEach(func() {
+					build.ArtifactReturns(db.BuildArtifact{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when looking up the artifact fails", func() {
+				BeforeEach(func() {
+					build.ArtifactReturns(db.BuildArtifact{}, false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/test-reports", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/test-reports")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the test reports are found", func() {
+				BeforeEach(func() {
+					build.TestReportsReturns([]db.TestReport{
+						{
+							StepName: "unit",
+							Total:    3,
+							Passed:   1,
+							Failed:   1,
+							Skipped:  1,
+							Failures: []string{"TestFails"},
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the test reports", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"step_name": "unit",
+							"total": 3,
+							"passed": 1,
+							"failed": 1,
+							"skipped": 1,
+							"failures": ["TestFails"]
+						}
+					]`))
+				})
+			})
+
+			Context("when looking up the test reports fails", func() {
+				BeforeEach(func() {
+					build.TestReportsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/builds/log-search", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = "q=panic&pipeline_name=some-pipeline&job_name=some-job&since=1500000000&limit=1000&context=1"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/teams/some-team/builds/log-search?" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not search", func() {
+				Expect(dbTeam.SearchBuildLogsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the search succeeds", func() {
+				BeforeEach(func() {
+					dbTeam.SearchBuildLogsReturns([]db.BuildLogMatch{
+						{
+							BuildID:      42,
+							BuildName:    "7",
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							Line:         "panic: oh no",
+							Before:       []string{"running tests"},
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("searches with the given scope, capping the limit", func() {
+					Expect(dbTeam.SearchBuildLogsCallCount()).To(Equal(1))
+					Expect(dbTeam.SearchBuildLogsArgsForCall(0)).To(Equal(db.BuildLogSearch{
+						Query:        "panic",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Since:        time.Unix(1500000000, 0),
+						Limit:        500,
+						ContextLines: 1,
+					}))
+				})
+
+				It("returns the matching lines", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"build_id": 42,
+							"build_name": "7",
+							"pipeline_name": "some-pipeline",
+							"job_name": "some-job",
+							"line": "panic: oh no",
+							"before": ["running tests"],
+							"after": []
+						}
+					]`))
+				})
+			})
+
+			Context("when the query is missing", func() {
+				BeforeEach(func() {
+					query = "pipeline_name=some-pipeline"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when since is malformed", func() {
+				BeforeEach(func() {
+					query = "q=panic&since=yesterday"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the search fails", func() {
+				BeforeEach(func() {
+					dbTeam.SearchBuildLogsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
 			})
 		})
 	})
@@ -0,0 +1,99 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ApproveBuild(w http.ResponseWriter, r *http.Request) {
+	s.resolveApproval(w, r, atc.ApprovalStatusApproved)
+}
+
+func (s *Server) RejectBuild(w http.ResponseWriter, r *http.Request) {
+	s.resolveApproval(w, r, atc.ApprovalStatusRejected)
+}
+
+func (s *Server) resolveApproval(w http.ResponseWriter, r *http.Request, status atc.ApprovalStatus) {
+	hLog := s.logger.Session("resolve-approval", lager.Data{
+		"build":  r.FormValue(":build_id"),
+		"status": status,
+	})
+
+	buildID, err := strconv.Atoi(r.FormValue(":build_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var request atc.ApprovalRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil && err != io.EOF {
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if !authTeamFound {
+		hLog.Error("failed-to-get-team-from-auth", errors.New("failed-to-get-team-from-auth"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	build, found, err := s.buildFactory.Build(buildID)
+	if err != nil {
+		hLog.Error("failed-to-get-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if !canApprove(authTeam, build) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	resolved, err := build.ResolveApproval(status, authTeam.Name(), request.Comment)
+	if err != nil {
+		hLog.Error("failed-to-resolve-approval", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !resolved {
+		hLog.Info("build-not-awaiting-approval")
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// canApprove reports whether the team may approve the build. Without any
+// configured approvers, only the team owning the build may approve it.
+func canApprove(team auth.Team, build db.Build) bool {
+	approvers := build.Approvers()
+	if len(approvers) == 0 {
+		return team.IsAuthorized(build.TeamName())
+	}
+
+	for _, approver := range approvers {
+		if team.IsAuthorized(approver) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListBuildArtifacts(build db.Build) http.Handler {
+	log := s.logger.Session("list-build-artifacts", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		artifacts, err := build.Artifacts()
+		if err != nil {
+			log.Error("failed-to-get-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.BuildArtifact{}
+		for _, artifact := range artifacts {
+			presented = append(presented, present.BuildArtifact(artifact))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(presented)
+	})
+}
+
+func (s *Server) GetBuildArtifact(build db.Build) http.Handler {
+	log := s.logger.Session("get-build-artifact", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":artifact_name")
+
+		artifact, found, err := build.Artifact(name)
+		if err != nil {
+			log.Error("failed-to-get-artifact", err, lager.Data{"artifact": name})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		workers, err := s.workerClient.RunningWorkers(log)
+		if err != nil {
+			log.Error("failed-to-get-workers", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		for _, worker := range workers {
+			if worker.Name() != artifact.WorkerName {
+				continue
+			}
+
+			volume, found, err := worker.LookupVolume(log, artifact.VolumeHandle)
+			if err != nil {
+				log.Error("failed-to-lookup-volume", err, lager.Data{"handle": artifact.VolumeHandle})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !found {
+				break
+			}
+
+			stream, err := volume.StreamOut(".")
+			if err != nil {
+				log.Error("failed-to-stream-out-volume", err, lager.Data{"handle": artifact.VolumeHandle})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			defer stream.Close()
+
+			w.Header().Set("Content-Type", "application/x-tar")
+			w.Header().Set("Content-Disposition", "attachment; filename="+artifact.Name+".tar")
+			w.WriteHeader(http.StatusOK)
+
+			_, err = io.Copy(w, stream)
+			if err != nil {
+				log.Error("failed-to-stream-artifact", err)
+			}
+
+			return
+		}
+
+		log.Info("artifact-volume-not-found", lager.Data{"worker": artifact.WorkerName, "handle": artifact.VolumeHandle})
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
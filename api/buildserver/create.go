@@ -3,6 +3,7 @@ package buildserver
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -22,6 +23,16 @@ func (s *Server) CreateBuild(team db.Team) http.Handler {
 			return
 		}
 
+		var holdOnFailure time.Duration
+		if r.FormValue("hold_containers_on_failure") != "" {
+			holdOnFailure, err = time.ParseDuration(r.FormValue("hold_containers_on_failure"))
+			if err != nil || holdOnFailure < 0 || holdOnFailure > atc.MaxContainerHold {
+				hLog.Info("malformed-hold-containers-on-failure", lager.Data{"value": r.FormValue("hold_containers_on_failure")})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
 		build, err := team.CreateOneOffBuild()
 		if err != nil {
 			hLog.Error("failed-to-create-one-off-build", err)
@@ -29,6 +40,15 @@ func (s *Server) CreateBuild(team db.Team) http.Handler {
 			return
 		}
 
+		if holdOnFailure != 0 {
+			err = build.SetHoldContainersOnFailure(holdOnFailure)
+			if err != nil {
+				hLog.Error("failed-to-set-hold-containers-on-failure", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		engineBuild, err := s.engine.CreateBuild(hLog, build, plan)
 		if err != nil {
 			hLog.Error("failed-to-start-build", err)
@@ -5,13 +5,61 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 )
 
 func (s *Server) GetBuild(build db.Build) http.Handler {
+	logger := s.logger.Session("get-build")
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presentedBuild := present.Build(build)
+
+		if len(build.Metadata()) != 0 {
+			// the metadata written by tasks is as revealing as the build's logs
+			visible, err := logsVisible(r, build)
+			if err != nil {
+				logger.Error("failed-to-check-log-visibility", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if visible {
+				presentedBuild.Metadata = build.Metadata().ToATCMetadata()
+			}
+		}
+
 		w.WriteHeader(http.StatusOK)
 
-		json.NewEncoder(w).Encode(present.Build(build))
+		json.NewEncoder(w).Encode(presentedBuild)
 	})
 }
+
+// logsVisible determines whether the requester may see the build's logs:
+// they are authorized for its team or its job is public.
+func logsVisible(r *http.Request, build db.Build) (bool, error) {
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if authTeamFound && authTeam.IsAuthorized(build.TeamName()) {
+		return true, nil
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	job, found, err := pipeline.Job(build.JobName())
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	return job.Config().Public, nil
+}
@@ -0,0 +1,44 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) HoldBuildContainers(build db.Build) http.Handler {
+	hLog := s.logger.Session("hold-build-containers", lager.Data{
+		"build": build.ID(),
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		duration, err := time.ParseDuration(r.FormValue("duration"))
+		if err != nil || duration <= 0 || duration > atc.MaxContainerHold {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		held, err := build.HoldContainers(duration)
+		if err != nil {
+			hLog.Error("failed-to-hold-containers", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !held {
+			hLog.Info("held-containers-quota-reached")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(present.Build(build))
+	})
+}
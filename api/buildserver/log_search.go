@@ -0,0 +1,109 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+const (
+	logSearchDefaultLimit = 50
+	logSearchMaxLimit     = 500
+
+	logSearchDefaultContext = 2
+	logSearchMaxContext     = 10
+)
+
+func (s *Server) SearchBuildLogs(team db.Team) http.Handler {
+	log := s.logger.Session("search-build-logs", lager.Data{"team": team.Name()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.logSearchEnabled {
+			http.Error(w, "build log search is not enabled", http.StatusNotImplemented)
+			return
+		}
+
+		search := db.BuildLogSearch{
+			Query:        r.FormValue("q"),
+			PipelineName: r.FormValue("pipeline_name"),
+			JobName:      r.FormValue("job_name"),
+			Limit:        logSearchDefaultLimit,
+			ContextLines: logSearchDefaultContext,
+		}
+
+		if search.Query == "" {
+			http.Error(w, "missing search query", http.StatusBadRequest)
+			return
+		}
+
+		if search.JobName != "" && search.PipelineName == "" {
+			http.Error(w, "a job can only be searched within a pipeline", http.StatusBadRequest)
+			return
+		}
+
+		var err error
+		search.Since, err = timeParam(r, "since")
+		if err != nil {
+			http.Error(w, "malformed since: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		search.Until, err = timeParam(r, "until")
+		if err != nil {
+			http.Error(w, "malformed until: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if limit, err := strconv.Atoi(r.FormValue("limit")); err == nil && limit > 0 {
+			search.Limit = limit
+			if search.Limit > logSearchMaxLimit {
+				search.Limit = logSearchMaxLimit
+			}
+		}
+
+		if context, err := strconv.Atoi(r.FormValue("context")); err == nil && context >= 0 {
+			search.ContextLines = context
+			if search.ContextLines > logSearchMaxContext {
+				search.ContextLines = logSearchMaxContext
+			}
+		}
+
+		matches, err := team.SearchBuildLogs(search)
+		if err != nil {
+			log.Error("failed-to-search-build-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.BuildLogMatch{}
+		for _, match := range matches {
+			presented = append(presented, present.BuildLogMatch(match))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(presented)
+	})
+}
+
+// timeParam parses a time given in seconds since the epoch, or returns the
+// zero time if it is absent.
+func timeParam(r *http.Request, name string) (time.Time, error) {
+	value := r.FormValue(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(seconds, 0), nil
+}
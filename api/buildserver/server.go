@@ -23,6 +23,7 @@ type Server struct {
 	buildFactory        db.BuildFactory
 	eventHandlerFactory EventHandlerFactory
 	drain               <-chan struct{}
+	logSearchEnabled    bool
 	rejector            auth.Rejector
 }
 
@@ -35,6 +36,7 @@ func NewServer(
 	buildFactory db.BuildFactory,
 	eventHandlerFactory EventHandlerFactory,
 	drain <-chan struct{},
+	logSearchEnabled bool,
 ) *Server {
 	return &Server{
 		logger: logger,
@@ -47,6 +49,7 @@ func NewServer(
 		buildFactory:        buildFactory,
 		eventHandlerFactory: eventHandlerFactory,
 		drain:               drain,
+		logSearchEnabled:    logSearchEnabled,
 
 		rejector: auth.UnauthorizedRejector{},
 	}
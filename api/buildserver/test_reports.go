@@ -0,0 +1,33 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListBuildTestReports(build db.Build) http.Handler {
+	log := s.logger.Session("list-build-test-reports", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reports, err := build.TestReports()
+		if err != nil {
+			log.Error("failed-to-get-test-reports", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.TestReport{}
+		for _, report := range reports {
+			presented = append(presented, present.TestReport(report))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(presented)
+	})
+}
@@ -515,6 +515,76 @@ var _ = Describe("Config API", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
 							})
 						})
+
+						Context("when an input is passed by a job of another pipeline", func() {
+							var otherPipeline *dbfakes.FakePipeline
+
+							BeforeEach(func() {
+								pipelineConfig.Jobs[0].Plan[0].Passed = []string{"other-pipeline/other-job"}
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+
+								otherPipeline = new(dbfakes.FakePipeline)
+								otherPipeline.JobReturns(new(dbfakes.FakeJob), true, nil)
+								dbTeam.PipelineReturns(otherPipeline, true, nil)
+							})
+
+							It("looks up the job in the other pipeline", func() {
+								Expect(dbTeam.PipelineArgsForCall(0)).To(Equal("other-pipeline"))
+								Expect(otherPipeline.JobArgsForCall(0)).To(Equal("other-job"))
+							})
+
+							It("saves it", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							})
+
+							Context("when the other pipeline does not exist", func() {
+								BeforeEach(func() {
+									dbTeam.PipelineReturns(nil, false, nil)
+								})
+
+								It("returns 400 with the error and does not save it", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+									{
+										"errors": [
+											"jobs.some-job.get.some-input.passed references an unknown pipeline ('other-pipeline')"
+										]
+									}`))
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+
+							Context("when the job does not exist in the other pipeline", func() {
+								BeforeEach(func() {
+									otherPipeline.JobReturns(nil, false, nil)
+								})
+
+								It("returns 400 with the error and does not save it", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+									{
+										"errors": [
+											"jobs.some-job.get.some-input.passed references an unknown job of pipeline 'other-pipeline' ('other-job')"
+										]
+									}`))
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+
+							Context("when looking up the other pipeline fails", func() {
+								BeforeEach(func() {
+									dbTeam.PipelineReturns(nil, false, errors.New("nope"))
+								})
+
+								It("returns 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+						})
 					})
 
 					Context("YAML", func() {
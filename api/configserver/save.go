@@ -124,6 +124,18 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	errorMessages, err = validateOtherPipelinesPassed(team, config)
+	if err != nil {
+		session.Error("failed-to-validate-passed-jobs-of-other-pipelines", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(errorMessages) > 0 {
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
 	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
@@ -143,6 +155,63 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
 }
 
+// validateOtherPipelinesPassed checks that the jobs of other pipelines which
+// are referenced by passed constraints exist in the team.
+func validateOtherPipelinesPassed(team db.Team, config atc.Config) ([]string, error) {
+	errorMessages := []string{}
+	pipelines := map[string]db.Pipeline{}
+
+	for _, job := range config.Jobs {
+		for _, input := range job.Inputs() {
+			constraints := []struct {
+				field string
+				jobs  []string
+			}{
+				{"passed", input.Passed},
+				{"passed_any", input.PassedAny},
+			}
+
+			for _, constraint := range constraints {
+				field := constraint.field
+
+				for _, passed := range constraint.jobs {
+					pipelineName, jobName := atc.SplitPassedJob(passed)
+					if pipelineName == "" {
+						continue
+					}
+
+					pipeline, found := pipelines[pipelineName]
+					if !found {
+						var err error
+						pipeline, found, err = team.Pipeline(pipelineName)
+						if err != nil {
+							return nil, err
+						}
+
+						if !found {
+							errorMessages = append(errorMessages, fmt.Sprintf("jobs.%s.get.%s.%s references an unknown pipeline ('%s')", job.Name, input.Name, field, pipelineName))
+							continue
+						}
+
+						pipelines[pipelineName] = pipeline
+					}
+
+					_, found, err := pipeline.Job(jobName)
+					if err != nil {
+						return nil, err
+					}
+
+					if !found {
+						errorMessages = append(errorMessages, fmt.Sprintf("jobs.%s.get.%s.%s references an unknown job of pipeline '%s' ('%s')", job.Name, input.Name, field, pipelineName, jobName))
+					}
+				}
+			}
+		}
+	}
+
+	return errorMessages, nil
+}
+
 func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages []string, session lager.Logger) {
 	w.WriteHeader(http.StatusBadRequest)
 	s.writeSaveConfigResponse(w, SaveConfigResponse{
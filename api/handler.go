@@ -68,6 +68,8 @@ func NewHandler(
 
 	isTLSEnabled bool,
 
+	logSearchEnabled bool,
+
 	cliDownloadsDir string,
 	version string,
 	workerVersion string,
@@ -103,10 +105,11 @@ func NewHandler(
 		dbBuildFactory,
 		eventHandlerFactory,
 		drain,
+		logSearchEnabled,
 	)
 
 	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, variablesFactory)
-	resourceServer := resourceserver.NewServer(logger, scannerFactory)
+	resourceServer := resourceserver.NewServer(logger, scannerFactory, externalURL)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, dbTeamFactory)
 
@@ -135,25 +138,35 @@ func NewHandler(
 		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
 		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
 
-		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
-		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
-		atc.CreateBuild:         teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
-		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
-		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
-		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
-		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
-		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
-
-		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
-		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
-		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
-		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
-		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
-		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
-		atc.MainJobBadge:   mainredirect.Handler{atc.Routes, atc.JobBadge},
+		atc.GetBuild:             buildHandlerFactory.HandlerFor(buildServer.GetBuild),
+		atc.ListBuilds:           http.HandlerFunc(buildServer.ListBuilds),
+		atc.CreateBuild:          teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
+		atc.BuildResources:       buildHandlerFactory.HandlerFor(buildServer.BuildResources),
+		atc.AbortBuild:           buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.HoldBuildContainers:  buildHandlerFactory.HandlerFor(buildServer.HoldBuildContainers),
+		atc.ApproveBuild:         http.HandlerFunc(buildServer.ApproveBuild),
+		atc.RejectBuild:          http.HandlerFunc(buildServer.RejectBuild),
+		atc.GetBuildPlan:         buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
+		atc.GetBuildPreparation:  buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
+		atc.BuildEvents:          buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
+		atc.ListBuildArtifacts:   buildHandlerFactory.HandlerFor(buildServer.ListBuildArtifacts),
+		atc.GetBuildArtifact:     buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifact),
+		atc.ListBuildTestReports: buildHandlerFactory.HandlerFor(buildServer.ListBuildTestReports),
+		atc.SearchBuildLogs:      teamHandlerFactory.HandlerFor(buildServer.SearchBuildLogs),
+
+		atc.ListJobs:          pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
+		atc.GetJob:            pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
+		atc.ListJobBuilds:     pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
+		atc.ListJobInputs:     pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.ExplainJob:        pipelineHandlerFactory.HandlerFor(jobServer.ExplainJob),
+		atc.GetJobTestHistory: pipelineHandlerFactory.HandlerFor(jobServer.GetJobTestHistory),
+		atc.GetJobBuild:       pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
+		atc.CreateJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.RerunJobBuild:     pipelineHandlerFactory.HandlerFor(jobServer.RerunJobBuild),
+		atc.PauseJob:          pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
+		atc.UnpauseJob:        pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.JobBadge:          pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+		atc.MainJobBadge:      mainredirect.Handler{atc.Routes, atc.JobBadge},
 
 		atc.ListAllPipelines:    http.HandlerFunc(pipelineServer.ListAllPipelines),
 		atc.ListPipelines:       http.HandlerFunc(pipelineServer.ListPipelines),
@@ -174,6 +187,7 @@ func NewHandler(
 		atc.UnpauseResource:      pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
 		atc.CheckResource:        pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 		atc.CheckResourceWebHook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
+		atc.ListResourceChecks:   pipelineHandlerFactory.HandlerFor(resourceServer.ListResourceChecks),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
@@ -206,9 +220,10 @@ func NewHandler(
 
 		atc.ListVolumes: teamHandlerFactory.HandlerFor(volumesServer.ListVolumes),
 
-		atc.ListTeams:   http.HandlerFunc(teamServer.ListTeams),
-		atc.SetTeam:     http.HandlerFunc(teamServer.SetTeam),
-		atc.DestroyTeam: http.HandlerFunc(teamServer.DestroyTeam),
+		atc.ListTeams:    http.HandlerFunc(teamServer.ListTeams),
+		atc.SetTeam:      http.HandlerFunc(teamServer.SetTeam),
+		atc.DestroyTeam:  http.HandlerFunc(teamServer.DestroyTeam),
+		atc.GetTeamQuota: http.HandlerFunc(teamServer.GetTeamQuota),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/cloudfoundry/bosh-cli/director/template"
@@ -13,6 +14,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/jobserver"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
@@ -278,6 +280,24 @@ var _ = Describe("Jobs API", func() {
 						})
 					})
 
+					Context("when the job has a schedule", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name:     "some-job",
+								Schedule: &atc.ScheduleConfig{Cron: "0 9 * * *", Location: "UTC"},
+							})
+							fakeJob.LastScheduleTimeReturns(time.Date(2017, time.October, 6, 12, 30, 0, 0, time.UTC))
+						})
+
+						It("returns the next time the schedule fires", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(job.NextScheduleTime).To(Equal(time.Date(2017, time.October, 7, 9, 0, 0, 0, time.UTC).Unix()))
+						})
+					})
+
 					Context("when getting the job's builds fails", func() {
 						BeforeEach(func() {
 							fakeJob.FinishedAndNextBuildReturns(nil, nil, errors.New("oh no!"))
@@ -1325,6 +1345,211 @@ var _ = Describe("Jobs API", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 						})
 					})
+
+					Context("when input overrides are given", func() {
+						var requestBody string
+
+						BeforeEach(func() {
+							requestBody = `{"input_overrides":{"some-input":{"version":{"ref":"abc"}}}}`
+
+							fakePipeline.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{
+								ID:      7,
+								Enabled: true,
+								VersionedResource: db.VersionedResource{
+									Resource: "some-input",
+									Version:  db.ResourceVersion{"ref": "abc"},
+								},
+							}, true, nil)
+
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							build.NameReturns("1")
+							build.JobNameReturns("some-job")
+							build.PipelineNameReturns("a-pipeline")
+							build.TeamNameReturns("some-team")
+							build.StatusReturns(db.BuildStatusPending)
+							fakeScheduler.TriggerImmediatelyWithInputOverridesReturns(build, nil, nil)
+						})
+
+						Context("when the team is an admin team", func() {
+							BeforeEach(func() {
+								userContextReader.GetTeamReturns("some-team", true, true)
+							})
+
+							Context("when overriding by version", func() {
+								BeforeEach(func() {
+									var err error
+									request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(requestBody))
+									Expect(err).NotTo(HaveOccurred())
+								})
+
+								It("looks up the version of the input's resource", func() {
+									Expect(fakePipeline.GetVersionedResourceByVersionCallCount()).To(Equal(1))
+									version, resourceName := fakePipeline.GetVersionedResourceByVersionArgsForCall(0)
+									Expect(version).To(Equal(atc.Version{"ref": "abc"}))
+									Expect(resourceName).To(Equal("some-input"))
+								})
+
+								It("triggers the build with the input overrides", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+									Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(BeZero())
+									Expect(fakeScheduler.TriggerImmediatelyWithInputOverridesCallCount()).To(Equal(1))
+									_, job, inputOverrides, _, _ := fakeScheduler.TriggerImmediatelyWithInputOverridesArgsForCall(0)
+									Expect(job).To(Equal(fakeJob))
+									Expect(inputOverrides).To(Equal(map[string]int{"some-input": 7}))
+								})
+
+								Context("when the version does not exist", func() {
+									BeforeEach(func() {
+										fakePipeline.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{}, false, nil)
+									})
+
+									It("returns 400 without triggering", func() {
+										Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+										Expect(fakeScheduler.TriggerImmediatelyWithInputOverridesCallCount()).To(BeZero())
+									})
+								})
+
+								Context("when looking up the version fails", func() {
+									BeforeEach(func() {
+										fakePipeline.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{}, false, errors.New("nope"))
+									})
+
+									It("returns 500", func() {
+										Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+									})
+								})
+							})
+
+							Context("when overriding by versioned resource ID", func() {
+								BeforeEach(func() {
+									var err error
+									request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{"input_overrides":{"some-input":{"versioned_resource_id":7}}}`))
+									Expect(err).NotTo(HaveOccurred())
+
+									fakePipeline.GetVersionedResourceReturns(db.SavedVersionedResource{
+										ID:      7,
+										Enabled: true,
+										VersionedResource: db.VersionedResource{
+											Resource: "some-input",
+										},
+									}, true, nil)
+								})
+
+								It("triggers the build with the input overrides", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+									Expect(fakePipeline.GetVersionedResourceCallCount()).To(Equal(1))
+									Expect(fakePipeline.GetVersionedResourceArgsForCall(0)).To(Equal(7))
+
+									_, _, inputOverrides, _, _ := fakeScheduler.TriggerImmediatelyWithInputOverridesArgsForCall(0)
+									Expect(inputOverrides).To(Equal(map[string]int{"some-input": 7}))
+								})
+
+								Context("when the version belongs to another resource", func() {
+									BeforeEach(func() {
+										fakePipeline.GetVersionedResourceReturns(db.SavedVersionedResource{
+											ID:      7,
+											Enabled: true,
+											VersionedResource: db.VersionedResource{
+												Resource: "some-other-resource",
+											},
+										}, true, nil)
+									})
+
+									It("returns 400", func() {
+										Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+									})
+								})
+
+								Context("when the version is disabled", func() {
+									BeforeEach(func() {
+										fakePipeline.GetVersionedResourceReturns(db.SavedVersionedResource{
+											ID: 7,
+											VersionedResource: db.VersionedResource{
+												Resource: "some-input",
+											},
+										}, true, nil)
+									})
+
+									It("returns 400", func() {
+										Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+									})
+								})
+							})
+
+							Context("when the input does not exist", func() {
+								BeforeEach(func() {
+									var err error
+									request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{"input_overrides":{"bogus":{"versioned_resource_id":7}}}`))
+									Expect(err).NotTo(HaveOccurred())
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+							})
+
+							Context("when both a version and a versioned resource ID are given", func() {
+								BeforeEach(func() {
+									var err error
+									request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{"input_overrides":{"some-input":{"version":{"ref":"abc"},"versioned_resource_id":7}}}`))
+									Expect(err).NotTo(HaveOccurred())
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+							})
+						})
+
+						Context("when the team is not an admin team", func() {
+							BeforeEach(func() {
+								userContextReader.GetTeamReturns("some-team", false, true)
+
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(requestBody))
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("returns 403", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+								Expect(fakeScheduler.TriggerImmediatelyWithInputOverridesCallCount()).To(BeZero())
+							})
+
+							Context("when the job allows input overrides", func() {
+								BeforeEach(func() {
+									fakeJob.ConfigReturns(atc.JobConfig{
+										Name:                "some-job",
+										AllowInputOverrides: true,
+										Plan: atc.PlanSequence{
+											{
+												Get: "some-input",
+											},
+										},
+									})
+								})
+
+								It("triggers the build with the input overrides", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(fakeScheduler.TriggerImmediatelyWithInputOverridesCallCount()).To(Equal(1))
+								})
+							})
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{"input_overrides":`))
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
 				})
 			})
 
@@ -1350,6 +1575,238 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
+		var request *http.Request
+		var response *http.Response
+
+		var fakeScheduler *schedulerfakes.FakeBuildScheduler
+		var fakeRerunBuild *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			var err error
+
+			request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/3", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+			fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+
+			fakeRerunBuild = new(dbfakes.FakeBuild)
+			fakeRerunBuild.IDReturns(3)
+			fakeRerunBuild.StatusReturns(db.BuildStatusFailed)
+			fakeRerunBuild.ResourcesReturns([]db.BuildInput{{Name: "some-input"}}, nil, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", true, true)
+			})
+
+			Context("when getting the job succeeds", func() {
+				BeforeEach(func() {
+					fakeJob.NameReturns("some-job")
+					fakeJob.ConfigReturns(atc.JobConfig{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{
+								Get: "some-input",
+							},
+						},
+					})
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				Context("when the build is found", func() {
+					BeforeEach(func() {
+						fakeJob.BuildReturns(fakeRerunBuild, true, nil)
+					})
+
+					It("looks up the build by name", func() {
+						Expect(fakeJob.BuildCallCount()).To(Equal(1))
+						Expect(fakeJob.BuildArgsForCall(0)).To(Equal("3"))
+					})
+
+					Context("when rerunning the build succeeds", func() {
+						BeforeEach(func() {
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							build.NameReturns("4")
+							build.JobNameReturns("some-job")
+							build.PipelineNameReturns("a-pipeline")
+							build.TeamNameReturns("some-team")
+							build.StatusReturns(db.BuildStatusPending)
+							build.RerunOfReturns(3)
+							fakeScheduler.RerunImmediatelyReturns(build, nil, nil)
+						})
+
+						It("reruns the build using the current config", func() {
+							Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(1))
+
+							_, job, rerunBuild, _, resourceTypes := fakeScheduler.RerunImmediatelyArgsForCall(0)
+							Expect(job).To(Equal(fakeJob))
+							Expect(rerunBuild).To(Equal(fakeRerunBuild))
+							Expect(resourceTypes).To(Equal(versionedResourceTypes))
+						})
+
+						It("returns 200 OK", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						It("returns the new build", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+							"id": 42,
+							"name": "4",
+							"job_name": "some-job",
+							"status": "pending",
+							"url": "/teams/some-team/pipelines/a-pipeline/jobs/some-job/builds/4",
+							"api_url": "/api/v1/builds/42",
+							"pipeline_name": "a-pipeline",
+							"team_name": "some-team",
+							"rerun_of": 3
+						}`))
+						})
+					})
+
+					Context("when rerunning the build fails", func() {
+						BeforeEach(func() {
+							fakeScheduler.RerunImmediatelyReturns(nil, nil, errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the build has not started yet", func() {
+						BeforeEach(func() {
+							fakeRerunBuild.StatusReturns(db.BuildStatusPending)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+
+						It("does not rerun the build", func() {
+							Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the build was aborted before it started", func() {
+						BeforeEach(func() {
+							fakeRerunBuild.StatusReturns(db.BuildStatusAborted)
+							fakeRerunBuild.ResourcesReturns([]db.BuildInput{}, []db.BuildOutput{}, nil)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+
+						It("does not rerun the build", func() {
+							Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(0))
+						})
+
+						Context("when the job has no inputs", func() {
+							BeforeEach(func() {
+								fakeJob.ConfigReturns(atc.JobConfig{
+									Name: "some-job",
+									Plan: atc.PlanSequence{{Task: "some-task"}},
+								})
+
+								fakeScheduler.RerunImmediatelyReturns(new(dbfakes.FakeBuild), nil, nil)
+							})
+
+							It("reruns the build", func() {
+								Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(1))
+							})
+						})
+					})
+
+					Context("when getting the build's inputs fails", func() {
+						BeforeEach(func() {
+							fakeRerunBuild.ResourcesReturns(nil, nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+
+						It("does not rerun the build", func() {
+							Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when manual triggering is disabled", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name:                 "some-job",
+								DisableManualTrigger: true,
+							})
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+
+						It("does not rerun the build", func() {
+							Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when the build is not found", func() {
+					BeforeEach(func() {
+						fakeJob.BuildReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when getting the build fails", func() {
+					BeforeEach(func() {
+						fakeJob.BuildReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns a 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", func() {
 		var response *http.Response
 
@@ -1547,6 +2004,343 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduling", func() {
+		var (
+			response *http.Response
+			query    string
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/scheduling" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", true, true)
+			})
+
+			Context("when the job exists", func() {
+				var (
+					fakeJob       *dbfakes.FakeJob
+					fakeScheduler *schedulerfakes.FakeBuildScheduler
+				)
+
+				BeforeEach(func() {
+					fakeJob = new(dbfakes.FakeJob)
+					fakeJob.NameReturns("some-job")
+					fakePipeline.JobReturns(fakeJob, true, nil)
+
+					fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+					fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+				})
+
+				Context("when the job can be explained", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainJobReturns(atc.JobSchedulingExplanation{
+							JobName:         "some-job",
+							InputsSatisfied: false,
+							Inputs: []atc.InputSchedulingExplanation{
+								{
+									Name:     "some-input",
+									Resource: "some-resource",
+									Passed:   []string{"upstream-job"},
+									Trigger:  true,
+									Candidates: []atc.VersionCandidateExplanation{
+										{
+											ID:        1,
+											Version:   atc.Version{"ref": "v1"},
+											Enabled:   true,
+											Reason:    "version has not passed all of the required jobs",
+											NotPassed: []string{"upstream-job"},
+										},
+									},
+								},
+							},
+							NextPendingBuild: "3",
+							MaxInFlight:      1,
+							RunningBuilds:    []string{"some-job/2"},
+							BlockedBy:        "max in flight of 1 reached",
+						}, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("explains the job with the default candidate limit", func() {
+						Expect(fakeScheduler.ExplainJobCallCount()).To(Equal(1))
+						_, actualJob, limit := fakeScheduler.ExplainJobArgsForCall(0)
+						Expect(actualJob.Name()).To(Equal("some-job"))
+						Expect(limit).To(Equal(jobserver.DefaultExplainCandidates))
+					})
+
+					It("returns the explanation", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"job_name": "some-job",
+							"paused": false,
+							"pipeline_paused": false,
+							"inputs_satisfied": false,
+							"trigger_changed": false,
+							"inputs": [
+								{
+									"name": "some-input",
+									"resource": "some-resource",
+									"passed": ["upstream-job"],
+									"trigger": true,
+									"resolved": false,
+									"new_version": false,
+									"candidates": [
+										{
+											"id": 1,
+											"version": {"ref": "v1"},
+											"enabled": true,
+											"selected": false,
+											"reason": "version has not passed all of the required jobs",
+											"not_passed": ["upstream-job"]
+										}
+									]
+								}
+							],
+							"next_pending_build": "3",
+							"max_in_flight": 1,
+							"running_builds": ["some-job/2"],
+							"blocked_by": "max in flight of 1 reached"
+						}`))
+					})
+
+					Context("when a limit is given", func() {
+						BeforeEach(func() {
+							query = "?limit=3"
+						})
+
+						It("explains that many candidates", func() {
+							_, _, limit := fakeScheduler.ExplainJobArgsForCall(0)
+							Expect(limit).To(Equal(3))
+						})
+					})
+
+					Context("when the limit is larger than the maximum", func() {
+						BeforeEach(func() {
+							query = "?limit=100000"
+						})
+
+						It("explains the maximum number of candidates", func() {
+							_, _, limit := fakeScheduler.ExplainJobArgsForCall(0)
+							Expect(limit).To(Equal(jobserver.MaxExplainCandidates))
+						})
+					})
+
+					Context("when the limit is invalid", func() {
+						BeforeEach(func() {
+							query = "?limit=nope"
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+				})
+
+				Context("when explaining the job fails", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainJobReturns(atc.JobSchedulingExplanation{}, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job does not exist", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the job fails", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/test-history", func() {
+		var (
+			response *http.Response
+			query    string
+			fakeJob  *dbfakes.FakeJob
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakePipeline.JobReturns(fakeJob, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/test-history" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", true, true)
+			})
+
+			Context("when the test history can be found", func() {
+				BeforeEach(func() {
+					failing := db.TestReport{StepName: "unit", Total: 2, Passed: 1, Failed: 1, Passes: []string{"TestFixed"}, Failures: []string{"TestFlaky"}}
+					passing := db.TestReport{StepName: "unit", Total: 2, Passed: 2, Passes: []string{"TestFixed", "TestFlaky"}, Failures: []string{}}
+
+					fakeJob.TestHistoryReturns([]db.BuildTestReports{
+						{BuildID: 4, BuildName: "4", Status: db.BuildStatusFailed, Reports: []db.TestReport{failing}},
+						{BuildID: 3, BuildName: "3", Status: db.BuildStatusSucceeded, Reports: []db.TestReport{passing}},
+						{BuildID: 2, BuildName: "2", Status: db.BuildStatusFailed, Reports: []db.TestReport{failing}},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("gets the history of the default number of builds", func() {
+					Expect(fakeJob.TestHistoryCallCount()).To(Equal(1))
+					Expect(fakeJob.TestHistoryArgsForCall(0)).To(Equal(jobserver.DefaultTestHistoryLimit))
+				})
+
+				It("returns the history with its flaky tests", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"builds": [
+							{
+								"build_id": 4,
+								"build_name": "4",
+								"status": "failed",
+								"reports": [{"step_name": "unit", "total": 2, "passed": 1, "failed": 1, "skipped": 0, "failures": ["TestFlaky"]}]
+							},
+							{
+								"build_id": 3,
+								"build_name": "3",
+								"status": "succeeded",
+								"reports": [{"step_name": "unit", "total": 2, "passed": 2, "failed": 0, "skipped": 0, "failures": []}]
+							},
+							{
+								"build_id": 2,
+								"build_name": "2",
+								"status": "failed",
+								"reports": [{"step_name": "unit", "total": 2, "passed": 1, "failed": 1, "skipped": 0, "failures": ["TestFlaky"]}]
+							}
+						],
+						"flaky_tests": [
+							{"step_name": "unit", "name": "TestFlaky", "failures": 2, "builds": 3}
+						]
+					}`))
+				})
+
+				Context("when a limit is given", func() {
+					BeforeEach(func() {
+						query = "?limit=3"
+					})
+
+					It("gets the history of that many builds", func() {
+						Expect(fakeJob.TestHistoryArgsForCall(0)).To(Equal(3))
+					})
+				})
+
+				Context("when the limit is invalid", func() {
+					BeforeEach(func() {
+						query = "?limit=nope"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Context("when getting the test history fails", func() {
+				BeforeEach(func() {
+					fakeJob.TestHistoryReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the job does not exist", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated and the pipeline is public", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(true)
+			})
+
+			Context("when the job is private", func() {
+				It("returns Unauthorized", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when the job is public", func() {
+				BeforeEach(func() {
+					fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job", Public: true})
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
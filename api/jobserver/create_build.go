@@ -2,19 +2,41 @@ package jobserver
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 )
 
+type inputOverrideError struct {
+	input   string
+	problem string
+}
+
+func (e inputOverrideError) Error() string {
+	return fmt.Sprintf("invalid override for input '%s': %s", e.input, e.problem)
+}
+
 func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger := s.logger.Session("create-job-build")
 
 		jobName := r.FormValue(":job_name")
 
+		var request atc.CreateJobBuildRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		job, found, err := pipeline.Job(jobName)
 		if err != nil {
 			logger.Error("failed-to-get-resource-types", err)
@@ -32,6 +54,34 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		var inputOverrides map[string]int
+		if len(request.InputOverrides) != 0 {
+			authTeam, authTeamFound := auth.GetTeam(r)
+			if !authTeamFound {
+				logger.Error("failed-to-get-team-from-auth", errors.New("failed-to-get-team-from-auth"))
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !canOverrideInputs(authTeam, job.Config()) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			inputOverrides, err = resolveInputOverrides(pipeline, job.Config(), request.InputOverrides)
+			if err != nil {
+				if _, ok := err.(inputOverrideError); ok {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprintf(w, "%s", err)
+					return
+				}
+
+				logger.Error("failed-to-resolve-input-overrides", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		scheduler := s.schedulerFactory.BuildScheduler(pipeline, s.externalURL, s.variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name()))
 
 		resourceTypes, err := pipeline.ResourceTypes()
@@ -50,7 +100,12 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		build, _, err := scheduler.TriggerImmediately(logger, job, resources, versionedResourceTypes)
+		var build db.Build
+		if inputOverrides != nil {
+			build, _, err = scheduler.TriggerImmediatelyWithInputOverrides(logger, job, inputOverrides, resources, versionedResourceTypes)
+		} else {
+			build, _, err = scheduler.TriggerImmediately(logger, job, resources, versionedResourceTypes)
+		}
 		if err != nil {
 			logger.Error("failed-to-trigger", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -61,3 +116,57 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 		json.NewEncoder(w).Encode(present.Build(build))
 	})
 }
+
+// overriding inputs bypasses the job's passed constraints, so the pipeline's
+// team may only do it for jobs which opt in; admin teams always may
+func canOverrideInputs(authTeam auth.Team, jobConfig atc.JobConfig) bool {
+	return authTeam.IsAdmin() || jobConfig.AllowInputOverrides
+}
+
+// resolveInputOverrides maps each overridden input name to the ID of the
+// versioned resource it should use
+func resolveInputOverrides(
+	pipeline db.Pipeline,
+	jobConfig atc.JobConfig,
+	overrides map[string]atc.InputVersionOverride,
+) (map[string]int, error) {
+	jobInputs := map[string]atc.JobInput{}
+	for _, input := range jobConfig.Inputs() {
+		jobInputs[input.Name] = input
+	}
+
+	inputOverrides := map[string]int{}
+	for inputName, override := range overrides {
+		input, found := jobInputs[inputName]
+		if !found {
+			return nil, inputOverrideError{input: inputName, problem: "job has no such input"}
+		}
+
+		if (override.Version == nil) == (override.VersionedResourceID == 0) {
+			return nil, inputOverrideError{input: inputName, problem: "exactly one of version or versioned_resource_id must be given"}
+		}
+
+		var savedVersion db.SavedVersionedResource
+		var err error
+		if override.VersionedResourceID != 0 {
+			savedVersion, found, err = pipeline.GetVersionedResource(override.VersionedResourceID)
+		} else {
+			savedVersion, found, err = pipeline.GetVersionedResourceByVersion(override.Version, input.Resource)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !found || savedVersion.Resource != input.Resource {
+			return nil, inputOverrideError{input: inputName, problem: "version not found"}
+		}
+
+		if !savedVersion.Enabled {
+			return nil, inputOverrideError{input: inputName, problem: "version is disabled"}
+		}
+
+		inputOverrides[inputName] = savedVersion.ID
+	}
+
+	return inputOverrides, nil
+}
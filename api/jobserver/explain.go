@@ -0,0 +1,63 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// DefaultExplainCandidates is the number of most recent versions of each
+// input's resource to explain when no limit is given.
+const DefaultExplainCandidates = 10
+
+// MaxExplainCandidates bounds the limit which may be given, as each
+// candidate is checked against every constraint on the input.
+const MaxExplainCandidates = 100
+
+func (s *Server) ExplainJob(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("explain-job")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		limit := DefaultExplainCandidates
+		if urlLimit := r.FormValue(atc.PaginationQueryLimit); urlLimit != "" {
+			var err error
+			limit, err = strconv.Atoi(urlLimit)
+			if err != nil || limit <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if limit > MaxExplainCandidates {
+				limit = MaxExplainCandidates
+			}
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		variables := s.variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name())
+		scheduler := s.schedulerFactory.BuildScheduler(pipeline, s.externalURL, variables)
+
+		explanation, err := scheduler.ExplainJob(logger, job, limit)
+		if err != nil {
+			logger.Error("failed-to-explain-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(explanation)
+	})
+}
@@ -0,0 +1,99 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) RerunJobBuild(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("rerun-job-build")
+
+		jobName := r.FormValue(":job_name")
+		buildName := r.FormValue(":build_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if job.Config().DisableManualTrigger {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		rerunBuild, found, err := job.Build(buildName)
+		if err != nil {
+			logger.Error("failed-to-get-job-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// a build that has not started yet has no inputs to rerun with
+		if rerunBuild.Status() == db.BuildStatusPending {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		// neither has a build which was aborted or errored before its inputs
+		// were determined
+		if len(job.Config().Inputs()) > 0 {
+			inputs, _, err := rerunBuild.Resources()
+			if err != nil {
+				logger.Error("failed-to-get-build-inputs", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if len(inputs) == 0 {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, "build %s has no inputs to rerun with", rerunBuild.Name())
+				return
+			}
+		}
+
+		scheduler := s.schedulerFactory.BuildScheduler(pipeline, s.externalURL, s.variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name()))
+
+		resourceTypes, err := pipeline.ResourceTypes()
+		if err != nil {
+			logger.Error("failed-to-get-resource-types", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		versionedResourceTypes := resourceTypes.Deserialize()
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		build, _, err := scheduler.RerunImmediately(logger, job, rerunBuild, resources, versionedResourceTypes)
+		if err != nil {
+			logger.Error("failed-to-rerun", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "failed to rerun: %s", err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(present.Build(build))
+	})
+}
@@ -0,0 +1,62 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+// DefaultTestHistoryLimit is the number of latest builds with test reports to
+// return when no limit is given.
+const DefaultTestHistoryLimit = 25
+
+func (s *Server) GetJobTestHistory(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-job-test-history")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		limit := DefaultTestHistoryLimit
+		if urlLimit := r.FormValue(atc.PaginationQueryLimit); urlLimit != "" {
+			var err error
+			limit, err = strconv.Atoi(urlLimit)
+			if err != nil || limit <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// the names of failed tests are as revealing as the builds' logs
+		if !job.Config().Public && !auth.IsAuthorized(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		history, err := job.TestHistory(limit)
+		if err != nil {
+			logger.Error("failed-to-get-test-history", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(present.TestHistory(history))
+	})
+}
@@ -52,6 +52,9 @@ func Build(build db.Build) atc.Build {
 		TeamName:     build.TeamName(),
 		URL:          reqURL,
 		APIURL:       apiURL,
+		RerunOf:      build.RerunOf(),
+		SupersededBy: build.SupersededBy(),
+		TriggeredBy:  build.TriggeredBy(),
 	}
 
 	if !build.StartTime().IsZero() {
@@ -66,5 +69,30 @@ func Build(build db.Build) atc.Build {
 		atcBuild.ReapTime = build.ReapTime().Unix()
 	}
 
+	if !build.ScheduleTime().IsZero() {
+		atcBuild.ScheduleTime = build.ScheduleTime().Unix()
+	}
+
+	if !build.ContainersHeldUntil().IsZero() {
+		atcBuild.ContainersHeldUntil = build.ContainersHeldUntil().Unix()
+	}
+
+	if build.ApprovalStatus() != "" {
+		atcBuild.Approval = &atc.BuildApproval{
+			Status:    build.ApprovalStatus(),
+			Approvers: build.Approvers(),
+			Approver:  build.Approver(),
+			Comment:   build.ApprovalComment(),
+		}
+
+		if !build.ApprovalDeadline().IsZero() {
+			atcBuild.Approval.Deadline = build.ApprovalDeadline().Unix()
+		}
+
+		if build.Status() == db.BuildStatusPending && build.ApprovalStatus() == atc.ApprovalStatusAwaiting {
+			atcBuild.Status = string(atc.StatusAwaitingApproval)
+		}
+	}
+
 	return atcBuild
 }
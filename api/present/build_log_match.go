@@ -0,0 +1,28 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func BuildLogMatch(match db.BuildLogMatch) atc.BuildLogMatch {
+	before := match.Before
+	if before == nil {
+		before = []string{}
+	}
+
+	after := match.After
+	if after == nil {
+		after = []string{}
+	}
+
+	return atc.BuildLogMatch{
+		BuildID:      match.BuildID,
+		BuildName:    match.BuildName,
+		PipelineName: match.PipelineName,
+		JobName:      match.JobName,
+		Line:         match.Line,
+		Before:       before,
+		After:        after,
+	}
+}
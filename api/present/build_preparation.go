@@ -17,6 +17,8 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		PausedPipeline:      atc.BuildPreparationStatus(preparation.PausedPipeline),
 		PausedJob:           atc.BuildPreparationStatus(preparation.PausedJob),
 		MaxRunningBuilds:    atc.BuildPreparationStatus(preparation.MaxRunningBuilds),
+		TeamQuota:           atc.BuildPreparationStatus(preparation.TeamQuota),
+		Approval:            atc.BuildPreparationStatus(preparation.Approval),
 		Inputs:              inputs,
 		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
 		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
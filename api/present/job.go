@@ -1,6 +1,8 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web"
@@ -59,10 +61,11 @@ func Job(
 	sanitizedInputs := []atc.JobInput{}
 	for _, input := range job.Config().Inputs() {
 		sanitizedInputs = append(sanitizedInputs, atc.JobInput{
-			Name:     input.Name,
-			Resource: input.Resource,
-			Passed:   input.Passed,
-			Trigger:  input.Trigger,
+			Name:      input.Name,
+			Resource:  input.Resource,
+			Passed:    input.Passed,
+			PassedAny: input.PassedAny,
+			Trigger:   input.Trigger,
 		})
 	}
 
@@ -74,6 +77,19 @@ func Job(
 		})
 	}
 
+	var nextScheduleTime int64
+	if schedule := job.Config().Schedule; schedule != nil {
+		lastScheduleTime := job.LastScheduleTime()
+		if lastScheduleTime.IsZero() {
+			lastScheduleTime = time.Now()
+		}
+
+		next, err := schedule.Next(lastScheduleTime)
+		if err == nil {
+			nextScheduleTime = next.Unix()
+		}
+	}
+
 	return atc.Job{
 		ID: job.ID(),
 
@@ -85,6 +101,7 @@ func Job(
 		FinishedBuild:        presentedFinishedBuild,
 		NextBuild:            presentedNextBuild,
 		TransitionBuild:      presentedTransitionBuild,
+		NextScheduleTime:     nextScheduleTime,
 
 		Inputs:  sanitizedInputs,
 		Outputs: sanitizedOutputs,
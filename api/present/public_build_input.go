@@ -22,5 +22,6 @@ func PublicBuildInput(input db.BuildInput, pipelineID int) atc.PublicBuildInput
 		Metadata:        metadata,
 		PipelineID:      pipelineID,
 		FirstOccurrence: input.FirstOccurrence,
+		Overridden:      input.Overridden,
 	}
 }
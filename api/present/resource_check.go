@@ -0,0 +1,21 @@
+package present
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func ResourceCheck(check db.ResourceCheck) atc.ResourceCheck {
+	return atc.ResourceCheck{
+		ID:            check.ID,
+		StartTime:     check.StartTime.Unix(),
+		EndTime:       check.EndTime.Unix(),
+		Duration:      int64(check.Duration() / time.Millisecond),
+		VersionsFound: check.VersionsFound,
+		ExitStatus:    check.ExitStatus,
+		CheckError:    check.CheckError,
+		Stderr:        check.Stderr,
+	}
+}
@@ -6,8 +6,18 @@ import (
 )
 
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	presentedTeam := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 	}
+
+	if defaultJobPriority := team.DefaultJobPriority(); defaultJobPriority != 0 {
+		presentedTeam.DefaultJobPriority = &defaultJobPriority
+	}
+
+	if quota := team.Quota(); !quota.IsZero() || quota.MaxHeldContainers > 0 {
+		presentedTeam.Quota = &quota
+	}
+
+	return presentedTeam
 }
@@ -0,0 +1,54 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func TestReport(report db.TestReport) atc.TestReport {
+	failures := report.Failures
+	if failures == nil {
+		failures = []string{}
+	}
+
+	return atc.TestReport{
+		StepName: report.StepName,
+		Total:    report.Total,
+		Passed:   report.Passed,
+		Failed:   report.Failed,
+		Skipped:  report.Skipped,
+		Failures: failures,
+	}
+}
+
+func TestHistory(history []db.BuildTestReports) atc.TestHistory {
+	presented := atc.TestHistory{
+		Builds:     []atc.BuildTestReports{},
+		FlakyTests: []atc.FlakyTest{},
+	}
+
+	for _, build := range history {
+		reports := []atc.TestReport{}
+		for _, report := range build.Reports {
+			reports = append(reports, TestReport(report))
+		}
+
+		presented.Builds = append(presented.Builds, atc.BuildTestReports{
+			BuildID:   build.BuildID,
+			BuildName: build.BuildName,
+			Status:    string(build.Status),
+			Reports:   reports,
+		})
+	}
+
+	for _, test := range db.FlakyTests(history) {
+		presented.FlakyTests = append(presented.FlakyTests, atc.FlakyTest{
+			StepName: test.StepName,
+			Name:     test.Name,
+			Failures: test.Failures,
+			Builds:   test.Builds,
+		})
+	}
+
+	return presented
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -668,4 +669,140 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", func() {
+		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/checks"+queryParams, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+				userContextReader.GetTeamReturns("", false, false)
+				fakePipeline.PublicReturns(true)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+			})
+
+			Context("when all the params are passed", func() {
+				BeforeEach(func() {
+					queryParams = "?since=2&until=3&limit=8"
+				})
+
+				It("passes them through", func() {
+					Expect(fakePipeline.GetResourceChecksCallCount()).To(Equal(1))
+
+					resourceName, page := fakePipeline.GetResourceChecksArgsForCall(0)
+					Expect(resourceName).To(Equal("some-resource"))
+					Expect(page).To(Equal(db.Page{
+						Since: 2,
+						Until: 3,
+						Limit: 8,
+					}))
+				})
+			})
+
+			Context("when getting the checks succeeds", func() {
+				BeforeEach(func() {
+					fakePipeline.NameReturns("some-pipeline")
+					fakePipeline.GetResourceChecksReturns([]db.ResourceCheck{
+						{
+							ID:            4,
+							StartTime:     time.Unix(100, 0),
+							EndTime:       time.Unix(102, 0),
+							VersionsFound: 1,
+						},
+						{
+							ID:         2,
+							StartTime:  time.Unix(50, 0),
+							EndTime:    time.Unix(50, 500000000),
+							ExitStatus: 1,
+							Stderr:     "some-stderr",
+						},
+					}, db.Pagination{
+						Previous: &db.Page{Until: 4, Limit: 2},
+						Next:     &db.Page{Since: 2, Limit: 2},
+					}, true, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the json", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 4,
+							"start_time": 100,
+							"end_time": 102,
+							"duration_ms": 2000,
+							"versions_found": 1,
+							"exit_status": 0
+						},
+						{
+							"id": 2,
+							"start_time": 50,
+							"end_time": 50,
+							"duration_ms": 500,
+							"versions_found": 0,
+							"exit_status": 1,
+							"stderr": "some-stderr"
+						}
+					]`))
+				})
+
+				It("returns Link headers per rfc5988", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/some-pipeline/resources/some-resource/checks?until=4&limit=2>; rel="previous"`, externalURL),
+						fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/some-pipeline/resources/some-resource/checks?since=2&limit=2>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
+			Context("when the resource can't be found", func() {
+				BeforeEach(func() {
+					fakePipeline.GetResourceChecksReturns(nil, db.Pagination{}, false, nil)
+				})
+
+				It("returns 404 not found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the checks fails", func() {
+				BeforeEach(func() {
+					fakePipeline.GetResourceChecksReturns(nil, db.Pagination{}, false, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
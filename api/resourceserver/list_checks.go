@@ -0,0 +1,88 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListResourceChecks(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-resource-checks")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			until int
+			since int
+			limit int
+		)
+
+		resourceName := r.FormValue(":resource_name")
+		teamName := r.FormValue(":team_name")
+
+		urlUntil := r.FormValue(atc.PaginationQueryUntil)
+		until, _ = strconv.Atoi(urlUntil)
+
+		urlSince := r.FormValue(atc.PaginationQuerySince)
+		since, _ = strconv.Atoi(urlSince)
+
+		urlLimit := r.FormValue(atc.PaginationQueryLimit)
+		limit, _ = strconv.Atoi(urlLimit)
+		if limit == 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		checks, pagination, found, err := pipeline.GetResourceChecks(resourceName, db.Page{
+			Until: until,
+			Since: since,
+			Limit: limit,
+		})
+		if err != nil {
+			logger.Error("failed-to-get-resource-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if pagination.Next != nil {
+			s.addChecksLink(w, teamName, pipeline.Name(), resourceName, atc.PaginationQuerySince, pagination.Next.Since, pagination.Next.Limit, atc.LinkRelNext)
+		}
+
+		if pagination.Previous != nil {
+			s.addChecksLink(w, teamName, pipeline.Name(), resourceName, atc.PaginationQueryUntil, pagination.Previous.Until, pagination.Previous.Limit, atc.LinkRelPrevious)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.WriteHeader(http.StatusOK)
+
+		resourceChecks := make([]atc.ResourceCheck, len(checks))
+		for i := 0; i < len(checks); i++ {
+			resourceChecks[i] = present.ResourceCheck(checks[i])
+		}
+
+		json.NewEncoder(w).Encode(resourceChecks)
+	})
+}
+
+func (s *Server) addChecksLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, query string, id int, limit int, rel string) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/checks?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		teamName,
+		pipelineName,
+		resourceName,
+		query,
+		id,
+		atc.PaginationQueryLimit,
+		limit,
+		rel,
+	))
+}
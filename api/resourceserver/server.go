@@ -15,11 +15,13 @@ type ScannerFactory interface {
 type Server struct {
 	logger         lager.Logger
 	scannerFactory ScannerFactory
+	externalURL    string
 }
 
-func NewServer(logger lager.Logger, scannerFactory ScannerFactory) *Server {
+func NewServer(logger lager.Logger, scannerFactory ScannerFactory, externalURL string) *Server {
 	return &Server{
 		logger:         logger,
 		scannerFactory: scannerFactory,
+		externalURL:    externalURL,
 	}
 }
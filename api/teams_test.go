@@ -73,6 +73,7 @@ var _ = Describe("Teams API", func() {
 
 				fakeTeamOne.IDReturns(5)
 				fakeTeamOne.NameReturns("avengers")
+				fakeTeamOne.DefaultJobPriorityReturns(10)
 
 				fakeTeamTwo.IDReturns(9)
 				fakeTeamTwo.NameReturns("aliens")
@@ -101,7 +102,8 @@ var _ = Describe("Teams API", func() {
 				Expect(body).To(MatchJSON(`[
 					{
 						"id": 5,
-						"name": "avengers"
+						"name": "avengers",
+						"default_job_priority": 10
 					},
 					{
 						"id": 9,
@@ -209,6 +211,46 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
+			Context("when the team has a default job priority", func() {
+				BeforeEach(func() {
+					priority := 50
+					atcTeam = atc.Team{DefaultJobPriority: &priority}
+				})
+
+				Context("when the team is found", func() {
+					BeforeEach(func() {
+						dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					})
+
+					It("updates the default job priority", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateDefaultJobPriorityCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateDefaultJobPriorityArgsForCall(0)).To(Equal(50))
+					})
+
+					Context("when updating the default job priority fails", func() {
+						BeforeEach(func() {
+							fakeTeam.UpdateDefaultJobPriorityReturns(errors.New("nope"))
+						})
+
+						It("returns 500 Internal Server error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+			})
+
+			Context("when the team has no default job priority", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("leaves the default job priority alone", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.UpdateDefaultJobPriorityCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the team has provider auth configured", func() {
 				var (
 					fakeProviderName    = "FakeProvider"
@@ -311,6 +353,54 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when the team has a quota", func() {
+				BeforeEach(func() {
+					atcTeam = atc.Team{
+						Quota: &atc.TeamQuota{
+							MaxRunningBuilds: 10,
+							MaxContainers:    100,
+						},
+					}
+				})
+
+				Context("when the team is found", func() {
+					BeforeEach(func() {
+						dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					})
+
+					It("updates the quota", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateQuotaCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateQuotaArgsForCall(0)).To(Equal(atc.TeamQuota{
+							MaxRunningBuilds: 10,
+							MaxContainers:    100,
+						}))
+					})
+
+					Context("when updating the quota fails", func() {
+						BeforeEach(func() {
+							fakeTeam.UpdateQuotaReturns(errors.New("nope"))
+						})
+
+						It("returns 500 Internal Server error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the quota is invalid", func() {
+					BeforeEach(func() {
+						atcTeam.Quota.MaxContainers = -1
+						dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeTeam.UpdateQuotaCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -347,6 +437,20 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when the team has a quota", func() {
+				BeforeEach(func() {
+					atcTeam = atc.Team{
+						Quota: &atc.TeamQuota{MaxRunningBuilds: 1000},
+					}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(fakeTeam.UpdateQuotaCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -361,6 +465,110 @@ var _ = Describe("Teams API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/quota", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/quota")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-other-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as the requested team", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the team is found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					fakeTeam.QuotaReturns(atc.TeamQuota{
+						MaxRunningBuilds: 10,
+						Pipelines: map[string]atc.PipelineQuota{
+							"some-pipeline": {MaxRunningBuilds: 2},
+						},
+					})
+					fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{
+						RunningBuilds: 3,
+						Containers:    12,
+						Pipelines: map[string]atc.PipelineQuotaUsage{
+							"some-pipeline": {RunningBuilds: 2},
+						},
+					}, nil)
+				})
+
+				It("looks up the requested team", func() {
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+				})
+
+				It("returns the quota and its usage", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"quota": {
+							"max_running_builds": 10,
+							"pipelines": {
+								"some-pipeline": {"max_running_builds": 2}
+							}
+						},
+						"usage": {
+							"running_builds": 3,
+							"containers": 12,
+							"pipelines": {
+								"some-pipeline": {"running_builds": 2}
+							}
+						}
+					}`))
+				})
+
+				Context("when counting the usage fails", func() {
+					BeforeEach(func() {
+						fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{}, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team is not found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
 	Describe("DELETE /api/v1/teams/:team_name", func() {
 		var request *http.Request
 		var response *http.Response
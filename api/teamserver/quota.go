@@ -0,0 +1,53 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+)
+
+func (s *Server) GetTeamQuota(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("get-team-quota")
+
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if !authTeamFound {
+		hLog.Error("failed-to-get-team-from-auth", errors.New("failed-to-get-team-from-auth"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	teamName := r.FormValue(":team_name")
+
+	if !authTeam.IsAdmin() && !authTeam.IsAuthorized(teamName) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	usage, err := team.QuotaUsage()
+	if err != nil {
+		hLog.Error("failed-to-get-team-quota-usage", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(atc.TeamQuotaStatus{
+		Quota: team.Quota(),
+		Usage: usage,
+	})
+}
@@ -43,6 +43,21 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 
 	hLog.Debug("configured-authentication", lager.Data{"BasicAuth": atcTeam.BasicAuth, "ProviderAuth": atcTeam.Auth})
 
+	if atcTeam.Quota != nil {
+		if !authTeam.IsAdmin() {
+			hLog.Info("only-admins-can-set-team-quotas")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		err = atcTeam.Quota.Validate()
+		if err != nil {
+			hLog.Error("invalid-team-quota", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
 	if atcTeam.BasicAuth != nil {
 		if atcTeam.BasicAuth.BasicAuthUsername == "" || atcTeam.BasicAuth.BasicAuthPassword == "" {
 			hLog.Info("missing-basic-auth-username-or-password")
@@ -122,5 +137,19 @@ func (s *Server) updateCredentials(atcTeam atc.Team, team db.Team) error {
 		return err
 	}
 
+	if atcTeam.DefaultJobPriority != nil {
+		err = team.UpdateDefaultJobPriority(*atcTeam.DefaultJobPriority)
+		if err != nil {
+			return err
+		}
+	}
+
+	if atcTeam.Quota != nil {
+		err = team.UpdateQuota(*atcTeam.Quota)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
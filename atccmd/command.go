@@ -30,11 +30,14 @@ import (
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/gc"
 	"github.com/concourse/atc/lockrunner"
+	"github.com/concourse/atc/logstore"
 	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/pipelines"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/syslog"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/web"
 	"github.com/concourse/atc/web/manifest"
 	"github.com/concourse/atc/web/publichandler"
@@ -47,11 +50,13 @@ import (
 	jwt "github.com/dgrijalva/jwt-go"
 	multierror "github.com/hashicorp/go-multierror"
 	flags "github.com/jessevdk/go-flags"
+	"github.com/mitchellh/mapstructure"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
 	"github.com/tedsuo/ifrit/http_server"
 	"github.com/tedsuo/ifrit/sigmon"
 	"github.com/xoebus/zest"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/auth/routes"
@@ -110,6 +115,8 @@ type ATCCommand struct {
 
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	BaseResourceTypeDefaults FileFlag `long:"base-resource-type-defaults" description:"YAML file mapping base resource type names to source defaults applied to all resources of that type."`
+
 	Developer struct {
 		Noop bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
 	} `group:"Developer Options"`
@@ -128,6 +135,8 @@ type ATCCommand struct {
 		YellerEnvironment string `long:"yeller-environment" description:"Environment to tag on all Yeller events emitted."`
 	} `group:"Metrics & Diagnostics"`
 
+	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
+
 	Server struct {
 		XFrameOptions string `long:"x-frame-options" description:"The value to set for X-Frame-Options. If omitted, the header is not set."`
 	} `group:"Web Server"`
@@ -137,10 +146,20 @@ type ATCCommand struct {
 	GC struct {
 		Interval          time.Duration `long:"interval" default:"30s" description:"Interval on which to perform garbage collection."`
 		WorkerConcurrency int           `long:"worker-concurrency" default:"50" description:"Maximum number of delete operations to have in flight per worker."`
+		ChecksToRetain    int           `long:"checks-to-retain" default:"100" description:"Number of check runs to keep in each resource's check history."`
+		ArtifactRetention time.Duration `long:"artifact-retention" default:"168h" description:"Duration for which the artifacts kept by builds are retained."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
+	BuildLogArchive logstore.Config `group:"Build Log Archiving" namespace:"build-log-archive"`
+
+	SyslogDrainer syslog.Config `group:"Syslog Drainer" namespace:"syslog"`
+
+	EnableBuildLogSearch bool `long:"enable-build-log-search" description:"Index the output of builds so that it can be searched through the API. Indexing adds to the cost of saving build events."`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
+	MaxActiveContainersPerWorker int `long:"max-active-containers-per-worker" default:"250" description:"Number of active containers at which a worker is considered busy. While workers or a team's quota are busy, pending builds wait for those of the team's higher priority jobs. Set to 0 to only consider quotas."`
+
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
 }
 
@@ -232,6 +251,10 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		return nil, err
 	}
 
+	if err := cmd.Tracing.Prepare(logger.Session("tracing")); err != nil {
+		return nil, fmt.Errorf("failed to configure tracing: %s", err)
+	}
+
 	connectionCountingDriverName := "connection-counting"
 	metric.SetupConnectionCountingDriver("postgres", cmd.Postgres.ConnectionString(), connectionCountingDriverName)
 
@@ -269,7 +292,17 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		oldKey = db.NewEncryptionKey(cmd.OldEncryptionKey.AEAD)
 	}
 
-	dbConn, err := cmd.constructDBConn(retryingDriverName, logger, newKey, oldKey)
+	logStore, err := cmd.BuildLogArchive.LogStore()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure build log archiving: %s", err)
+	}
+
+	baseResourceTypeDefaults, err := cmd.loadBaseResourceTypeDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	dbConn, err := cmd.constructDBConn(retryingDriverName, logger, newKey, oldKey, logStore, baseResourceTypeDefaults)
 	if err != nil {
 		return nil, err
 	}
@@ -292,12 +325,15 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	dbWorkerFactory := db.NewWorkerFactory(dbConn)
 	dbWorkerLifecycle := db.NewWorkerLifecycle(dbConn)
 	resourceConfigCheckSessionLifecycle := db.NewResourceConfigCheckSessionLifecycle(dbConn)
+	resourceCheckLifecycle := db.NewResourceCheckLifecycle(dbConn)
+	buildArtifactLifecycle := db.NewBuildArtifactLifecycle(dbConn)
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn)
 	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(dbConn)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
 	dbResourceConfigCheckSessionFactory := db.NewResourceConfigCheckSessionFactory(dbConn, lockFactory)
 	dbWorkerBaseResourceTypeFactory := db.NewWorkerBaseResourceTypeFactory(dbConn)
 	dbWorkerTaskCacheFactory := db.NewWorkerTaskCacheFactory(dbConn)
+	dbTaskResultFactory := db.NewTaskResultFactory(dbConn)
 	resourceFetcherFactory := resource.NewFetcherFactory(lockFactory, clock.NewClock(), dbResourceCacheFactory)
 	workerClient := cmd.constructWorkerPool(
 		logger,
@@ -317,13 +353,15 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	resourceFactory := resourceFactoryFactory.FactoryFor(workerClient)
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory)
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, dbTaskResultFactory, variablesFactory)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		resourceFactory,
 		dbResourceConfigCheckSessionFactory,
 		cmd.ResourceCheckingInterval,
 		engine,
+		teamFactory,
+		cmd.MaxActiveContainersPerWorker,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
@@ -551,6 +589,16 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 					logger.Session("resource-config-check-session-collector"),
 					resourceConfigCheckSessionLifecycle,
 				),
+				gc.NewResourceCheckCollector(
+					logger.Session("resource-check-collector"),
+					resourceCheckLifecycle,
+					cmd.GC.ChecksToRetain,
+				),
+				gc.NewBuildArtifactCollector(
+					logger.Session("build-artifact-collector"),
+					buildArtifactLifecycle,
+					cmd.GC.ArtifactRetention,
+				),
 			),
 			"collector",
 			lockFactory,
@@ -564,6 +612,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 				logger.Session("build-reaper"),
 				dbPipelineFactory,
 				500,
+				cmd.SyslogDrainer.IsConfigured(),
 			),
 			"build-reaper",
 			lockFactory,
@@ -586,6 +635,30 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		members = cmd.appendStaticWorker(logger, dbWorkerFactory, members)
 	}
 
+	if cmd.EnableBuildLogSearch {
+		members = append(members, grouper.Member{
+			Name: "build-log-indexer",
+			Runner: lockrunner.NewRunner(
+				logger.Session("build-log-indexer-runner"),
+				builds.NewLogIndexer(
+					logger.Session("build-log-indexer"),
+					db.NewBuildLogIndex(dbConn),
+				),
+				"build-log-indexer",
+				lockFactory,
+				clock.NewClock(),
+				time.Minute,
+			),
+		})
+	}
+
+	if cmd.SyslogDrainer.IsConfigured() {
+		members, err = cmd.appendSyslogDrainer(logger, dbBuildFactory, lockFactory, members)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if httpsHandler != nil {
 		cert, err := tls.LoadX509KeyPair(string(cmd.TLSCert), string(cmd.TLSKey))
 		if err != nil {
@@ -708,6 +781,13 @@ func (cmd *ATCCommand) validate() error {
 		)
 	}
 
+	if cmd.SyslogDrainer.IsConfigured() {
+		err := cmd.SyslogDrainer.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -743,8 +823,8 @@ func (cmd *ATCCommand) configureMetrics(logger lager.Logger) error {
 	return metric.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes)
 }
 
-func (cmd *ATCCommand) constructDBConn(driverName string, logger lager.Logger, newKey *db.EncryptionKey, oldKey *db.EncryptionKey) (db.Conn, error) {
-	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), newKey, oldKey)
+func (cmd *ATCCommand) constructDBConn(driverName string, logger lager.Logger, newKey *db.EncryptionKey, oldKey *db.EncryptionKey, logStore db.LogStore, baseResourceTypeDefaults map[string]atc.Source) (db.Conn, error) {
+	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), newKey, oldKey, logStore, baseResourceTypeDefaults)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %s", err)
 	}
@@ -841,6 +921,39 @@ func (cmd *ATCCommand) loadOrGenerateSigningKey() (*rsa.PrivateKey, error) {
 	return signingKey, nil
 }
 
+func (cmd *ATCCommand) loadBaseResourceTypeDefaults() (map[string]atc.Source, error) {
+	if cmd.BaseResourceTypeDefaults == "" {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(cmd.BaseResourceTypeDefaults.Path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base resource type defaults file: %s", err)
+	}
+
+	var payload map[string]interface{}
+	err = yaml.Unmarshal(content, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base resource type defaults: %s", err)
+	}
+
+	defaults := map[string]atc.Source{}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:     &defaults,
+		DecodeHook: atc.SanitizeDecodeHook,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base resource type defaults: %s", err)
+	}
+
+	return defaults, nil
+}
+
 func (cmd *ATCCommand) configureAuthForDefaultTeam(teamFactory db.TeamFactory) error {
 	team, found, err := teamFactory.FindTeam(atc.DefaultTeamName)
 	if err != nil {
@@ -889,6 +1002,7 @@ func (cmd *ATCCommand) constructEngine(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
+	dbTaskResultFactory db.TaskResultFactory,
 	variablesFactory creds.VariablesFactory,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
@@ -896,6 +1010,7 @@ func (cmd *ATCCommand) constructEngine(
 		resourceFetcher,
 		resourceFactory,
 		dbResourceCacheFactory,
+		dbTaskResultFactory,
 		variablesFactory,
 	)
 
@@ -978,6 +1093,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewAPIMetricsWrappa(logger),
+		wrappa.NewAPITracingWrappa(),
 		wrappa.NewAPIAuthWrappa(
 			authValidator,
 			getTokenValidator,
@@ -1022,6 +1138,8 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 		cmd.isTLSEnabled(),
 
+		cmd.EnableBuildLogSearch,
+
 		cmd.CLIArtifactsDir.Path(),
 		Version,
 		WorkerVersion,
@@ -1114,6 +1232,50 @@ func (cmd *ATCCommand) appendStaticWorker(
 	)
 }
 
+func (cmd *ATCCommand) appendSyslogDrainer(
+	logger lager.Logger,
+	buildFactory db.BuildFactory,
+	lockFactory lock.LockFactory,
+	members []grouper.Member,
+) ([]grouper.Member, error) {
+	tlsConfig, err := cmd.SyslogDrainer.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	hostname := cmd.SyslogDrainer.Hostname
+	if hostname == "" {
+		hostname, err = os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(members,
+		grouper.Member{
+			Name: "syslog-drainer",
+			Runner: lockrunner.NewRunner(
+				logger.Session("syslog-drainer-runner"),
+				syslog.NewDrainer(
+					logger.Session("syslog-drainer"),
+					buildFactory,
+					cmd.SyslogDrainer.Transport,
+					cmd.SyslogDrainer.Address,
+					tlsConfig,
+					hostname,
+					cmd.SyslogDrainer.AppName,
+					cmd.SyslogDrainer.StructuredDataID,
+					syslog.DefaultBatchSize,
+				),
+				"syslog-drainer",
+				lockFactory,
+				clock.NewClock(),
+				cmd.SyslogDrainer.Interval,
+			),
+		},
+	), nil
+}
+
 func (cmd *ATCCommand) isTLSEnabled() bool {
 	return cmd.TLSBindPort != 0
 }
@@ -9,6 +9,18 @@ const (
 	StatusFailed    BuildStatus = "failed"
 	StatusErrored   BuildStatus = "errored"
 	StatusAborted   BuildStatus = "aborted"
+
+	// a pending build that is waiting for someone to approve it
+	StatusAwaitingApproval BuildStatus = "awaiting_approval"
+)
+
+type ApprovalStatus string
+
+const (
+	ApprovalStatusAwaiting ApprovalStatus = "awaiting"
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+	ApprovalStatusTimedOut ApprovalStatus = "timed_out"
 )
 
 type Build struct {
@@ -23,11 +35,44 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+	RerunOf      int    `json:"rerun_of,omitempty"`
+	SupersededBy int    `json:"superseded_by,omitempty"`
+	TriggeredBy  int    `json:"triggered_by,omitempty"`
+	ScheduleTime int64  `json:"schedule_time,omitempty"`
+
+	ContainersHeldUntil int64 `json:"containers_held_until,omitempty"`
+
+	Approval *BuildApproval `json:"approval,omitempty"`
+
+	Metadata []MetadataField `json:"metadata,omitempty"`
+}
+
+type BuildApproval struct {
+	Status    ApprovalStatus `json:"status"`
+	Approvers []string       `json:"approvers,omitempty"`
+	Approver  string         `json:"approver,omitempty"`
+	Comment   string         `json:"comment,omitempty"`
+	Deadline  int64          `json:"deadline,omitempty"`
+}
+
+type ApprovalRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+type CreateJobBuildRequest struct {
+	InputOverrides map[string]InputVersionOverride `json:"input_overrides,omitempty"`
+}
+
+// InputVersionOverride selects the version of an input either by the version
+// itself or by the ID of the versioned resource.
+type InputVersionOverride struct {
+	Version             Version `json:"version,omitempty"`
+	VersionedResourceID int     `json:"versioned_resource_id,omitempty"`
 }
 
 func (b Build) IsRunning() bool {
 	switch BuildStatus(b.Status) {
-	case StatusPending, StatusStarted:
+	case StatusPending, StatusStarted, StatusAwaitingApproval:
 		return true
 	default:
 		return false
@@ -42,6 +87,50 @@ func (b Build) OneOff() bool {
 	return b.JobName == ""
 }
 
+type BuildArtifact struct {
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type TestReport struct {
+	StepName string   `json:"step_name"`
+	Total    int      `json:"total"`
+	Passed   int      `json:"passed"`
+	Failed   int      `json:"failed"`
+	Skipped  int      `json:"skipped"`
+	Failures []string `json:"failures"`
+}
+
+type BuildTestReports struct {
+	BuildID   int          `json:"build_id"`
+	BuildName string       `json:"build_name"`
+	Status    string       `json:"status"`
+	Reports   []TestReport `json:"reports"`
+}
+
+type FlakyTest struct {
+	StepName string `json:"step_name"`
+	Name     string `json:"name"`
+	Failures int    `json:"failures"`
+	Builds   int    `json:"builds"`
+}
+
+type TestHistory struct {
+	Builds     []BuildTestReports `json:"builds"`
+	FlakyTests []FlakyTest        `json:"flaky_tests"`
+}
+
+type BuildLogMatch struct {
+	BuildID      int    `json:"build_id"`
+	BuildName    string `json:"build_name"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	JobName      string `json:"job_name,omitempty"`
+
+	Line   string   `json:"line"`
+	Before []string `json:"before"`
+	After  []string `json:"after"`
+}
+
 type BuildPreparationStatus string
 
 const (
@@ -57,6 +146,8 @@ type BuildPreparation struct {
 	PausedPipeline      BuildPreparationStatus            `json:"paused_pipeline"`
 	PausedJob           BuildPreparationStatus            `json:"paused_job"`
 	MaxRunningBuilds    BuildPreparationStatus            `json:"max_running_builds"`
+	TeamQuota           BuildPreparationStatus            `json:"team_quota"`
+	Approval            BuildPreparationStatus            `json:"approval"`
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
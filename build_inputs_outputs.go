@@ -13,6 +13,7 @@ type PublicBuildInput struct {
 	Metadata        []MetadataField `json:"metadata"`
 	PipelineID      int             `json:"pipeline_id"`
 	FirstOccurrence bool            `json:"first_occurrence"`
+	Overridden      bool            `json:"overridden,omitempty"`
 }
 
 type VersionedResource struct {
@@ -40,6 +40,13 @@ var _ = Describe("Build", func() {
 			Expect(build.Abortable()).To(BeTrue())
 		})
 
+		It("returns true if the build is awaiting approval", func() {
+			build := atc.Build{
+				Status: string(atc.StatusAwaitingApproval),
+			}
+			Expect(build.IsRunning()).To(BeTrue())
+		})
+
 		It("returns false if in any other state", func() {
 			states := []atc.BuildStatus{
 				atc.StatusAborted,
@@ -0,0 +1,40 @@
+package builds
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+func NewLogIndexer(
+	logger lager.Logger,
+
+	buildLogIndex db.BuildLogIndex,
+) *LogIndexer {
+	return &LogIndexer{
+		logger:        logger,
+		buildLogIndex: buildLogIndex,
+	}
+}
+
+// LogIndexer indexes the build event tables of new pipelines and teams so
+// that their build logs can be searched.
+type LogIndexer struct {
+	logger lager.Logger
+
+	buildLogIndex db.BuildLogIndex
+}
+
+func (li *LogIndexer) Run() error {
+	iLog := li.logger.Session("index")
+
+	iLog.Debug("start")
+	defer iLog.Debug("done")
+
+	err := li.buildLogIndex.IndexBuildEvents(iLog)
+	if err != nil {
+		iLog.Error("failed-to-index-build-events", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package builds_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/builds"
+	"github.com/concourse/atc/db/dbfakes"
+)
+
+var _ = Describe("LogIndexer", func() {
+	var (
+		fakeBuildLogIndex *dbfakes.FakeBuildLogIndex
+
+		indexer *builds.LogIndexer
+	)
+
+	BeforeEach(func() {
+		fakeBuildLogIndex = new(dbfakes.FakeBuildLogIndex)
+
+		indexer = builds.NewLogIndexer(
+			lagertest.NewTestLogger("test"),
+			fakeBuildLogIndex,
+		)
+	})
+
+	Describe("Run", func() {
+		It("indexes the build events", func() {
+			Expect(indexer.Run()).To(Succeed())
+			Expect(fakeBuildLogIndex.IndexBuildEventsCallCount()).To(Equal(1))
+		})
+
+		Context("when indexing fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuildLogIndex.IndexBuildEventsReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(indexer.Run()).To(Equal(disaster))
+			})
+		})
+	})
+})
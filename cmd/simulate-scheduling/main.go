@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/scheduler/simulator"
+	"github.com/jessevdk/go-flags"
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v2"
+)
+
+// SimulateSchedulingCommand resolves a pipeline's job inputs against a
+// versions DB dump, as returned by the versions-db API endpoint, without
+// talking to an ATC.
+type SimulateSchedulingCommand struct {
+	VersionsDB flags.Filename `long:"versions-db" required:"true" description:"Path to a versions DB dump. May be gzipped."`
+	Config     flags.Filename `long:"config"      required:"true" description:"Path to the pipeline config (YAML or JSON)."`
+
+	Jobs       []string `long:"job"        description:"Job to resolve the inputs of. Can be specified multiple times. Defaults to every job."`
+	Candidates int      `long:"candidates" default:"5" description:"Number of latest versions per input to explain."`
+}
+
+func main() {
+	cmd := &SimulateSchedulingCommand{}
+
+	parser := flags.NewParser(cmd, flags.Default)
+	parser.NamespaceDelimiter = "-"
+
+	_, err := parser.Parse()
+	if err != nil {
+		os.Exit(1)
+	}
+
+	err = cmd.Execute()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func (cmd *SimulateSchedulingCommand) Execute() error {
+	versions, err := loadVersionsDB(string(cmd.VersionsDB))
+	if err != nil {
+		return fmt.Errorf("failed to load versions db: %s", err)
+	}
+
+	config, err := loadConfig(string(cmd.Config))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %s", err)
+	}
+
+	simulation := simulator.Simulation{
+		Versions:       versions,
+		Config:         config,
+		CandidateLimit: cmd.Candidates,
+	}
+
+	results, err := simulation.Run(cmd.Jobs)
+	if err != nil {
+		return err
+	}
+
+	simulation.Report(os.Stdout, results)
+
+	return nil
+}
+
+func loadVersionsDB(path string) (*algorithm.VersionsDB, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	var source io.Reader = reader
+
+	// dumps attached to bug reports are usually compressed
+	magic, err := reader.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		defer gr.Close()
+
+		source = gr
+	}
+
+	versions := &algorithm.VersionsDB{}
+	err = json.NewDecoder(source).Decode(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func loadConfig(path string) (atc.Config, error) {
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	// YAML is a superset of JSON, so this handles both
+	var configStructure interface{}
+	err = yaml.Unmarshal(payload, &configStructure)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	var config atc.Config
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &config,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			atc.SanitizeDecodeHook,
+			atc.VersionConfigDecodeHook,
+		),
+	})
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	err = decoder.Decode(configStructure)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return config, nil
+}
@@ -65,6 +65,7 @@ type ResourceType struct {
 	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
 	Privileged bool   `yaml:"privileged,omitempty" json:"privileged" mapstructure:"privileged"`
 	Tags       Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
+	Defaults   Source `yaml:"defaults,omitempty" json:"defaults,omitempty" mapstructure:"defaults"`
 }
 
 type ResourceTypes []ResourceType
@@ -79,6 +80,18 @@ func (types ResourceTypes) Lookup(name string) (ResourceType, bool) {
 	return ResourceType{}, false
 }
 
+// DefaultsFor returns the source defaults for resources of the given type,
+// falling back to the given base resource type defaults. A pipeline resource
+// type shadows a base resource type of the same name, so its defaults are
+// used instead of any configured for the base type.
+func (types ResourceTypes) DefaultsFor(typeName string, baseDefaults map[string]Source) Source {
+	if t, found := types.Lookup(typeName); found {
+		return t.Defaults
+	}
+
+	return baseDefaults[typeName]
+}
+
 func (types ResourceTypes) Without(name string) ResourceTypes {
 	newTypes := ResourceTypes{}
 	for _, t := range types {
@@ -221,6 +234,8 @@ type PlanConfig struct {
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
 	// jobs that this resource must have made it through
 	Passed []string `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
+	// jobs of which this resource must have made it through at least one
+	PassedAny []string `yaml:"passed_any,omitempty" json:"passed_any,omitempty" mapstructure:"passed_any"`
 	// whether to trigger based on this resource changing
 	Trigger bool `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
 
@@ -239,6 +254,10 @@ type PlanConfig struct {
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
+	// reuse the result of an earlier run of the task with the same config, image and inputs
+	CacheResult bool `yaml:"cache_result,omitempty" json:"cache_result,omitempty" mapstructure:"cache_result"`
+	// outputs of the task to keep as artifacts of the build once it finishes
+	Artifacts []string `yaml:"artifacts,omitempty" json:"artifacts,omitempty" mapstructure:"artifacts"`
 
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
@@ -0,0 +1,274 @@
+package creds
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// RedactedValue replaces the values of credentials in build logs.
+const RedactedValue = "((redacted))"
+
+// minRedactedLength is the length below which values are not redacted, as
+// masking every occurrence of e.g. "true" or "1" would mangle the logs without
+// protecting anything.
+const minRedactedLength = 4
+
+// maxHeldBack bounds the text RedactPrefix holds back, so that output which
+// does not break lines, e.g. a progress bar, is still shown as it is written.
+const maxHeldBack = 4096
+
+// SecretTracker records the values of the credentials interpolated for a
+// build.
+type SecretTracker interface {
+	Track(name string, value interface{})
+}
+
+// Redactor masks the values of the credentials interpolated for a build, and
+// their common encodings, in text printed by the build.
+type Redactor struct {
+	unredacted map[string]bool
+
+	secrets  map[string]bool
+	longest  int
+	replacer *strings.Replacer
+	lock     sync.RWMutex
+}
+
+// NewRedactor constructs a Redactor which does not redact the values of the
+// named variables.
+func NewRedactor(unredacted []string) *Redactor {
+	names := map[string]bool{}
+	for _, name := range unredacted {
+		names[name] = true
+	}
+
+	return &Redactor{
+		unredacted: names,
+		secrets:    map[string]bool{},
+	}
+}
+
+// Track records the value of a variable, which may be a string or a
+// structure of them, so that it is redacted.
+func (redactor *Redactor) Track(name string, value interface{}) {
+	if redactor.unredacted[name] {
+		return
+	}
+
+	redactor.lock.Lock()
+	defer redactor.lock.Unlock()
+
+	changed := false
+	for _, secret := range secretStrings(value) {
+		for _, encoded := range encodings(secret) {
+			if len(encoded) < minRedactedLength || redactor.secrets[encoded] {
+				continue
+			}
+
+			redactor.secrets[encoded] = true
+			changed = true
+
+			if len(encoded) > redactor.longest {
+				redactor.longest = len(encoded)
+			}
+		}
+	}
+
+	if changed {
+		redactor.replacer = newRedactingReplacer(redactor.secrets)
+	}
+}
+
+// Redact masks every tracked value in the text.
+func (redactor *Redactor) Redact(text string) string {
+	redactor.lock.RLock()
+	replacer := redactor.replacer
+	redactor.lock.RUnlock()
+
+	if replacer == nil {
+		return text
+	}
+
+	return replacer.Replace(text)
+}
+
+// RedactPrefix masks every tracked value in as much of the text as cannot be
+// the start of a value continued by text which is yet to be written. The rest
+// is returned so that it can be redacted along with what follows it; it is
+// always shorter than the longest tracked value unless one of the values
+// starts in it.
+//
+// Text before the last line break is never held back, as values spanning
+// lines are also tracked a line at a time, and no more than maxHeldBack bytes
+// are held back for output which does not break lines.
+func (redactor *Redactor) RedactPrefix(text string) (string, string) {
+	redactor.lock.RLock()
+	replacer := redactor.replacer
+	longest := redactor.longest
+	secrets := make([]string, 0, len(redactor.secrets))
+	for secret := range redactor.secrets {
+		secrets = append(secrets, secret)
+	}
+	redactor.lock.RUnlock()
+
+	if replacer == nil {
+		return text, ""
+	}
+
+	cut := len(text) - (longest - 1)
+	if cut < 0 {
+		cut = 0
+	}
+
+	// never cut through a value, as neither half would then be redacted
+	for moved := true; moved; {
+		moved = false
+
+		for _, secret := range secrets {
+			start := cut - len(secret) + 1
+			if start < 0 {
+				start = 0
+			}
+
+			i := strings.Index(text[start:], secret)
+			if i != -1 && start+i < cut {
+				cut = start + i
+				moved = true
+			}
+		}
+	}
+
+	if lineEnd := strings.LastIndex(text, "\n") + 1; lineEnd > cut {
+		cut = lineEnd
+	}
+
+	if minCut := len(text) - maxHeldBack; minCut > cut {
+		cut = minCut
+	}
+
+	for cut > 0 && cut < len(text) && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+
+	return replacer.Replace(text[:cut]), text[cut:]
+}
+
+// trackedVariables records the values of the variables it resolves.
+type trackedVariables struct {
+	Variables
+
+	tracker SecretTracker
+}
+
+// NewTrackedVariables wraps the variables so that the value of each variable
+// resolved is recorded by the tracker.
+func NewTrackedVariables(variables Variables, tracker SecretTracker) Variables {
+	if tracker == nil {
+		return variables
+	}
+
+	return trackedVariables{
+		Variables: variables,
+		tracker:   tracker,
+	}
+}
+
+func (variables trackedVariables) Get(definition template.VariableDefinition) (interface{}, bool, error) {
+	value, found, err := variables.Variables.Get(definition)
+	if err == nil && found {
+		variables.tracker.Track(definition.Name, value)
+	}
+
+	return value, found, err
+}
+
+func secretStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		secrets := []string{v}
+
+		// multi-line values, e.g. private keys, are usually printed a line at
+		// a time, with their own indentation
+		if strings.Contains(v, "\n") {
+			for _, line := range strings.Split(v, "\n") {
+				secrets = append(secrets, strings.TrimSpace(line))
+			}
+		}
+
+		return secrets
+	case map[interface{}]interface{}:
+		secrets := []string{}
+		for _, val := range v {
+			secrets = append(secrets, secretStrings(val)...)
+		}
+		return secrets
+	case map[string]interface{}:
+		secrets := []string{}
+		for _, val := range v {
+			secrets = append(secrets, secretStrings(val)...)
+		}
+		return secrets
+	case []interface{}:
+		secrets := []string{}
+		for _, val := range v {
+			secrets = append(secrets, secretStrings(val)...)
+		}
+		return secrets
+	default:
+		return nil
+	}
+}
+
+func encodings(secret string) []string {
+	encoded := []string{
+		secret,
+		base64.StdEncoding.EncodeToString([]byte(secret)),
+		base64.RawStdEncoding.EncodeToString([]byte(secret)),
+		base64.URLEncoding.EncodeToString([]byte(secret)),
+		url.QueryEscape(secret),
+	}
+
+	escaped, err := json.Marshal(secret)
+	if err == nil {
+		encoded = append(encoded, strings.Trim(string(escaped), `"`))
+	}
+
+	return encoded
+}
+
+func newRedactingReplacer(secrets map[string]bool) *strings.Replacer {
+	sorted := []string{}
+	for secret := range secrets {
+		sorted = append(sorted, secret)
+	}
+
+	// replace the longest values first, so that a value which contains
+	// another is masked entirely
+	sort.Sort(byLengthDescending(sorted))
+
+	oldnew := []string{}
+	for _, secret := range sorted {
+		oldnew = append(oldnew, secret, RedactedValue)
+	}
+
+	return strings.NewReplacer(oldnew...)
+}
+
+type byLengthDescending []string
+
+func (s byLengthDescending) Len() int      { return len(s) }
+func (s byLengthDescending) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byLengthDescending) Less(i, j int) bool {
+	if len(s[i]) != len(s[j]) {
+		return len(s[i]) > len(s[j])
+	}
+
+	return s[i] < s[j]
+}
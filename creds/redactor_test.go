@@ -0,0 +1,191 @@
+package creds_test
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redactor", func() {
+	var redactor *creds.Redactor
+
+	BeforeEach(func() {
+		redactor = creds.NewRedactor([]string{"some-public-var"})
+	})
+
+	Describe("Redact", func() {
+		Context("when nothing has been tracked", func() {
+			It("returns the text as-is", func() {
+				Expect(redactor.Redact("some text")).To(Equal("some text"))
+			})
+		})
+
+		Context("when a value has been tracked", func() {
+			BeforeEach(func() {
+				redactor.Track("some-secret", "s3cret/value&more")
+			})
+
+			It("redacts the value", func() {
+				Expect(redactor.Redact("password: s3cret/value&more")).To(Equal("password: ((redacted))"))
+			})
+
+			It("redacts its base64 encoding", func() {
+				Expect(redactor.Redact("czNjcmV0L3ZhbHVlJm1vcmU=")).To(Equal("((redacted))"))
+			})
+
+			It("redacts its URL encoding", func() {
+				Expect(redactor.Redact("https://example.com/?p=s3cret%2Fvalue%26more")).To(Equal("https://example.com/?p=((redacted))"))
+			})
+
+			It("redacts its JSON encoding", func() {
+				redactor.Track("some-quoted-secret", `some "quoted" value`)
+				Expect(redactor.Redact(`{"p":"some \"quoted\" value"}`)).To(Equal(`{"p":"((redacted))"}`))
+			})
+		})
+
+		Context("when a multi-line value has been tracked", func() {
+			BeforeEach(func() {
+				redactor.Track("some-key", "first-line\n  second-line\n")
+			})
+
+			It("redacts each of its lines", func() {
+				Expect(redactor.Redact("  second-line")).To(Equal("  ((redacted))"))
+			})
+		})
+
+		Context("when a structured value has been tracked", func() {
+			BeforeEach(func() {
+				redactor.Track("some-creds", map[interface{}]interface{}{
+					"username": "some-username",
+					"password": []interface{}{"some-password"},
+				})
+			})
+
+			It("redacts each of its strings", func() {
+				Expect(redactor.Redact("some-username:some-password")).To(Equal("((redacted)):((redacted))"))
+			})
+		})
+
+		Context("when a value containing another has been tracked", func() {
+			BeforeEach(func() {
+				redactor.Track("some-secret", "secret")
+				redactor.Track("some-longer-secret", "secret-and-more")
+			})
+
+			It("redacts the longer value entirely", func() {
+				Expect(redactor.Redact("secret-and-more")).To(Equal("((redacted))"))
+			})
+		})
+
+		Context("when a short value has been tracked", func() {
+			BeforeEach(func() {
+				redactor.Track("some-flag", "yes")
+			})
+
+			It("does not redact it", func() {
+				Expect(redactor.Redact("yes")).To(Equal("yes"))
+			})
+		})
+
+		Context("when a variable which opted out has been tracked", func() {
+			BeforeEach(func() {
+				redactor.Track("some-public-var", "some-public-value")
+			})
+
+			It("does not redact it", func() {
+				Expect(redactor.Redact("some-public-value")).To(Equal("some-public-value"))
+			})
+		})
+	})
+
+	Describe("RedactPrefix", func() {
+		Context("when nothing has been tracked", func() {
+			It("holds nothing back", func() {
+				redacted, rest := redactor.RedactPrefix("some text")
+				Expect(redacted).To(Equal("some text"))
+				Expect(rest).To(BeEmpty())
+			})
+		})
+
+		Context("when a value has been tracked", func() {
+			BeforeEach(func() {
+				// the longest encoding is its 24 character base64 encoding
+				redactor.Track("some-secret", "some-secret-value")
+			})
+
+			It("holds back text which could be the start of a value", func() {
+				text := strings.Repeat("x", 30)
+
+				redacted, rest := redactor.RedactPrefix(text)
+				Expect(redacted).To(Equal(strings.Repeat("x", 7)))
+				Expect(rest).To(Equal(strings.Repeat("x", 23)))
+			})
+
+			It("holds back everything while the text is shorter than the values", func() {
+				redacted, rest := redactor.RedactPrefix("some-secr")
+				Expect(redacted).To(BeEmpty())
+				Expect(rest).To(Equal("some-secr"))
+			})
+
+			It("does not cut through a value", func() {
+				text := strings.Repeat("x", 40) + "some-secret-value" + strings.Repeat("y", 10)
+
+				redacted, rest := redactor.RedactPrefix(text)
+				Expect(redacted).To(Equal(strings.Repeat("x", 40)))
+				Expect(rest).To(Equal("some-secret-value" + strings.Repeat("y", 10)))
+			})
+
+			It("redacts the values before the cut", func() {
+				text := "some-secret-value" + strings.Repeat("y", 40)
+
+				redacted, rest := redactor.RedactPrefix(text)
+				Expect(redacted).To(Equal("((redacted))" + strings.Repeat("y", 17)))
+				Expect(rest).To(Equal(strings.Repeat("y", 23)))
+			})
+
+			It("does not hold back text before the last line break", func() {
+				text := strings.Repeat("x", 30) + "\nsome-secret-value\nsome"
+
+				redacted, rest := redactor.RedactPrefix(text)
+				Expect(redacted).To(Equal(strings.Repeat("x", 30) + "\n((redacted))\n"))
+				Expect(rest).To(Equal("some"))
+			})
+
+			It("holds back no more than a few kilobytes of text without line breaks", func() {
+				redactor.Track("some-long-secret", strings.Repeat("y", 6000))
+
+				text := strings.Repeat("x", 10000)
+
+				redacted, rest := redactor.RedactPrefix(text)
+				Expect(redacted).To(Equal(strings.Repeat("x", 10000-4096)))
+				Expect(rest).To(Equal(strings.Repeat("x", 4096)))
+			})
+
+			It("does not cut through a multi-byte rune", func() {
+				text := strings.Repeat("x", 6) + "é" + strings.Repeat("x", 22)
+
+				redacted, rest := redactor.RedactPrefix(text)
+				Expect(redacted).To(Equal(strings.Repeat("x", 6)))
+				Expect(rest).To(Equal("é" + strings.Repeat("x", 22)))
+			})
+		})
+	})
+
+	Describe("NewTrackedVariables", func() {
+		It("tracks the values of the variables interpolated", func() {
+			variables := creds.NewTrackedVariables(template.StaticVariables{
+				"some-param": "some-secret-value",
+			}, redactor)
+
+			_, err := creds.NewSource(variables, atc.Source{"key": "((some-param))"}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(redactor.Redact("some-secret-value")).To(Equal("((redacted))"))
+		})
+	})
+})
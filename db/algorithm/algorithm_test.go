@@ -819,4 +819,141 @@ var _ = DescribeTable("Input resolving",
 			},
 		},
 	}),
+
+	Entry("resolves passed_any to the latest version that passed any of the jobs", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+
+				// passed b but not a
+				{Job: "simple-b", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				PassedAny: []string{"simple-a", "simple-b"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("does not resolve passed_any when no version passed any of the jobs", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			},
+			BuildOutputs: []DBRow{
+				{Job: "simple-c", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				PassedAny: []string{"simple-a", "simple-b"},
+			},
+		},
+
+		Result: Result{
+			OK:     false,
+			Values: map[string]string{},
+		},
+	}),
+
+	Entry("combines passed and passed_any on the same input", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-b", BuildID: 2, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+
+				// passed a and c
+				{Job: "simple-a", BuildID: 3, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "simple-c", BuildID: 4, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+
+				// passed b and c but not a
+				{Job: "simple-b", BuildID: 5, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+				{Job: "simple-c", BuildID: 6, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				Passed:    []string{"simple-a"},
+				PassedAny: []string{"simple-b", "simple-c"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("does not require passed_any inputs to come from common builds", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-a", BuildID: 1, Resource: "resource-y", Version: "ryv1", CheckOrder: 1},
+
+				// newer x from a, newer y from b; never built together
+				{Job: "simple-a", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "simple-b", BuildID: 3, Resource: "resource-y", Version: "ryv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", PassedAny: []string{"simple-a", "simple-b"}},
+			{Name: "resource-y", Resource: "resource-y", PassedAny: []string{"simple-a", "simple-b"}},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+				"resource-y": "ryv2",
+			},
+		},
+	}),
+
+	Entry("keeps passed inputs on common builds alongside a passed_any input", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-a", BuildID: 1, Resource: "resource-y", Version: "ryv1", CheckOrder: 1},
+
+				// y went through b only; x must still match a's build
+				{Job: "simple-a", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "simple-b", BuildID: 3, Resource: "resource-y", Version: "ryv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Passed: []string{"simple-a"}},
+			{Name: "resource-y", Resource: "resource-y", Passed: []string{"simple-a"}},
+			{Name: "resource-y-any", Resource: "resource-y", PassedAny: []string{"simple-a", "simple-b"}},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x":     "rxv1",
+				"resource-y":     "ryv1",
+				"resource-y-any": "ryv2",
+			},
+		},
+	}),
 )
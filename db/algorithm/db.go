@@ -116,3 +116,36 @@ func (db VersionsDB) VersionsOfResourcePassedJobs(resourceID int, passed JobSet)
 
 	return candidates
 }
+
+func (db VersionsDB) VersionsOfResourcePassedAnyJobs(resourceID int, passedAny JobSet) VersionCandidates {
+	candidates := VersionCandidates{}
+
+	for _, output := range db.BuildOutputs {
+		if output.ResourceID != resourceID {
+			continue
+		}
+
+		if _, found := passedAny[output.JobID]; !found {
+			continue
+		}
+
+		candidates.Add(VersionCandidate{
+			VersionID:  output.VersionID,
+			CheckOrder: output.CheckOrder,
+		})
+	}
+
+	return candidates
+}
+
+func (db VersionsDB) VersionPassedJob(resourceID int, versionID int, jobID int) bool {
+	for _, output := range db.BuildOutputs {
+		if output.ResourceID == resourceID &&
+			output.VersionID == versionID &&
+			output.JobID == jobID {
+			return true
+		}
+	}
+
+	return false
+}
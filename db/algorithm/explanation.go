@@ -0,0 +1,145 @@
+package algorithm
+
+type Reason string
+
+const (
+	ReasonDisabled          Reason = "version is disabled"
+	ReasonNotPinned         Reason = "input is pinned to another version"
+	ReasonNotLatest         Reason = "a newer version is available"
+	ReasonNotPassed         Reason = "version has not passed all of the required jobs"
+	ReasonNotPassedAny      Reason = "version has not passed any of the passed_any jobs"
+	ReasonNoCompatibleInput Reason = "no versions of the other inputs satisfy the passed constraints together with this version"
+	ReasonNotChosen         Reason = "another version was chosen"
+)
+
+type InputExplanation struct {
+	Name       string
+	ResourceID int
+	Passed     JobSet
+	PassedAny  JobSet
+
+	Resolved        bool
+	VersionID       int
+	FirstOccurrence bool
+
+	Candidates []CandidateExplanation
+}
+
+type CandidateExplanation struct {
+	VersionID int
+	Selected  bool
+	Reason    Reason
+	NotPassed JobSet
+}
+
+// Explain reports, for each input, whether it resolved to a version for the
+// next build and why each of the given candidate versions was or was not
+// chosen.
+func (configs InputConfigs) Explain(db *VersionsDB, candidates map[string][]int) []InputExplanation {
+	mapping, resolved := configs.Resolve(db)
+
+	explanations := []InputExplanation{}
+	for i, inputConfig := range configs {
+		explanation := InputExplanation{
+			Name:       inputConfig.Name,
+			ResourceID: inputConfig.ResourceID,
+			Passed:     inputConfig.Passed,
+			PassedAny:  inputConfig.PassedAny,
+			Candidates: []CandidateExplanation{},
+		}
+
+		if resolved {
+			inputVersion := mapping[inputConfig.Name]
+			explanation.Resolved = true
+			explanation.VersionID = inputVersion.VersionID
+			explanation.FirstOccurrence = inputVersion.FirstOccurrence
+		}
+
+		for _, versionID := range candidates[inputConfig.Name] {
+			candidate := configs.explainCandidate(db, i, versionID)
+			if resolved && candidate.Reason == "" {
+				if explanation.VersionID == versionID {
+					candidate.Selected = true
+				} else {
+					candidate.Reason = ReasonNotChosen
+				}
+			}
+
+			explanation.Candidates = append(explanation.Candidates, candidate)
+		}
+
+		explanations = append(explanations, explanation)
+	}
+
+	return explanations
+}
+
+func (configs InputConfigs) explainCandidate(db *VersionsDB, input int, versionID int) CandidateExplanation {
+	inputConfig := configs[input]
+	candidate := CandidateExplanation{VersionID: versionID}
+
+	// disabled versions are not loaded into the versions db
+	_, found := db.FindVersionOfResource(inputConfig.ResourceID, versionID)
+	if !found {
+		candidate.Reason = ReasonDisabled
+		return candidate
+	}
+
+	if inputConfig.PinnedVersionID != 0 && inputConfig.PinnedVersionID != versionID {
+		candidate.Reason = ReasonNotPinned
+		return candidate
+	}
+
+	if len(inputConfig.Passed) == 0 && len(inputConfig.PassedAny) == 0 {
+		if inputConfig.PinnedVersionID == 0 && !inputConfig.UseEveryVersion {
+			latest, found := db.LatestVersionOfResource(inputConfig.ResourceID)
+			if found && latest.VersionID != versionID {
+				candidate.Reason = ReasonNotLatest
+				return candidate
+			}
+		}
+
+		return candidate
+	}
+
+	notPassed := JobSet{}
+	for jobID := range inputConfig.Passed {
+		if !db.VersionPassedJob(inputConfig.ResourceID, versionID, jobID) {
+			notPassed[jobID] = struct{}{}
+		}
+	}
+
+	if len(notPassed) > 0 {
+		candidate.Reason = ReasonNotPassed
+		candidate.NotPassed = notPassed
+		return candidate
+	}
+
+	if len(inputConfig.PassedAny) != 0 {
+		passedAny := false
+		for jobID := range inputConfig.PassedAny {
+			if db.VersionPassedJob(inputConfig.ResourceID, versionID, jobID) {
+				passedAny = true
+				break
+			}
+		}
+
+		if !passedAny {
+			candidate.Reason = ReasonNotPassedAny
+			candidate.NotPassed = inputConfig.PassedAny
+			return candidate
+		}
+	}
+
+	pinned := make(InputConfigs, len(configs))
+	copy(pinned, configs)
+	pinned[input].UseEveryVersion = false
+	pinned[input].PinnedVersionID = versionID
+
+	_, ok := pinned.Resolve(db)
+	if !ok {
+		candidate.Reason = ReasonNoCompatibleInput
+	}
+
+	return candidate
+}
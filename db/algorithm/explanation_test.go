@@ -0,0 +1,184 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var (
+		versionsDB   *algorithm.VersionsDB
+		inputConfigs algorithm.InputConfigs
+		candidates   map[string][]int
+
+		explanations []algorithm.InputExplanation
+	)
+
+	output := func(jobID, buildID, resourceID, versionID, checkOrder int) algorithm.BuildOutput {
+		return algorithm.BuildOutput{
+			ResourceVersion: algorithm.ResourceVersion{VersionID: versionID, ResourceID: resourceID, CheckOrder: checkOrder},
+			BuildID:         buildID,
+			JobID:           jobID,
+		}
+	}
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 21, CheckOrder: 3},
+				{VersionID: 4, ResourceID: 21, CheckOrder: 4},
+				{VersionID: 5, ResourceID: 22, CheckOrder: 1},
+				{VersionID: 6, ResourceID: 22, CheckOrder: 2},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				output(11, 31, 21, 1, 1),
+				output(11, 31, 22, 5, 1),
+				output(12, 32, 21, 1, 1),
+
+				output(11, 33, 21, 2, 2),
+				output(11, 33, 22, 6, 2),
+				output(12, 34, 21, 2, 2),
+
+				// passed both jobs, but never together with a version of r2
+				output(11, 35, 21, 3, 3),
+				output(12, 36, 21, 3, 3),
+
+				// passed only j1
+				output(11, 37, 21, 4, 4),
+			},
+			BuildInputs: []algorithm.BuildInput{},
+			JobIDs:      map[string]int{"j1": 11, "j2": 12, "current": 13},
+			ResourceIDs: map[string]int{"r1": 21, "r2": 22},
+		}
+
+		// 99 is a disabled version, which is not loaded into the versions db
+		candidates = map[string][]int{
+			"a": {99, 4, 3, 2, 1},
+			"b": {6, 5},
+		}
+	})
+
+	JustBeforeEach(func() {
+		explanations = inputConfigs.Explain(versionsDB, candidates)
+	})
+
+	Context("when the inputs have no passed constraints", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "a", ResourceID: 21, Passed: algorithm.JobSet{}, JobID: 13},
+			}
+		})
+
+		It("selects the latest version and rules out the rest", func() {
+			Expect(explanations).To(Equal([]algorithm.InputExplanation{
+				{
+					Name:            "a",
+					ResourceID:      21,
+					Passed:          algorithm.JobSet{},
+					Resolved:        true,
+					VersionID:       4,
+					FirstOccurrence: true,
+					Candidates: []algorithm.CandidateExplanation{
+						{VersionID: 99, Reason: algorithm.ReasonDisabled},
+						{VersionID: 4, Selected: true},
+						{VersionID: 3, Reason: algorithm.ReasonNotLatest},
+						{VersionID: 2, Reason: algorithm.ReasonNotLatest},
+						{VersionID: 1, Reason: algorithm.ReasonNotLatest},
+					},
+				},
+			}))
+		})
+
+		Context("when the input is pinned", func() {
+			BeforeEach(func() {
+				inputConfigs[0].PinnedVersionID = 2
+			})
+
+			It("rules out every other version", func() {
+				Expect(explanations[0].VersionID).To(Equal(2))
+				Expect(explanations[0].Candidates).To(Equal([]algorithm.CandidateExplanation{
+					{VersionID: 99, Reason: algorithm.ReasonDisabled},
+					{VersionID: 4, Reason: algorithm.ReasonNotPinned},
+					{VersionID: 3, Reason: algorithm.ReasonNotPinned},
+					{VersionID: 2, Selected: true},
+					{VersionID: 1, Reason: algorithm.ReasonNotPinned},
+				}))
+			})
+		})
+	})
+
+	Context("when the inputs have passed constraints", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "a", ResourceID: 21, Passed: algorithm.JobSet{11: {}, 12: {}}, JobID: 13},
+				{Name: "b", ResourceID: 22, Passed: algorithm.JobSet{11: {}}, JobID: 13},
+			}
+		})
+
+		It("explains which constraint ruled out each version", func() {
+			Expect(explanations[0].Resolved).To(BeTrue())
+			Expect(explanations[0].VersionID).To(Equal(2))
+			Expect(explanations[0].Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 99, Reason: algorithm.ReasonDisabled},
+				{VersionID: 4, Reason: algorithm.ReasonNotPassed, NotPassed: algorithm.JobSet{12: {}}},
+				{VersionID: 3, Reason: algorithm.ReasonNoCompatibleInput},
+				{VersionID: 2, Selected: true},
+				{VersionID: 1, Reason: algorithm.ReasonNotChosen},
+			}))
+
+			Expect(explanations[1].Resolved).To(BeTrue())
+			Expect(explanations[1].VersionID).To(Equal(6))
+			Expect(explanations[1].Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 6, Selected: true},
+				{VersionID: 5, Reason: algorithm.ReasonNotChosen},
+			}))
+		})
+
+		Context("when no version satisfies the constraints", func() {
+			BeforeEach(func() {
+				versionsDB.BuildOutputs = []algorithm.BuildOutput{
+					output(11, 31, 21, 1, 1),
+				}
+			})
+
+			It("reports the inputs as unresolved", func() {
+				Expect(explanations[0].Resolved).To(BeFalse())
+				Expect(explanations[0].Candidates).To(ContainElement(algorithm.CandidateExplanation{
+					VersionID: 1,
+					Reason:    algorithm.ReasonNotPassed,
+					NotPassed: algorithm.JobSet{12: {}},
+				}))
+
+				Expect(explanations[1].Resolved).To(BeFalse())
+				Expect(explanations[1].Candidates).To(Equal([]algorithm.CandidateExplanation{
+					{VersionID: 6, Reason: algorithm.ReasonNotPassed, NotPassed: algorithm.JobSet{11: {}}},
+					{VersionID: 5, Reason: algorithm.ReasonNotPassed, NotPassed: algorithm.JobSet{11: {}}},
+				}))
+			})
+		})
+	})
+
+	Context("when the input has passed_any constraints", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "a", ResourceID: 21, Passed: algorithm.JobSet{}, PassedAny: algorithm.JobSet{12: {}}, JobID: 13},
+			}
+		})
+
+		It("rules out versions that passed none of the jobs", func() {
+			Expect(explanations[0].Resolved).To(BeTrue())
+			Expect(explanations[0].VersionID).To(Equal(3))
+			Expect(explanations[0].PassedAny).To(Equal(algorithm.JobSet{12: {}}))
+			Expect(explanations[0].Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 99, Reason: algorithm.ReasonDisabled},
+				{VersionID: 4, Reason: algorithm.ReasonNotPassedAny, NotPassed: algorithm.JobSet{12: {}}},
+				{VersionID: 3, Selected: true},
+				{VersionID: 2, Reason: algorithm.ReasonNotChosen},
+				{VersionID: 1, Reason: algorithm.ReasonNotChosen},
+			}))
+		})
+	})
+})
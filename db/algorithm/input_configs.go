@@ -6,6 +6,7 @@ type InputConfig struct {
 	Name            string
 	JobName         string
 	Passed          JobSet
+	PassedAny       JobSet
 	UseEveryVersion bool
 	PinnedVersionID int
 	ResourceID      int
@@ -19,7 +20,7 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 	for _, inputConfig := range configs {
 		versionCandidates := VersionCandidates{}
 
-		if len(inputConfig.Passed) == 0 {
+		if len(inputConfig.Passed) == 0 && len(inputConfig.PassedAny) == 0 {
 			if inputConfig.UseEveryVersion {
 				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID)
 			} else {
@@ -41,12 +42,29 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 				return nil, false
 			}
 		} else {
-			jobs = jobs.Union(inputConfig.Passed)
+			if len(inputConfig.Passed) != 0 {
+				jobs = jobs.Union(inputConfig.Passed)
 
-			versionCandidates = db.VersionsOfResourcePassedJobs(
-				inputConfig.ResourceID,
-				inputConfig.Passed,
-			)
+				versionCandidates = db.VersionsOfResourcePassedJobs(
+					inputConfig.ResourceID,
+					inputConfig.Passed,
+				)
+			}
+
+			if len(inputConfig.PassedAny) != 0 {
+				// passed_any jobs are deliberately left out of the common build
+				// pruning; a version only has to have come out of one of them
+				passedAnyCandidates := db.VersionsOfResourcePassedAnyJobs(
+					inputConfig.ResourceID,
+					inputConfig.PassedAny,
+				)
+
+				if len(inputConfig.Passed) == 0 {
+					versionCandidates = passedAnyCandidates
+				} else {
+					versionCandidates = versionCandidates.IntersectByVersion(passedAnyCandidates)
+				}
+			}
 
 			if versionCandidates.IsEmpty() {
 				return nil, false
@@ -35,10 +35,11 @@ type Example struct {
 type Inputs []Input
 
 type Input struct {
-	Name     string
-	Resource string
-	Passed   []string
-	Version  Version
+	Name      string
+	Resource  string
+	Passed    []string
+	PassedAny []string
+	Version   Version
 }
 
 type Version struct {
@@ -147,6 +148,11 @@ func (example Example) Run() {
 			passed[jobIDs.ID(jobName)] = struct{}{}
 		}
 
+		passedAny := algorithm.JobSet{}
+		for _, jobName := range input.PassedAny {
+			passedAny[jobIDs.ID(jobName)] = struct{}{}
+		}
+
 		var versionID int
 		if input.Version.Pinned != "" {
 			versionID = versionIDs.ID(input.Version.Pinned)
@@ -155,6 +161,7 @@ func (example Example) Run() {
 		inputConfigs[i] = algorithm.InputConfig{
 			Name:            input.Name,
 			Passed:          passed,
+			PassedAny:       passedAny,
 			ResourceID:      resourceIDs.ID(input.Resource),
 			UseEveryVersion: input.Version.Every,
 			PinnedVersionID: versionID,
@@ -27,7 +27,7 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce").
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.approval_status, b.approvers, b.approver, b.approval_comment, b.approval_deadline, b.rerun_of, b.schedule_time, b.superseded_by, b.triggered_by, b.metadata, b.logs_archived, b.hold_containers_until, b.drained").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
@@ -48,11 +48,24 @@ type Build interface {
 	EngineMetadata() string
 	PublicPlan() *json.RawMessage
 	Status() BuildStatus
+	CreateTime() time.Time
 	StartTime() time.Time
 	EndTime() time.Time
 	ReapTime() time.Time
 	IsManuallyTriggered() bool
 	IsScheduled() bool
+	RerunOf() int
+	SupersededBy() int
+	TriggeredBy() int
+	Metadata() ResourceMetadataFields
+	ScheduleTime() time.Time
+	ContainersHeldUntil() time.Time
+
+	ApprovalStatus() atc.ApprovalStatus
+	Approvers() []string
+	Approver() string
+	ApprovalComment() string
+	ApprovalDeadline() time.Time
 
 	IsRunning() bool
 
@@ -68,14 +81,28 @@ type Build interface {
 
 	SetInterceptible(bool) error
 
+	SetHoldContainersOnFailure(time.Duration) error
+	HoldContainers(time.Duration) (bool, error)
+
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
 
+	IsDrained() bool
+	UndrainedEvents(limit int) ([]BuildEvent, error)
+	SaveDrainOffset(offset int) error
+	MarkDrained() error
+
 	SaveInput(input BuildInput) error
 	SaveOutput(vr VersionedResource, explicit bool) error
 	UseInputs(inputs []BuildInput) error
+	InputOverrides() (map[string]int, error)
 
 	Resources() ([]BuildInput, []BuildOutput, error)
+	Artifacts() ([]BuildArtifact, error)
+	Artifact(name string) (BuildArtifact, bool, error)
+	SaveTestReport(report TestReport) error
+	SaveMetadata(metadata ResourceMetadataFields) error
+	TestReports() ([]TestReport, error)
 	GetVersionedResources() (SavedVersionedResources, error)
 	SaveImageResourceVersion(*UsedResourceCache) error
 
@@ -85,6 +112,9 @@ type Build interface {
 	MarkAsAborted() error
 	AbortNotifier() (Notifier, error)
 	Schedule() (bool, error)
+
+	RequestApproval(approvers []string, deadline time.Time, inputs []BuildInput) (bool, error)
+	ResolveApproval(status atc.ApprovalStatus, approver string, comment string) (bool, error)
 }
 
 type build struct {
@@ -102,14 +132,31 @@ type build struct {
 	jobName      string
 
 	isManuallyTriggered bool
+	rerunOf             int
+	supersededBy        int
+	triggeredBy         int
+	metadata            ResourceMetadataFields
+	scheduleTime        time.Time
 
 	engine         string
 	engineMetadata string
 	publicPlan     *json.RawMessage
 
-	startTime time.Time
-	endTime   time.Time
-	reapTime  time.Time
+	createTime time.Time
+	startTime  time.Time
+	endTime    time.Time
+	reapTime   time.Time
+
+	logsArchived bool
+	drained      bool
+
+	containersHeldUntil time.Time
+
+	approvalStatus   atc.ApprovalStatus
+	approvers        []string
+	approver         string
+	approvalComment  string
+	approvalDeadline time.Time
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -117,23 +164,47 @@ type build struct {
 
 var ErrBuildDisappeared = errors.New("build-disappeared-from-db")
 
-func (b *build) ID() int                      { return b.id }
-func (b *build) Name() string                 { return b.name }
-func (b *build) JobID() int                   { return b.jobID }
-func (b *build) JobName() string              { return b.jobName }
-func (b *build) PipelineID() int              { return b.pipelineID }
-func (b *build) PipelineName() string         { return b.pipelineName }
-func (b *build) TeamID() int                  { return b.teamID }
-func (b *build) TeamName() string             { return b.teamName }
-func (b *build) IsManuallyTriggered() bool    { return b.isManuallyTriggered }
-func (b *build) Engine() string               { return b.engine }
-func (b *build) EngineMetadata() string       { return b.engineMetadata }
-func (b *build) PublicPlan() *json.RawMessage { return b.publicPlan }
-func (b *build) StartTime() time.Time         { return b.startTime }
-func (b *build) EndTime() time.Time           { return b.endTime }
-func (b *build) ReapTime() time.Time          { return b.reapTime }
-func (b *build) Status() BuildStatus          { return b.status }
-func (b *build) IsScheduled() bool            { return b.scheduled }
+// ErrNoLogStore is returned when reading the events of a build which were
+// archived while no log store is configured to read them back from.
+var ErrNoLogStore = errors.New("build events are archived but no log store is configured")
+
+func (b *build) ID() int                          { return b.id }
+func (b *build) Name() string                     { return b.name }
+func (b *build) JobID() int                       { return b.jobID }
+func (b *build) JobName() string                  { return b.jobName }
+func (b *build) PipelineID() int                  { return b.pipelineID }
+func (b *build) PipelineName() string             { return b.pipelineName }
+func (b *build) TeamID() int                      { return b.teamID }
+func (b *build) TeamName() string                 { return b.teamName }
+func (b *build) IsManuallyTriggered() bool        { return b.isManuallyTriggered }
+func (b *build) Engine() string                   { return b.engine }
+func (b *build) EngineMetadata() string           { return b.engineMetadata }
+func (b *build) PublicPlan() *json.RawMessage     { return b.publicPlan }
+func (b *build) CreateTime() time.Time            { return b.createTime }
+func (b *build) StartTime() time.Time             { return b.startTime }
+func (b *build) EndTime() time.Time               { return b.endTime }
+func (b *build) ReapTime() time.Time              { return b.reapTime }
+func (b *build) Status() BuildStatus              { return b.status }
+func (b *build) IsScheduled() bool                { return b.scheduled }
+func (b *build) RerunOf() int                     { return b.rerunOf }
+func (b *build) SupersededBy() int                { return b.supersededBy }
+func (b *build) TriggeredBy() int                 { return b.triggeredBy }
+func (b *build) Metadata() ResourceMetadataFields { return b.metadata }
+func (b *build) ScheduleTime() time.Time          { return b.scheduleTime }
+
+// ContainersHeldUntil returns when the hold on the build's containers
+// expires, or the zero time if they have never been held.
+func (b *build) ContainersHeldUntil() time.Time { return b.containersHeldUntil }
+
+func (b *build) ApprovalStatus() atc.ApprovalStatus { return b.approvalStatus }
+func (b *build) Approvers() []string                { return b.approvers }
+func (b *build) Approver() string                   { return b.approver }
+func (b *build) ApprovalComment() string            { return b.approvalComment }
+func (b *build) ApprovalDeadline() time.Time        { return b.approvalDeadline }
+
+// IsDrained returns whether all of the build's events have been drained to
+// syslog. Builds which finished before the drainer existed count as drained.
+func (b *build) IsDrained() bool { return b.drained }
 
 func (b *build) IsRunning() bool {
 	switch b.status {
@@ -285,9 +356,25 @@ func (b *build) Finish(status BuildStatus) error {
 
 	defer tx.Rollback()
 
+	err = b.finish(tx, status)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return b.afterFinish(status)
+}
+
+// finish completes the build within the given transaction. Once it is
+// committed, afterFinish must be called.
+func (b *build) finish(tx Tx, status BuildStatus) error {
 	var endTime time.Time
 
-	err = psql.Update("builds").
+	err := psql.Update("builds").
 		Set("status", status).
 		Set("end_time", sq.Expr("now()")).
 		Set("completed", true).
@@ -327,16 +414,35 @@ func (b *build) Finish(status BuildStatus) error {
 		if err != nil {
 			return err
 		}
+
+		// outputs only count towards the versions DB once the build has
+		// succeeded, so mark them as modified for it to pick them up
+		_, err = psql.Update("build_outputs").
+			Set("modified_time", sq.Expr("now()")).
+			Where(sq.Eq{"build_id": b.id}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
 	}
 
-	err = tx.Commit()
+	return nil
+}
+
+// afterFinish notifies those watching the build that it has finished and
+// refreshes the views of builds per job.
+func (b *build) afterFinish(status BuildStatus) error {
+	err := b.conn.Bus().Notify(buildEventsChannel(b.id))
 	if err != nil {
 		return err
 	}
 
-	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
-	if err != nil {
-		return err
+	if status == BuildStatusFailed || status == BuildStatusErrored {
+		err = b.holdContainersOnFailure()
+		if err != nil {
+			return err
+		}
 	}
 
 	_, err = b.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY latest_completed_builds_per_job`)
@@ -357,6 +463,155 @@ func (b *build) Finish(status BuildStatus) error {
 	return nil
 }
 
+// SetHoldContainersOnFailure makes the build hold its containers for the
+// given duration should it fail, instead of its job's configured duration.
+func (b *build) SetHoldContainersOnFailure(duration time.Duration) error {
+	_, err := psql.Update("builds").
+		Set("hold_containers_on_failure", int(duration/time.Second)).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// HoldContainers keeps the build's containers from being garbage collected
+// for the given duration from now, extending any existing hold. Held builds
+// are kept interceptible so that their containers can be hijacked. The
+// containers are not held if they would take the team over its quota of
+// held containers.
+func (b *build) HoldContainers(duration time.Duration) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	// locking the team serializes the holds of its builds, so that they
+	// cannot exceed the quota together
+	var quotaJSON sql.NullString
+	err = psql.Select("quota").
+		From("teams").
+		Where(sq.Eq{"id": b.teamID}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&quotaJSON)
+	if err != nil {
+		return false, err
+	}
+
+	var quota atc.TeamQuota
+	if quotaJSON.Valid {
+		err = json.Unmarshal([]byte(quotaJSON.String), &quota)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	heldContainers, err := countHeldContainers(tx, b.teamID, b.id)
+	if err != nil {
+		return false, err
+	}
+
+	var containers int
+	err = psql.Select("COUNT(*)").
+		From("containers").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"state":    []string{ContainerStateCreating, ContainerStateCreated},
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&containers)
+	if err != nil {
+		return false, err
+	}
+
+	if heldContainers+containers > quota.HeldContainersLimit() {
+		return false, nil
+	}
+
+	var heldUntil time.Time
+	err = psql.Update("builds").
+		Set("interceptible", true).
+		Set("hold_containers_until", sq.Expr("GREATEST(COALESCE(hold_containers_until, now()), now() + ? * INTERVAL '1 second')", int(duration/time.Second))).
+		Where(sq.Eq{"id": b.id}).
+		Suffix("RETURNING hold_containers_until").
+		RunWith(tx).
+		QueryRow().
+		Scan(&heldUntil)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	b.containersHeldUntil = heldUntil
+
+	return true, nil
+}
+
+// holdContainersOnFailure holds the containers of a failed build for the
+// duration set on the build, or else configured on its job.
+func (b *build) holdContainersOnFailure() error {
+	var seconds int
+	err := psql.Select("hold_containers_on_failure").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&seconds)
+	if err != nil {
+		return err
+	}
+
+	duration := time.Duration(seconds) * time.Second
+
+	if duration == 0 && b.jobID != 0 {
+		j := &job{conn: b.conn, lockFactory: b.lockFactory}
+
+		err := scanJob(j, jobsQuery.Where(sq.Eq{"j.id": b.jobID}).RunWith(b.conn).QueryRow())
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+
+		duration = j.config.HoldContainersOnFailureDuration()
+	}
+
+	if duration <= 0 {
+		return nil
+	}
+
+	_, err = b.HoldContainers(duration)
+	return err
+}
+
+// countHeldContainers counts the active containers of the team's builds which
+// are currently held, other than those of the given build.
+func countHeldContainers(runner sq.BaseRunner, teamID int, exceptBuildID int) (int, error) {
+	var count int
+	err := psql.Select("COUNT(*)").
+		From("containers c").
+		Join("builds b ON b.id = c.build_id").
+		Where(sq.Eq{
+			"b.team_id": teamID,
+			"c.state":   []string{ContainerStateCreating, ContainerStateCreated},
+		}).
+		Where(sq.NotEq{"b.id": exceptBuildID}).
+		Where(sq.Expr("b.hold_containers_until > now()")).
+		RunWith(runner).
+		QueryRow().
+		Scan(&count)
+	return count, err
+}
+
 func (b *build) Delete() (bool, error) {
 	rows, err := psql.Delete("builds").
 		Where(sq.Eq{
@@ -439,6 +694,177 @@ func (b *build) Schedule() (bool, error) {
 	return rows == 1, nil
 }
 
+// RequestApproval marks a pending build as awaiting approval by one of the
+// given approvers. A zero deadline means the approval never times out.
+//
+// The inputs are saved as the build's inputs so that the versions which are
+// approved are the ones the build runs with.
+func (b *build) RequestApproval(approvers []string, deadline time.Time, inputs []BuildInput) (bool, error) {
+	approversJSON, err := json.Marshal(approvers)
+	if err != nil {
+		return false, err
+	}
+
+	var deadlineValue interface{}
+	if !deadline.IsZero() {
+		deadlineValue = deadline
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := psql.Update("builds").
+		Set("approval_status", string(atc.ApprovalStatusAwaiting)).
+		Set("approvers", string(approversJSON)).
+		Set("approval_deadline", deadlineValue).
+		Where(sq.Eq{
+			"id":              b.id,
+			"status":          string(BuildStatusPending),
+			"approval_status": nil,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rows == 0 {
+		return false, nil
+	}
+
+	err = b.useInputsTx(tx, inputs)
+	if err != nil {
+		return false, err
+	}
+
+	approvalInputs := []event.ApprovalInput{}
+	for _, input := range inputs {
+		approvalInputs = append(approvalInputs, event.ApprovalInput{
+			Name:     input.Name,
+			Resource: input.Resource,
+			Version:  atc.Version(input.Version),
+		})
+	}
+
+	err = b.saveEvent(tx, event.Approval{
+		Status:    atc.ApprovalStatusAwaiting,
+		Approvers: approvers,
+		Inputs:    approvalInputs,
+		Time:      time.Now().Unix(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	b.approvalStatus = atc.ApprovalStatusAwaiting
+	b.approvers = approvers
+	b.approvalDeadline = deadline
+
+	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ResolveApproval records the outcome of a build that is awaiting approval.
+// Builds that are not approved are aborted, as they will never be started.
+func (b *build) ResolveApproval(status atc.ApprovalStatus, approver string, comment string) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := psql.Update("builds").
+		Set("approval_status", string(status)).
+		Set("approver", approver).
+		Set("approval_comment", comment).
+		Where(sq.Eq{
+			"id":              b.id,
+			"status":          string(BuildStatusPending),
+			"approval_status": string(atc.ApprovalStatusAwaiting),
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rows == 0 {
+		return false, nil
+	}
+
+	err = b.saveEvent(tx, event.Approval{
+		Status:   status,
+		Approver: approver,
+		Comment:  comment,
+		Time:     time.Now().Unix(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	approved := status == atc.ApprovalStatusApproved
+
+	// aborted along with resolving the approval, so that a build which is
+	// not approved is never left pending
+	if !approved {
+		err = b.finish(tx, BuildStatusAborted)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	b.approvalStatus = status
+	b.approver = approver
+	b.approvalComment = comment
+
+	if !approved {
+		b.status = BuildStatusAborted
+
+		err = b.afterFinish(BuildStatusAborted)
+		if err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (b *build) Pipeline() (Pipeline, bool, error) {
 	if b.pipelineID == 0 {
 		return nil, false, nil
@@ -503,6 +929,8 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 			PausedPipeline:      BuildPreparationStatusNotBlocking,
 			PausedJob:           BuildPreparationStatusNotBlocking,
 			MaxRunningBuilds:    BuildPreparationStatusNotBlocking,
+			TeamQuota:           BuildPreparationStatusNotBlocking,
+			Approval:            BuildPreparationStatusNotBlocking,
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusNotBlocking,
 			MissingInputReasons: MissingInputReasons{},
@@ -513,17 +941,18 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		pausedPipeline     bool
 		pausedJob          bool
 		maxInFlightReached bool
+		teamQuotaReached   bool
 		pipelineID         int
 		jobName            string
 	)
-	err := psql.Select("p.paused, j.paused, j.max_in_flight_reached, j.pipeline_id, j.name").
+	err := psql.Select("p.paused, j.paused, j.max_in_flight_reached, j.team_quota_reached, j.pipeline_id, j.name").
 		From("builds b").
 		Join("jobs j ON b.job_id = j.id").
 		Join("pipelines p ON j.pipeline_id = p.id").
 		Where(sq.Eq{"b.id": b.id}).
 		RunWith(b.conn).
 		QueryRow().
-		Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &pipelineID, &jobName)
+		Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &teamQuotaReached, &pipelineID, &jobName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return BuildPreparation{}, false, nil
@@ -546,6 +975,16 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
+	teamQuotaReachedStatus := BuildPreparationStatusNotBlocking
+	if teamQuotaReached {
+		teamQuotaReachedStatus = BuildPreparationStatusBlocking
+	}
+
+	approvalStatus := BuildPreparationStatusNotBlocking
+	if b.approvalStatus == atc.ApprovalStatusAwaiting {
+		approvalStatus = BuildPreparationStatusBlocking
+	}
+
 	tf := NewTeamFactory(b.conn, b.lockFactory)
 	t, found, err := tf.FindTeam(b.teamName)
 	if err != nil {
@@ -608,7 +1047,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 				inputs[configInput.Name] = BuildPreparationStatusNotBlocking
 			} else {
 				inputs[configInput.Name] = BuildPreparationStatusBlocking
-				if len(configInput.Passed) > 0 {
+				if len(configInput.Passed) > 0 || len(configInput.PassedAny) > 0 {
 					if configInput.Version != nil && configInput.Version.Pinned != nil {
 						_, found, err := pipeline.GetVersionedResourceByVersion(configInput.Version.Pinned, configInput.Resource)
 						if err != nil {
@@ -649,6 +1088,8 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		PausedPipeline:      pausedPipelineStatus,
 		PausedJob:           pausedJobStatus,
 		MaxRunningBuilds:    maxInFlightReachedStatus,
+		TeamQuota:           teamQuotaReachedStatus,
+		Approval:            approvalStatus,
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
@@ -658,6 +1099,15 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 }
 
 func (b *build) Events(from uint) (EventSource, error) {
+	if b.logsArchived {
+		store := b.conn.LogStore()
+		if store == nil {
+			return nil, ErrNoLogStore
+		}
+
+		return store.Events(b.id, from)
+	}
+
 	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
 		return true, nil
 	})
@@ -705,6 +1155,95 @@ func (b *build) SaveEvent(event atc.Event) error {
 	return nil
 }
 
+// UndrainedEvents returns the events which have not yet been drained, up to
+// the given limit. While the build is running only the events before the
+// first gap in their IDs are returned, as the event filling the gap may not
+// have been committed yet.
+func (b *build) UndrainedEvents(limit int) ([]BuildEvent, error) {
+	var (
+		offset    int
+		completed bool
+	)
+
+	err := psql.Select("drain_offset", "completed").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&offset, &completed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrBuildDisappeared
+		}
+		return nil, err
+	}
+
+	rows, err := psql.Select("event_id", "type", "version", "payload", "save_time").
+		From("build_events").
+		Where(sq.Eq{"build_id": b.id}).
+		Where(sq.GtOrEq{"event_id": offset}).
+		OrderBy("event_id ASC").
+		Limit(uint64(limit)).
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := []BuildEvent{}
+	for rows.Next() {
+		var (
+			id       int
+			t, v, p  string
+			saveTime pq.NullTime
+		)
+
+		err := rows.Scan(&id, &t, &v, &p, &saveTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if !completed && id != offset+len(events) {
+			break
+		}
+
+		data := json.RawMessage(p)
+
+		events = append(events, BuildEvent{
+			ID: id,
+			Envelope: event.Envelope{
+				Data:    &data,
+				Event:   atc.EventType(t),
+				Version: atc.EventVersion(v),
+			},
+			SaveTime: saveTime.Time,
+		})
+	}
+
+	return events, nil
+}
+
+// SaveDrainOffset records the ID of the next event to be drained.
+func (b *build) SaveDrainOffset(offset int) error {
+	_, err := psql.Update("builds").
+		Set("drain_offset", offset).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+func (b *build) MarkDrained() error {
+	_, err := psql.Update("builds").
+		Set("drained", true).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
 func (b *build) SaveInput(input BuildInput) error {
 	if b.pipelineID == 0 {
 		return nil
@@ -775,13 +1314,26 @@ func (b *build) UseInputs(inputs []BuildInput) error {
 		return err
 	}
 
+	err = b.useInputsTx(tx, inputs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (b *build) useInputsTx(tx Tx, inputs []BuildInput) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
 	row := pipelinesQuery.
 		Where(sq.Eq{"p.id": b.pipelineID}).
 		RunWith(tx).
 		QueryRow()
 
 	pipeline := &pipeline{conn: b.conn, lockFactory: b.lockFactory}
-	err = scanPipeline(pipeline, row)
+	err := scanPipeline(pipeline, row)
 	if err != nil {
 		return err
 	}
@@ -793,15 +1345,233 @@ func (b *build) UseInputs(inputs []BuildInput) error {
 		}
 	}
 
+	return nil
+}
+
+func (b *build) InputOverrides() (map[string]int, error) {
+	rows, err := psql.Select("input_name", "versioned_resource_id").
+		From("build_input_overrides").
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	inputOverrides := map[string]int{}
+	for rows.Next() {
+		var inputName string
+		var versionedResourceID int
+		err := rows.Scan(&inputName, &versionedResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		inputOverrides[inputName] = versionedResourceID
+	}
+
+	return inputOverrides, nil
+}
+
+func (b *build) Artifacts() ([]BuildArtifact, error) {
+	rows, err := buildArtifactsQuery.
+		Where(sq.Eq{"a.build_id": b.id}).
+		OrderBy("a.name").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	artifacts := []BuildArtifact{}
+	for rows.Next() {
+		artifact, err := scanBuildArtifact(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, nil
+}
+
+func (b *build) Artifact(name string) (BuildArtifact, bool, error) {
+	artifact, err := scanBuildArtifact(buildArtifactsQuery.
+		Where(sq.Eq{
+			"a.build_id": b.id,
+			"a.name":     name,
+		}).
+		RunWith(b.conn).
+		QueryRow())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildArtifact{}, false, nil
+		}
+
+		return BuildArtifact{}, false, err
+	}
+
+	return artifact, true, nil
+}
+
+// SaveTestReport saves the test report of a step, replacing the step's
+// report from an earlier attempt.
+func (b *build) SaveTestReport(report TestReport) error {
+	passes := report.Passes
+	if passes == nil {
+		passes = []string{}
+	}
+
+	passesJSON, err := json.Marshal(passes)
+	if err != nil {
+		return err
+	}
+
+	failures := report.Failures
+	if failures == nil {
+		failures = []string{}
+	}
+
+	failuresJSON, err := json.Marshal(failures)
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = psql.Delete("build_test_reports").
+		Where(sq.Eq{
+			"build_id":  b.id,
+			"step_name": report.StepName,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("build_test_reports").
+		Columns("build_id", "step_name", "total", "passed", "failed", "skipped", "passes", "failures").
+		Values(b.id, report.StepName, report.Total, report.Passed, report.Failed, report.Skipped, string(passesJSON), string(failuresJSON)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
+// SaveMetadata adds metadata to the build. Fields with the name of a field
+// saved earlier, e.g. by another step, replace it.
+func (b *build) SaveMetadata(metadata ResourceMetadataFields) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var savedJSON sql.NullString
+	err = psql.Select("metadata").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&savedJSON)
+	if err != nil {
+		return err
+	}
+
+	saved := ResourceMetadataFields{}
+	if savedJSON.Valid {
+		err = json.Unmarshal([]byte(savedJSON.String), &saved)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, field := range metadata {
+		replaced := false
+		for i, savedField := range saved {
+			if savedField.Name == field.Name {
+				saved[i] = field
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			saved = append(saved, field)
+		}
+	}
+
+	metadataJSON, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("metadata", string(metadataJSON)).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.metadata = saved
+
+	return nil
+}
+
+func (b *build) TestReports() ([]TestReport, error) {
+	rows, err := testReportsQuery.
+		Where(sq.Eq{"r.build_id": b.id}).
+		OrderBy("r.step_name").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	reports := []TestReport{}
+	for rows.Next() {
+		report, err := scanTestReport(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
 func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 	inputs := []BuildInput{}
 	outputs := []BuildOutput{}
 
 	rows, err := b.conn.Query(`
-		SELECT i.name, r.name, v.type, v.version, v.metadata,
+		SELECT i.name, r.name, v.type, v.version, v.metadata, i.overridden,
 		NOT EXISTS (
 			SELECT 1
 			FROM build_inputs ci, builds cb
@@ -832,10 +1602,10 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 	for rows.Next() {
 		var inputName string
 		var vr VersionedResource
-		var firstOccurrence bool
+		var overridden, firstOccurrence bool
 
 		var version, metadata string
-		err := rows.Scan(&inputName, &vr.Resource, &vr.Type, &version, &metadata, &firstOccurrence)
+		err := rows.Scan(&inputName, &vr.Resource, &vr.Type, &version, &metadata, &overridden, &firstOccurrence)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -854,6 +1624,7 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			Name:              inputName,
 			VersionedResource: vr,
 			FirstOccurrence:   firstOccurrence,
+			Overridden:        overridden,
 		})
 	}
 
@@ -980,15 +1751,20 @@ func buildEventSeq(buildid int) string {
 
 func scanBuild(b *build, row scannable, encryptionStrategy EncryptionStrategy) error {
 	var (
-		jobID, pipelineID                                         sql.NullInt64
+		jobID, pipelineID, rerunOf, supersededBy, triggeredBy     sql.NullInt64
 		engine, engineMetadata, jobName, pipelineName, publicPlan sql.NullString
-		startTime, endTime, reapTime                              pq.NullTime
+		createTime, startTime, endTime, reapTime, scheduleTime    pq.NullTime
 		nonce                                                     sql.NullString
 
+		approvalStatus, approvers, approver, approvalComment sql.NullString
+		approvalDeadline, containersHeldUntil                pq.NullTime
+
+		metadata sql.NullString
+
 		status string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &approvalStatus, &approvers, &approver, &approvalComment, &approvalDeadline, &rerunOf, &scheduleTime, &supersededBy, &triggeredBy, &metadata, &b.logsArchived, &containersHeldUntil, &b.drained)
 	if err != nil {
 		return err
 	}
@@ -999,9 +1775,28 @@ func scanBuild(b *build, row scannable, encryptionStrategy EncryptionStrategy) e
 	b.pipelineName = pipelineName.String
 	b.pipelineID = int(pipelineID.Int64)
 	b.engine = engine.String
+	b.createTime = createTime.Time
 	b.startTime = startTime.Time
 	b.endTime = endTime.Time
 	b.reapTime = reapTime.Time
+	b.rerunOf = int(rerunOf.Int64)
+	b.supersededBy = int(supersededBy.Int64)
+	b.triggeredBy = int(triggeredBy.Int64)
+	b.scheduleTime = scheduleTime.Time
+	b.containersHeldUntil = containersHeldUntil.Time
+
+	b.approvalStatus = atc.ApprovalStatus(approvalStatus.String)
+	b.approver = approver.String
+	b.approvalComment = approvalComment.String
+	b.approvalDeadline = approvalDeadline.Time
+
+	b.approvers = nil
+	if approvers.Valid {
+		err = json.Unmarshal([]byte(approvers.String), &b.approvers)
+		if err != nil {
+			return err
+		}
+	}
 
 	var noncense *string
 	if nonce.Valid {
@@ -1022,6 +1817,14 @@ func scanBuild(b *build, row scannable, encryptionStrategy EncryptionStrategy) e
 		}
 	}
 
+	b.metadata = nil
+	if metadata.Valid {
+		err = json.Unmarshal([]byte(metadata.String), &b.metadata)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// BuildArtifact is an output of a build's step which is kept as a volume
+// after the build finishes.
+type BuildArtifact struct {
+	Name      string
+	CreatedAt time.Time
+
+	WorkerName   string
+	VolumeHandle string
+}
+
+type buildArtifact struct {
+	BuildID int
+	Name    string
+}
+
+func (artifact buildArtifact) FindOrCreate(tx Tx) (int, error) {
+	var id int
+	err := psql.Select("id").
+		From("build_artifacts").
+		Where(sq.Eq{
+			"build_id": artifact.BuildID,
+			"name":     artifact.Name,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+
+	if err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	err = psql.Insert("build_artifacts").
+		Columns("build_id", "name").
+		Values(artifact.BuildID, artifact.Name).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return 0, ErrSafeRetryFindOrCreate
+		}
+
+		return 0, err
+	}
+
+	return id, nil
+}
+
+var buildArtifactsQuery = psql.Select("a.name, a.created_at, v.worker_name, v.handle").
+	From("build_artifacts a").
+	Join("volumes v ON v.build_artifact_id = a.id").
+	Where(sq.Eq{"v.state": string(VolumeStateCreated)})
+
+func scanBuildArtifact(row scannable) (BuildArtifact, error) {
+	var artifact BuildArtifact
+	err := row.Scan(&artifact.Name, &artifact.CreatedAt, &artifact.WorkerName, &artifact.VolumeHandle)
+	return artifact, err
+}
+
+//go:generate counterfeiter . BuildArtifactLifecycle
+
+type BuildArtifactLifecycle interface {
+	RemoveExpiredArtifacts(retention time.Duration) error
+}
+
+type buildArtifactLifecycle struct {
+	conn Conn
+}
+
+func NewBuildArtifactLifecycle(conn Conn) BuildArtifactLifecycle {
+	return buildArtifactLifecycle{
+		conn: conn,
+	}
+}
+
+// RemoveExpiredArtifacts removes the artifacts created longer than the
+// retention ago. Their volumes are then garbage collected as orphans.
+func (lifecycle buildArtifactLifecycle) RemoveExpiredArtifacts(retention time.Duration) error {
+	_, err := psql.Delete("build_artifacts").
+		Where(sq.Lt{"created_at": time.Now().Add(-retention)}).
+		RunWith(lifecycle.conn).
+		Exec()
+
+	return err
+}
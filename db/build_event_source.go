@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/event"
@@ -12,6 +13,17 @@ import (
 var ErrEndOfBuildEventStream = errors.New("end of build event stream")
 var ErrBuildEventStreamClosed = errors.New("build event stream closed")
 
+// BuildEvent is an event saved to a build, along with its position in the
+// build's event stream.
+type BuildEvent struct {
+	ID       int
+	Envelope event.Envelope
+
+	// SaveTime is when the event was saved. It is zero for events saved
+	// before it was recorded.
+	SaveTime time.Time
+}
+
 //go:generate counterfeiter . EventSource
 
 type EventSource interface {
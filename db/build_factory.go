@@ -13,6 +13,7 @@ type BuildFactory interface {
 	Build(int) (Build, bool, error)
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
+	UndrainedBuilds() ([]Build, error)
 
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
@@ -77,6 +78,10 @@ func (f *buildFactory) MarkNonInterceptibleBuilds() error {
 			sq.Expr("id NOT IN (select build_id FROM latest_builds)"),
 			sq.Eq{"status": string(BuildStatusSucceeded)},
 		}).
+		Where(sq.Or{
+			sq.Eq{"hold_containers_until": nil},
+			sq.Expr("hold_containers_until < now()"),
+		}).
 		RunWith(f.conn).
 		Exec()
 	if err != nil {
@@ -112,6 +117,36 @@ func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	return bs, nil
 }
 
+// UndrainedBuilds returns the builds which have started and whose events
+// have not all been drained, oldest first.
+func (f *buildFactory) UndrainedBuilds() ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(sq.Eq{"b.drained": false}).
+		Where(sq.NotEq{"b.status": BuildStatusPending}).
+		OrderBy("b.id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		b := &build{conn: f.conn, lockFactory: f.lockFactory}
+		err := scanBuild(b, rows, f.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, b)
+	}
+
+	return bs, nil
+}
+
 func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, conn Conn, lockFactory lock.LockFactory) ([]Build, Pagination, error) {
 	var rows *sql.Rows
 	var err error
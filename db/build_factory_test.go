@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/atc/db"
 
 	"github.com/concourse/atc"
@@ -64,6 +66,25 @@ var _ = Describe("BuildFactory", func() {
 				Entry("failed is non-interceptible", db.BuildStatusFailed, BeFalse()),
 			)
 
+			It("does not mark builds whose containers are held", func() {
+				b, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = b.Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+
+				held, err := b.HoldContainers(time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(held).To(BeTrue())
+
+				err = buildFactory.MarkNonInterceptibleBuilds()
+				Expect(err).NotTo(HaveOccurred())
+
+				i, err := b.Interceptible()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(i).To(BeTrue())
+			})
+
 			It("non-completed is interceptible", func() {
 				b, err := defaultTeam.CreateOneOffBuild()
 				Expect(err).NotTo(HaveOccurred())
@@ -259,4 +280,46 @@ var _ = Describe("BuildFactory", func() {
 			Expect(builds).To(ConsistOf(build1DB, build2DB))
 		})
 	})
+
+	Describe("UndrainedBuilds", func() {
+		var (
+			startedBuild db.Build
+			drainedBuild db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			startedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			drainedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, build := range []db.Build{startedBuild, drainedBuild} {
+				started, err := build.Start("some-engine", `{"so":"meta"}`, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+			}
+
+			err = drainedBuild.MarkDrained()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the builds which have started and are not yet drained", func() {
+			builds, err := buildFactory.UndrainedBuilds()
+			Expect(err).NotTo(HaveOccurred())
+
+			startedBuild.Reload()
+			Expect(builds).To(Equal([]db.Build{startedBuild}))
+		})
+
+		It("marks the drained builds as drained", func() {
+			_, err := drainedBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(drainedBuild.IsDrained()).To(BeTrue())
+		})
+	})
 })
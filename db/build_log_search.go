@@ -0,0 +1,399 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// BuildLogSearch finds the lines of build output containing Query, ignoring
+// case, within the builds matching the rest of the fields.
+type BuildLogSearch struct {
+	Query string
+
+	PipelineName string
+	JobName      string
+
+	// Since and Until limit the search to builds started within the range.
+	Since time.Time
+	Until time.Time
+
+	// Limit is the maximum number of lines to return.
+	Limit int
+
+	// ContextLines is the number of lines to return before and after each
+	// matching line.
+	ContextLines int
+}
+
+// BuildLogMatch is a line of a build's output which matched a search.
+type BuildLogMatch struct {
+	BuildID      int
+	BuildName    string
+	PipelineName string
+	JobName      string
+
+	Line   string
+	Before []string
+	After  []string
+}
+
+// logSearchText is the output of a log event, as indexed by BuildLogIndex.
+const logSearchText = "(payload::json ->> 'payload')"
+
+// logContextEvents is the number of log events on either side of a match
+// which are read to find the lines around it.
+const logContextEvents = 20
+
+func searchBuildLogs(conn Conn, teamID int, search BuildLogSearch) ([]BuildLogMatch, error) {
+	query := psql.Select("e.build_id", "e.event_id", "e.payload", "b.name", "p.name", "j.name").
+		From("build_events e").
+		Join("builds b ON b.id = e.build_id").
+		LeftJoin("pipelines p ON p.id = b.pipeline_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		// a literal, so that the planner can use the partial indexes
+		Where("e.type = 'log'").
+		Where(sq.Eq{"b.team_id": teamID}).
+		// served by the trigram indexes, which match within words too
+		Where(sq.Expr(logSearchText+" ILIKE ?", "%"+likeEscaper.Replace(search.Query)+"%")).
+		OrderBy("e.build_id DESC", "e.event_id ASC").
+		Limit(uint64(search.Limit))
+
+	if search.PipelineName != "" {
+		query = query.Where(sq.Eq{"p.name": search.PipelineName})
+	}
+
+	if search.JobName != "" {
+		query = query.Where(sq.Eq{"j.name": search.JobName})
+	}
+
+	if !search.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"b.start_time": search.Since})
+	}
+
+	if !search.Until.IsZero() {
+		query = query.Where(sq.Lt{"b.start_time": search.Until})
+	}
+
+	rows, err := query.RunWith(conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	events := []matchedLogEvent{}
+
+	for rows.Next() {
+		var (
+			event   matchedLogEvent
+			payload string
+		)
+
+		err := rows.Scan(&event.buildID, &event.eventID, &payload, &event.buildName, &event.pipelineName, &event.jobName)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		chunk, err := parseLogChunk(event.eventID, payload)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		event.originID = chunk.originID
+
+		events = append(events, event)
+	}
+
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, err := logChunksAround(conn, events)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []BuildLogMatch{}
+
+	for i, event := range events {
+		for _, line := range matchingLines(chunks[i], event.eventID, search.Query, search.ContextLines) {
+			line.BuildID = event.buildID
+			line.BuildName = event.buildName
+			line.PipelineName = event.pipelineName.String
+			line.JobName = event.jobName.String
+
+			matches = append(matches, line)
+
+			if len(matches) == search.Limit {
+				return matches, nil
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+type matchedLogEvent struct {
+	buildID      int
+	eventID      int
+	originID     string
+	buildName    string
+	pipelineName sql.NullString
+	jobName      sql.NullString
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type logChunk struct {
+	eventID  int
+	originID string
+	output   string
+}
+
+func parseLogChunk(eventID int, payload string) (logChunk, error) {
+	var log struct {
+		Origin struct {
+			ID string `json:"id"`
+		} `json:"origin"`
+		Payload string `json:"payload"`
+	}
+
+	err := json.Unmarshal([]byte(payload), &log)
+	if err != nil {
+		return logChunk{}, err
+	}
+
+	return logChunk{
+		eventID:  eventID,
+		originID: log.Origin.ID,
+		output:   log.Payload,
+	}, nil
+}
+
+// logChunksAround returns the output of the same step surrounding each of
+// the events, in order. The output of all of them is read in one query.
+func logChunksAround(conn Conn, events []matchedLogEvent) ([][]logChunk, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	windows := sq.Or{}
+	for _, event := range events {
+		windows = append(windows, sq.And{
+			sq.Eq{"build_id": event.buildID},
+			sq.GtOrEq{"event_id": event.eventID - logContextEvents},
+			sq.LtOrEq{"event_id": event.eventID + logContextEvents},
+		})
+	}
+
+	rows, err := psql.Select("build_id", "event_id", "payload").
+		From("build_events").
+		Where("type = 'log'").
+		Where(windows).
+		OrderBy("build_id ASC", "event_id ASC").
+		RunWith(conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	buildChunks := map[int][]logChunk{}
+	for rows.Next() {
+		var (
+			buildID int
+			id      int
+			payload string
+		)
+
+		err := rows.Scan(&buildID, &id, &payload)
+		if err != nil {
+			return nil, err
+		}
+
+		chunk, err := parseLogChunk(id, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		buildChunks[buildID] = append(buildChunks[buildID], chunk)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([][]logChunk, len(events))
+	for i, event := range events {
+		chunks[i] = []logChunk{}
+
+		for _, chunk := range buildChunks[event.buildID] {
+			if chunk.originID != event.originID {
+				continue
+			}
+
+			if chunk.eventID < event.eventID-logContextEvents || chunk.eventID > event.eventID+logContextEvents {
+				continue
+			}
+
+			chunks[i] = append(chunks[i], chunk)
+		}
+	}
+
+	return chunks, nil
+}
+
+// matchingLines finds the lines containing the query whose first match lies
+// within the given event's output. A line is often split across events, so
+// the output of the surrounding events is joined to find the whole line and
+// its context.
+func matchingLines(chunks []logChunk, eventID int, query string, contextLines int) []BuildLogMatch {
+	var (
+		output     string
+		start, end int
+	)
+
+	for _, chunk := range chunks {
+		if chunk.eventID == eventID {
+			start = len(output)
+			end = start + len(chunk.output)
+		}
+
+		output += chunk.output
+	}
+
+	rawLines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+	lines := make([]string, len(rawLines))
+	for i, line := range rawLines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	needle := strings.ToLower(query)
+
+	matches := []BuildLogMatch{}
+
+	lineStart := 0
+	for i, line := range lines {
+		offset := strings.Index(strings.ToLower(line), needle)
+		if offset != -1 && lineStart+offset >= start && lineStart+offset < end {
+			before := i - contextLines
+			if before < 0 {
+				before = 0
+			}
+
+			after := i + 1 + contextLines
+			if after > len(lines) {
+				after = len(lines)
+			}
+
+			matches = append(matches, BuildLogMatch{
+				Line:   line,
+				Before: append([]string{}, lines[before:i]...),
+				After:  append([]string{}, lines[i+1:after]...),
+			})
+		}
+
+		lineStart += len(rawLines[i]) + 1
+	}
+
+	return matches
+}
+
+//go:generate counterfeiter . BuildLogIndex
+
+// BuildLogIndex maintains the trigram indexes used to search build logs.
+// Each pipeline and team has its own table of build events, so new tables
+// are indexed as they appear.
+type BuildLogIndex interface {
+	IndexBuildEvents(logger lager.Logger) error
+}
+
+type buildLogIndex struct {
+	conn Conn
+}
+
+func NewBuildLogIndex(conn Conn) BuildLogIndex {
+	return &buildLogIndex{
+		conn: conn,
+	}
+}
+
+func (index *buildLogIndex) IndexBuildEvents(logger lager.Logger) error {
+	rows, err := index.conn.Query(`
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = 'build_events'
+		AND NOT EXISTS (
+			SELECT 1
+			FROM pg_class ic
+			JOIN pg_index ix ON ix.indexrelid = ic.oid
+			WHERE ic.relname = c.relname || '_log_trgm'
+			AND ix.indisvalid
+		)
+		ORDER BY c.relname
+	`)
+	if err != nil {
+		return err
+	}
+
+	tables := []string{}
+	for rows.Next() {
+		var table string
+		err := rows.Scan(&table)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		tables = append(tables, table)
+	}
+
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		indexName := table + "_log_trgm"
+
+		// an index left invalid by a failed concurrent build must be
+		// dropped before it can be built again
+		_, err := index.conn.Exec(fmt.Sprintf(`DROP INDEX IF EXISTS %s`, indexName))
+		if err != nil {
+			return err
+		}
+
+		_, err = index.conn.Exec(fmt.Sprintf(`
+			CREATE INDEX CONCURRENTLY %s ON %s
+			USING gin (%s gin_trgm_ops)
+			WHERE type = 'log'
+		`, indexName, table, logSearchText))
+		if err != nil {
+			return err
+		}
+
+		// the full-text index which preceded it matched only whole words
+		_, err = index.conn.Exec(fmt.Sprintf(`DROP INDEX IF EXISTS %s_log_search`, table))
+		if err != nil {
+			return err
+		}
+
+		logger.Info("indexed-build-events", lager.Data{"table": table})
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package db_test
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildLogIndex", func() {
+	var (
+		index db.BuildLogIndex
+		team  db.Team
+	)
+
+	indexExists := func(table string) bool {
+		var exists bool
+		err := dbConn.QueryRow(`
+			SELECT EXISTS (SELECT 1 FROM pg_indexes WHERE tablename = $1 AND indexname = $2)
+		`, table, table+"_log_trgm").Scan(&exists)
+		Expect(err).NotTo(HaveOccurred())
+
+		return exists
+	}
+
+	BeforeEach(func() {
+		var err error
+		team, err = teamFactory.CreateTeam(atc.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		index = db.NewBuildLogIndex(dbConn)
+	})
+
+	Describe("IndexBuildEvents", func() {
+		It("indexes the build events tables which are not yet indexed", func() {
+			table := fmt.Sprintf("team_build_events_%d", team.ID())
+			Expect(indexExists(table)).To(BeFalse())
+
+			logger := lagertest.NewTestLogger("build-log-index")
+
+			Expect(index.IndexBuildEvents(logger)).To(Succeed())
+			Expect(indexExists(table)).To(BeTrue())
+
+			indexedMessages := len(logger.LogMessages())
+
+			Expect(index.IndexBuildEvents(logger)).To(Succeed())
+			Expect(logger.LogMessages()).To(HaveLen(indexedMessages))
+		})
+	})
+})
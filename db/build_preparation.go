@@ -35,6 +35,8 @@ type BuildPreparation struct {
 	PausedPipeline      BuildPreparationStatus
 	PausedJob           BuildPreparationStatus
 	MaxRunningBuilds    BuildPreparationStatus
+	TeamQuota           BuildPreparationStatus
+	Approval            BuildPreparationStatus
 	Inputs              map[string]BuildPreparationStatus
 	InputsSatisfied     BuildPreparationStatus
 	MissingInputReasons MissingInputReasons
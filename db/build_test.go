@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -174,6 +175,347 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("HoldContainers", func() {
+		var build db.Build
+
+		createContainer := func(build db.Build) {
+			owner := db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-plan"))
+			_, err := team.CreateContainer(defaultWorker.Name(), owner, fullMetadata)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			createContainer(build)
+		})
+
+		It("holds the containers for the given duration", func() {
+			held, err := build.HoldContainers(time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.ContainersHeldUntil()).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+		})
+
+		It("extends the hold but never shortens it", func() {
+			_, err := build.HoldContainers(time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = build.HoldContainers(2 * time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.ContainersHeldUntil()).To(BeTemporally("~", time.Now().Add(2*time.Hour), time.Minute))
+
+			_, err = build.HoldContainers(time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.ContainersHeldUntil()).To(BeTemporally("~", time.Now().Add(2*time.Hour), time.Minute))
+		})
+
+		Context("when the team would exceed its held containers quota", func() {
+			BeforeEach(func() {
+				err := team.UpdateQuota(atc.TeamQuota{MaxHeldContainers: 1})
+				Expect(err).NotTo(HaveOccurred())
+
+				otherBuild, err := team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				createContainer(otherBuild)
+
+				held, err := otherBuild.HoldContainers(time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(held).To(BeTrue())
+			})
+
+			It("does not hold the containers", func() {
+				held, err := build.HoldContainers(time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(held).To(BeFalse())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.ContainersHeldUntil()).To(BeZero())
+			})
+		})
+
+		Context("when the build is set to hold its containers on failure", func() {
+			BeforeEach(func() {
+				err := build.SetHoldContainersOnFailure(time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("holds them when the build fails", func() {
+				err := build.Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.ContainersHeldUntil()).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			})
+
+			It("does not hold them when the build succeeds", func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.ContainersHeldUntil()).To(BeZero())
+			})
+		})
+
+		Context("when the job is configured to hold containers on failure", func() {
+			BeforeEach(func() {
+				pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:                    "some-job",
+							HoldContainersOnFailure: "30m",
+						},
+					},
+				}, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				job, found, err := pipeline.Job("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				createContainer(build)
+			})
+
+			It("holds them for the configured duration when the build errors", func() {
+				err := build.Finish(db.BuildStatusErrored)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.ContainersHeldUntil()).To(BeTemporally("~", time.Now().Add(30*time.Minute), time.Minute))
+			})
+		})
+	})
+
+	Describe("Approval", func() {
+		var build db.Build
+		var deadline time.Time
+
+		nextApprovalEvent := func(events db.EventSource) event.Approval {
+			ev, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeApproval))
+
+			var approval event.Approval
+			err = json.Unmarshal(*ev.Data, &approval)
+			Expect(err).NotTo(HaveOccurred())
+
+			return approval
+		}
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			deadline = time.Now().Add(time.Hour).Truncate(time.Second)
+		})
+
+		Describe("RequestApproval", func() {
+			It("marks the build as awaiting approval", func() {
+				requested, err := build.RequestApproval([]string{"approvers"}, deadline, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requested).To(BeTrue())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusPending))
+				Expect(build.ApprovalStatus()).To(Equal(atc.ApprovalStatusAwaiting))
+				Expect(build.Approvers()).To(Equal([]string{"approvers"}))
+				Expect(build.ApprovalDeadline().Unix()).To(Equal(deadline.Unix()))
+			})
+
+			It("saves an approval event", func() {
+				_, err := build.RequestApproval([]string{"approvers"}, deadline, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer events.Close()
+
+				approval := nextApprovalEvent(events)
+				Expect(approval.Status).To(Equal(atc.ApprovalStatusAwaiting))
+				Expect(approval.Approvers).To(Equal([]string{"approvers"}))
+			})
+
+			It("does not request approval twice", func() {
+				requested, err := build.RequestApproval(nil, time.Time{}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requested).To(BeTrue())
+
+				requested, err = build.RequestApproval(nil, time.Time{}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requested).To(BeFalse())
+			})
+
+			It("leaves the deadline unset without a timeout", func() {
+				_, err := build.RequestApproval(nil, time.Time{}, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.ApprovalDeadline()).To(BeZero())
+			})
+
+			Context("when a job build", func() {
+				var versionedResource db.VersionedResource
+
+				BeforeEach(func() {
+					pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+						Jobs: atc.JobConfigs{
+							{
+								Name: "some-job",
+							},
+						},
+						Resources: atc.ResourceConfigs{
+							{
+								Name: "some-resource",
+								Type: "some-type",
+							},
+						},
+					}, db.ConfigVersion(0), db.PipelineUnpaused)
+					Expect(err).NotTo(HaveOccurred())
+
+					job, found, err := pipeline.Job("some-job")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					build, err = job.CreateBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					versionedResource = db.VersionedResource{
+						Resource: "some-resource",
+						Type:     "some-type",
+						Version:  db.ResourceVersion{"some": "version"},
+						Metadata: []db.ResourceMetadataField{},
+					}
+				})
+
+				It("pins the inputs to be approved and shows them in the approval event", func() {
+					requested, err := build.RequestApproval([]string{"approvers"}, deadline, []db.BuildInput{
+						{Name: "some-input", VersionedResource: versionedResource},
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(requested).To(BeTrue())
+
+					inputs, _, err := build.Resources()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(inputs).To(HaveLen(1))
+					Expect(inputs[0].Name).To(Equal("some-input"))
+					Expect(inputs[0].Version).To(Equal(db.ResourceVersion{"some": "version"}))
+
+					events, err := build.Events(0)
+					Expect(err).NotTo(HaveOccurred())
+
+					defer events.Close()
+
+					approval := nextApprovalEvent(events)
+					Expect(approval.Inputs).To(Equal([]event.ApprovalInput{
+						{Name: "some-input", Resource: "some-resource", Version: atc.Version{"some": "version"}},
+					}))
+				})
+			})
+		})
+
+		Describe("ResolveApproval", func() {
+			Context("when the build is not awaiting approval", func() {
+				It("does nothing", func() {
+					resolved, err := build.ResolveApproval(atc.ApprovalStatusApproved, "some-team", "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resolved).To(BeFalse())
+				})
+			})
+
+			Context("when the build is awaiting approval", func() {
+				BeforeEach(func() {
+					_, err := build.RequestApproval([]string{"approvers"}, deadline, nil)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("records the approver and the comment", func() {
+					resolved, err := build.ResolveApproval(atc.ApprovalStatusApproved, "approvers", "ship it")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resolved).To(BeTrue())
+
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Status()).To(Equal(db.BuildStatusPending))
+					Expect(build.ApprovalStatus()).To(Equal(atc.ApprovalStatusApproved))
+					Expect(build.Approver()).To(Equal("approvers"))
+					Expect(build.ApprovalComment()).To(Equal("ship it"))
+
+					events, err := build.Events(0)
+					Expect(err).NotTo(HaveOccurred())
+
+					defer events.Close()
+
+					nextApprovalEvent(events)
+
+					approval := nextApprovalEvent(events)
+					Expect(approval.Status).To(Equal(atc.ApprovalStatusApproved))
+					Expect(approval.Approver).To(Equal("approvers"))
+					Expect(approval.Comment).To(Equal("ship it"))
+				})
+
+				It("aborts the build when it is rejected", func() {
+					resolved, err := build.ResolveApproval(atc.ApprovalStatusRejected, "approvers", "not today")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resolved).To(BeTrue())
+
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Status()).To(Equal(db.BuildStatusAborted))
+					Expect(build.ApprovalStatus()).To(Equal(atc.ApprovalStatusRejected))
+					Expect(build.IsRunning()).To(BeFalse())
+					Expect(build.EndTime()).NotTo(BeZero())
+				})
+
+				It("aborts the build when it times out", func() {
+					resolved, err := build.ResolveApproval(atc.ApprovalStatusTimedOut, "", "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resolved).To(BeTrue())
+
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Status()).To(Equal(db.BuildStatusAborted))
+					Expect(build.ApprovalStatus()).To(Equal(atc.ApprovalStatusTimedOut))
+				})
+
+				It("only resolves the approval once", func() {
+					_, err := build.ResolveApproval(atc.ApprovalStatusApproved, "approvers", "")
+					Expect(err).NotTo(HaveOccurred())
+
+					resolved, err := build.ResolveApproval(atc.ApprovalStatusRejected, "approvers", "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resolved).To(BeFalse())
+				})
+			})
+		})
+	})
+
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
 			build, err := team.CreateOneOffBuild()
@@ -297,6 +639,79 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("UndrainedEvents", func() {
+		var build db.Build
+
+		// checks that each event has a save time and clears it, so that the
+		// events can be compared
+		withoutSaveTimes := func(events []db.BuildEvent) []db.BuildEvent {
+			for i := range events {
+				Expect(events[i].SaveTime).To(BeTemporally("~", time.Now(), time.Minute))
+				events[i].SaveTime = time.Time{}
+			}
+
+			return events
+		}
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, payload := range []string{"a", "b", "c"} {
+				err = build.SaveEvent(event.Log{Payload: payload})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns the events from the saved drain offset, up to the limit", func() {
+			events, err := build.UndrainedEvents(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(withoutSaveTimes(events)).To(Equal([]db.BuildEvent{
+				{ID: 0, Envelope: envelope(event.Log{Payload: "a"})},
+				{ID: 1, Envelope: envelope(event.Log{Payload: "b"})},
+			}))
+
+			err = build.SaveDrainOffset(2)
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err = build.UndrainedEvents(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(withoutSaveTimes(events)).To(Equal([]db.BuildEvent{
+				{ID: 2, Envelope: envelope(event.Log{Payload: "c"})},
+			}))
+		})
+
+		Context("when there is a gap in the event IDs", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(fmt.Sprintf("SELECT nextval('build_event_id_seq_%d')", build.ID()))
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEvent(event.Log{Payload: "d"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("stops at the gap while the build is running", func() {
+				events, err := build.UndrainedEvents(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(HaveLen(3))
+			})
+
+			It("skips over the gap once the build has finished", func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				events, err := build.UndrainedEvents(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutSaveTimes(events)).To(HaveLen(5))
+				Expect(events[3]).To(Equal(db.BuildEvent{
+					ID:       4,
+					Envelope: envelope(event.Log{Payload: "d"}),
+				}))
+			})
+		})
+	})
+
 	Describe("SaveInput", func() {
 		var pipeline db.Pipeline
 		var job db.Job
@@ -706,6 +1121,8 @@ var _ = Describe("Build", func() {
 				PausedPipeline:      db.BuildPreparationStatusNotBlocking,
 				PausedJob:           db.BuildPreparationStatusNotBlocking,
 				MaxRunningBuilds:    db.BuildPreparationStatusNotBlocking,
+				TeamQuota:           db.BuildPreparationStatusNotBlocking,
+				Approval:            db.BuildPreparationStatusNotBlocking,
 				Inputs:              map[string]db.BuildPreparationStatus{},
 				InputsSatisfied:     db.BuildPreparationStatusNotBlocking,
 				MissingInputReasons: db.MissingInputReasons{},
@@ -900,6 +1317,39 @@ var _ = Describe("Build", func() {
 						Expect(buildPrep).To(Equal(expectedBuildPrep))
 					})
 				})
+
+				Context("when the team quota is reached", func() {
+					BeforeEach(func() {
+						err := job.SetTeamQuotaReached(true)
+						Expect(err).NotTo(HaveOccurred())
+
+						expectedBuildPrep.TeamQuota = db.BuildPreparationStatusBlocking
+					})
+
+					It("returns build preparation with team quota reached", func() {
+						buildPrep, found, err := build.Preparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+				})
+
+				Context("when the build is awaiting approval", func() {
+					BeforeEach(func() {
+						requested, err := build.RequestApproval([]string{"some-approvers"}, time.Time{}, nil)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(requested).To(BeTrue())
+
+						expectedBuildPrep.Approval = db.BuildPreparationStatusBlocking
+					})
+
+					It("returns build preparation with approval blocking", func() {
+						buildPrep, found, err := build.Preparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+				})
 			})
 
 			Context("when inputs are not satisfied", func() {
@@ -1089,6 +1539,122 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SaveMetadata", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("has no metadata to begin with", func() {
+			Expect(build.Metadata()).To(BeEmpty())
+		})
+
+		It("saves the metadata, replacing fields saved earlier with the same name", func() {
+			err := build.SaveMetadata(db.ResourceMetadataFields{
+				{Name: "url", Value: "https://example.com/1"},
+				{Name: "sha", Value: "abc"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveMetadata(db.ResourceMetadataFields{
+				{Name: "url", Value: "https://example.com/2"},
+				{Name: "report", Value: "https://example.com/report"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedMetadata := db.ResourceMetadataFields{
+				{Name: "url", Value: "https://example.com/2"},
+				{Name: "sha", Value: "abc"},
+				{Name: "report", Value: "https://example.com/report"},
+			}
+			Expect(build.Metadata()).To(Equal(expectedMetadata))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Metadata()).To(Equal(expectedMetadata))
+		})
+	})
+
+	Describe("TestReports", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns no reports when none were saved", func() {
+			reports, err := build.TestReports()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reports).To(BeEmpty())
+		})
+
+		Context("when test reports are saved", func() {
+			BeforeEach(func() {
+				err := build.SaveTestReport(db.TestReport{
+					StepName: "unit",
+					Total:    3,
+					Passed:   1,
+					Failed:   1,
+					Skipped:  1,
+					Passes:   []string{"TestPasses"},
+					Failures: []string{"TestFails"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveTestReport(db.TestReport{
+					StepName: "integration",
+					Total:    1,
+					Passed:   1,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns them ordered by step name", func() {
+				reports, err := build.TestReports()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reports).To(Equal([]db.TestReport{
+					{
+						StepName: "integration",
+						Total:    1,
+						Passed:   1,
+						Passes:   []string{},
+						Failures: []string{},
+					},
+					{
+						StepName: "unit",
+						Total:    3,
+						Passed:   1,
+						Failed:   1,
+						Skipped:  1,
+						Passes:   []string{"TestPasses"},
+						Failures: []string{"TestFails"},
+					},
+				}))
+			})
+
+			It("replaces the report of a step when it is saved again", func() {
+				err := build.SaveTestReport(db.TestReport{
+					StepName: "unit",
+					Total:    3,
+					Passed:   3,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				reports, err := build.TestReports()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reports).To(HaveLen(2))
+				Expect(reports[1].Passed).To(Equal(3))
+				Expect(reports[1].Failures).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("UseInput", func() {
 		var build db.Build
 		BeforeEach(func() {
@@ -121,6 +121,15 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
+	CreateTimeStub        func() time.Time
+	createTimeMutex       sync.RWMutex
+	createTimeArgsForCall []struct{}
+	createTimeReturns     struct {
+		result1 time.Time
+	}
+	createTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct{}
@@ -166,6 +175,105 @@ type FakeBuild struct {
 	isScheduledReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	RerunOfStub        func() int
+	rerunOfMutex       sync.RWMutex
+	rerunOfArgsForCall []struct{}
+	rerunOfReturns     struct {
+		result1 int
+	}
+	rerunOfReturnsOnCall map[int]struct {
+		result1 int
+	}
+	SupersededByStub        func() int
+	supersededByMutex       sync.RWMutex
+	supersededByArgsForCall []struct{}
+	supersededByReturns     struct {
+		result1 int
+	}
+	supersededByReturnsOnCall map[int]struct {
+		result1 int
+	}
+	TriggeredByStub        func() int
+	triggeredByMutex       sync.RWMutex
+	triggeredByArgsForCall []struct{}
+	triggeredByReturns     struct {
+		result1 int
+	}
+	triggeredByReturnsOnCall map[int]struct {
+		result1 int
+	}
+	MetadataStub        func() db.ResourceMetadataFields
+	metadataMutex       sync.RWMutex
+	metadataArgsForCall []struct{}
+	metadataReturns     struct {
+		result1 db.ResourceMetadataFields
+	}
+	metadataReturnsOnCall map[int]struct {
+		result1 db.ResourceMetadataFields
+	}
+	ScheduleTimeStub        func() time.Time
+	scheduleTimeMutex       sync.RWMutex
+	scheduleTimeArgsForCall []struct{}
+	scheduleTimeReturns     struct {
+		result1 time.Time
+	}
+	scheduleTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	ContainersHeldUntilStub        func() time.Time
+	containersHeldUntilMutex       sync.RWMutex
+	containersHeldUntilArgsForCall []struct{}
+	containersHeldUntilReturns     struct {
+		result1 time.Time
+	}
+	containersHeldUntilReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	ApprovalStatusStub        func() atc.ApprovalStatus
+	approvalStatusMutex       sync.RWMutex
+	approvalStatusArgsForCall []struct{}
+	approvalStatusReturns     struct {
+		result1 atc.ApprovalStatus
+	}
+	approvalStatusReturnsOnCall map[int]struct {
+		result1 atc.ApprovalStatus
+	}
+	ApproversStub        func() []string
+	approversMutex       sync.RWMutex
+	approversArgsForCall []struct{}
+	approversReturns     struct {
+		result1 []string
+	}
+	approversReturnsOnCall map[int]struct {
+		result1 []string
+	}
+	ApproverStub        func() string
+	approverMutex       sync.RWMutex
+	approverArgsForCall []struct{}
+	approverReturns     struct {
+		result1 string
+	}
+	approverReturnsOnCall map[int]struct {
+		result1 string
+	}
+	ApprovalCommentStub        func() string
+	approvalCommentMutex       sync.RWMutex
+	approvalCommentArgsForCall []struct{}
+	approvalCommentReturns     struct {
+		result1 string
+	}
+	approvalCommentReturnsOnCall map[int]struct {
+		result1 string
+	}
+	ApprovalDeadlineStub        func() time.Time
+	approvalDeadlineMutex       sync.RWMutex
+	approvalDeadlineArgsForCall []struct{}
+	approvalDeadlineReturns     struct {
+		result1 time.Time
+	}
+	approvalDeadlineReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	IsRunningStub        func() bool
 	isRunningMutex       sync.RWMutex
 	isRunningArgsForCall []struct{}
@@ -274,6 +382,30 @@ type FakeBuild struct {
 	setInterceptibleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetHoldContainersOnFailureStub        func(time.Duration) error
+	setHoldContainersOnFailureMutex       sync.RWMutex
+	setHoldContainersOnFailureArgsForCall []struct {
+		duration time.Duration
+	}
+	setHoldContainersOnFailureReturns struct {
+		result1 error
+	}
+	setHoldContainersOnFailureReturnsOnCall map[int]struct {
+		result1 error
+	}
+	HoldContainersStub        func(time.Duration) (bool, error)
+	holdContainersMutex       sync.RWMutex
+	holdContainersArgsForCall []struct {
+		duration time.Duration
+	}
+	holdContainersReturns struct {
+		result1 bool
+		result2 error
+	}
+	holdContainersReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	EventsStub        func(uint) (db.EventSource, error)
 	eventsMutex       sync.RWMutex
 	eventsArgsForCall []struct {
@@ -298,6 +430,48 @@ type FakeBuild struct {
 	saveEventReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UndrainedEventsStub        func(int) ([]db.BuildEvent, error)
+	undrainedEventsMutex       sync.RWMutex
+	undrainedEventsArgsForCall []struct {
+		limit int
+	}
+	undrainedEventsReturns struct {
+		result1 []db.BuildEvent
+		result2 error
+	}
+	undrainedEventsReturnsOnCall map[int]struct {
+		result1 []db.BuildEvent
+		result2 error
+	}
+	IsDrainedStub        func() bool
+	isDrainedMutex       sync.RWMutex
+	isDrainedArgsForCall []struct{}
+	isDrainedReturns     struct {
+		result1 bool
+	}
+	isDrainedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	SaveDrainOffsetStub        func(int) error
+	saveDrainOffsetMutex       sync.RWMutex
+	saveDrainOffsetArgsForCall []struct {
+		offset int
+	}
+	saveDrainOffsetReturns struct {
+		result1 error
+	}
+	saveDrainOffsetReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MarkDrainedStub        func() error
+	markDrainedMutex       sync.RWMutex
+	markDrainedArgsForCall []struct{}
+	markDrainedReturns     struct {
+		result1 error
+	}
+	markDrainedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveInputStub        func(input db.BuildInput) error
 	saveInputMutex       sync.RWMutex
 	saveInputArgsForCall []struct {
@@ -332,6 +506,17 @@ type FakeBuild struct {
 	useInputsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InputOverridesStub        func() (map[string]int, error)
+	inputOverridesMutex       sync.RWMutex
+	inputOverridesArgsForCall []struct{}
+	inputOverridesReturns     struct {
+		result1 map[string]int
+		result2 error
+	}
+	inputOverridesReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
 	ResourcesStub        func() ([]db.BuildInput, []db.BuildOutput, error)
 	resourcesMutex       sync.RWMutex
 	resourcesArgsForCall []struct{}
@@ -345,6 +530,65 @@ type FakeBuild struct {
 		result2 []db.BuildOutput
 		result3 error
 	}
+	ArtifactsStub        func() ([]db.BuildArtifact, error)
+	artifactsMutex       sync.RWMutex
+	artifactsArgsForCall []struct{}
+	artifactsReturns     struct {
+		result1 []db.BuildArtifact
+		result2 error
+	}
+	artifactsReturnsOnCall map[int]struct {
+		result1 []db.BuildArtifact
+		result2 error
+	}
+	ArtifactStub        func(name string) (db.BuildArtifact, bool, error)
+	artifactMutex       sync.RWMutex
+	artifactArgsForCall []struct {
+		name string
+	}
+	artifactReturns struct {
+		result1 db.BuildArtifact
+		result2 bool
+		result3 error
+	}
+	artifactReturnsOnCall map[int]struct {
+		result1 db.BuildArtifact
+		result2 bool
+		result3 error
+	}
+	SaveTestReportStub        func(db.TestReport) error
+	saveTestReportMutex       sync.RWMutex
+	saveTestReportArgsForCall []struct {
+		report db.TestReport
+	}
+	saveTestReportReturns struct {
+		result1 error
+	}
+	saveTestReportReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveMetadataStub        func(db.ResourceMetadataFields) error
+	saveMetadataMutex       sync.RWMutex
+	saveMetadataArgsForCall []struct {
+		metadata db.ResourceMetadataFields
+	}
+	saveMetadataReturns struct {
+		result1 error
+	}
+	saveMetadataReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TestReportsStub        func() ([]db.TestReport, error)
+	testReportsMutex       sync.RWMutex
+	testReportsArgsForCall []struct{}
+	testReportsReturns     struct {
+		result1 []db.TestReport
+		result2 error
+	}
+	testReportsReturnsOnCall map[int]struct {
+		result1 []db.TestReport
+		result2 error
+	}
 	GetVersionedResourcesStub        func() (db.SavedVersionedResources, error)
 	getVersionedResourcesMutex       sync.RWMutex
 	getVersionedResourcesArgsForCall []struct{}
@@ -422,6 +666,36 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RequestApprovalStub        func(approvers []string, deadline time.Time, inputs []db.BuildInput) (bool, error)
+	requestApprovalMutex       sync.RWMutex
+	requestApprovalArgsForCall []struct {
+		approvers []string
+		deadline  time.Time
+		inputs    []db.BuildInput
+	}
+	requestApprovalReturns struct {
+		result1 bool
+		result2 error
+	}
+	requestApprovalReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ResolveApprovalStub        func(status atc.ApprovalStatus, approver string, comment string) (bool, error)
+	resolveApprovalMutex       sync.RWMutex
+	resolveApprovalArgsForCall []struct {
+		status   atc.ApprovalStatus
+		approver string
+		comment  string
+	}
+	resolveApprovalReturns struct {
+		result1 bool
+		result2 error
+	}
+	resolveApprovalReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -906,6 +1180,46 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
+func (fake *FakeBuild) CreateTime() time.Time {
+	fake.createTimeMutex.Lock()
+	ret, specificReturn := fake.createTimeReturnsOnCall[len(fake.createTimeArgsForCall)]
+	fake.createTimeArgsForCall = append(fake.createTimeArgsForCall, struct{}{})
+	fake.recordInvocation("CreateTime", []interface{}{})
+	fake.createTimeMutex.Unlock()
+	if fake.CreateTimeStub != nil {
+		return fake.CreateTimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.createTimeReturns.result1
+}
+
+func (fake *FakeBuild) CreateTimeCallCount() int {
+	fake.createTimeMutex.RLock()
+	defer fake.createTimeMutex.RUnlock()
+	return len(fake.createTimeArgsForCall)
+}
+
+func (fake *FakeBuild) CreateTimeReturns(result1 time.Time) {
+	fake.CreateTimeStub = nil
+	fake.createTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) CreateTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.CreateTimeStub = nil
+	if fake.createTimeReturnsOnCall == nil {
+		fake.createTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeBuild) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -1106,160 +1420,600 @@ func (fake *FakeBuild) IsScheduledReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
-func (fake *FakeBuild) IsRunning() bool {
-	fake.isRunningMutex.Lock()
-	ret, specificReturn := fake.isRunningReturnsOnCall[len(fake.isRunningArgsForCall)]
-	fake.isRunningArgsForCall = append(fake.isRunningArgsForCall, struct{}{})
-	fake.recordInvocation("IsRunning", []interface{}{})
-	fake.isRunningMutex.Unlock()
-	if fake.IsRunningStub != nil {
-		return fake.IsRunningStub()
+func (fake *FakeBuild) RerunOf() int {
+	fake.rerunOfMutex.Lock()
+	ret, specificReturn := fake.rerunOfReturnsOnCall[len(fake.rerunOfArgsForCall)]
+	fake.rerunOfArgsForCall = append(fake.rerunOfArgsForCall, struct{}{})
+	fake.recordInvocation("RerunOf", []interface{}{})
+	fake.rerunOfMutex.Unlock()
+	if fake.RerunOfStub != nil {
+		return fake.RerunOfStub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.isRunningReturns.result1
+	return fake.rerunOfReturns.result1
 }
 
-func (fake *FakeBuild) IsRunningCallCount() int {
-	fake.isRunningMutex.RLock()
-	defer fake.isRunningMutex.RUnlock()
-	return len(fake.isRunningArgsForCall)
+func (fake *FakeBuild) RerunOfCallCount() int {
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	return len(fake.rerunOfArgsForCall)
 }
 
-func (fake *FakeBuild) IsRunningReturns(result1 bool) {
-	fake.IsRunningStub = nil
-	fake.isRunningReturns = struct {
-		result1 bool
+func (fake *FakeBuild) RerunOfReturns(result1 int) {
+	fake.RerunOfStub = nil
+	fake.rerunOfReturns = struct {
+		result1 int
 	}{result1}
 }
 
-func (fake *FakeBuild) IsRunningReturnsOnCall(i int, result1 bool) {
-	fake.IsRunningStub = nil
-	if fake.isRunningReturnsOnCall == nil {
-		fake.isRunningReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *FakeBuild) RerunOfReturnsOnCall(i int, result1 int) {
+	fake.RerunOfStub = nil
+	if fake.rerunOfReturnsOnCall == nil {
+		fake.rerunOfReturnsOnCall = make(map[int]struct {
+			result1 int
 		})
 	}
-	fake.isRunningReturnsOnCall[i] = struct {
-		result1 bool
+	fake.rerunOfReturnsOnCall[i] = struct {
+		result1 int
 	}{result1}
 }
 
-func (fake *FakeBuild) Reload() (bool, error) {
-	fake.reloadMutex.Lock()
-	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
-	fake.reloadArgsForCall = append(fake.reloadArgsForCall, struct{}{})
-	fake.recordInvocation("Reload", []interface{}{})
-	fake.reloadMutex.Unlock()
-	if fake.ReloadStub != nil {
-		return fake.ReloadStub()
+func (fake *FakeBuild) SupersededBy() int {
+	fake.supersededByMutex.Lock()
+	ret, specificReturn := fake.supersededByReturnsOnCall[len(fake.supersededByArgsForCall)]
+	fake.supersededByArgsForCall = append(fake.supersededByArgsForCall, struct{}{})
+	fake.recordInvocation("SupersededBy", []interface{}{})
+	fake.supersededByMutex.Unlock()
+	if fake.SupersededByStub != nil {
+		return fake.SupersededByStub()
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.reloadReturns.result1, fake.reloadReturns.result2
+	return fake.supersededByReturns.result1
 }
 
-func (fake *FakeBuild) ReloadCallCount() int {
-	fake.reloadMutex.RLock()
-	defer fake.reloadMutex.RUnlock()
-	return len(fake.reloadArgsForCall)
+func (fake *FakeBuild) SupersededByCallCount() int {
+	fake.supersededByMutex.RLock()
+	defer fake.supersededByMutex.RUnlock()
+	return len(fake.supersededByArgsForCall)
 }
 
-func (fake *FakeBuild) ReloadReturns(result1 bool, result2 error) {
-	fake.ReloadStub = nil
-	fake.reloadReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+func (fake *FakeBuild) SupersededByReturns(result1 int) {
+	fake.SupersededByStub = nil
+	fake.supersededByReturns = struct {
+		result1 int
+	}{result1}
 }
 
-func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.ReloadStub = nil
-	if fake.reloadReturnsOnCall == nil {
-		fake.reloadReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
+func (fake *FakeBuild) SupersededByReturnsOnCall(i int, result1 int) {
+	fake.SupersededByStub = nil
+	if fake.supersededByReturnsOnCall == nil {
+		fake.supersededByReturnsOnCall = make(map[int]struct {
+			result1 int
 		})
 	}
-	fake.reloadReturnsOnCall[i] = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+	fake.supersededByReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
 }
 
-func (fake *FakeBuild) Interceptible() (bool, error) {
-	fake.interceptibleMutex.Lock()
-	ret, specificReturn := fake.interceptibleReturnsOnCall[len(fake.interceptibleArgsForCall)]
-	fake.interceptibleArgsForCall = append(fake.interceptibleArgsForCall, struct{}{})
-	fake.recordInvocation("Interceptible", []interface{}{})
-	fake.interceptibleMutex.Unlock()
-	if fake.InterceptibleStub != nil {
-		return fake.InterceptibleStub()
+func (fake *FakeBuild) TriggeredBy() int {
+	fake.triggeredByMutex.Lock()
+	ret, specificReturn := fake.triggeredByReturnsOnCall[len(fake.triggeredByArgsForCall)]
+	fake.triggeredByArgsForCall = append(fake.triggeredByArgsForCall, struct{}{})
+	fake.recordInvocation("TriggeredBy", []interface{}{})
+	fake.triggeredByMutex.Unlock()
+	if fake.TriggeredByStub != nil {
+		return fake.TriggeredByStub()
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.interceptibleReturns.result1, fake.interceptibleReturns.result2
+	return fake.triggeredByReturns.result1
 }
 
-func (fake *FakeBuild) InterceptibleCallCount() int {
-	fake.interceptibleMutex.RLock()
-	defer fake.interceptibleMutex.RUnlock()
-	return len(fake.interceptibleArgsForCall)
+func (fake *FakeBuild) TriggeredByCallCount() int {
+	fake.triggeredByMutex.RLock()
+	defer fake.triggeredByMutex.RUnlock()
+	return len(fake.triggeredByArgsForCall)
 }
 
-func (fake *FakeBuild) InterceptibleReturns(result1 bool, result2 error) {
-	fake.InterceptibleStub = nil
-	fake.interceptibleReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+func (fake *FakeBuild) TriggeredByReturns(result1 int) {
+	fake.TriggeredByStub = nil
+	fake.triggeredByReturns = struct {
+		result1 int
+	}{result1}
 }
 
-func (fake *FakeBuild) InterceptibleReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.InterceptibleStub = nil
-	if fake.interceptibleReturnsOnCall == nil {
-		fake.interceptibleReturnsOnCall = make(map[int]struct {
-			result1 bool
-			result2 error
+func (fake *FakeBuild) TriggeredByReturnsOnCall(i int, result1 int) {
+	fake.TriggeredByStub = nil
+	if fake.triggeredByReturnsOnCall == nil {
+		fake.triggeredByReturnsOnCall = make(map[int]struct {
+			result1 int
 		})
 	}
-	fake.interceptibleReturnsOnCall[i] = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
+	fake.triggeredByReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
 }
 
-func (fake *FakeBuild) AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
-	fake.acquireTrackingLockMutex.Lock()
-	ret, specificReturn := fake.acquireTrackingLockReturnsOnCall[len(fake.acquireTrackingLockArgsForCall)]
-	fake.acquireTrackingLockArgsForCall = append(fake.acquireTrackingLockArgsForCall, struct {
-		logger   lager.Logger
-		interval time.Duration
-	}{logger, interval})
-	fake.recordInvocation("AcquireTrackingLock", []interface{}{logger, interval})
-	fake.acquireTrackingLockMutex.Unlock()
-	if fake.AcquireTrackingLockStub != nil {
-		return fake.AcquireTrackingLockStub(logger, interval)
+func (fake *FakeBuild) Metadata() db.ResourceMetadataFields {
+	fake.metadataMutex.Lock()
+	ret, specificReturn := fake.metadataReturnsOnCall[len(fake.metadataArgsForCall)]
+	fake.metadataArgsForCall = append(fake.metadataArgsForCall, struct{}{})
+	fake.recordInvocation("Metadata", []interface{}{})
+	fake.metadataMutex.Unlock()
+	if fake.MetadataStub != nil {
+		return fake.MetadataStub()
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	return fake.acquireTrackingLockReturns.result1, fake.acquireTrackingLockReturns.result2, fake.acquireTrackingLockReturns.result3
+	return fake.metadataReturns.result1
 }
 
-func (fake *FakeBuild) AcquireTrackingLockCallCount() int {
-	fake.acquireTrackingLockMutex.RLock()
-	defer fake.acquireTrackingLockMutex.RUnlock()
-	return len(fake.acquireTrackingLockArgsForCall)
+func (fake *FakeBuild) MetadataCallCount() int {
+	fake.metadataMutex.RLock()
+	defer fake.metadataMutex.RUnlock()
+	return len(fake.metadataArgsForCall)
 }
 
-func (fake *FakeBuild) AcquireTrackingLockArgsForCall(i int) (lager.Logger, time.Duration) {
-	fake.acquireTrackingLockMutex.RLock()
-	defer fake.acquireTrackingLockMutex.RUnlock()
-	return fake.acquireTrackingLockArgsForCall[i].logger, fake.acquireTrackingLockArgsForCall[i].interval
+func (fake *FakeBuild) MetadataReturns(result1 db.ResourceMetadataFields) {
+	fake.MetadataStub = nil
+	fake.metadataReturns = struct {
+		result1 db.ResourceMetadataFields
+	}{result1}
+}
+
+func (fake *FakeBuild) MetadataReturnsOnCall(i int, result1 db.ResourceMetadataFields) {
+	fake.MetadataStub = nil
+	if fake.metadataReturnsOnCall == nil {
+		fake.metadataReturnsOnCall = make(map[int]struct {
+			result1 db.ResourceMetadataFields
+		})
+	}
+	fake.metadataReturnsOnCall[i] = struct {
+		result1 db.ResourceMetadataFields
+	}{result1}
+}
+
+func (fake *FakeBuild) ScheduleTime() time.Time {
+	fake.scheduleTimeMutex.Lock()
+	ret, specificReturn := fake.scheduleTimeReturnsOnCall[len(fake.scheduleTimeArgsForCall)]
+	fake.scheduleTimeArgsForCall = append(fake.scheduleTimeArgsForCall, struct{}{})
+	fake.recordInvocation("ScheduleTime", []interface{}{})
+	fake.scheduleTimeMutex.Unlock()
+	if fake.ScheduleTimeStub != nil {
+		return fake.ScheduleTimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.scheduleTimeReturns.result1
+}
+
+func (fake *FakeBuild) ScheduleTimeCallCount() int {
+	fake.scheduleTimeMutex.RLock()
+	defer fake.scheduleTimeMutex.RUnlock()
+	return len(fake.scheduleTimeArgsForCall)
+}
+
+func (fake *FakeBuild) ScheduleTimeReturns(result1 time.Time) {
+	fake.ScheduleTimeStub = nil
+	fake.scheduleTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) ScheduleTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.ScheduleTimeStub = nil
+	if fake.scheduleTimeReturnsOnCall == nil {
+		fake.scheduleTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.scheduleTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) ContainersHeldUntil() time.Time {
+	fake.containersHeldUntilMutex.Lock()
+	ret, specificReturn := fake.containersHeldUntilReturnsOnCall[len(fake.containersHeldUntilArgsForCall)]
+	fake.containersHeldUntilArgsForCall = append(fake.containersHeldUntilArgsForCall, struct{}{})
+	fake.recordInvocation("ContainersHeldUntil", []interface{}{})
+	fake.containersHeldUntilMutex.Unlock()
+	if fake.ContainersHeldUntilStub != nil {
+		return fake.ContainersHeldUntilStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.containersHeldUntilReturns.result1
+}
+
+func (fake *FakeBuild) ContainersHeldUntilCallCount() int {
+	fake.containersHeldUntilMutex.RLock()
+	defer fake.containersHeldUntilMutex.RUnlock()
+	return len(fake.containersHeldUntilArgsForCall)
+}
+
+func (fake *FakeBuild) ContainersHeldUntilReturns(result1 time.Time) {
+	fake.ContainersHeldUntilStub = nil
+	fake.containersHeldUntilReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) ContainersHeldUntilReturnsOnCall(i int, result1 time.Time) {
+	fake.ContainersHeldUntilStub = nil
+	if fake.containersHeldUntilReturnsOnCall == nil {
+		fake.containersHeldUntilReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.containersHeldUntilReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) ApprovalStatus() atc.ApprovalStatus {
+	fake.approvalStatusMutex.Lock()
+	ret, specificReturn := fake.approvalStatusReturnsOnCall[len(fake.approvalStatusArgsForCall)]
+	fake.approvalStatusArgsForCall = append(fake.approvalStatusArgsForCall, struct{}{})
+	fake.recordInvocation("ApprovalStatus", []interface{}{})
+	fake.approvalStatusMutex.Unlock()
+	if fake.ApprovalStatusStub != nil {
+		return fake.ApprovalStatusStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.approvalStatusReturns.result1
+}
+
+func (fake *FakeBuild) ApprovalStatusCallCount() int {
+	fake.approvalStatusMutex.RLock()
+	defer fake.approvalStatusMutex.RUnlock()
+	return len(fake.approvalStatusArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalStatusReturns(result1 atc.ApprovalStatus) {
+	fake.ApprovalStatusStub = nil
+	fake.approvalStatusReturns = struct {
+		result1 atc.ApprovalStatus
+	}{result1}
+}
+
+func (fake *FakeBuild) ApprovalStatusReturnsOnCall(i int, result1 atc.ApprovalStatus) {
+	fake.ApprovalStatusStub = nil
+	if fake.approvalStatusReturnsOnCall == nil {
+		fake.approvalStatusReturnsOnCall = make(map[int]struct {
+			result1 atc.ApprovalStatus
+		})
+	}
+	fake.approvalStatusReturnsOnCall[i] = struct {
+		result1 atc.ApprovalStatus
+	}{result1}
+}
+
+func (fake *FakeBuild) Approvers() []string {
+	fake.approversMutex.Lock()
+	ret, specificReturn := fake.approversReturnsOnCall[len(fake.approversArgsForCall)]
+	fake.approversArgsForCall = append(fake.approversArgsForCall, struct{}{})
+	fake.recordInvocation("Approvers", []interface{}{})
+	fake.approversMutex.Unlock()
+	if fake.ApproversStub != nil {
+		return fake.ApproversStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.approversReturns.result1
+}
+
+func (fake *FakeBuild) ApproversCallCount() int {
+	fake.approversMutex.RLock()
+	defer fake.approversMutex.RUnlock()
+	return len(fake.approversArgsForCall)
+}
+
+func (fake *FakeBuild) ApproversReturns(result1 []string) {
+	fake.ApproversStub = nil
+	fake.approversReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeBuild) ApproversReturnsOnCall(i int, result1 []string) {
+	fake.ApproversStub = nil
+	if fake.approversReturnsOnCall == nil {
+		fake.approversReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.approversReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeBuild) Approver() string {
+	fake.approverMutex.Lock()
+	ret, specificReturn := fake.approverReturnsOnCall[len(fake.approverArgsForCall)]
+	fake.approverArgsForCall = append(fake.approverArgsForCall, struct{}{})
+	fake.recordInvocation("Approver", []interface{}{})
+	fake.approverMutex.Unlock()
+	if fake.ApproverStub != nil {
+		return fake.ApproverStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.approverReturns.result1
+}
+
+func (fake *FakeBuild) ApproverCallCount() int {
+	fake.approverMutex.RLock()
+	defer fake.approverMutex.RUnlock()
+	return len(fake.approverArgsForCall)
+}
+
+func (fake *FakeBuild) ApproverReturns(result1 string) {
+	fake.ApproverStub = nil
+	fake.approverReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) ApproverReturnsOnCall(i int, result1 string) {
+	fake.ApproverStub = nil
+	if fake.approverReturnsOnCall == nil {
+		fake.approverReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.approverReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) ApprovalComment() string {
+	fake.approvalCommentMutex.Lock()
+	ret, specificReturn := fake.approvalCommentReturnsOnCall[len(fake.approvalCommentArgsForCall)]
+	fake.approvalCommentArgsForCall = append(fake.approvalCommentArgsForCall, struct{}{})
+	fake.recordInvocation("ApprovalComment", []interface{}{})
+	fake.approvalCommentMutex.Unlock()
+	if fake.ApprovalCommentStub != nil {
+		return fake.ApprovalCommentStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.approvalCommentReturns.result1
+}
+
+func (fake *FakeBuild) ApprovalCommentCallCount() int {
+	fake.approvalCommentMutex.RLock()
+	defer fake.approvalCommentMutex.RUnlock()
+	return len(fake.approvalCommentArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalCommentReturns(result1 string) {
+	fake.ApprovalCommentStub = nil
+	fake.approvalCommentReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) ApprovalCommentReturnsOnCall(i int, result1 string) {
+	fake.ApprovalCommentStub = nil
+	if fake.approvalCommentReturnsOnCall == nil {
+		fake.approvalCommentReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.approvalCommentReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) ApprovalDeadline() time.Time {
+	fake.approvalDeadlineMutex.Lock()
+	ret, specificReturn := fake.approvalDeadlineReturnsOnCall[len(fake.approvalDeadlineArgsForCall)]
+	fake.approvalDeadlineArgsForCall = append(fake.approvalDeadlineArgsForCall, struct{}{})
+	fake.recordInvocation("ApprovalDeadline", []interface{}{})
+	fake.approvalDeadlineMutex.Unlock()
+	if fake.ApprovalDeadlineStub != nil {
+		return fake.ApprovalDeadlineStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.approvalDeadlineReturns.result1
+}
+
+func (fake *FakeBuild) ApprovalDeadlineCallCount() int {
+	fake.approvalDeadlineMutex.RLock()
+	defer fake.approvalDeadlineMutex.RUnlock()
+	return len(fake.approvalDeadlineArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalDeadlineReturns(result1 time.Time) {
+	fake.ApprovalDeadlineStub = nil
+	fake.approvalDeadlineReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) ApprovalDeadlineReturnsOnCall(i int, result1 time.Time) {
+	fake.ApprovalDeadlineStub = nil
+	if fake.approvalDeadlineReturnsOnCall == nil {
+		fake.approvalDeadlineReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.approvalDeadlineReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) IsRunning() bool {
+	fake.isRunningMutex.Lock()
+	ret, specificReturn := fake.isRunningReturnsOnCall[len(fake.isRunningArgsForCall)]
+	fake.isRunningArgsForCall = append(fake.isRunningArgsForCall, struct{}{})
+	fake.recordInvocation("IsRunning", []interface{}{})
+	fake.isRunningMutex.Unlock()
+	if fake.IsRunningStub != nil {
+		return fake.IsRunningStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.isRunningReturns.result1
+}
+
+func (fake *FakeBuild) IsRunningCallCount() int {
+	fake.isRunningMutex.RLock()
+	defer fake.isRunningMutex.RUnlock()
+	return len(fake.isRunningArgsForCall)
+}
+
+func (fake *FakeBuild) IsRunningReturns(result1 bool) {
+	fake.IsRunningStub = nil
+	fake.isRunningReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsRunningReturnsOnCall(i int, result1 bool) {
+	fake.IsRunningStub = nil
+	if fake.isRunningReturnsOnCall == nil {
+		fake.isRunningReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isRunningReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) Reload() (bool, error) {
+	fake.reloadMutex.Lock()
+	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
+	fake.reloadArgsForCall = append(fake.reloadArgsForCall, struct{}{})
+	fake.recordInvocation("Reload", []interface{}{})
+	fake.reloadMutex.Unlock()
+	if fake.ReloadStub != nil {
+		return fake.ReloadStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.reloadReturns.result1, fake.reloadReturns.result2
+}
+
+func (fake *FakeBuild) ReloadCallCount() int {
+	fake.reloadMutex.RLock()
+	defer fake.reloadMutex.RUnlock()
+	return len(fake.reloadArgsForCall)
+}
+
+func (fake *FakeBuild) ReloadReturns(result1 bool, result2 error) {
+	fake.ReloadStub = nil
+	fake.reloadReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ReloadStub = nil
+	if fake.reloadReturnsOnCall == nil {
+		fake.reloadReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.reloadReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Interceptible() (bool, error) {
+	fake.interceptibleMutex.Lock()
+	ret, specificReturn := fake.interceptibleReturnsOnCall[len(fake.interceptibleArgsForCall)]
+	fake.interceptibleArgsForCall = append(fake.interceptibleArgsForCall, struct{}{})
+	fake.recordInvocation("Interceptible", []interface{}{})
+	fake.interceptibleMutex.Unlock()
+	if fake.InterceptibleStub != nil {
+		return fake.InterceptibleStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.interceptibleReturns.result1, fake.interceptibleReturns.result2
+}
+
+func (fake *FakeBuild) InterceptibleCallCount() int {
+	fake.interceptibleMutex.RLock()
+	defer fake.interceptibleMutex.RUnlock()
+	return len(fake.interceptibleArgsForCall)
+}
+
+func (fake *FakeBuild) InterceptibleReturns(result1 bool, result2 error) {
+	fake.InterceptibleStub = nil
+	fake.interceptibleReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) InterceptibleReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.InterceptibleStub = nil
+	if fake.interceptibleReturnsOnCall == nil {
+		fake.interceptibleReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.interceptibleReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
+	fake.acquireTrackingLockMutex.Lock()
+	ret, specificReturn := fake.acquireTrackingLockReturnsOnCall[len(fake.acquireTrackingLockArgsForCall)]
+	fake.acquireTrackingLockArgsForCall = append(fake.acquireTrackingLockArgsForCall, struct {
+		logger   lager.Logger
+		interval time.Duration
+	}{logger, interval})
+	fake.recordInvocation("AcquireTrackingLock", []interface{}{logger, interval})
+	fake.acquireTrackingLockMutex.Unlock()
+	if fake.AcquireTrackingLockStub != nil {
+		return fake.AcquireTrackingLockStub(logger, interval)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.acquireTrackingLockReturns.result1, fake.acquireTrackingLockReturns.result2, fake.acquireTrackingLockReturns.result3
+}
+
+func (fake *FakeBuild) AcquireTrackingLockCallCount() int {
+	fake.acquireTrackingLockMutex.RLock()
+	defer fake.acquireTrackingLockMutex.RUnlock()
+	return len(fake.acquireTrackingLockArgsForCall)
+}
+
+func (fake *FakeBuild) AcquireTrackingLockArgsForCall(i int) (lager.Logger, time.Duration) {
+	fake.acquireTrackingLockMutex.RLock()
+	defer fake.acquireTrackingLockMutex.RUnlock()
+	return fake.acquireTrackingLockArgsForCall[i].logger, fake.acquireTrackingLockArgsForCall[i].interval
 }
 
 func (fake *FakeBuild) AcquireTrackingLockReturns(result1 lock.Lock, result2 bool, result3 error) {
@@ -1525,106 +2279,384 @@ func (fake *FakeBuild) SetInterceptibleReturnsOnCall(i int, result1 error) {
 			result1 error
 		})
 	}
-	fake.setInterceptibleReturnsOnCall[i] = struct {
-		result1 error
+	fake.setInterceptibleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetHoldContainersOnFailure(duration time.Duration) error {
+	fake.setHoldContainersOnFailureMutex.Lock()
+	ret, specificReturn := fake.setHoldContainersOnFailureReturnsOnCall[len(fake.setHoldContainersOnFailureArgsForCall)]
+	fake.setHoldContainersOnFailureArgsForCall = append(fake.setHoldContainersOnFailureArgsForCall, struct {
+		duration time.Duration
+	}{duration})
+	fake.recordInvocation("SetHoldContainersOnFailure", []interface{}{duration})
+	fake.setHoldContainersOnFailureMutex.Unlock()
+	if fake.SetHoldContainersOnFailureStub != nil {
+		return fake.SetHoldContainersOnFailureStub(duration)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setHoldContainersOnFailureReturns.result1
+}
+
+func (fake *FakeBuild) SetHoldContainersOnFailureCallCount() int {
+	fake.setHoldContainersOnFailureMutex.RLock()
+	defer fake.setHoldContainersOnFailureMutex.RUnlock()
+	return len(fake.setHoldContainersOnFailureArgsForCall)
+}
+
+func (fake *FakeBuild) SetHoldContainersOnFailureArgsForCall(i int) time.Duration {
+	fake.setHoldContainersOnFailureMutex.RLock()
+	defer fake.setHoldContainersOnFailureMutex.RUnlock()
+	return fake.setHoldContainersOnFailureArgsForCall[i].duration
+}
+
+func (fake *FakeBuild) SetHoldContainersOnFailureReturns(result1 error) {
+	fake.SetHoldContainersOnFailureStub = nil
+	fake.setHoldContainersOnFailureReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetHoldContainersOnFailureReturnsOnCall(i int, result1 error) {
+	fake.SetHoldContainersOnFailureStub = nil
+	if fake.setHoldContainersOnFailureReturnsOnCall == nil {
+		fake.setHoldContainersOnFailureReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setHoldContainersOnFailureReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) HoldContainers(duration time.Duration) (bool, error) {
+	fake.holdContainersMutex.Lock()
+	ret, specificReturn := fake.holdContainersReturnsOnCall[len(fake.holdContainersArgsForCall)]
+	fake.holdContainersArgsForCall = append(fake.holdContainersArgsForCall, struct {
+		duration time.Duration
+	}{duration})
+	fake.recordInvocation("HoldContainers", []interface{}{duration})
+	fake.holdContainersMutex.Unlock()
+	if fake.HoldContainersStub != nil {
+		return fake.HoldContainersStub(duration)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.holdContainersReturns.result1, fake.holdContainersReturns.result2
+}
+
+func (fake *FakeBuild) HoldContainersCallCount() int {
+	fake.holdContainersMutex.RLock()
+	defer fake.holdContainersMutex.RUnlock()
+	return len(fake.holdContainersArgsForCall)
+}
+
+func (fake *FakeBuild) HoldContainersArgsForCall(i int) time.Duration {
+	fake.holdContainersMutex.RLock()
+	defer fake.holdContainersMutex.RUnlock()
+	return fake.holdContainersArgsForCall[i].duration
+}
+
+func (fake *FakeBuild) HoldContainersReturns(result1 bool, result2 error) {
+	fake.HoldContainersStub = nil
+	fake.holdContainersReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) HoldContainersReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.HoldContainersStub = nil
+	if fake.holdContainersReturnsOnCall == nil {
+		fake.holdContainersReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.holdContainersReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Events(arg1 uint) (db.EventSource, error) {
+	fake.eventsMutex.Lock()
+	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.recordInvocation("Events", []interface{}{arg1})
+	fake.eventsMutex.Unlock()
+	if fake.EventsStub != nil {
+		return fake.EventsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.eventsReturns.result1, fake.eventsReturns.result2
+}
+
+func (fake *FakeBuild) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeBuild) EventsArgsForCall(i int) uint {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return fake.eventsArgsForCall[i].arg1
+}
+
+func (fake *FakeBuild) EventsReturns(result1 db.EventSource, result2 error) {
+	fake.EventsStub = nil
+	fake.eventsReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result2 error) {
+	fake.EventsStub = nil
+	if fake.eventsReturnsOnCall == nil {
+		fake.eventsReturnsOnCall = make(map[int]struct {
+			result1 db.EventSource
+			result2 error
+		})
+	}
+	fake.eventsReturnsOnCall[i] = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveEvent(event atc.Event) error {
+	fake.saveEventMutex.Lock()
+	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
+	fake.saveEventArgsForCall = append(fake.saveEventArgsForCall, struct {
+		event atc.Event
+	}{event})
+	fake.recordInvocation("SaveEvent", []interface{}{event})
+	fake.saveEventMutex.Unlock()
+	if fake.SaveEventStub != nil {
+		return fake.SaveEventStub(event)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveEventReturns.result1
+}
+
+func (fake *FakeBuild) SaveEventCallCount() int {
+	fake.saveEventMutex.RLock()
+	defer fake.saveEventMutex.RUnlock()
+	return len(fake.saveEventArgsForCall)
+}
+
+func (fake *FakeBuild) SaveEventArgsForCall(i int) atc.Event {
+	fake.saveEventMutex.RLock()
+	defer fake.saveEventMutex.RUnlock()
+	return fake.saveEventArgsForCall[i].event
+}
+
+func (fake *FakeBuild) SaveEventReturns(result1 error) {
+	fake.SaveEventStub = nil
+	fake.saveEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEventReturnsOnCall(i int, result1 error) {
+	fake.SaveEventStub = nil
+	if fake.saveEventReturnsOnCall == nil {
+		fake.saveEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) UndrainedEvents(limit int) ([]db.BuildEvent, error) {
+	fake.undrainedEventsMutex.Lock()
+	ret, specificReturn := fake.undrainedEventsReturnsOnCall[len(fake.undrainedEventsArgsForCall)]
+	fake.undrainedEventsArgsForCall = append(fake.undrainedEventsArgsForCall, struct {
+		limit int
+	}{limit})
+	fake.recordInvocation("UndrainedEvents", []interface{}{limit})
+	fake.undrainedEventsMutex.Unlock()
+	if fake.UndrainedEventsStub != nil {
+		return fake.UndrainedEventsStub(limit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.undrainedEventsReturns.result1, fake.undrainedEventsReturns.result2
+}
+
+func (fake *FakeBuild) UndrainedEventsCallCount() int {
+	fake.undrainedEventsMutex.RLock()
+	defer fake.undrainedEventsMutex.RUnlock()
+	return len(fake.undrainedEventsArgsForCall)
+}
+
+func (fake *FakeBuild) UndrainedEventsArgsForCall(i int) int {
+	fake.undrainedEventsMutex.RLock()
+	defer fake.undrainedEventsMutex.RUnlock()
+	return fake.undrainedEventsArgsForCall[i].limit
+}
+
+func (fake *FakeBuild) UndrainedEventsReturns(result1 []db.BuildEvent, result2 error) {
+	fake.UndrainedEventsStub = nil
+	fake.undrainedEventsReturns = struct {
+		result1 []db.BuildEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) UndrainedEventsReturnsOnCall(i int, result1 []db.BuildEvent, result2 error) {
+	fake.UndrainedEventsStub = nil
+	if fake.undrainedEventsReturnsOnCall == nil {
+		fake.undrainedEventsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildEvent
+			result2 error
+		})
+	}
+	fake.undrainedEventsReturnsOnCall[i] = struct {
+		result1 []db.BuildEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) IsDrained() bool {
+	fake.isDrainedMutex.Lock()
+	ret, specificReturn := fake.isDrainedReturnsOnCall[len(fake.isDrainedArgsForCall)]
+	fake.isDrainedArgsForCall = append(fake.isDrainedArgsForCall, struct{}{})
+	fake.recordInvocation("IsDrained", []interface{}{})
+	fake.isDrainedMutex.Unlock()
+	if fake.IsDrainedStub != nil {
+		return fake.IsDrainedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.isDrainedReturns.result1
+}
+
+func (fake *FakeBuild) IsDrainedCallCount() int {
+	fake.isDrainedMutex.RLock()
+	defer fake.isDrainedMutex.RUnlock()
+	return len(fake.isDrainedArgsForCall)
+}
+
+func (fake *FakeBuild) IsDrainedReturns(result1 bool) {
+	fake.IsDrainedStub = nil
+	fake.isDrainedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsDrainedReturnsOnCall(i int, result1 bool) {
+	fake.IsDrainedStub = nil
+	if fake.isDrainedReturnsOnCall == nil {
+		fake.isDrainedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isDrainedReturnsOnCall[i] = struct {
+		result1 bool
 	}{result1}
 }
 
-func (fake *FakeBuild) Events(arg1 uint) (db.EventSource, error) {
-	fake.eventsMutex.Lock()
-	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
-	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
-		arg1 uint
-	}{arg1})
-	fake.recordInvocation("Events", []interface{}{arg1})
-	fake.eventsMutex.Unlock()
-	if fake.EventsStub != nil {
-		return fake.EventsStub(arg1)
+func (fake *FakeBuild) SaveDrainOffset(offset int) error {
+	fake.saveDrainOffsetMutex.Lock()
+	ret, specificReturn := fake.saveDrainOffsetReturnsOnCall[len(fake.saveDrainOffsetArgsForCall)]
+	fake.saveDrainOffsetArgsForCall = append(fake.saveDrainOffsetArgsForCall, struct {
+		offset int
+	}{offset})
+	fake.recordInvocation("SaveDrainOffset", []interface{}{offset})
+	fake.saveDrainOffsetMutex.Unlock()
+	if fake.SaveDrainOffsetStub != nil {
+		return fake.SaveDrainOffsetStub(offset)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fake.eventsReturns.result1, fake.eventsReturns.result2
+	return fake.saveDrainOffsetReturns.result1
 }
 
-func (fake *FakeBuild) EventsCallCount() int {
-	fake.eventsMutex.RLock()
-	defer fake.eventsMutex.RUnlock()
-	return len(fake.eventsArgsForCall)
+func (fake *FakeBuild) SaveDrainOffsetCallCount() int {
+	fake.saveDrainOffsetMutex.RLock()
+	defer fake.saveDrainOffsetMutex.RUnlock()
+	return len(fake.saveDrainOffsetArgsForCall)
 }
 
-func (fake *FakeBuild) EventsArgsForCall(i int) uint {
-	fake.eventsMutex.RLock()
-	defer fake.eventsMutex.RUnlock()
-	return fake.eventsArgsForCall[i].arg1
+func (fake *FakeBuild) SaveDrainOffsetArgsForCall(i int) int {
+	fake.saveDrainOffsetMutex.RLock()
+	defer fake.saveDrainOffsetMutex.RUnlock()
+	return fake.saveDrainOffsetArgsForCall[i].offset
 }
 
-func (fake *FakeBuild) EventsReturns(result1 db.EventSource, result2 error) {
-	fake.EventsStub = nil
-	fake.eventsReturns = struct {
-		result1 db.EventSource
-		result2 error
-	}{result1, result2}
+func (fake *FakeBuild) SaveDrainOffsetReturns(result1 error) {
+	fake.SaveDrainOffsetStub = nil
+	fake.saveDrainOffsetReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result2 error) {
-	fake.EventsStub = nil
-	if fake.eventsReturnsOnCall == nil {
-		fake.eventsReturnsOnCall = make(map[int]struct {
-			result1 db.EventSource
-			result2 error
+func (fake *FakeBuild) SaveDrainOffsetReturnsOnCall(i int, result1 error) {
+	fake.SaveDrainOffsetStub = nil
+	if fake.saveDrainOffsetReturnsOnCall == nil {
+		fake.saveDrainOffsetReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.eventsReturnsOnCall[i] = struct {
-		result1 db.EventSource
-		result2 error
-	}{result1, result2}
+	fake.saveDrainOffsetReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeBuild) SaveEvent(event atc.Event) error {
-	fake.saveEventMutex.Lock()
-	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
-	fake.saveEventArgsForCall = append(fake.saveEventArgsForCall, struct {
-		event atc.Event
-	}{event})
-	fake.recordInvocation("SaveEvent", []interface{}{event})
-	fake.saveEventMutex.Unlock()
-	if fake.SaveEventStub != nil {
-		return fake.SaveEventStub(event)
+func (fake *FakeBuild) MarkDrained() error {
+	fake.markDrainedMutex.Lock()
+	ret, specificReturn := fake.markDrainedReturnsOnCall[len(fake.markDrainedArgsForCall)]
+	fake.markDrainedArgsForCall = append(fake.markDrainedArgsForCall, struct{}{})
+	fake.recordInvocation("MarkDrained", []interface{}{})
+	fake.markDrainedMutex.Unlock()
+	if fake.MarkDrainedStub != nil {
+		return fake.MarkDrainedStub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.saveEventReturns.result1
-}
-
-func (fake *FakeBuild) SaveEventCallCount() int {
-	fake.saveEventMutex.RLock()
-	defer fake.saveEventMutex.RUnlock()
-	return len(fake.saveEventArgsForCall)
+	return fake.markDrainedReturns.result1
 }
 
-func (fake *FakeBuild) SaveEventArgsForCall(i int) atc.Event {
-	fake.saveEventMutex.RLock()
-	defer fake.saveEventMutex.RUnlock()
-	return fake.saveEventArgsForCall[i].event
+func (fake *FakeBuild) MarkDrainedCallCount() int {
+	fake.markDrainedMutex.RLock()
+	defer fake.markDrainedMutex.RUnlock()
+	return len(fake.markDrainedArgsForCall)
 }
 
-func (fake *FakeBuild) SaveEventReturns(result1 error) {
-	fake.SaveEventStub = nil
-	fake.saveEventReturns = struct {
+func (fake *FakeBuild) MarkDrainedReturns(result1 error) {
+	fake.MarkDrainedStub = nil
+	fake.markDrainedReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeBuild) SaveEventReturnsOnCall(i int, result1 error) {
-	fake.SaveEventStub = nil
-	if fake.saveEventReturnsOnCall == nil {
-		fake.saveEventReturnsOnCall = make(map[int]struct {
+func (fake *FakeBuild) MarkDrainedReturnsOnCall(i int, result1 error) {
+	fake.MarkDrainedStub = nil
+	if fake.markDrainedReturnsOnCall == nil {
+		fake.markDrainedReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.saveEventReturnsOnCall[i] = struct {
+	fake.markDrainedReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -1779,6 +2811,49 @@ func (fake *FakeBuild) UseInputsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) InputOverrides() (map[string]int, error) {
+	fake.inputOverridesMutex.Lock()
+	ret, specificReturn := fake.inputOverridesReturnsOnCall[len(fake.inputOverridesArgsForCall)]
+	fake.inputOverridesArgsForCall = append(fake.inputOverridesArgsForCall, struct{}{})
+	fake.recordInvocation("InputOverrides", []interface{}{})
+	fake.inputOverridesMutex.Unlock()
+	if fake.InputOverridesStub != nil {
+		return fake.InputOverridesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.inputOverridesReturns.result1, fake.inputOverridesReturns.result2
+}
+
+func (fake *FakeBuild) InputOverridesCallCount() int {
+	fake.inputOverridesMutex.RLock()
+	defer fake.inputOverridesMutex.RUnlock()
+	return len(fake.inputOverridesArgsForCall)
+}
+
+func (fake *FakeBuild) InputOverridesReturns(result1 map[string]int, result2 error) {
+	fake.InputOverridesStub = nil
+	fake.inputOverridesReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) InputOverridesReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.InputOverridesStub = nil
+	if fake.inputOverridesReturnsOnCall == nil {
+		fake.inputOverridesReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.inputOverridesReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Resources() ([]db.BuildInput, []db.BuildOutput, error) {
 	fake.resourcesMutex.Lock()
 	ret, specificReturn := fake.resourcesReturnsOnCall[len(fake.resourcesArgsForCall)]
@@ -1825,6 +2900,242 @@ func (fake *FakeBuild) ResourcesReturnsOnCall(i int, result1 []db.BuildInput, re
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Artifacts() ([]db.BuildArtifact, error) {
+	fake.artifactsMutex.Lock()
+	ret, specificReturn := fake.artifactsReturnsOnCall[len(fake.artifactsArgsForCall)]
+	fake.artifactsArgsForCall = append(fake.artifactsArgsForCall, struct{}{})
+	fake.recordInvocation("Artifacts", []interface{}{})
+	fake.artifactsMutex.Unlock()
+	if fake.ArtifactsStub != nil {
+		return fake.ArtifactsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.artifactsReturns.result1, fake.artifactsReturns.result2
+}
+
+func (fake *FakeBuild) ArtifactsCallCount() int {
+	fake.artifactsMutex.RLock()
+	defer fake.artifactsMutex.RUnlock()
+	return len(fake.artifactsArgsForCall)
+}
+
+func (fake *FakeBuild) ArtifactsReturns(result1 []db.BuildArtifact, result2 error) {
+	fake.ArtifactsStub = nil
+	fake.artifactsReturns = struct {
+		result1 []db.BuildArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ArtifactsReturnsOnCall(i int, result1 []db.BuildArtifact, result2 error) {
+	fake.ArtifactsStub = nil
+	if fake.artifactsReturnsOnCall == nil {
+		fake.artifactsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildArtifact
+			result2 error
+		})
+	}
+	fake.artifactsReturnsOnCall[i] = struct {
+		result1 []db.BuildArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Artifact(name string) (db.BuildArtifact, bool, error) {
+	fake.artifactMutex.Lock()
+	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
+	fake.artifactArgsForCall = append(fake.artifactArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("Artifact", []interface{}{name})
+	fake.artifactMutex.Unlock()
+	if fake.ArtifactStub != nil {
+		return fake.ArtifactStub(name)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.artifactReturns.result1, fake.artifactReturns.result2, fake.artifactReturns.result3
+}
+
+func (fake *FakeBuild) ArtifactCallCount() int {
+	fake.artifactMutex.RLock()
+	defer fake.artifactMutex.RUnlock()
+	return len(fake.artifactArgsForCall)
+}
+
+func (fake *FakeBuild) ArtifactArgsForCall(i int) string {
+	fake.artifactMutex.RLock()
+	defer fake.artifactMutex.RUnlock()
+	return fake.artifactArgsForCall[i].name
+}
+
+func (fake *FakeBuild) ArtifactReturns(result1 db.BuildArtifact, result2 bool, result3 error) {
+	fake.ArtifactStub = nil
+	fake.artifactReturns = struct {
+		result1 db.BuildArtifact
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ArtifactReturnsOnCall(i int, result1 db.BuildArtifact, result2 bool, result3 error) {
+	fake.ArtifactStub = nil
+	if fake.artifactReturnsOnCall == nil {
+		fake.artifactReturnsOnCall = make(map[int]struct {
+			result1 db.BuildArtifact
+			result2 bool
+			result3 error
+		})
+	}
+	fake.artifactReturnsOnCall[i] = struct {
+		result1 db.BuildArtifact
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) SaveTestReport(report db.TestReport) error {
+	fake.saveTestReportMutex.Lock()
+	ret, specificReturn := fake.saveTestReportReturnsOnCall[len(fake.saveTestReportArgsForCall)]
+	fake.saveTestReportArgsForCall = append(fake.saveTestReportArgsForCall, struct {
+		report db.TestReport
+	}{report})
+	fake.recordInvocation("SaveTestReport", []interface{}{report})
+	fake.saveTestReportMutex.Unlock()
+	if fake.SaveTestReportStub != nil {
+		return fake.SaveTestReportStub(report)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveTestReportReturns.result1
+}
+
+func (fake *FakeBuild) SaveTestReportCallCount() int {
+	fake.saveTestReportMutex.RLock()
+	defer fake.saveTestReportMutex.RUnlock()
+	return len(fake.saveTestReportArgsForCall)
+}
+
+func (fake *FakeBuild) SaveTestReportArgsForCall(i int) db.TestReport {
+	fake.saveTestReportMutex.RLock()
+	defer fake.saveTestReportMutex.RUnlock()
+	return fake.saveTestReportArgsForCall[i].report
+}
+
+func (fake *FakeBuild) SaveTestReportReturns(result1 error) {
+	fake.SaveTestReportStub = nil
+	fake.saveTestReportReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveTestReportReturnsOnCall(i int, result1 error) {
+	fake.SaveTestReportStub = nil
+	if fake.saveTestReportReturnsOnCall == nil {
+		fake.saveTestReportReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTestReportReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveMetadata(metadata db.ResourceMetadataFields) error {
+	fake.saveMetadataMutex.Lock()
+	ret, specificReturn := fake.saveMetadataReturnsOnCall[len(fake.saveMetadataArgsForCall)]
+	fake.saveMetadataArgsForCall = append(fake.saveMetadataArgsForCall, struct {
+		metadata db.ResourceMetadataFields
+	}{metadata})
+	fake.recordInvocation("SaveMetadata", []interface{}{metadata})
+	fake.saveMetadataMutex.Unlock()
+	if fake.SaveMetadataStub != nil {
+		return fake.SaveMetadataStub(metadata)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveMetadataReturns.result1
+}
+
+func (fake *FakeBuild) SaveMetadataCallCount() int {
+	fake.saveMetadataMutex.RLock()
+	defer fake.saveMetadataMutex.RUnlock()
+	return len(fake.saveMetadataArgsForCall)
+}
+
+func (fake *FakeBuild) SaveMetadataArgsForCall(i int) db.ResourceMetadataFields {
+	fake.saveMetadataMutex.RLock()
+	defer fake.saveMetadataMutex.RUnlock()
+	return fake.saveMetadataArgsForCall[i].metadata
+}
+
+func (fake *FakeBuild) SaveMetadataReturns(result1 error) {
+	fake.SaveMetadataStub = nil
+	fake.saveMetadataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveMetadataReturnsOnCall(i int, result1 error) {
+	fake.SaveMetadataStub = nil
+	if fake.saveMetadataReturnsOnCall == nil {
+		fake.saveMetadataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveMetadataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) TestReports() ([]db.TestReport, error) {
+	fake.testReportsMutex.Lock()
+	ret, specificReturn := fake.testReportsReturnsOnCall[len(fake.testReportsArgsForCall)]
+	fake.testReportsArgsForCall = append(fake.testReportsArgsForCall, struct{}{})
+	fake.recordInvocation("TestReports", []interface{}{})
+	fake.testReportsMutex.Unlock()
+	if fake.TestReportsStub != nil {
+		return fake.TestReportsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.testReportsReturns.result1, fake.testReportsReturns.result2
+}
+
+func (fake *FakeBuild) TestReportsCallCount() int {
+	fake.testReportsMutex.RLock()
+	defer fake.testReportsMutex.RUnlock()
+	return len(fake.testReportsArgsForCall)
+}
+
+func (fake *FakeBuild) TestReportsReturns(result1 []db.TestReport, result2 error) {
+	fake.TestReportsStub = nil
+	fake.testReportsReturns = struct {
+		result1 []db.TestReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) TestReportsReturnsOnCall(i int, result1 []db.TestReport, result2 error) {
+	fake.TestReportsStub = nil
+	if fake.testReportsReturnsOnCall == nil {
+		fake.testReportsReturnsOnCall = make(map[int]struct {
+			result1 []db.TestReport
+			result2 error
+		})
+	}
+	fake.testReportsReturnsOnCall[i] = struct {
+		result1 []db.TestReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) GetVersionedResources() (db.SavedVersionedResources, error) {
 	fake.getVersionedResourcesMutex.Lock()
 	ret, specificReturn := fake.getVersionedResourcesReturnsOnCall[len(fake.getVersionedResourcesArgsForCall)]
@@ -2131,6 +3442,122 @@ func (fake *FakeBuild) ScheduleReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RequestApproval(approvers []string, deadline time.Time, inputs []db.BuildInput) (bool, error) {
+	var approversCopy []string
+	if approvers != nil {
+		approversCopy = make([]string, len(approvers))
+		copy(approversCopy, approvers)
+	}
+	var inputsCopy []db.BuildInput
+	if inputs != nil {
+		inputsCopy = make([]db.BuildInput, len(inputs))
+		copy(inputsCopy, inputs)
+	}
+	fake.requestApprovalMutex.Lock()
+	ret, specificReturn := fake.requestApprovalReturnsOnCall[len(fake.requestApprovalArgsForCall)]
+	fake.requestApprovalArgsForCall = append(fake.requestApprovalArgsForCall, struct {
+		approvers []string
+		deadline  time.Time
+		inputs    []db.BuildInput
+	}{approversCopy, deadline, inputsCopy})
+	fake.recordInvocation("RequestApproval", []interface{}{approversCopy, deadline, inputsCopy})
+	fake.requestApprovalMutex.Unlock()
+	if fake.RequestApprovalStub != nil {
+		return fake.RequestApprovalStub(approvers, deadline, inputs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.requestApprovalReturns.result1, fake.requestApprovalReturns.result2
+}
+
+func (fake *FakeBuild) RequestApprovalCallCount() int {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	return len(fake.requestApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) RequestApprovalArgsForCall(i int) ([]string, time.Time, []db.BuildInput) {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	return fake.requestApprovalArgsForCall[i].approvers, fake.requestApprovalArgsForCall[i].deadline, fake.requestApprovalArgsForCall[i].inputs
+}
+
+func (fake *FakeBuild) RequestApprovalReturns(result1 bool, result2 error) {
+	fake.RequestApprovalStub = nil
+	fake.requestApprovalReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RequestApprovalReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.RequestApprovalStub = nil
+	if fake.requestApprovalReturnsOnCall == nil {
+		fake.requestApprovalReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.requestApprovalReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ResolveApproval(status atc.ApprovalStatus, approver string, comment string) (bool, error) {
+	fake.resolveApprovalMutex.Lock()
+	ret, specificReturn := fake.resolveApprovalReturnsOnCall[len(fake.resolveApprovalArgsForCall)]
+	fake.resolveApprovalArgsForCall = append(fake.resolveApprovalArgsForCall, struct {
+		status   atc.ApprovalStatus
+		approver string
+		comment  string
+	}{status, approver, comment})
+	fake.recordInvocation("ResolveApproval", []interface{}{status, approver, comment})
+	fake.resolveApprovalMutex.Unlock()
+	if fake.ResolveApprovalStub != nil {
+		return fake.ResolveApprovalStub(status, approver, comment)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.resolveApprovalReturns.result1, fake.resolveApprovalReturns.result2
+}
+
+func (fake *FakeBuild) ResolveApprovalCallCount() int {
+	fake.resolveApprovalMutex.RLock()
+	defer fake.resolveApprovalMutex.RUnlock()
+	return len(fake.resolveApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) ResolveApprovalArgsForCall(i int) (atc.ApprovalStatus, string, string) {
+	fake.resolveApprovalMutex.RLock()
+	defer fake.resolveApprovalMutex.RUnlock()
+	return fake.resolveApprovalArgsForCall[i].status, fake.resolveApprovalArgsForCall[i].approver, fake.resolveApprovalArgsForCall[i].comment
+}
+
+func (fake *FakeBuild) ResolveApprovalReturns(result1 bool, result2 error) {
+	fake.ResolveApprovalStub = nil
+	fake.resolveApprovalReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ResolveApprovalReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ResolveApprovalStub = nil
+	if fake.resolveApprovalReturnsOnCall == nil {
+		fake.resolveApprovalReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.resolveApprovalReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2158,6 +3585,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.publicPlanMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
+	fake.createTimeMutex.RLock()
+	defer fake.createTimeMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.endTimeMutex.RLock()
@@ -2168,6 +3597,28 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.isManuallyTriggeredMutex.RUnlock()
 	fake.isScheduledMutex.RLock()
 	defer fake.isScheduledMutex.RUnlock()
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	fake.supersededByMutex.RLock()
+	defer fake.supersededByMutex.RUnlock()
+	fake.triggeredByMutex.RLock()
+	defer fake.triggeredByMutex.RUnlock()
+	fake.metadataMutex.RLock()
+	defer fake.metadataMutex.RUnlock()
+	fake.scheduleTimeMutex.RLock()
+	defer fake.scheduleTimeMutex.RUnlock()
+	fake.containersHeldUntilMutex.RLock()
+	defer fake.containersHeldUntilMutex.RUnlock()
+	fake.approvalStatusMutex.RLock()
+	defer fake.approvalStatusMutex.RUnlock()
+	fake.approversMutex.RLock()
+	defer fake.approversMutex.RUnlock()
+	fake.approverMutex.RLock()
+	defer fake.approverMutex.RUnlock()
+	fake.approvalCommentMutex.RLock()
+	defer fake.approvalCommentMutex.RUnlock()
+	fake.approvalDeadlineMutex.RLock()
+	defer fake.approvalDeadlineMutex.RUnlock()
 	fake.isRunningMutex.RLock()
 	defer fake.isRunningMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -2186,18 +3637,42 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.finishMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
+	fake.setHoldContainersOnFailureMutex.RLock()
+	defer fake.setHoldContainersOnFailureMutex.RUnlock()
+	fake.holdContainersMutex.RLock()
+	defer fake.holdContainersMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
+	fake.undrainedEventsMutex.RLock()
+	defer fake.undrainedEventsMutex.RUnlock()
+	fake.isDrainedMutex.RLock()
+	defer fake.isDrainedMutex.RUnlock()
+	fake.saveDrainOffsetMutex.RLock()
+	defer fake.saveDrainOffsetMutex.RUnlock()
+	fake.markDrainedMutex.RLock()
+	defer fake.markDrainedMutex.RUnlock()
 	fake.saveInputMutex.RLock()
 	defer fake.saveInputMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	fake.useInputsMutex.RLock()
 	defer fake.useInputsMutex.RUnlock()
+	fake.inputOverridesMutex.RLock()
+	defer fake.inputOverridesMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.artifactsMutex.RLock()
+	defer fake.artifactsMutex.RUnlock()
+	fake.artifactMutex.RLock()
+	defer fake.artifactMutex.RUnlock()
+	fake.saveTestReportMutex.RLock()
+	defer fake.saveTestReportMutex.RUnlock()
+	fake.saveMetadataMutex.RLock()
+	defer fake.saveMetadataMutex.RUnlock()
+	fake.testReportsMutex.RLock()
+	defer fake.testReportsMutex.RUnlock()
 	fake.getVersionedResourcesMutex.RLock()
 	defer fake.getVersionedResourcesMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
@@ -2212,6 +3687,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	fake.resolveApprovalMutex.RLock()
+	defer fake.resolveApprovalMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
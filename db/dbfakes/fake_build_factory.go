@@ -49,6 +49,17 @@ type FakeBuildFactory struct {
 		result1 []db.Build
 		result2 error
 	}
+	UndrainedBuildsStub        func() ([]db.Build, error)
+	undrainedBuildsMutex       sync.RWMutex
+	undrainedBuildsArgsForCall []struct{}
+	undrainedBuildsReturns     struct {
+		result1 []db.Build
+		result2 error
+	}
+	undrainedBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	MarkNonInterceptibleBuildsStub        func() error
 	markNonInterceptibleBuildsMutex       sync.RWMutex
 	markNonInterceptibleBuildsArgsForCall []struct{}
@@ -213,6 +224,49 @@ func (fake *FakeBuildFactory) GetAllStartedBuildsReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *FakeBuildFactory) UndrainedBuilds() ([]db.Build, error) {
+	fake.undrainedBuildsMutex.Lock()
+	ret, specificReturn := fake.undrainedBuildsReturnsOnCall[len(fake.undrainedBuildsArgsForCall)]
+	fake.undrainedBuildsArgsForCall = append(fake.undrainedBuildsArgsForCall, struct{}{})
+	fake.recordInvocation("UndrainedBuilds", []interface{}{})
+	fake.undrainedBuildsMutex.Unlock()
+	if fake.UndrainedBuildsStub != nil {
+		return fake.UndrainedBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.undrainedBuildsReturns.result1, fake.undrainedBuildsReturns.result2
+}
+
+func (fake *FakeBuildFactory) UndrainedBuildsCallCount() int {
+	fake.undrainedBuildsMutex.RLock()
+	defer fake.undrainedBuildsMutex.RUnlock()
+	return len(fake.undrainedBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) UndrainedBuildsReturns(result1 []db.Build, result2 error) {
+	fake.UndrainedBuildsStub = nil
+	fake.undrainedBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) UndrainedBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.UndrainedBuildsStub = nil
+	if fake.undrainedBuildsReturnsOnCall == nil {
+		fake.undrainedBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.undrainedBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) MarkNonInterceptibleBuilds() error {
 	fake.markNonInterceptibleBuildsMutex.Lock()
 	ret, specificReturn := fake.markNonInterceptibleBuildsReturnsOnCall[len(fake.markNonInterceptibleBuildsArgsForCall)]
@@ -262,6 +316,8 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.publicBuildsMutex.RUnlock()
 	fake.getAllStartedBuildsMutex.RLock()
 	defer fake.getAllStartedBuildsMutex.RUnlock()
+	fake.undrainedBuildsMutex.RLock()
+	defer fake.undrainedBuildsMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
 	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
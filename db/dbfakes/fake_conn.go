@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -29,6 +30,24 @@ type FakeConn struct {
 	encryptionStrategyReturnsOnCall map[int]struct {
 		result1 db.EncryptionStrategy
 	}
+	LogStoreStub        func() db.LogStore
+	logStoreMutex       sync.RWMutex
+	logStoreArgsForCall []struct{}
+	logStoreReturns     struct {
+		result1 db.LogStore
+	}
+	logStoreReturnsOnCall map[int]struct {
+		result1 db.LogStore
+	}
+	BaseResourceTypeDefaultsStub        func() map[string]atc.Source
+	baseResourceTypeDefaultsMutex       sync.RWMutex
+	baseResourceTypeDefaultsArgsForCall []struct{}
+	baseResourceTypeDefaultsReturns     struct {
+		result1 map[string]atc.Source
+	}
+	baseResourceTypeDefaultsReturnsOnCall map[int]struct {
+		result1 map[string]atc.Source
+	}
 	BeginStub        func() (db.Tx, error)
 	beginMutex       sync.RWMutex
 	beginArgsForCall []struct{}
@@ -223,6 +242,86 @@ func (fake *FakeConn) EncryptionStrategyReturnsOnCall(i int, result1 db.Encrypti
 	}{result1}
 }
 
+func (fake *FakeConn) LogStore() db.LogStore {
+	fake.logStoreMutex.Lock()
+	ret, specificReturn := fake.logStoreReturnsOnCall[len(fake.logStoreArgsForCall)]
+	fake.logStoreArgsForCall = append(fake.logStoreArgsForCall, struct{}{})
+	fake.recordInvocation("LogStore", []interface{}{})
+	fake.logStoreMutex.Unlock()
+	if fake.LogStoreStub != nil {
+		return fake.LogStoreStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.logStoreReturns.result1
+}
+
+func (fake *FakeConn) LogStoreCallCount() int {
+	fake.logStoreMutex.RLock()
+	defer fake.logStoreMutex.RUnlock()
+	return len(fake.logStoreArgsForCall)
+}
+
+func (fake *FakeConn) LogStoreReturns(result1 db.LogStore) {
+	fake.LogStoreStub = nil
+	fake.logStoreReturns = struct {
+		result1 db.LogStore
+	}{result1}
+}
+
+func (fake *FakeConn) LogStoreReturnsOnCall(i int, result1 db.LogStore) {
+	fake.LogStoreStub = nil
+	if fake.logStoreReturnsOnCall == nil {
+		fake.logStoreReturnsOnCall = make(map[int]struct {
+			result1 db.LogStore
+		})
+	}
+	fake.logStoreReturnsOnCall[i] = struct {
+		result1 db.LogStore
+	}{result1}
+}
+
+func (fake *FakeConn) BaseResourceTypeDefaults() map[string]atc.Source {
+	fake.baseResourceTypeDefaultsMutex.Lock()
+	ret, specificReturn := fake.baseResourceTypeDefaultsReturnsOnCall[len(fake.baseResourceTypeDefaultsArgsForCall)]
+	fake.baseResourceTypeDefaultsArgsForCall = append(fake.baseResourceTypeDefaultsArgsForCall, struct{}{})
+	fake.recordInvocation("BaseResourceTypeDefaults", []interface{}{})
+	fake.baseResourceTypeDefaultsMutex.Unlock()
+	if fake.BaseResourceTypeDefaultsStub != nil {
+		return fake.BaseResourceTypeDefaultsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.baseResourceTypeDefaultsReturns.result1
+}
+
+func (fake *FakeConn) BaseResourceTypeDefaultsCallCount() int {
+	fake.baseResourceTypeDefaultsMutex.RLock()
+	defer fake.baseResourceTypeDefaultsMutex.RUnlock()
+	return len(fake.baseResourceTypeDefaultsArgsForCall)
+}
+
+func (fake *FakeConn) BaseResourceTypeDefaultsReturns(result1 map[string]atc.Source) {
+	fake.BaseResourceTypeDefaultsStub = nil
+	fake.baseResourceTypeDefaultsReturns = struct {
+		result1 map[string]atc.Source
+	}{result1}
+}
+
+func (fake *FakeConn) BaseResourceTypeDefaultsReturnsOnCall(i int, result1 map[string]atc.Source) {
+	fake.BaseResourceTypeDefaultsStub = nil
+	if fake.baseResourceTypeDefaultsReturnsOnCall == nil {
+		fake.baseResourceTypeDefaultsReturnsOnCall = make(map[int]struct {
+			result1 map[string]atc.Source
+		})
+	}
+	fake.baseResourceTypeDefaultsReturnsOnCall[i] = struct {
+		result1 map[string]atc.Source
+	}{result1}
+}
+
 func (fake *FakeConn) Begin() (db.Tx, error) {
 	fake.beginMutex.Lock()
 	ret, specificReturn := fake.beginReturnsOnCall[len(fake.beginArgsForCall)]
@@ -685,6 +784,10 @@ func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	defer fake.busMutex.RUnlock()
 	fake.encryptionStrategyMutex.RLock()
 	defer fake.encryptionStrategyMutex.RUnlock()
+	fake.logStoreMutex.RLock()
+	defer fake.logStoreMutex.RUnlock()
+	fake.baseResourceTypeDefaultsMutex.RLock()
+	defer fake.baseResourceTypeDefaultsMutex.RUnlock()
 	fake.beginMutex.RLock()
 	defer fake.beginMutex.RUnlock()
 	fake.driverMutex.RLock()
@@ -102,6 +102,18 @@ type FakeCreatedVolume struct {
 	initializeTaskCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InitializeArtifactStub        func(int, string) error
+	initializeArtifactMutex       sync.RWMutex
+	initializeArtifactArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	initializeArtifactReturns struct {
+		result1 error
+	}
+	initializeArtifactReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ContainerHandleStub        func() string
 	containerHandleMutex       sync.RWMutex
 	containerHandleArgsForCall []struct{}
@@ -554,6 +566,55 @@ func (fake *FakeCreatedVolume) InitializeTaskCacheReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
+func (fake *FakeCreatedVolume) InitializeArtifact(arg1 int, arg2 string) error {
+	fake.initializeArtifactMutex.Lock()
+	ret, specificReturn := fake.initializeArtifactReturnsOnCall[len(fake.initializeArtifactArgsForCall)]
+	fake.initializeArtifactArgsForCall = append(fake.initializeArtifactArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("InitializeArtifact", []interface{}{arg1, arg2})
+	fake.initializeArtifactMutex.Unlock()
+	if fake.InitializeArtifactStub != nil {
+		return fake.InitializeArtifactStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.initializeArtifactReturns.result1
+}
+
+func (fake *FakeCreatedVolume) InitializeArtifactCallCount() int {
+	fake.initializeArtifactMutex.RLock()
+	defer fake.initializeArtifactMutex.RUnlock()
+	return len(fake.initializeArtifactArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) InitializeArtifactArgsForCall(i int) (int, string) {
+	fake.initializeArtifactMutex.RLock()
+	defer fake.initializeArtifactMutex.RUnlock()
+	return fake.initializeArtifactArgsForCall[i].arg1, fake.initializeArtifactArgsForCall[i].arg2
+}
+
+func (fake *FakeCreatedVolume) InitializeArtifactReturns(result1 error) {
+	fake.InitializeArtifactStub = nil
+	fake.initializeArtifactReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) InitializeArtifactReturnsOnCall(i int, result1 error) {
+	fake.InitializeArtifactStub = nil
+	if fake.initializeArtifactReturnsOnCall == nil {
+		fake.initializeArtifactReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initializeArtifactReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCreatedVolume) ContainerHandle() string {
 	fake.containerHandleMutex.Lock()
 	ret, specificReturn := fake.containerHandleReturnsOnCall[len(fake.containerHandleArgsForCall)]
@@ -790,6 +851,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.initializeResourceCacheMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
+	fake.initializeArtifactMutex.RLock()
+	defer fake.initializeArtifactMutex.RUnlock()
 	fake.containerHandleMutex.RLock()
 	defer fake.containerHandleMutex.RUnlock()
 	fake.parentHandleMutex.RLock()
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -91,6 +92,33 @@ type FakeJob struct {
 	configReturnsOnCall map[int]struct {
 		result1 atc.JobConfig
 	}
+	PriorityStub        func() int
+	priorityMutex       sync.RWMutex
+	priorityArgsForCall []struct{}
+	priorityReturns     struct {
+		result1 int
+	}
+	priorityReturnsOnCall map[int]struct {
+		result1 int
+	}
+	LastScheduleTimeStub        func() time.Time
+	lastScheduleTimeMutex       sync.RWMutex
+	lastScheduleTimeArgsForCall []struct{}
+	lastScheduleTimeReturns     struct {
+		result1 time.Time
+	}
+	lastScheduleTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	TriggerOnSinceStub        func() time.Time
+	triggerOnSinceMutex       sync.RWMutex
+	triggerOnSinceArgsForCall []struct{}
+	triggerOnSinceReturns     struct {
+		result1 time.Time
+	}
+	triggerOnSinceReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct{}
@@ -131,6 +159,91 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateBuildWithInputOverridesStub        func(inputOverrides map[string]int) (db.Build, error)
+	createBuildWithInputOverridesMutex       sync.RWMutex
+	createBuildWithInputOverridesArgsForCall []struct {
+		inputOverrides map[string]int
+	}
+	createBuildWithInputOverridesReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithInputOverridesReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	CreateScheduledBuildStub        func(scheduleTime time.Time) (db.Build, bool, error)
+	createScheduledBuildMutex       sync.RWMutex
+	createScheduledBuildArgsForCall []struct {
+		scheduleTime time.Time
+	}
+	createScheduledBuildReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	createScheduledBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	InitLastScheduleTimeStub        func(now time.Time) error
+	initLastScheduleTimeMutex       sync.RWMutex
+	initLastScheduleTimeArgsForCall []struct {
+		now time.Time
+	}
+	initLastScheduleTimeReturns struct {
+		result1 error
+	}
+	initLastScheduleTimeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateTriggeredBuildsStub        func() ([]db.Build, error)
+	createTriggeredBuildsMutex       sync.RWMutex
+	createTriggeredBuildsArgsForCall []struct{}
+	createTriggeredBuildsReturns     struct {
+		result1 []db.Build
+		result2 error
+	}
+	createTriggeredBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	InitTriggerOnSinceStub        func(now time.Time) error
+	initTriggerOnSinceMutex       sync.RWMutex
+	initTriggerOnSinceArgsForCall []struct {
+		now time.Time
+	}
+	initTriggerOnSinceReturns struct {
+		result1 error
+	}
+	initTriggerOnSinceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SkipTriggersUntilStub        func(time.Time) error
+	skipTriggersUntilMutex       sync.RWMutex
+	skipTriggersUntilArgsForCall []struct {
+		now time.Time
+	}
+	skipTriggersUntilReturns struct {
+		result1 error
+	}
+	skipTriggersUntilReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RerunBuildStub        func(build db.Build) (db.Build, error)
+	rerunBuildMutex       sync.RWMutex
+	rerunBuildArgsForCall []struct {
+		build db.Build
+	}
+	rerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	rerunBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	BuildsStub        func(page db.Page) ([]db.Build, db.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -161,6 +274,19 @@ type FakeJob struct {
 		result2 bool
 		result3 error
 	}
+	TestHistoryStub        func(int) ([]db.BuildTestReports, error)
+	testHistoryMutex       sync.RWMutex
+	testHistoryArgsForCall []struct {
+		limit int
+	}
+	testHistoryReturns struct {
+		result1 []db.BuildTestReports
+		result2 error
+	}
+	testHistoryReturnsOnCall map[int]struct {
+		result1 []db.BuildTestReports
+		result2 error
+	}
 	FinishedAndNextBuildStub        func() (db.Build, db.Build, error)
 	finishedAndNextBuildMutex       sync.RWMutex
 	finishedAndNextBuildArgsForCall []struct{}
@@ -194,6 +320,19 @@ type FakeJob struct {
 	ensurePendingBuildExistsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SupersedePendingBuildsStub        func() (db.Build, bool, error)
+	supersedePendingBuildsMutex       sync.RWMutex
+	supersedePendingBuildsArgsForCall []struct{}
+	supersedePendingBuildsReturns     struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	supersedePendingBuildsReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	GetPendingBuildsStub        func() ([]db.Build, error)
 	getPendingBuildsMutex       sync.RWMutex
 	getPendingBuildsArgsForCall []struct{}
@@ -271,6 +410,17 @@ type FakeJob struct {
 	setMaxInFlightReachedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetTeamQuotaReachedStub        func(bool) error
+	setTeamQuotaReachedMutex       sync.RWMutex
+	setTeamQuotaReachedArgsForCall []struct {
+		arg1 bool
+	}
+	setTeamQuotaReachedReturns struct {
+		result1 error
+	}
+	setTeamQuotaReachedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetRunningBuildsBySerialGroupStub        func(serialGroups []string) ([]db.Build, error)
 	getRunningBuildsBySerialGroupMutex       sync.RWMutex
 	getRunningBuildsBySerialGroupArgsForCall []struct {
@@ -663,6 +813,126 @@ func (fake *FakeJob) ConfigReturnsOnCall(i int, result1 atc.JobConfig) {
 	}{result1}
 }
 
+func (fake *FakeJob) Priority() int {
+	fake.priorityMutex.Lock()
+	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
+	fake.priorityArgsForCall = append(fake.priorityArgsForCall, struct{}{})
+	fake.recordInvocation("Priority", []interface{}{})
+	fake.priorityMutex.Unlock()
+	if fake.PriorityStub != nil {
+		return fake.PriorityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.priorityReturns.result1
+}
+
+func (fake *FakeJob) PriorityCallCount() int {
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	return len(fake.priorityArgsForCall)
+}
+
+func (fake *FakeJob) PriorityReturns(result1 int) {
+	fake.PriorityStub = nil
+	fake.priorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeJob) PriorityReturnsOnCall(i int, result1 int) {
+	fake.PriorityStub = nil
+	if fake.priorityReturnsOnCall == nil {
+		fake.priorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.priorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeJob) LastScheduleTime() time.Time {
+	fake.lastScheduleTimeMutex.Lock()
+	ret, specificReturn := fake.lastScheduleTimeReturnsOnCall[len(fake.lastScheduleTimeArgsForCall)]
+	fake.lastScheduleTimeArgsForCall = append(fake.lastScheduleTimeArgsForCall, struct{}{})
+	fake.recordInvocation("LastScheduleTime", []interface{}{})
+	fake.lastScheduleTimeMutex.Unlock()
+	if fake.LastScheduleTimeStub != nil {
+		return fake.LastScheduleTimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.lastScheduleTimeReturns.result1
+}
+
+func (fake *FakeJob) LastScheduleTimeCallCount() int {
+	fake.lastScheduleTimeMutex.RLock()
+	defer fake.lastScheduleTimeMutex.RUnlock()
+	return len(fake.lastScheduleTimeArgsForCall)
+}
+
+func (fake *FakeJob) LastScheduleTimeReturns(result1 time.Time) {
+	fake.LastScheduleTimeStub = nil
+	fake.lastScheduleTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) LastScheduleTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.LastScheduleTimeStub = nil
+	if fake.lastScheduleTimeReturnsOnCall == nil {
+		fake.lastScheduleTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastScheduleTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) TriggerOnSince() time.Time {
+	fake.triggerOnSinceMutex.Lock()
+	ret, specificReturn := fake.triggerOnSinceReturnsOnCall[len(fake.triggerOnSinceArgsForCall)]
+	fake.triggerOnSinceArgsForCall = append(fake.triggerOnSinceArgsForCall, struct{}{})
+	fake.recordInvocation("TriggerOnSince", []interface{}{})
+	fake.triggerOnSinceMutex.Unlock()
+	if fake.TriggerOnSinceStub != nil {
+		return fake.TriggerOnSinceStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.triggerOnSinceReturns.result1
+}
+
+func (fake *FakeJob) TriggerOnSinceCallCount() int {
+	fake.triggerOnSinceMutex.RLock()
+	defer fake.triggerOnSinceMutex.RUnlock()
+	return len(fake.triggerOnSinceArgsForCall)
+}
+
+func (fake *FakeJob) TriggerOnSinceReturns(result1 time.Time) {
+	fake.TriggerOnSinceStub = nil
+	fake.triggerOnSinceReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) TriggerOnSinceReturnsOnCall(i int, result1 time.Time) {
+	fake.TriggerOnSinceStub = nil
+	if fake.triggerOnSinceReturnsOnCall == nil {
+		fake.triggerOnSinceReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.triggerOnSinceReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeJob) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -829,6 +1099,349 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateBuildWithInputOverrides(inputOverrides map[string]int) (db.Build, error) {
+	fake.createBuildWithInputOverridesMutex.Lock()
+	ret, specificReturn := fake.createBuildWithInputOverridesReturnsOnCall[len(fake.createBuildWithInputOverridesArgsForCall)]
+	fake.createBuildWithInputOverridesArgsForCall = append(fake.createBuildWithInputOverridesArgsForCall, struct {
+		inputOverrides map[string]int
+	}{inputOverrides})
+	fake.recordInvocation("CreateBuildWithInputOverrides", []interface{}{inputOverrides})
+	fake.createBuildWithInputOverridesMutex.Unlock()
+	if fake.CreateBuildWithInputOverridesStub != nil {
+		return fake.CreateBuildWithInputOverridesStub(inputOverrides)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createBuildWithInputOverridesReturns.result1, fake.createBuildWithInputOverridesReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithInputOverridesCallCount() int {
+	fake.createBuildWithInputOverridesMutex.RLock()
+	defer fake.createBuildWithInputOverridesMutex.RUnlock()
+	return len(fake.createBuildWithInputOverridesArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithInputOverridesArgsForCall(i int) map[string]int {
+	fake.createBuildWithInputOverridesMutex.RLock()
+	defer fake.createBuildWithInputOverridesMutex.RUnlock()
+	return fake.createBuildWithInputOverridesArgsForCall[i].inputOverrides
+}
+
+func (fake *FakeJob) CreateBuildWithInputOverridesReturns(result1 db.Build, result2 error) {
+	fake.CreateBuildWithInputOverridesStub = nil
+	fake.createBuildWithInputOverridesReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithInputOverridesReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.CreateBuildWithInputOverridesStub = nil
+	if fake.createBuildWithInputOverridesReturnsOnCall == nil {
+		fake.createBuildWithInputOverridesReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithInputOverridesReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateScheduledBuild(scheduleTime time.Time) (db.Build, bool, error) {
+	fake.createScheduledBuildMutex.Lock()
+	ret, specificReturn := fake.createScheduledBuildReturnsOnCall[len(fake.createScheduledBuildArgsForCall)]
+	fake.createScheduledBuildArgsForCall = append(fake.createScheduledBuildArgsForCall, struct {
+		scheduleTime time.Time
+	}{scheduleTime})
+	fake.recordInvocation("CreateScheduledBuild", []interface{}{scheduleTime})
+	fake.createScheduledBuildMutex.Unlock()
+	if fake.CreateScheduledBuildStub != nil {
+		return fake.CreateScheduledBuildStub(scheduleTime)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.createScheduledBuildReturns.result1, fake.createScheduledBuildReturns.result2, fake.createScheduledBuildReturns.result3
+}
+
+func (fake *FakeJob) CreateScheduledBuildCallCount() int {
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	return len(fake.createScheduledBuildArgsForCall)
+}
+
+func (fake *FakeJob) CreateScheduledBuildArgsForCall(i int) time.Time {
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	return fake.createScheduledBuildArgsForCall[i].scheduleTime
+}
+
+func (fake *FakeJob) CreateScheduledBuildReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.CreateScheduledBuildStub = nil
+	fake.createScheduledBuildReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) CreateScheduledBuildReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.CreateScheduledBuildStub = nil
+	if fake.createScheduledBuildReturnsOnCall == nil {
+		fake.createScheduledBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createScheduledBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) InitLastScheduleTime(now time.Time) error {
+	fake.initLastScheduleTimeMutex.Lock()
+	ret, specificReturn := fake.initLastScheduleTimeReturnsOnCall[len(fake.initLastScheduleTimeArgsForCall)]
+	fake.initLastScheduleTimeArgsForCall = append(fake.initLastScheduleTimeArgsForCall, struct {
+		now time.Time
+	}{now})
+	fake.recordInvocation("InitLastScheduleTime", []interface{}{now})
+	fake.initLastScheduleTimeMutex.Unlock()
+	if fake.InitLastScheduleTimeStub != nil {
+		return fake.InitLastScheduleTimeStub(now)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.initLastScheduleTimeReturns.result1
+}
+
+func (fake *FakeJob) InitLastScheduleTimeCallCount() int {
+	fake.initLastScheduleTimeMutex.RLock()
+	defer fake.initLastScheduleTimeMutex.RUnlock()
+	return len(fake.initLastScheduleTimeArgsForCall)
+}
+
+func (fake *FakeJob) InitLastScheduleTimeArgsForCall(i int) time.Time {
+	fake.initLastScheduleTimeMutex.RLock()
+	defer fake.initLastScheduleTimeMutex.RUnlock()
+	return fake.initLastScheduleTimeArgsForCall[i].now
+}
+
+func (fake *FakeJob) InitLastScheduleTimeReturns(result1 error) {
+	fake.InitLastScheduleTimeStub = nil
+	fake.initLastScheduleTimeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) InitLastScheduleTimeReturnsOnCall(i int, result1 error) {
+	fake.InitLastScheduleTimeStub = nil
+	if fake.initLastScheduleTimeReturnsOnCall == nil {
+		fake.initLastScheduleTimeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initLastScheduleTimeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) CreateTriggeredBuilds() ([]db.Build, error) {
+	fake.createTriggeredBuildsMutex.Lock()
+	ret, specificReturn := fake.createTriggeredBuildsReturnsOnCall[len(fake.createTriggeredBuildsArgsForCall)]
+	fake.createTriggeredBuildsArgsForCall = append(fake.createTriggeredBuildsArgsForCall, struct{}{})
+	fake.recordInvocation("CreateTriggeredBuilds", []interface{}{})
+	fake.createTriggeredBuildsMutex.Unlock()
+	if fake.CreateTriggeredBuildsStub != nil {
+		return fake.CreateTriggeredBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createTriggeredBuildsReturns.result1, fake.createTriggeredBuildsReturns.result2
+}
+
+func (fake *FakeJob) CreateTriggeredBuildsCallCount() int {
+	fake.createTriggeredBuildsMutex.RLock()
+	defer fake.createTriggeredBuildsMutex.RUnlock()
+	return len(fake.createTriggeredBuildsArgsForCall)
+}
+
+func (fake *FakeJob) CreateTriggeredBuildsReturns(result1 []db.Build, result2 error) {
+	fake.CreateTriggeredBuildsStub = nil
+	fake.createTriggeredBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateTriggeredBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.CreateTriggeredBuildsStub = nil
+	if fake.createTriggeredBuildsReturnsOnCall == nil {
+		fake.createTriggeredBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.createTriggeredBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) InitTriggerOnSince(now time.Time) error {
+	fake.initTriggerOnSinceMutex.Lock()
+	ret, specificReturn := fake.initTriggerOnSinceReturnsOnCall[len(fake.initTriggerOnSinceArgsForCall)]
+	fake.initTriggerOnSinceArgsForCall = append(fake.initTriggerOnSinceArgsForCall, struct {
+		now time.Time
+	}{now})
+	fake.recordInvocation("InitTriggerOnSince", []interface{}{now})
+	fake.initTriggerOnSinceMutex.Unlock()
+	if fake.InitTriggerOnSinceStub != nil {
+		return fake.InitTriggerOnSinceStub(now)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.initTriggerOnSinceReturns.result1
+}
+
+func (fake *FakeJob) InitTriggerOnSinceCallCount() int {
+	fake.initTriggerOnSinceMutex.RLock()
+	defer fake.initTriggerOnSinceMutex.RUnlock()
+	return len(fake.initTriggerOnSinceArgsForCall)
+}
+
+func (fake *FakeJob) InitTriggerOnSinceArgsForCall(i int) time.Time {
+	fake.initTriggerOnSinceMutex.RLock()
+	defer fake.initTriggerOnSinceMutex.RUnlock()
+	return fake.initTriggerOnSinceArgsForCall[i].now
+}
+
+func (fake *FakeJob) InitTriggerOnSinceReturns(result1 error) {
+	fake.InitTriggerOnSinceStub = nil
+	fake.initTriggerOnSinceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) InitTriggerOnSinceReturnsOnCall(i int, result1 error) {
+	fake.InitTriggerOnSinceStub = nil
+	if fake.initTriggerOnSinceReturnsOnCall == nil {
+		fake.initTriggerOnSinceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initTriggerOnSinceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) SkipTriggersUntil(now time.Time) error {
+	fake.skipTriggersUntilMutex.Lock()
+	ret, specificReturn := fake.skipTriggersUntilReturnsOnCall[len(fake.skipTriggersUntilArgsForCall)]
+	fake.skipTriggersUntilArgsForCall = append(fake.skipTriggersUntilArgsForCall, struct {
+		now time.Time
+	}{now})
+	fake.recordInvocation("SkipTriggersUntil", []interface{}{now})
+	fake.skipTriggersUntilMutex.Unlock()
+	if fake.SkipTriggersUntilStub != nil {
+		return fake.SkipTriggersUntilStub(now)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.skipTriggersUntilReturns.result1
+}
+
+func (fake *FakeJob) SkipTriggersUntilCallCount() int {
+	fake.skipTriggersUntilMutex.RLock()
+	defer fake.skipTriggersUntilMutex.RUnlock()
+	return len(fake.skipTriggersUntilArgsForCall)
+}
+
+func (fake *FakeJob) SkipTriggersUntilArgsForCall(i int) time.Time {
+	fake.skipTriggersUntilMutex.RLock()
+	defer fake.skipTriggersUntilMutex.RUnlock()
+	return fake.skipTriggersUntilArgsForCall[i].now
+}
+
+func (fake *FakeJob) SkipTriggersUntilReturns(result1 error) {
+	fake.SkipTriggersUntilStub = nil
+	fake.skipTriggersUntilReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) SkipTriggersUntilReturnsOnCall(i int, result1 error) {
+	fake.SkipTriggersUntilStub = nil
+	if fake.skipTriggersUntilReturnsOnCall == nil {
+		fake.skipTriggersUntilReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.skipTriggersUntilReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) RerunBuild(build db.Build) (db.Build, error) {
+	fake.rerunBuildMutex.Lock()
+	ret, specificReturn := fake.rerunBuildReturnsOnCall[len(fake.rerunBuildArgsForCall)]
+	fake.rerunBuildArgsForCall = append(fake.rerunBuildArgsForCall, struct {
+		build db.Build
+	}{build})
+	fake.recordInvocation("RerunBuild", []interface{}{build})
+	fake.rerunBuildMutex.Unlock()
+	if fake.RerunBuildStub != nil {
+		return fake.RerunBuildStub(build)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.rerunBuildReturns.result1, fake.rerunBuildReturns.result2
+}
+
+func (fake *FakeJob) RerunBuildCallCount() int {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return len(fake.rerunBuildArgsForCall)
+}
+
+func (fake *FakeJob) RerunBuildArgsForCall(i int) db.Build {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return fake.rerunBuildArgsForCall[i].build
+}
+
+func (fake *FakeJob) RerunBuildReturns(result1 db.Build, result2 error) {
+	fake.RerunBuildStub = nil
+	fake.rerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.RerunBuildStub = nil
+	if fake.rerunBuildReturnsOnCall == nil {
+		fake.rerunBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.rerunBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Builds(page db.Page) ([]db.Build, db.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -937,6 +1550,57 @@ func (fake *FakeJob) BuildReturnsOnCall(i int, result1 db.Build, result2 bool, r
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) TestHistory(limit int) ([]db.BuildTestReports, error) {
+	fake.testHistoryMutex.Lock()
+	ret, specificReturn := fake.testHistoryReturnsOnCall[len(fake.testHistoryArgsForCall)]
+	fake.testHistoryArgsForCall = append(fake.testHistoryArgsForCall, struct {
+		limit int
+	}{limit})
+	fake.recordInvocation("TestHistory", []interface{}{limit})
+	fake.testHistoryMutex.Unlock()
+	if fake.TestHistoryStub != nil {
+		return fake.TestHistoryStub(limit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.testHistoryReturns.result1, fake.testHistoryReturns.result2
+}
+
+func (fake *FakeJob) TestHistoryCallCount() int {
+	fake.testHistoryMutex.RLock()
+	defer fake.testHistoryMutex.RUnlock()
+	return len(fake.testHistoryArgsForCall)
+}
+
+func (fake *FakeJob) TestHistoryArgsForCall(i int) int {
+	fake.testHistoryMutex.RLock()
+	defer fake.testHistoryMutex.RUnlock()
+	return fake.testHistoryArgsForCall[i].limit
+}
+
+func (fake *FakeJob) TestHistoryReturns(result1 []db.BuildTestReports, result2 error) {
+	fake.TestHistoryStub = nil
+	fake.testHistoryReturns = struct {
+		result1 []db.BuildTestReports
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) TestHistoryReturnsOnCall(i int, result1 []db.BuildTestReports, result2 error) {
+	fake.TestHistoryStub = nil
+	if fake.testHistoryReturnsOnCall == nil {
+		fake.testHistoryReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildTestReports
+			result2 error
+		})
+	}
+	fake.testHistoryReturnsOnCall[i] = struct {
+		result1 []db.BuildTestReports
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) FinishedAndNextBuild() (db.Build, db.Build, error) {
 	fake.finishedAndNextBuildMutex.Lock()
 	ret, specificReturn := fake.finishedAndNextBuildReturnsOnCall[len(fake.finishedAndNextBuildArgsForCall)]
@@ -1071,6 +1735,52 @@ func (fake *FakeJob) EnsurePendingBuildExistsReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeJob) SupersedePendingBuilds() (db.Build, bool, error) {
+	fake.supersedePendingBuildsMutex.Lock()
+	ret, specificReturn := fake.supersedePendingBuildsReturnsOnCall[len(fake.supersedePendingBuildsArgsForCall)]
+	fake.supersedePendingBuildsArgsForCall = append(fake.supersedePendingBuildsArgsForCall, struct{}{})
+	fake.recordInvocation("SupersedePendingBuilds", []interface{}{})
+	fake.supersedePendingBuildsMutex.Unlock()
+	if fake.SupersedePendingBuildsStub != nil {
+		return fake.SupersedePendingBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.supersedePendingBuildsReturns.result1, fake.supersedePendingBuildsReturns.result2, fake.supersedePendingBuildsReturns.result3
+}
+
+func (fake *FakeJob) SupersedePendingBuildsCallCount() int {
+	fake.supersedePendingBuildsMutex.RLock()
+	defer fake.supersedePendingBuildsMutex.RUnlock()
+	return len(fake.supersedePendingBuildsArgsForCall)
+}
+
+func (fake *FakeJob) SupersedePendingBuildsReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.SupersedePendingBuildsStub = nil
+	fake.supersedePendingBuildsReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) SupersedePendingBuildsReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.SupersedePendingBuildsStub = nil
+	if fake.supersedePendingBuildsReturnsOnCall == nil {
+		fake.supersedePendingBuildsReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.supersedePendingBuildsReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) GetPendingBuilds() ([]db.Build, error) {
 	fake.getPendingBuildsMutex.Lock()
 	ret, specificReturn := fake.getPendingBuildsReturnsOnCall[len(fake.getPendingBuildsArgsForCall)]
@@ -1387,6 +2097,54 @@ func (fake *FakeJob) SetMaxInFlightReachedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) SetTeamQuotaReached(arg1 bool) error {
+	fake.setTeamQuotaReachedMutex.Lock()
+	ret, specificReturn := fake.setTeamQuotaReachedReturnsOnCall[len(fake.setTeamQuotaReachedArgsForCall)]
+	fake.setTeamQuotaReachedArgsForCall = append(fake.setTeamQuotaReachedArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	fake.recordInvocation("SetTeamQuotaReached", []interface{}{arg1})
+	fake.setTeamQuotaReachedMutex.Unlock()
+	if fake.SetTeamQuotaReachedStub != nil {
+		return fake.SetTeamQuotaReachedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setTeamQuotaReachedReturns.result1
+}
+
+func (fake *FakeJob) SetTeamQuotaReachedCallCount() int {
+	fake.setTeamQuotaReachedMutex.RLock()
+	defer fake.setTeamQuotaReachedMutex.RUnlock()
+	return len(fake.setTeamQuotaReachedArgsForCall)
+}
+
+func (fake *FakeJob) SetTeamQuotaReachedArgsForCall(i int) bool {
+	fake.setTeamQuotaReachedMutex.RLock()
+	defer fake.setTeamQuotaReachedMutex.RUnlock()
+	return fake.setTeamQuotaReachedArgsForCall[i].arg1
+}
+
+func (fake *FakeJob) SetTeamQuotaReachedReturns(result1 error) {
+	fake.SetTeamQuotaReachedStub = nil
+	fake.setTeamQuotaReachedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) SetTeamQuotaReachedReturnsOnCall(i int, result1 error) {
+	fake.SetTeamQuotaReachedStub = nil
+	if fake.setTeamQuotaReachedReturnsOnCall == nil {
+		fake.setTeamQuotaReachedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setTeamQuotaReachedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeJob) GetRunningBuildsBySerialGroup(serialGroups []string) ([]db.Build, error) {
 	var serialGroupsCopy []string
 	if serialGroups != nil {
@@ -1523,6 +2281,12 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.teamNameMutex.RUnlock()
 	fake.configMutex.RLock()
 	defer fake.configMutex.RUnlock()
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	fake.lastScheduleTimeMutex.RLock()
+	defer fake.lastScheduleTimeMutex.RUnlock()
+	fake.triggerOnSinceMutex.RLock()
+	defer fake.triggerOnSinceMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.pauseMutex.RLock()
@@ -1531,16 +2295,34 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.unpauseMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createBuildWithInputOverridesMutex.RLock()
+	defer fake.createBuildWithInputOverridesMutex.RUnlock()
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	fake.initLastScheduleTimeMutex.RLock()
+	defer fake.initLastScheduleTimeMutex.RUnlock()
+	fake.createTriggeredBuildsMutex.RLock()
+	defer fake.createTriggeredBuildsMutex.RUnlock()
+	fake.initTriggerOnSinceMutex.RLock()
+	defer fake.initTriggerOnSinceMutex.RUnlock()
+	fake.skipTriggersUntilMutex.RLock()
+	defer fake.skipTriggersUntilMutex.RUnlock()
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.testHistoryMutex.RLock()
+	defer fake.testHistoryMutex.RUnlock()
 	fake.finishedAndNextBuildMutex.RLock()
 	defer fake.finishedAndNextBuildMutex.RUnlock()
 	fake.updateFirstLoggedBuildIDMutex.RLock()
 	defer fake.updateFirstLoggedBuildIDMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
+	fake.supersedePendingBuildsMutex.RLock()
+	defer fake.supersedePendingBuildsMutex.RUnlock()
 	fake.getPendingBuildsMutex.RLock()
 	defer fake.getPendingBuildsMutex.RUnlock()
 	fake.getIndependentBuildInputsMutex.RLock()
@@ -1555,6 +2337,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.deleteNextInputMappingMutex.RUnlock()
 	fake.setMaxInFlightReachedMutex.RLock()
 	defer fake.setMaxInFlightReachedMutex.RUnlock()
+	fake.setTeamQuotaReachedMutex.RLock()
+	defer fake.setTeamQuotaReachedMutex.RUnlock()
 	fake.getRunningBuildsBySerialGroupMutex.RLock()
 	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
 	fake.getNextPendingBuildBySerialGroupMutex.RLock()
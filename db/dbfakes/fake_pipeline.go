@@ -130,6 +130,36 @@ type FakePipeline struct {
 	setResourceCheckErrorReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveResourceCheckStub        func(db.Resource, db.ResourceCheck) error
+	saveResourceCheckMutex       sync.RWMutex
+	saveResourceCheckArgsForCall []struct {
+		arg1 db.Resource
+		arg2 db.ResourceCheck
+	}
+	saveResourceCheckReturns struct {
+		result1 error
+	}
+	saveResourceCheckReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetResourceChecksStub        func(string, db.Page) ([]db.ResourceCheck, db.Pagination, bool, error)
+	getResourceChecksMutex       sync.RWMutex
+	getResourceChecksArgsForCall []struct {
+		resourceName string
+		page         db.Page
+	}
+	getResourceChecksReturns struct {
+		result1 []db.ResourceCheck
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}
+	getResourceChecksReturnsOnCall map[int]struct {
+		result1 []db.ResourceCheck
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}
 	SaveResourceVersionsStub        func(atc.ResourceConfig, []atc.Version) error
 	saveResourceVersionsMutex       sync.RWMutex
 	saveResourceVersionsArgsForCall []struct {
@@ -202,6 +232,21 @@ type FakePipeline struct {
 		result2 bool
 		result3 error
 	}
+	GetVersionedResourceStub        func(versionedResourceID int) (db.SavedVersionedResource, bool, error)
+	getVersionedResourceMutex       sync.RWMutex
+	getVersionedResourceArgsForCall []struct {
+		versionedResourceID int
+	}
+	getVersionedResourceReturns struct {
+		result1 db.SavedVersionedResource
+		result2 bool
+		result3 error
+	}
+	getVersionedResourceReturnsOnCall map[int]struct {
+		result1 db.SavedVersionedResource
+		result2 bool
+		result3 error
+	}
 	DisableVersionedResourceStub        func(versionedResourceID int) error
 	disableVersionedResourceMutex       sync.RWMutex
 	disableVersionedResourceArgsForCall []struct {
@@ -993,6 +1038,113 @@ func (fake *FakePipeline) SetResourceCheckErrorReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakePipeline) SaveResourceCheck(arg1 db.Resource, arg2 db.ResourceCheck) error {
+	fake.saveResourceCheckMutex.Lock()
+	ret, specificReturn := fake.saveResourceCheckReturnsOnCall[len(fake.saveResourceCheckArgsForCall)]
+	fake.saveResourceCheckArgsForCall = append(fake.saveResourceCheckArgsForCall, struct {
+		arg1 db.Resource
+		arg2 db.ResourceCheck
+	}{arg1, arg2})
+	fake.recordInvocation("SaveResourceCheck", []interface{}{arg1, arg2})
+	fake.saveResourceCheckMutex.Unlock()
+	if fake.SaveResourceCheckStub != nil {
+		return fake.SaveResourceCheckStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveResourceCheckReturns.result1
+}
+
+func (fake *FakePipeline) SaveResourceCheckCallCount() int {
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	return len(fake.saveResourceCheckArgsForCall)
+}
+
+func (fake *FakePipeline) SaveResourceCheckArgsForCall(i int) (db.Resource, db.ResourceCheck) {
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	return fake.saveResourceCheckArgsForCall[i].arg1, fake.saveResourceCheckArgsForCall[i].arg2
+}
+
+func (fake *FakePipeline) SaveResourceCheckReturns(result1 error) {
+	fake.SaveResourceCheckStub = nil
+	fake.saveResourceCheckReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) SaveResourceCheckReturnsOnCall(i int, result1 error) {
+	fake.SaveResourceCheckStub = nil
+	if fake.saveResourceCheckReturnsOnCall == nil {
+		fake.saveResourceCheckReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveResourceCheckReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) GetResourceChecks(resourceName string, page db.Page) ([]db.ResourceCheck, db.Pagination, bool, error) {
+	fake.getResourceChecksMutex.Lock()
+	ret, specificReturn := fake.getResourceChecksReturnsOnCall[len(fake.getResourceChecksArgsForCall)]
+	fake.getResourceChecksArgsForCall = append(fake.getResourceChecksArgsForCall, struct {
+		resourceName string
+		page         db.Page
+	}{resourceName, page})
+	fake.recordInvocation("GetResourceChecks", []interface{}{resourceName, page})
+	fake.getResourceChecksMutex.Unlock()
+	if fake.GetResourceChecksStub != nil {
+		return fake.GetResourceChecksStub(resourceName, page)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	return fake.getResourceChecksReturns.result1, fake.getResourceChecksReturns.result2, fake.getResourceChecksReturns.result3, fake.getResourceChecksReturns.result4
+}
+
+func (fake *FakePipeline) GetResourceChecksCallCount() int {
+	fake.getResourceChecksMutex.RLock()
+	defer fake.getResourceChecksMutex.RUnlock()
+	return len(fake.getResourceChecksArgsForCall)
+}
+
+func (fake *FakePipeline) GetResourceChecksArgsForCall(i int) (string, db.Page) {
+	fake.getResourceChecksMutex.RLock()
+	defer fake.getResourceChecksMutex.RUnlock()
+	return fake.getResourceChecksArgsForCall[i].resourceName, fake.getResourceChecksArgsForCall[i].page
+}
+
+func (fake *FakePipeline) GetResourceChecksReturns(result1 []db.ResourceCheck, result2 db.Pagination, result3 bool, result4 error) {
+	fake.GetResourceChecksStub = nil
+	fake.getResourceChecksReturns = struct {
+		result1 []db.ResourceCheck
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakePipeline) GetResourceChecksReturnsOnCall(i int, result1 []db.ResourceCheck, result2 db.Pagination, result3 bool, result4 error) {
+	fake.GetResourceChecksStub = nil
+	if fake.getResourceChecksReturnsOnCall == nil {
+		fake.getResourceChecksReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceCheck
+			result2 db.Pagination
+			result3 bool
+			result4 error
+		})
+	}
+	fake.getResourceChecksReturnsOnCall[i] = struct {
+		result1 []db.ResourceCheck
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
 func (fake *FakePipeline) SaveResourceVersions(arg1 atc.ResourceConfig, arg2 []atc.Version) error {
 	var arg2Copy []atc.Version
 	if arg2 != nil {
@@ -1257,6 +1409,60 @@ func (fake *FakePipeline) GetVersionedResourceByVersionReturnsOnCall(i int, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) GetVersionedResource(versionedResourceID int) (db.SavedVersionedResource, bool, error) {
+	fake.getVersionedResourceMutex.Lock()
+	ret, specificReturn := fake.getVersionedResourceReturnsOnCall[len(fake.getVersionedResourceArgsForCall)]
+	fake.getVersionedResourceArgsForCall = append(fake.getVersionedResourceArgsForCall, struct {
+		versionedResourceID int
+	}{versionedResourceID})
+	fake.recordInvocation("GetVersionedResource", []interface{}{versionedResourceID})
+	fake.getVersionedResourceMutex.Unlock()
+	if fake.GetVersionedResourceStub != nil {
+		return fake.GetVersionedResourceStub(versionedResourceID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getVersionedResourceReturns.result1, fake.getVersionedResourceReturns.result2, fake.getVersionedResourceReturns.result3
+}
+
+func (fake *FakePipeline) GetVersionedResourceCallCount() int {
+	fake.getVersionedResourceMutex.RLock()
+	defer fake.getVersionedResourceMutex.RUnlock()
+	return len(fake.getVersionedResourceArgsForCall)
+}
+
+func (fake *FakePipeline) GetVersionedResourceArgsForCall(i int) int {
+	fake.getVersionedResourceMutex.RLock()
+	defer fake.getVersionedResourceMutex.RUnlock()
+	return fake.getVersionedResourceArgsForCall[i].versionedResourceID
+}
+
+func (fake *FakePipeline) GetVersionedResourceReturns(result1 db.SavedVersionedResource, result2 bool, result3 error) {
+	fake.GetVersionedResourceStub = nil
+	fake.getVersionedResourceReturns = struct {
+		result1 db.SavedVersionedResource
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) GetVersionedResourceReturnsOnCall(i int, result1 db.SavedVersionedResource, result2 bool, result3 error) {
+	fake.GetVersionedResourceStub = nil
+	if fake.getVersionedResourceReturnsOnCall == nil {
+		fake.getVersionedResourceReturnsOnCall = make(map[int]struct {
+			result1 db.SavedVersionedResource
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getVersionedResourceReturnsOnCall[i] = struct {
+		result1 db.SavedVersionedResource
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) DisableVersionedResource(versionedResourceID int) error {
 	fake.disableVersionedResourceMutex.Lock()
 	ret, specificReturn := fake.disableVersionedResourceReturnsOnCall[len(fake.disableVersionedResourceArgsForCall)]
@@ -2385,6 +2591,10 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.reloadMutex.RUnlock()
 	fake.setResourceCheckErrorMutex.RLock()
 	defer fake.setResourceCheckErrorMutex.RUnlock()
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	fake.getResourceChecksMutex.RLock()
+	defer fake.getResourceChecksMutex.RUnlock()
 	fake.saveResourceVersionsMutex.RLock()
 	defer fake.saveResourceVersionsMutex.RUnlock()
 	fake.getResourceVersionsMutex.RLock()
@@ -2395,6 +2605,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.getLatestVersionedResourceMutex.RUnlock()
 	fake.getVersionedResourceByVersionMutex.RLock()
 	defer fake.getVersionedResourceByVersionMutex.RUnlock()
+	fake.getVersionedResourceMutex.RLock()
+	defer fake.getVersionedResourceMutex.RUnlock()
 	fake.disableVersionedResourceMutex.RLock()
 	defer fake.disableVersionedResourceMutex.RUnlock()
 	fake.enableVersionedResourceMutex.RLock()
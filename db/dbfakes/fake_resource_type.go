@@ -54,6 +54,15 @@ type FakeResourceType struct {
 	sourceReturnsOnCall map[int]struct {
 		result1 atc.Source
 	}
+	DefaultsStub        func() atc.Source
+	defaultsMutex       sync.RWMutex
+	defaultsArgsForCall []struct{}
+	defaultsReturns     struct {
+		result1 atc.Source
+	}
+	defaultsReturnsOnCall map[int]struct {
+		result1 atc.Source
+	}
 	SetResourceConfigStub        func(int) error
 	setResourceConfigMutex       sync.RWMutex
 	setResourceConfigArgsForCall []struct {
@@ -300,6 +309,46 @@ func (fake *FakeResourceType) SourceReturnsOnCall(i int, result1 atc.Source) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) Defaults() atc.Source {
+	fake.defaultsMutex.Lock()
+	ret, specificReturn := fake.defaultsReturnsOnCall[len(fake.defaultsArgsForCall)]
+	fake.defaultsArgsForCall = append(fake.defaultsArgsForCall, struct{}{})
+	fake.recordInvocation("Defaults", []interface{}{})
+	fake.defaultsMutex.Unlock()
+	if fake.DefaultsStub != nil {
+		return fake.DefaultsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.defaultsReturns.result1
+}
+
+func (fake *FakeResourceType) DefaultsCallCount() int {
+	fake.defaultsMutex.RLock()
+	defer fake.defaultsMutex.RUnlock()
+	return len(fake.defaultsArgsForCall)
+}
+
+func (fake *FakeResourceType) DefaultsReturns(result1 atc.Source) {
+	fake.DefaultsStub = nil
+	fake.defaultsReturns = struct {
+		result1 atc.Source
+	}{result1}
+}
+
+func (fake *FakeResourceType) DefaultsReturnsOnCall(i int, result1 atc.Source) {
+	fake.DefaultsStub = nil
+	if fake.defaultsReturnsOnCall == nil {
+		fake.defaultsReturnsOnCall = make(map[int]struct {
+			result1 atc.Source
+		})
+	}
+	fake.defaultsReturnsOnCall[i] = struct {
+		result1 atc.Source
+	}{result1}
+}
+
 func (fake *FakeResourceType) SetResourceConfig(arg1 int) error {
 	fake.setResourceConfigMutex.Lock()
 	ret, specificReturn := fake.setResourceConfigReturnsOnCall[len(fake.setResourceConfigArgsForCall)]
@@ -492,6 +541,8 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.privilegedMutex.RUnlock()
 	fake.sourceMutex.RLock()
 	defer fake.sourceMutex.RUnlock()
+	fake.defaultsMutex.RLock()
+	defer fake.defaultsMutex.RUnlock()
 	fake.setResourceConfigMutex.RLock()
 	defer fake.setResourceConfigMutex.RUnlock()
 	fake.versionMutex.RLock()
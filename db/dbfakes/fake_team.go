@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
 )
 
 type FakeTeam struct {
@@ -58,6 +59,61 @@ type FakeTeam struct {
 	authReturnsOnCall map[int]struct {
 		result1 map[string]*json.RawMessage
 	}
+	DefaultJobPriorityStub        func() int
+	defaultJobPriorityMutex       sync.RWMutex
+	defaultJobPriorityArgsForCall []struct{}
+	defaultJobPriorityReturns     struct {
+		result1 int
+	}
+	defaultJobPriorityReturnsOnCall map[int]struct {
+		result1 int
+	}
+	QuotaStub        func() atc.TeamQuota
+	quotaMutex       sync.RWMutex
+	quotaArgsForCall []struct{}
+	quotaReturns     struct {
+		result1 atc.TeamQuota
+	}
+	quotaReturnsOnCall map[int]struct {
+		result1 atc.TeamQuota
+	}
+	QuotaUsageStub        func() (atc.TeamQuotaUsage, error)
+	quotaUsageMutex       sync.RWMutex
+	quotaUsageArgsForCall []struct{}
+	quotaUsageReturns     struct {
+		result1 atc.TeamQuotaUsage
+		result2 error
+	}
+	quotaUsageReturnsOnCall map[int]struct {
+		result1 atc.TeamQuotaUsage
+		result2 error
+	}
+	AcquireQuotaLockStub        func(lager.Logger) (lock.Lock, bool, error)
+	acquireQuotaLockMutex       sync.RWMutex
+	acquireQuotaLockArgsForCall []struct {
+		logger lager.Logger
+	}
+	acquireQuotaLockReturns struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	acquireQuotaLockReturnsOnCall map[int]struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	PendingJobsStub        func() (db.Jobs, error)
+	pendingJobsMutex       sync.RWMutex
+	pendingJobsArgsForCall []struct{}
+	pendingJobsReturns     struct {
+		result1 db.Jobs
+		result2 error
+	}
+	pendingJobsReturnsOnCall map[int]struct {
+		result1 db.Jobs
+		result2 error
+	}
 	DeleteStub        func() error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct{}
@@ -170,6 +226,19 @@ type FakeTeam struct {
 		result2 db.Pagination
 		result3 error
 	}
+	SearchBuildLogsStub        func(db.BuildLogSearch) ([]db.BuildLogMatch, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 db.BuildLogSearch
+	}
+	searchBuildLogsReturns struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}
 	SaveWorkerStub        func(atcWorker atc.Worker, ttl time.Duration) (db.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -337,6 +406,28 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateDefaultJobPriorityStub        func(priority int) error
+	updateDefaultJobPriorityMutex       sync.RWMutex
+	updateDefaultJobPriorityArgsForCall []struct {
+		priority int
+	}
+	updateDefaultJobPriorityReturns struct {
+		result1 error
+	}
+	updateDefaultJobPriorityReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateQuotaStub        func(quota atc.TeamQuota) error
+	updateQuotaMutex       sync.RWMutex
+	updateQuotaArgsForCall []struct {
+		quota atc.TeamQuota
+	}
+	updateQuotaReturns struct {
+		result1 error
+	}
+	updateQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreatePipeStub        func(string, string) error
 	createPipeMutex       sync.RWMutex
 	createPipeArgsForCall []struct {
@@ -566,6 +657,226 @@ func (fake *FakeTeam) AuthReturnsOnCall(i int, result1 map[string]*json.RawMessa
 	}{result1}
 }
 
+func (fake *FakeTeam) DefaultJobPriority() int {
+	fake.defaultJobPriorityMutex.Lock()
+	ret, specificReturn := fake.defaultJobPriorityReturnsOnCall[len(fake.defaultJobPriorityArgsForCall)]
+	fake.defaultJobPriorityArgsForCall = append(fake.defaultJobPriorityArgsForCall, struct{}{})
+	fake.recordInvocation("DefaultJobPriority", []interface{}{})
+	fake.defaultJobPriorityMutex.Unlock()
+	if fake.DefaultJobPriorityStub != nil {
+		return fake.DefaultJobPriorityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.defaultJobPriorityReturns.result1
+}
+
+func (fake *FakeTeam) DefaultJobPriorityCallCount() int {
+	fake.defaultJobPriorityMutex.RLock()
+	defer fake.defaultJobPriorityMutex.RUnlock()
+	return len(fake.defaultJobPriorityArgsForCall)
+}
+
+func (fake *FakeTeam) DefaultJobPriorityReturns(result1 int) {
+	fake.DefaultJobPriorityStub = nil
+	fake.defaultJobPriorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeTeam) DefaultJobPriorityReturnsOnCall(i int, result1 int) {
+	fake.DefaultJobPriorityStub = nil
+	if fake.defaultJobPriorityReturnsOnCall == nil {
+		fake.defaultJobPriorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.defaultJobPriorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeTeam) Quota() atc.TeamQuota {
+	fake.quotaMutex.Lock()
+	ret, specificReturn := fake.quotaReturnsOnCall[len(fake.quotaArgsForCall)]
+	fake.quotaArgsForCall = append(fake.quotaArgsForCall, struct{}{})
+	fake.recordInvocation("Quota", []interface{}{})
+	fake.quotaMutex.Unlock()
+	if fake.QuotaStub != nil {
+		return fake.QuotaStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.quotaReturns.result1
+}
+
+func (fake *FakeTeam) QuotaCallCount() int {
+	fake.quotaMutex.RLock()
+	defer fake.quotaMutex.RUnlock()
+	return len(fake.quotaArgsForCall)
+}
+
+func (fake *FakeTeam) QuotaReturns(result1 atc.TeamQuota) {
+	fake.QuotaStub = nil
+	fake.quotaReturns = struct {
+		result1 atc.TeamQuota
+	}{result1}
+}
+
+func (fake *FakeTeam) QuotaReturnsOnCall(i int, result1 atc.TeamQuota) {
+	fake.QuotaStub = nil
+	if fake.quotaReturnsOnCall == nil {
+		fake.quotaReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamQuota
+		})
+	}
+	fake.quotaReturnsOnCall[i] = struct {
+		result1 atc.TeamQuota
+	}{result1}
+}
+
+func (fake *FakeTeam) QuotaUsage() (atc.TeamQuotaUsage, error) {
+	fake.quotaUsageMutex.Lock()
+	ret, specificReturn := fake.quotaUsageReturnsOnCall[len(fake.quotaUsageArgsForCall)]
+	fake.quotaUsageArgsForCall = append(fake.quotaUsageArgsForCall, struct{}{})
+	fake.recordInvocation("QuotaUsage", []interface{}{})
+	fake.quotaUsageMutex.Unlock()
+	if fake.QuotaUsageStub != nil {
+		return fake.QuotaUsageStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.quotaUsageReturns.result1, fake.quotaUsageReturns.result2
+}
+
+func (fake *FakeTeam) QuotaUsageCallCount() int {
+	fake.quotaUsageMutex.RLock()
+	defer fake.quotaUsageMutex.RUnlock()
+	return len(fake.quotaUsageArgsForCall)
+}
+
+func (fake *FakeTeam) QuotaUsageReturns(result1 atc.TeamQuotaUsage, result2 error) {
+	fake.QuotaUsageStub = nil
+	fake.quotaUsageReturns = struct {
+		result1 atc.TeamQuotaUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) QuotaUsageReturnsOnCall(i int, result1 atc.TeamQuotaUsage, result2 error) {
+	fake.QuotaUsageStub = nil
+	if fake.quotaUsageReturnsOnCall == nil {
+		fake.quotaUsageReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamQuotaUsage
+			result2 error
+		})
+	}
+	fake.quotaUsageReturnsOnCall[i] = struct {
+		result1 atc.TeamQuotaUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) AcquireQuotaLock(logger lager.Logger) (lock.Lock, bool, error) {
+	fake.acquireQuotaLockMutex.Lock()
+	ret, specificReturn := fake.acquireQuotaLockReturnsOnCall[len(fake.acquireQuotaLockArgsForCall)]
+	fake.acquireQuotaLockArgsForCall = append(fake.acquireQuotaLockArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.recordInvocation("AcquireQuotaLock", []interface{}{logger})
+	fake.acquireQuotaLockMutex.Unlock()
+	if fake.AcquireQuotaLockStub != nil {
+		return fake.AcquireQuotaLockStub(logger)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.acquireQuotaLockReturns.result1, fake.acquireQuotaLockReturns.result2, fake.acquireQuotaLockReturns.result3
+}
+
+func (fake *FakeTeam) AcquireQuotaLockCallCount() int {
+	fake.acquireQuotaLockMutex.RLock()
+	defer fake.acquireQuotaLockMutex.RUnlock()
+	return len(fake.acquireQuotaLockArgsForCall)
+}
+
+func (fake *FakeTeam) AcquireQuotaLockArgsForCall(i int) lager.Logger {
+	fake.acquireQuotaLockMutex.RLock()
+	defer fake.acquireQuotaLockMutex.RUnlock()
+	return fake.acquireQuotaLockArgsForCall[i].logger
+}
+
+func (fake *FakeTeam) AcquireQuotaLockReturns(result1 lock.Lock, result2 bool, result3 error) {
+	fake.AcquireQuotaLockStub = nil
+	fake.acquireQuotaLockReturns = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) AcquireQuotaLockReturnsOnCall(i int, result1 lock.Lock, result2 bool, result3 error) {
+	fake.AcquireQuotaLockStub = nil
+	if fake.acquireQuotaLockReturnsOnCall == nil {
+		fake.acquireQuotaLockReturnsOnCall = make(map[int]struct {
+			result1 lock.Lock
+			result2 bool
+			result3 error
+		})
+	}
+	fake.acquireQuotaLockReturnsOnCall[i] = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PendingJobs() (db.Jobs, error) {
+	fake.pendingJobsMutex.Lock()
+	ret, specificReturn := fake.pendingJobsReturnsOnCall[len(fake.pendingJobsArgsForCall)]
+	fake.pendingJobsArgsForCall = append(fake.pendingJobsArgsForCall, struct{}{})
+	fake.recordInvocation("PendingJobs", []interface{}{})
+	fake.pendingJobsMutex.Unlock()
+	if fake.PendingJobsStub != nil {
+		return fake.PendingJobsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.pendingJobsReturns.result1, fake.pendingJobsReturns.result2
+}
+
+func (fake *FakeTeam) PendingJobsCallCount() int {
+	fake.pendingJobsMutex.RLock()
+	defer fake.pendingJobsMutex.RUnlock()
+	return len(fake.pendingJobsArgsForCall)
+}
+
+func (fake *FakeTeam) PendingJobsReturns(result1 db.Jobs, result2 error) {
+	fake.PendingJobsStub = nil
+	fake.pendingJobsReturns = struct {
+		result1 db.Jobs
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PendingJobsReturnsOnCall(i int, result1 db.Jobs, result2 error) {
+	fake.PendingJobsStub = nil
+	if fake.pendingJobsReturnsOnCall == nil {
+		fake.pendingJobsReturnsOnCall = make(map[int]struct {
+			result1 db.Jobs
+			result2 error
+		})
+	}
+	fake.pendingJobsReturnsOnCall[i] = struct {
+		result1 db.Jobs
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Delete() error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -996,6 +1307,57 @@ func (fake *FakeTeam) PrivateAndPublicBuildsReturnsOnCall(i int, result1 []db.Bu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) SearchBuildLogs(arg1 db.BuildLogSearch) ([]db.BuildLogMatch, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 db.BuildLogSearch
+	}{arg1})
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1})
+	fake.searchBuildLogsMutex.Unlock()
+	if fake.SearchBuildLogsStub != nil {
+		return fake.SearchBuildLogsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.searchBuildLogsReturns.result1, fake.searchBuildLogsReturns.result2
+}
+
+func (fake *FakeTeam) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeam) SearchBuildLogsArgsForCall(i int) db.BuildLogSearch {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return fake.searchBuildLogsArgsForCall[i].arg1
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturns(result1 []db.BuildLogMatch, result2 error) {
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturnsOnCall(i int, result1 []db.BuildLogMatch, result2 error) {
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildLogMatch
+			result2 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (db.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1616,6 +1978,102 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) UpdateDefaultJobPriority(priority int) error {
+	fake.updateDefaultJobPriorityMutex.Lock()
+	ret, specificReturn := fake.updateDefaultJobPriorityReturnsOnCall[len(fake.updateDefaultJobPriorityArgsForCall)]
+	fake.updateDefaultJobPriorityArgsForCall = append(fake.updateDefaultJobPriorityArgsForCall, struct {
+		priority int
+	}{priority})
+	fake.recordInvocation("UpdateDefaultJobPriority", []interface{}{priority})
+	fake.updateDefaultJobPriorityMutex.Unlock()
+	if fake.UpdateDefaultJobPriorityStub != nil {
+		return fake.UpdateDefaultJobPriorityStub(priority)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateDefaultJobPriorityReturns.result1
+}
+
+func (fake *FakeTeam) UpdateDefaultJobPriorityCallCount() int {
+	fake.updateDefaultJobPriorityMutex.RLock()
+	defer fake.updateDefaultJobPriorityMutex.RUnlock()
+	return len(fake.updateDefaultJobPriorityArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateDefaultJobPriorityArgsForCall(i int) int {
+	fake.updateDefaultJobPriorityMutex.RLock()
+	defer fake.updateDefaultJobPriorityMutex.RUnlock()
+	return fake.updateDefaultJobPriorityArgsForCall[i].priority
+}
+
+func (fake *FakeTeam) UpdateDefaultJobPriorityReturns(result1 error) {
+	fake.UpdateDefaultJobPriorityStub = nil
+	fake.updateDefaultJobPriorityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateDefaultJobPriorityReturnsOnCall(i int, result1 error) {
+	fake.UpdateDefaultJobPriorityStub = nil
+	if fake.updateDefaultJobPriorityReturnsOnCall == nil {
+		fake.updateDefaultJobPriorityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateDefaultJobPriorityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateQuota(quota atc.TeamQuota) error {
+	fake.updateQuotaMutex.Lock()
+	ret, specificReturn := fake.updateQuotaReturnsOnCall[len(fake.updateQuotaArgsForCall)]
+	fake.updateQuotaArgsForCall = append(fake.updateQuotaArgsForCall, struct {
+		quota atc.TeamQuota
+	}{quota})
+	fake.recordInvocation("UpdateQuota", []interface{}{quota})
+	fake.updateQuotaMutex.Unlock()
+	if fake.UpdateQuotaStub != nil {
+		return fake.UpdateQuotaStub(quota)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateQuotaReturns.result1
+}
+
+func (fake *FakeTeam) UpdateQuotaCallCount() int {
+	fake.updateQuotaMutex.RLock()
+	defer fake.updateQuotaMutex.RUnlock()
+	return len(fake.updateQuotaArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateQuotaArgsForCall(i int) atc.TeamQuota {
+	fake.updateQuotaMutex.RLock()
+	defer fake.updateQuotaMutex.RUnlock()
+	return fake.updateQuotaArgsForCall[i].quota
+}
+
+func (fake *FakeTeam) UpdateQuotaReturns(result1 error) {
+	fake.UpdateQuotaStub = nil
+	fake.updateQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateQuotaReturnsOnCall(i int, result1 error) {
+	fake.UpdateQuotaStub = nil
+	if fake.updateQuotaReturnsOnCall == nil {
+		fake.updateQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) CreatePipe(arg1 string, arg2 string) error {
 	fake.createPipeMutex.Lock()
 	ret, specificReturn := fake.createPipeReturnsOnCall[len(fake.createPipeArgsForCall)]
@@ -1729,6 +2187,16 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.basicAuthMutex.RUnlock()
 	fake.authMutex.RLock()
 	defer fake.authMutex.RUnlock()
+	fake.defaultJobPriorityMutex.RLock()
+	defer fake.defaultJobPriorityMutex.RUnlock()
+	fake.quotaMutex.RLock()
+	defer fake.quotaMutex.RUnlock()
+	fake.quotaUsageMutex.RLock()
+	defer fake.quotaUsageMutex.RUnlock()
+	fake.acquireQuotaLockMutex.RLock()
+	defer fake.acquireQuotaLockMutex.RUnlock()
+	fake.pendingJobsMutex.RLock()
+	defer fake.pendingJobsMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
@@ -1747,6 +2215,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.privateAndPublicBuildsMutex.RLock()
 	defer fake.privateAndPublicBuildsMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.workersMutex.RLock()
@@ -1771,6 +2241,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.updateBasicAuthMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.updateDefaultJobPriorityMutex.RLock()
+	defer fake.updateDefaultJobPriorityMutex.RUnlock()
+	fake.updateQuotaMutex.RLock()
+	defer fake.updateQuotaMutex.RUnlock()
 	fake.createPipeMutex.RLock()
 	defer fake.createPipeMutex.RUnlock()
 	fake.getPipeMutex.RLock()
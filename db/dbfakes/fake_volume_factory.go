@@ -131,6 +131,22 @@ type FakeVolumeFactory struct {
 		result1 db.CreatingVolume
 		result2 error
 	}
+	CreateArtifactVolumeStub        func(teamID int, workerName string, buildID int, name string) (db.CreatingVolume, error)
+	createArtifactVolumeMutex       sync.RWMutex
+	createArtifactVolumeArgsForCall []struct {
+		teamID     int
+		workerName string
+		buildID    int
+		name       string
+	}
+	createArtifactVolumeReturns struct {
+		result1 db.CreatingVolume
+		result2 error
+	}
+	createArtifactVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatingVolume
+		result2 error
+	}
 	FindVolumesForContainerStub        func(db.CreatedContainer) ([]db.CreatedVolume, error)
 	findVolumesForContainerMutex       sync.RWMutex
 	findVolumesForContainerArgsForCall []struct {
@@ -618,6 +634,60 @@ func (fake *FakeVolumeFactory) CreateTaskCacheVolumeReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeFactory) CreateArtifactVolume(teamID int, workerName string, buildID int, name string) (db.CreatingVolume, error) {
+	fake.createArtifactVolumeMutex.Lock()
+	ret, specificReturn := fake.createArtifactVolumeReturnsOnCall[len(fake.createArtifactVolumeArgsForCall)]
+	fake.createArtifactVolumeArgsForCall = append(fake.createArtifactVolumeArgsForCall, struct {
+		teamID     int
+		workerName string
+		buildID    int
+		name       string
+	}{teamID, workerName, buildID, name})
+	fake.recordInvocation("CreateArtifactVolume", []interface{}{teamID, workerName, buildID, name})
+	fake.createArtifactVolumeMutex.Unlock()
+	if fake.CreateArtifactVolumeStub != nil {
+		return fake.CreateArtifactVolumeStub(teamID, workerName, buildID, name)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createArtifactVolumeReturns.result1, fake.createArtifactVolumeReturns.result2
+}
+
+func (fake *FakeVolumeFactory) CreateArtifactVolumeCallCount() int {
+	fake.createArtifactVolumeMutex.RLock()
+	defer fake.createArtifactVolumeMutex.RUnlock()
+	return len(fake.createArtifactVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) CreateArtifactVolumeArgsForCall(i int) (int, string, int, string) {
+	fake.createArtifactVolumeMutex.RLock()
+	defer fake.createArtifactVolumeMutex.RUnlock()
+	return fake.createArtifactVolumeArgsForCall[i].teamID, fake.createArtifactVolumeArgsForCall[i].workerName, fake.createArtifactVolumeArgsForCall[i].buildID, fake.createArtifactVolumeArgsForCall[i].name
+}
+
+func (fake *FakeVolumeFactory) CreateArtifactVolumeReturns(result1 db.CreatingVolume, result2 error) {
+	fake.CreateArtifactVolumeStub = nil
+	fake.createArtifactVolumeReturns = struct {
+		result1 db.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) CreateArtifactVolumeReturnsOnCall(i int, result1 db.CreatingVolume, result2 error) {
+	fake.CreateArtifactVolumeStub = nil
+	if fake.createArtifactVolumeReturnsOnCall == nil {
+		fake.createArtifactVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatingVolume
+			result2 error
+		})
+	}
+	fake.createArtifactVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeFactory) FindVolumesForContainer(arg1 db.CreatedContainer) ([]db.CreatedVolume, error) {
 	fake.findVolumesForContainerMutex.Lock()
 	ret, specificReturn := fake.findVolumesForContainerReturnsOnCall[len(fake.findVolumesForContainerArgsForCall)]
@@ -831,6 +901,8 @@ func (fake *FakeVolumeFactory) Invocations() map[string][][]interface{} {
 	defer fake.findTaskCacheVolumeMutex.RUnlock()
 	fake.createTaskCacheVolumeMutex.RLock()
 	defer fake.createTaskCacheVolumeMutex.RUnlock()
+	fake.createArtifactVolumeMutex.RLock()
+	defer fake.createArtifactVolumeMutex.RUnlock()
 	fake.findVolumesForContainerMutex.RLock()
 	defer fake.findVolumesForContainerMutex.RUnlock()
 	fake.getOrphanedVolumesMutex.RLock()
@@ -4,11 +4,13 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/lock"
+	"github.com/lib/pq"
 )
 
 //go:generate counterfeiter . Job
@@ -23,6 +25,9 @@ type Job interface {
 	TeamID() int
 	TeamName() string
 	Config() atc.JobConfig
+	Priority() int
+	LastScheduleTime() time.Time
+	TriggerOnSince() time.Time
 
 	Reload() (bool, error)
 
@@ -30,11 +35,20 @@ type Job interface {
 	Unpause() error
 
 	CreateBuild() (Build, error)
+	CreateBuildWithInputOverrides(inputOverrides map[string]int) (Build, error)
+	CreateScheduledBuild(scheduleTime time.Time) (Build, bool, error)
+	InitLastScheduleTime(now time.Time) error
+	CreateTriggeredBuilds() ([]Build, error)
+	InitTriggerOnSince(now time.Time) error
+	SkipTriggersUntil(now time.Time) error
+	RerunBuild(build Build) (Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	Build(name string) (Build, bool, error)
+	TestHistory(limit int) ([]BuildTestReports, error)
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
 	EnsurePendingBuildExists() error
+	SupersedePendingBuilds() (Build, bool, error)
 	GetPendingBuilds() ([]Build, error)
 
 	GetIndependentBuildInputs() ([]BuildInput, error)
@@ -44,11 +58,12 @@ type Job interface {
 	DeleteNextInputMapping() error
 
 	SetMaxInFlightReached(bool) error
+	SetTeamQuotaReached(bool) error
 	GetRunningBuildsBySerialGroup(serialGroups []string) ([]Build, error)
 	GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bool, error)
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "t.default_job_priority", "j.last_schedule_time", "j.trigger_on_since").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -63,6 +78,15 @@ func (e FirstLoggedBuildIDDecreasedError) Error() string {
 	return fmt.Sprintf("first logged build id for job '%s' decreased from %d to %d", e.Job, e.OldID, e.NewID)
 }
 
+type BuildOfOtherJobError struct {
+	Job     string
+	BuildID int
+}
+
+func (e BuildOfOtherJobError) Error() string {
+	return fmt.Sprintf("build %d is not a build of job '%s'", e.BuildID, e.Job)
+}
+
 type job struct {
 	id                 int
 	name               string
@@ -73,6 +97,9 @@ type job struct {
 	teamID             int
 	teamName           string
 	config             atc.JobConfig
+	teamPriority       int
+	lastScheduleTime   time.Time
+	triggerOnSince     time.Time
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -100,6 +127,24 @@ func (j *job) TeamID() int             { return j.teamID }
 func (j *job) TeamName() string        { return j.teamName }
 func (j *job) Config() atc.JobConfig   { return j.config }
 
+// LastScheduleTime returns when the job's schedule was last evaluated to
+// fire, or the zero time if it has not been evaluated yet.
+func (j *job) LastScheduleTime() time.Time { return j.lastScheduleTime }
+
+// TriggerOnSince returns when the job started being triggered by builds of
+// the jobs in its trigger_on config, or the zero time if it has not yet.
+func (j *job) TriggerOnSince() time.Time { return j.triggerOnSince }
+
+// Priority returns the priority configured on the job, falling back to the
+// team's default job priority.
+func (j *job) Priority() int {
+	if j.config.Priority != nil {
+		return *j.config.Priority
+	}
+
+	return j.teamPriority
+}
+
 func (j *job) Reload() (bool, error) {
 	row := jobsQuery.Where(sq.Eq{"j.id": j.id}).
 		RunWith(j.conn).
@@ -198,6 +243,303 @@ func (j *job) UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error {
 	return nil
 }
 
+// CreateScheduledBuild creates a pending build for the given fire time of the
+// job's schedule. The build is not created if the schedule has already fired
+// at or after that time, which keeps a fire time from triggering twice.
+func (j *job) CreateScheduledBuild(scheduleTime time.Time) (Build, bool, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := psql.Update("jobs").
+		Set("last_schedule_time", sq.Expr("now()")).
+		Where(sq.Eq{"id": j.id}).
+		Where(sq.Or{
+			sq.Eq{"last_schedule_time": nil},
+			sq.Lt{"last_schedule_time": scheduleTime},
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, false, nil
+	}
+
+	buildName, err := j.getNewBuildName(tx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":          buildName,
+		"job_id":        j.id,
+		"pipeline_id":   j.pipelineID,
+		"team_id":       j.teamID,
+		"status":        BuildStatusPending,
+		"schedule_time": scheduleTime,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = j.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY next_builds_per_job`)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
+// InitLastScheduleTime starts evaluating the job's schedule from the given
+// time, unless it is already being evaluated.
+func (j *job) InitLastScheduleTime(now time.Time) error {
+	result, err := psql.Update("jobs").
+		Set("last_schedule_time", now).
+		Where(sq.Eq{
+			"id":                 j.id,
+			"last_schedule_time": nil,
+		}).
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 1 {
+		j.lastScheduleTime = now
+	}
+
+	return nil
+}
+
+// CreateTriggeredBuilds creates a pending build for each build of the jobs in
+// the job's trigger_on config which finished with a matching status since
+// the job started being triggered, and has not triggered a build yet.
+func (j *job) CreateTriggeredBuilds() ([]Build, error) {
+	if len(j.config.TriggerOn) == 0 {
+		return []Build{}, nil
+	}
+
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	triggerConditions := sq.Or{}
+	for _, triggerOn := range j.config.TriggerOn {
+		statuses := []string{}
+		for _, status := range triggerOn.TriggerStatuses() {
+			statuses = append(statuses, string(status))
+		}
+
+		triggerConditions = append(triggerConditions, sq.Eq{
+			"uj.name":  triggerOn.Job,
+			"u.status": statuses,
+		})
+	}
+
+	rows, err := psql.Select("u.id").
+		From("builds u, jobs uj, jobs j").
+		Where(sq.Expr("uj.id = u.job_id")).
+		Where(sq.Expr("uj.pipeline_id = j.pipeline_id")).
+		Where(sq.Expr("u.end_time > j.trigger_on_since")).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM builds d WHERE d.job_id = j.id AND d.triggered_by = u.id)")).
+		Where(sq.Eq{
+			"j.id":        j.id,
+			"u.completed": true,
+		}).
+		Where(triggerConditions).
+		OrderBy("u.end_time ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	upstreamBuildIDs := []int{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		upstreamBuildIDs = append(upstreamBuildIDs, id)
+	}
+
+	builds := []Build{}
+	for _, upstreamBuildID := range upstreamBuildIDs {
+		buildName, err := j.getNewBuildName(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		build := &build{conn: j.conn, lockFactory: j.lockFactory}
+		err = createBuild(tx, build, map[string]interface{}{
+			"name":         buildName,
+			"job_id":       j.id,
+			"pipeline_id":  j.pipelineID,
+			"team_id":      j.teamID,
+			"status":       BuildStatusPending,
+			"triggered_by": upstreamBuildID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(builds) > 0 {
+		_, err = j.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY next_builds_per_job`)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return builds, nil
+}
+
+// InitTriggerOnSince starts triggering the job on builds which finish after
+// the given time, unless it is already being triggered.
+func (j *job) InitTriggerOnSince(now time.Time) error {
+	result, err := psql.Update("jobs").
+		Set("trigger_on_since", now).
+		Where(sq.Eq{
+			"id":               j.id,
+			"trigger_on_since": nil,
+		}).
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 1 {
+		j.triggerOnSince = now
+	}
+
+	return nil
+}
+
+// SkipTriggersUntil moves the time from which builds trigger the job forward
+// to the given time, so that builds which finished before it, e.g. while the
+// job was paused, never trigger it.
+func (j *job) SkipTriggersUntil(now time.Time) error {
+	result, err := psql.Update("jobs").
+		Set("trigger_on_since", now).
+		Where(sq.Eq{"id": j.id}).
+		Where(sq.Lt{"trigger_on_since": now}).
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 1 {
+		j.triggerOnSince = now
+	}
+
+	return nil
+}
+
+// RerunBuild creates a new pending build of the job which uses the exact
+// inputs of the given build rather than the job's next input mapping.
+func (j *job) RerunBuild(rerunBuild Build) (Build, error) {
+	if rerunBuild.JobID() != j.id {
+		return nil, BuildOfOtherJobError{Job: j.name, BuildID: rerunBuild.ID()}
+	}
+
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	buildName, err := j.getNewBuildName(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":               buildName,
+		"job_id":             j.id,
+		"pipeline_id":        j.pipelineID,
+		"team_id":            j.teamID,
+		"status":             BuildStatusPending,
+		"manually_triggered": true,
+		"rerun_of":           rerunBuild.ID(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO build_inputs (build_id, versioned_resource_id, name, overridden)
+		SELECT $1, versioned_resource_id, name, overridden
+		FROM build_inputs
+		WHERE build_id = $2
+	`, build.ID(), rerunBuild.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = j.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY next_builds_per_job`)
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
 func (j *job) Builds(page Page) ([]Build, Pagination, error) {
 	query := buildsQuery.Where(sq.Eq{"j.id": j.id})
 
@@ -277,6 +619,61 @@ func (j *job) Builds(page Page) ([]Build, Pagination, error) {
 	return builds, pagination, nil
 }
 
+// TestHistory returns the test reports of the job's latest builds which
+// reported any, newest first.
+func (j *job) TestHistory(limit int) ([]BuildTestReports, error) {
+	rows, err := psql.Select("b.id, b.name, b.status, r.step_name, r.total, r.passed, r.failed, r.skipped, r.failures").
+		From("build_test_reports r").
+		Join("builds b ON b.id = r.build_id").
+		Where(sq.Expr(`b.id IN (
+			SELECT rb.id
+			FROM builds rb
+			WHERE rb.job_id = ?
+			AND EXISTS (SELECT 1 FROM build_test_reports rr WHERE rr.build_id = rb.id)
+			ORDER BY rb.id DESC
+			LIMIT ?
+		)`, j.id, limit)).
+		OrderBy("b.id DESC", "r.step_name").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	history := []BuildTestReports{}
+	for rows.Next() {
+		var (
+			buildID   int
+			buildName string
+			status    string
+		)
+
+		report, err := scanTestReport(prefixedRow{
+			row:     rows,
+			columns: []interface{}{&buildID, &buildName, &status},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(history) == 0 || history[len(history)-1].BuildID != buildID {
+			history = append(history, BuildTestReports{
+				BuildID:   buildID,
+				BuildName: buildName,
+				Status:    BuildStatus(status),
+				Reports:   []TestReport{},
+			})
+		}
+
+		last := &history[len(history)-1]
+		last.Reports = append(last.Reports, report)
+	}
+
+	return history, nil
+}
+
 func (j *job) Build(name string) (Build, bool, error) {
 	row := buildsQuery.Where(sq.Eq{
 		"b.job_id": j.id,
@@ -392,6 +789,30 @@ func (j *job) SetMaxInFlightReached(reached bool) error {
 	return nil
 }
 
+func (j *job) SetTeamQuotaReached(reached bool) error {
+	result, err := psql.Update("jobs").
+		Set("team_quota_reached", reached).
+		Where(sq.Eq{
+			"id": j.id,
+		}).
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
 func (j *job) SaveIndependentInputMapping(inputMapping algorithm.InputMapping) error {
 	return j.saveJobInputMapping("independent_build_inputs", inputMapping)
 }
@@ -510,6 +931,141 @@ func (j *job) EnsurePendingBuildExists() error {
 	return nil
 }
 
+// SupersedePendingBuilds creates a pending build for the job's current input
+// mapping, unless a pending build that will use it already exists. Pending
+// builds created before it from an earlier input mapping are aborted without
+// being started, and record the new build as the one that superseded them.
+// Builds which were asked for in any other way (manually, as a rerun, with
+// overridden inputs, by trigger_on or a schedule, or awaiting approval) are
+// left alone.
+func (j *job) SupersedePendingBuilds() (Build, bool, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer tx.Rollback()
+
+	// builds rerunning another build, with overridden inputs or with inputs
+	// pinned for approval don't use the next input mapping, so they are not up
+	// to date with it
+	var upToDate bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM builds b
+			WHERE b.job_id = $1
+			AND b.status = 'pending'
+			AND b.rerun_of IS NULL
+			AND b.approval_status IS NULL
+			AND NOT EXISTS (SELECT 1 FROM build_input_overrides o WHERE o.build_id = b.id)
+			AND b.create_time >= (
+				SELECT COALESCE(max(create_time), '-infinity')
+				FROM next_build_inputs
+				WHERE job_id = $1
+			)
+		)
+	`, j.id).Scan(&upToDate)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if upToDate {
+		return nil, false, nil
+	}
+
+	buildName, err := j.getNewBuildName(tx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	pendingBuild := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, pendingBuild, map[string]interface{}{
+		"name":        buildName,
+		"job_id":      j.id,
+		"pipeline_id": j.pipelineID,
+		"team_id":     j.teamID,
+		"status":      BuildStatusPending,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := psql.Update("builds").
+		Set("superseded_by", pendingBuild.id).
+		Where(sq.Eq{
+			"job_id":             j.id,
+			"status":             string(BuildStatusPending),
+			"manually_triggered": false,
+			"rerun_of":           nil,
+			"triggered_by":       nil,
+			"schedule_time":      nil,
+			"approval_status":    nil,
+		}).
+		Where(sq.Lt{"id": pendingBuild.id}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM build_input_overrides o WHERE o.build_id = builds.id)")).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer rows.Close()
+
+	superseded := []*build{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, false, err
+		}
+
+		superseded = append(superseded, &build{
+			id:          id,
+			jobID:       j.id,
+			pipelineID:  j.pipelineID,
+			teamID:      j.teamID,
+			conn:        j.conn,
+			lockFactory: j.lockFactory,
+		})
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows.Close()
+
+	// finished along with superseding them, so that none is left aborted but
+	// not completed
+	for _, supersededBuild := range superseded {
+		err = supersededBuild.finish(tx, BuildStatusAborted)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = j.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY next_builds_per_job`)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, supersededBuild := range superseded {
+		err = supersededBuild.afterFinish(BuildStatusAborted)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	return pendingBuild, true, nil
+}
+
 func (j *job) GetPendingBuilds() ([]Build, error) {
 	builds := []Build{}
 
@@ -553,6 +1109,13 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 }
 
 func (j *job) CreateBuild() (Build, error) {
+	return j.CreateBuildWithInputOverrides(nil)
+}
+
+// CreateBuildWithInputOverrides creates a manually triggered build which
+// will use the given versioned resources (by input name) regardless of the
+// inputs' passed constraints.
+func (j *job) CreateBuildWithInputOverrides(inputOverrides map[string]int) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -578,6 +1141,17 @@ func (j *job) CreateBuild() (Build, error) {
 		return nil, err
 	}
 
+	for inputName, versionedResourceID := range inputOverrides {
+		_, err = psql.Insert("build_input_overrides").
+			Columns("build_id", "input_name", "versioned_resource_id").
+			Values(build.ID(), inputName, versionedResourceID).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -803,15 +1377,20 @@ func (j *job) saveJobInputMapping(table string, inputMapping algorithm.InputMapp
 
 func scanJob(j *job, row scannable) error {
 	var (
-		configBlob []byte
-		nonce      sql.NullString
+		configBlob       []byte
+		nonce            sql.NullString
+		lastScheduleTime pq.NullTime
+		triggerOnSince   pq.NullTime
 	)
 
-	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce)
+	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, &j.teamPriority, &lastScheduleTime, &triggerOnSince)
 	if err != nil {
 		return err
 	}
 
+	j.lastScheduleTime = lastScheduleTime.Time
+	j.triggerOnSince = triggerOnSince.Time
+
 	es := j.conn.EncryptionStrategy()
 
 	var noncense *string
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/concourse/atc"
@@ -120,6 +121,56 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("Priority", func() {
+		Context("when the job does not configure a priority", func() {
+			It("defaults to zero", func() {
+				Expect(job.Priority()).To(BeZero())
+			})
+
+			Context("when the team has a default job priority", func() {
+				BeforeEach(func() {
+					err := team.UpdateDefaultJobPriority(5)
+					Expect(err).NotTo(HaveOccurred())
+
+					found, err := job.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+
+				It("uses the team's default", func() {
+					Expect(job.Priority()).To(Equal(5))
+				})
+			})
+		})
+
+		Context("when the job configures a priority", func() {
+			BeforeEach(func() {
+				priority := 20
+				prioritizedPipeline, _, err := team.SavePipeline("prioritized-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:     "some-job",
+							Priority: &priority,
+						},
+					},
+				}, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = team.UpdateDefaultJobPriority(5)
+				Expect(err).NotTo(HaveOccurred())
+
+				var found bool
+				job, found, err = prioritizedPipeline.Job("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("takes precedence over the team's default", func() {
+				Expect(job.Priority()).To(Equal(20))
+			})
+		})
+	})
+
 	Describe("FinishedAndNextBuild", func() {
 		var otherPipeline db.Pipeline
 		var otherJob db.Job
@@ -362,6 +413,408 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("TestHistory", func() {
+		var builds []db.Build
+
+		BeforeEach(func() {
+			builds = nil
+			for i := 0; i < 4; i++ {
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				builds = append(builds, build)
+			}
+
+			for _, i := range []int{0, 1, 3} {
+				err := builds[i].SaveTestReport(db.TestReport{
+					StepName: "unit",
+					Total:    1,
+					Failed:   1,
+					Failures: []string{fmt.Sprintf("Test%d", i)},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			err := builds[3].SaveTestReport(db.TestReport{
+				StepName: "integration",
+				Total:    1,
+				Passed:   1,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the reports of the latest builds with reports, newest first", func() {
+			history, err := job.TestHistory(2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(history).To(HaveLen(2))
+
+			Expect(history[0].BuildID).To(Equal(builds[3].ID()))
+			Expect(history[0].BuildName).To(Equal(builds[3].Name()))
+			Expect(history[0].Status).To(Equal(db.BuildStatusPending))
+			Expect(history[0].Reports).To(HaveLen(2))
+			Expect(history[0].Reports[0].StepName).To(Equal("integration"))
+			Expect(history[0].Reports[1].Failures).To(Equal([]string{"Test3"}))
+
+			Expect(history[1].BuildID).To(Equal(builds[1].ID()))
+			Expect(history[1].Reports).To(HaveLen(1))
+		})
+	})
+
+	Describe("CreateBuildWithInputOverrides", func() {
+		var savedVersion db.SavedVersionedResource
+
+		BeforeEach(func() {
+			err := pipeline.SaveResourceVersions(
+				atc.ResourceConfig{
+					Name: "some-resource",
+					Type: "some-type",
+				},
+				[]atc.Version{{"version": "v1"}},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			var found bool
+			savedVersion, found, err = pipeline.GetVersionedResourceByVersion(atc.Version{"version": "v1"}, "some-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("creates a pending, manually triggered build with the input overrides", func() {
+			build, err := job.CreateBuildWithInputOverrides(map[string]int{"some-input": savedVersion.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Status()).To(Equal(db.BuildStatusPending))
+			Expect(build.IsManuallyTriggered()).To(BeTrue())
+
+			inputOverrides, err := build.InputOverrides()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputOverrides).To(Equal(map[string]int{"some-input": savedVersion.ID}))
+		})
+
+		It("does not give plainly triggered builds any overrides", func() {
+			build, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			inputOverrides, err := build.InputOverrides()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputOverrides).To(BeEmpty())
+		})
+
+		It("keeps track of which inputs were overridden once the inputs are used", func() {
+			build, err := job.CreateBuildWithInputOverrides(map[string]int{"some-input": savedVersion.ID})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.UseInputs([]db.BuildInput{
+				{
+					Name:              "some-input",
+					VersionedResource: savedVersion.VersionedResource,
+					Overridden:        true,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			inputs, _, err := build.Resources()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(HaveLen(1))
+			Expect(inputs[0].Overridden).To(BeTrue())
+		})
+	})
+
+	Describe("schedules", func() {
+		It("starts evaluating the schedule only once", func() {
+			Expect(job.LastScheduleTime()).To(BeZero())
+
+			firstTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+			err := job.InitLastScheduleTime(firstTime)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.LastScheduleTime()).To(BeTemporally("==", firstTime))
+
+			err = job.InitLastScheduleTime(time.Now())
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := job.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.LastScheduleTime()).To(BeTemporally("==", firstTime))
+		})
+
+		Context("when the schedule has started being evaluated", func() {
+			var scheduleTime time.Time
+
+			BeforeEach(func() {
+				err := job.InitLastScheduleTime(time.Now().Add(-time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+
+				scheduleTime = time.Now().Add(-time.Minute).Truncate(time.Second)
+			})
+
+			It("creates a pending build which records the fire time", func() {
+				build, created, err := job.CreateScheduledBuild(scheduleTime)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusPending))
+				Expect(build.IsManuallyTriggered()).To(BeFalse())
+				Expect(build.ScheduleTime()).To(BeTemporally("==", scheduleTime))
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+				Expect(pendingBuilds[0].ID()).To(Equal(build.ID()))
+			})
+
+			It("updates the last schedule time", func() {
+				_, _, err := job.CreateScheduledBuild(scheduleTime)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := job.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(job.LastScheduleTime()).To(BeTemporally(">=", scheduleTime))
+			})
+
+			It("does not create a second build for the same fire time", func() {
+				_, created, err := job.CreateScheduledBuild(scheduleTime)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				_, created, err = job.CreateScheduledBuild(scheduleTime)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeFalse())
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("trigger_on", func() {
+		var (
+			triggerPipeline db.Pipeline
+			upstreamJob     db.Job
+			downstreamJob   db.Job
+			config          atc.Config
+		)
+
+		finishUpstreamBuild := func(status db.BuildStatus) db.Build {
+			build, err := upstreamJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(status)
+			Expect(err).NotTo(HaveOccurred())
+
+			return build
+		}
+
+		BeforeEach(func() {
+			config = atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "deploy"},
+					{
+						Name: "smoke-tests",
+						TriggerOn: []atc.TriggerOnConfig{
+							{Job: "deploy", Statuses: []atc.BuildStatus{atc.StatusSucceeded, atc.StatusFailed}},
+						},
+					},
+				},
+			}
+
+			var err error
+			triggerPipeline, _, err = team.SavePipeline("trigger-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			var found bool
+			upstreamJob, found, err = triggerPipeline.Job("deploy")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			downstreamJob, found, err = triggerPipeline.Job("smoke-tests")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("starts triggering only once", func() {
+			Expect(downstreamJob.TriggerOnSince()).To(BeZero())
+
+			firstTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+			err := downstreamJob.InitTriggerOnSince(firstTime)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(downstreamJob.TriggerOnSince()).To(BeTemporally("==", firstTime))
+
+			err = downstreamJob.InitTriggerOnSince(time.Now())
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := downstreamJob.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(downstreamJob.TriggerOnSince()).To(BeTemporally("==", firstTime))
+		})
+
+		It("does not trigger on builds which finished before it started triggering", func() {
+			finishUpstreamBuild(db.BuildStatusSucceeded)
+
+			err := downstreamJob.InitTriggerOnSince(time.Now().Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := downstreamJob.CreateTriggeredBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		Context("when it has started triggering", func() {
+			BeforeEach(func() {
+				err := downstreamJob.InitTriggerOnSince(time.Now().Add(-time.Minute))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates a pending build for each matching upstream build, once", func() {
+				succeeded := finishUpstreamBuild(db.BuildStatusSucceeded)
+				finishUpstreamBuild(db.BuildStatusErrored)
+				failed := finishUpstreamBuild(db.BuildStatusFailed)
+
+				builds, err := downstreamJob.CreateTriggeredBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(2))
+
+				Expect(builds[0].Status()).To(Equal(db.BuildStatusPending))
+				Expect(builds[0].JobName()).To(Equal("smoke-tests"))
+				Expect(builds[0].TriggeredBy()).To(Equal(succeeded.ID()))
+				Expect(builds[1].TriggeredBy()).To(Equal(failed.ID()))
+
+				pendingBuilds, err := downstreamJob.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(2))
+
+				builds, err = downstreamJob.CreateTriggeredBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+
+			It("does not trigger on builds which finished before triggers were skipped", func() {
+				finishUpstreamBuild(db.BuildStatusSucceeded)
+
+				skippedUntil := time.Now().Add(time.Minute).Truncate(time.Second)
+				err := downstreamJob.SkipTriggersUntil(skippedUntil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(downstreamJob.TriggerOnSince()).To(BeTemporally("==", skippedUntil))
+
+				builds, err := downstreamJob.CreateTriggeredBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+
+			It("does not move the time triggers are skipped until backwards", func() {
+				err := downstreamJob.SkipTriggersUntil(time.Now().Add(-time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := downstreamJob.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(downstreamJob.TriggerOnSince()).To(BeTemporally("~", time.Now().Add(-time.Minute), 10*time.Second))
+			})
+
+			It("does not trigger on builds which have not finished", func() {
+				_, err := upstreamJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				builds, err := downstreamJob.CreateTriggeredBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+
+			It("stops triggering when trigger_on is removed from the config", func() {
+				config.Jobs[1].TriggerOn = nil
+
+				_, _, err := team.SavePipeline("trigger-pipeline", config, triggerPipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := downstreamJob.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(downstreamJob.TriggerOnSince()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("RerunBuild", func() {
+		var originalBuild db.Build
+
+		BeforeEach(func() {
+			err := pipeline.SaveResourceVersions(
+				atc.ResourceConfig{
+					Name: "some-resource",
+					Type: "some-type",
+				},
+				[]atc.Version{{"version": "v1"}},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			originalBuild, err = job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = originalBuild.SaveInput(db.BuildInput{
+				Name: "some-input",
+				VersionedResource: db.VersionedResource{
+					Resource: "some-resource",
+					Type:     "some-type",
+					Version:  db.ResourceVersion{"version": "v1"},
+				},
+				FirstOccurrence: true,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = originalBuild.Finish(db.BuildStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates a pending, manually triggered build which refers to the rerun build", func() {
+			rerun, err := job.RerunBuild(originalBuild)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rerun.ID()).NotTo(Equal(originalBuild.ID()))
+			Expect(rerun.Name()).To(Equal("2"))
+			Expect(rerun.Status()).To(Equal(db.BuildStatusPending))
+			Expect(rerun.IsManuallyTriggered()).To(BeTrue())
+			Expect(rerun.RerunOf()).To(Equal(originalBuild.ID()))
+
+			found, err := rerun.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(rerun.RerunOf()).To(Equal(originalBuild.ID()))
+		})
+
+		It("copies the inputs of the rerun build", func() {
+			rerun, err := job.RerunBuild(originalBuild)
+			Expect(err).NotTo(HaveOccurred())
+
+			inputs, _, err := rerun.Resources()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(HaveLen(1))
+			Expect(inputs[0].Name).To(Equal("some-input"))
+			Expect(inputs[0].VersionedResource.Version).To(Equal(db.ResourceVersion{"version": "v1"}))
+		})
+
+		It("becomes the next pending build of the job", func() {
+			rerun, err := job.RerunBuild(originalBuild)
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			Expect(pendingBuilds[0].ID()).To(Equal(rerun.ID()))
+		})
+
+		Context("when the build belongs to another job", func() {
+			It("returns an error", func() {
+				otherJob, found, err := pipeline.Job("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = otherJob.RerunBuild(originalBuild)
+				Expect(err).To(Equal(db.BuildOfOtherJobError{Job: "some-other-job", BuildID: originalBuild.ID()}))
+			})
+		})
+	})
+
 	Describe("GetRunningBuildsBySerialGroup", func() {
 		Describe("same job", func() {
 			var startedBuild, scheduledBuild db.Build
@@ -1173,4 +1626,190 @@ var _ = Describe("Job", func() {
 			})
 		})
 	})
+
+	Describe("SupersedePendingBuilds", func() {
+		saveNextVersion := func(version string) {
+			err := pipeline.SaveResourceVersions(
+				atc.ResourceConfig{
+					Name: "some-resource",
+					Type: "some-type",
+				},
+				[]atc.Version{{"version": version}},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			savedVersion, found, err := pipeline.GetLatestVersionedResource("some-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = job.SaveNextInputMapping(algorithm.InputMapping{
+				"some-input": algorithm.InputVersion{
+					VersionID:       savedVersion.ID,
+					FirstOccurrence: true,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			saveNextVersion("v1")
+		})
+
+		It("creates a pending build", func() {
+			build, created, err := job.SupersedePendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusPending))
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			Expect(pendingBuilds[0].ID()).To(Equal(build.ID()))
+		})
+
+		It("does not create another build until the input mapping changes", func() {
+			_, created, err := job.SupersedePendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			_, created, err = job.SupersedePendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeFalse())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+		})
+
+		Context("when pending builds exist from before the input mapping changed", func() {
+			var olderBuild db.Build
+
+			BeforeEach(func() {
+				var err error
+				olderBuild, _, err = job.SupersedePendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			// clears the manually triggered flag, so that builds which are always
+			// created manually show they are kept for their own sake
+			notManuallyTriggered := func(build db.Build) {
+				_, err := dbConn.Exec(`UPDATE builds SET manually_triggered = false WHERE id = $1`, build.ID())
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			expectNotSuperseded := func(build db.Build) {
+				saveNextVersion("v2")
+
+				_, created, err := job.SupersedePendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusPending))
+				Expect(build.SupersededBy()).To(BeZero())
+			}
+
+			It("aborts them as superseded by a new pending build", func() {
+				saveNextVersion("v2")
+
+				build, created, err := job.SupersedePendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+				Expect(pendingBuilds[0].ID()).To(Equal(build.ID()))
+
+				found, err := olderBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(olderBuild.Status()).To(Equal(db.BuildStatusAborted))
+				Expect(olderBuild.SupersededBy()).To(Equal(build.ID()))
+				Expect(olderBuild.EndTime()).NotTo(BeZero())
+				Expect(olderBuild.IsRunning()).To(BeFalse())
+			})
+
+			It("does not abort started builds", func() {
+				started, err := olderBuild.Start("some-engine", `{"some":"metadata"}`, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				saveNextVersion("v2")
+
+				_, _, err = job.SupersedePendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := olderBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(olderBuild.Status()).To(Equal(db.BuildStatusStarted))
+				Expect(olderBuild.SupersededBy()).To(BeZero())
+			})
+
+			It("does not abort manually triggered builds", func() {
+				manualBuild, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				expectNotSuperseded(manualBuild)
+			})
+
+			It("does not abort reruns", func() {
+				err := olderBuild.Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+
+				rerun, err := job.RerunBuild(olderBuild)
+				Expect(err).NotTo(HaveOccurred())
+
+				notManuallyTriggered(rerun)
+				expectNotSuperseded(rerun)
+			})
+
+			It("does not abort builds with overridden inputs", func() {
+				savedVersion, found, err := pipeline.GetLatestVersionedResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				overriddenBuild, err := job.CreateBuildWithInputOverrides(map[string]int{
+					"some-input": savedVersion.ID,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				notManuallyTriggered(overriddenBuild)
+				expectNotSuperseded(overriddenBuild)
+			})
+
+			It("does not abort builds triggered by another job", func() {
+				otherJob, found, err := pipeline.Job("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				upstreamBuild, err := otherJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = dbConn.Exec(`UPDATE builds SET triggered_by = $1 WHERE id = $2`, upstreamBuild.ID(), olderBuild.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				expectNotSuperseded(olderBuild)
+			})
+
+			It("does not abort scheduled builds", func() {
+				scheduledBuild, created, err := job.CreateScheduledBuild(time.Now())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				expectNotSuperseded(scheduledBuild)
+			})
+
+			It("does not abort builds awaiting approval", func() {
+				requested, err := olderBuild.RequestApproval([]string{"some-approvers"}, time.Time{}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requested).To(BeTrue())
+
+				expectNotSuperseded(olderBuild)
+			})
+		})
+	})
 })
@@ -19,6 +19,7 @@ const (
 	LockTypeBatch
 	LockTypeVolumeCreating
 	LockTypeContainerCreating
+	LockTypeTeamQuota
 )
 
 var ErrLostLock = errors.New("lock was lost while held, possibly due to connection breakage")
@@ -47,6 +48,10 @@ func NewContainerCreatingLockID(containerID int) LockID {
 	return LockID{LockTypeContainerCreating, containerID}
 }
 
+func NewTeamQuotaLockID(teamID int) LockID {
+	return LockID{LockTypeTeamQuota, teamID}
+}
+
 //go:generate counterfeiter . LockFactory
 
 type LockFactory interface {
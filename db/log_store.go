@@ -0,0 +1,75 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
+)
+
+//go:generate counterfeiter . LogStore
+
+// LogStore archives the events of finished builds outside of the database.
+// Once a build's events are archived they are removed from the database and
+// read back from the store.
+type LogStore interface {
+	// Archive stores every event read from the source, until it ends with
+	// ErrEndOfBuildEventStream.
+	Archive(buildID int, events EventSource) error
+
+	// Events reads a build's archived events, starting from the given offset.
+	Events(buildID int, from uint) (EventSource, error)
+}
+
+// rowsEventSource reads the events of a finished build from the database.
+type rowsEventSource struct {
+	rows *sql.Rows
+}
+
+func (source rowsEventSource) Next() (event.Envelope, error) {
+	if !source.rows.Next() {
+		err := source.rows.Err()
+		if err != nil {
+			return event.Envelope{}, err
+		}
+
+		return event.Envelope{}, ErrEndOfBuildEventStream
+	}
+
+	var t, v, p string
+	err := source.rows.Scan(&t, &v, &p)
+	if err != nil {
+		return event.Envelope{}, err
+	}
+
+	data := json.RawMessage(p)
+
+	return event.Envelope{
+		Data:    &data,
+		Event:   atc.EventType(t),
+		Version: atc.EventVersion(v),
+	}, nil
+}
+
+func (source rowsEventSource) Close() error {
+	return source.rows.Close()
+}
+
+func archiveBuildEvents(conn Conn, store LogStore, buildID int) error {
+	rows, err := conn.Query(`
+		SELECT type, version, payload
+		FROM build_events
+		WHERE build_id = $1
+		ORDER BY event_id ASC
+	`, buildID)
+	if err != nil {
+		return err
+	}
+
+	source := rowsEventSource{rows: rows}
+
+	defer source.Close()
+
+	return store.Archive(buildID, source)
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddResourceChecks(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE resource_checks (
+			id serial PRIMARY KEY,
+			resource_id integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+			start_time timestamp with time zone NOT NULL,
+			end_time timestamp with time zone NOT NULL,
+			versions_found integer NOT NULL DEFAULT 0,
+			exit_status integer NOT NULL DEFAULT 0,
+			check_error text,
+			stderr text
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX resource_checks_resource_id_id_idx ON resource_checks (resource_id, id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddJobPriorities(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN default_job_priority integer NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN create_time timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	// the best guess for existing builds; those not yet started are left
+	// without a create time rather than reporting a bogus queue time
+	_, err = tx.Exec(`
+		UPDATE builds
+		SET create_time = start_time
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		ALTER COLUMN create_time SET DEFAULT now()
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
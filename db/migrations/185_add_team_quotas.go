@@ -0,0 +1,23 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddTeamQuotas(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN quota text
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE jobs
+		ADD COLUMN team_quota_reached bool NOT NULL DEFAULT false
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
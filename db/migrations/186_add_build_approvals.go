@@ -0,0 +1,19 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildApprovals(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN approval_status text,
+		ADD COLUMN approvers text,
+		ADD COLUMN approver text,
+		ADD COLUMN approval_comment text,
+		ADD COLUMN approval_deadline timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
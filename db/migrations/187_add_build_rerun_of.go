@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildRerunOf(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN rerun_of integer REFERENCES builds (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
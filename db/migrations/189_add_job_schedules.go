@@ -0,0 +1,23 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddJobSchedules(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE jobs
+		ADD COLUMN last_schedule_time timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN schedule_time timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
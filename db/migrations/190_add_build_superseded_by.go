@@ -0,0 +1,25 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildSupersededBy(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN superseded_by integer REFERENCES builds (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	// rows are only replaced when the input's version changes, so this tells
+	// whether the mapping changed since a pending build was created
+	_, err = tx.Exec(`
+		ALTER TABLE next_build_inputs
+		ADD COLUMN create_time timestamp with time zone NOT NULL DEFAULT now()
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddJobTriggerOn(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE jobs
+		ADD COLUMN trigger_on_since timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN triggered_by integer REFERENCES builds (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	// an upstream build triggers at most one build of each job
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX builds_job_id_triggered_by ON builds (job_id, triggered_by)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddTaskResults(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE task_results (
+			id serial PRIMARY KEY,
+			job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+			step_name text NOT NULL,
+			cache_key text NOT NULL,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// only the latest result of each task step is kept
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX task_results_job_id_step_name ON task_results (job_id, step_name)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX task_results_build_id ON task_results (build_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
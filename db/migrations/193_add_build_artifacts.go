@@ -0,0 +1,92 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildArtifacts(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_artifacts (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			name text NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX build_artifacts_build_id_name ON build_artifacts (build_id, name)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX build_artifacts_created_at ON build_artifacts (created_at)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+		ADD COLUMN build_artifact_id integer REFERENCES build_artifacts (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX volumes_build_artifact_id ON volumes (build_artifact_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+		DROP CONSTRAINT cannot_invalidate_during_initialization
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+    ALTER TABLE volumes
+    ADD CONSTRAINT cannot_invalidate_during_initialization CHECK (
+    (
+      state IN ('created', 'destroying', 'failed') AND (
+      (
+        worker_resource_cache_id IS NULL
+      ) AND (
+        worker_base_resource_type_id IS NULL
+      ) AND (
+        worker_task_cache_id IS NULL
+      ) AND (
+        build_artifact_id IS NULL
+      ) AND (
+        container_id IS NULL
+      )
+    )
+      ) OR (
+        (
+          worker_resource_cache_id IS NOT NULL
+        ) OR (
+          worker_base_resource_type_id IS NOT NULL
+        ) OR (
+          worker_task_cache_id IS NOT NULL
+        ) OR (
+          build_artifact_id IS NOT NULL
+        ) OR (
+          container_id IS NOT NULL
+        )
+      )
+    )
+  `)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildTestReports(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_test_reports (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			step_name text NOT NULL,
+			total integer NOT NULL,
+			passed integer NOT NULL,
+			failed integer NOT NULL,
+			skipped integer NOT NULL,
+			failures text NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX build_test_reports_build_id_step_name ON build_test_reports (build_id, step_name)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildMetadata(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN metadata text
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildLogsArchived(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN logs_archived bool NOT NULL DEFAULT false
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
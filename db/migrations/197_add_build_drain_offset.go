@@ -0,0 +1,34 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildDrainOffset(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN drain_offset integer NOT NULL DEFAULT 0,
+		ADD COLUMN drained bool NOT NULL DEFAULT false
+	`)
+	if err != nil {
+		return err
+	}
+
+	// builds which finished before the drainer existed are not drained
+	// retroactively
+	_, err = tx.Exec(`
+		UPDATE builds
+		SET drained = true
+		WHERE completed
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX builds_undrained_idx ON builds (id) WHERE NOT drained
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildContainerHolds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN hold_containers_on_failure integer NOT NULL DEFAULT 0,
+		ADD COLUMN hold_containers_until timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
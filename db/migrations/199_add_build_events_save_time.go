@@ -0,0 +1,25 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildEventsSaveTime(tx migration.LimitedTx) error {
+	// existing events are left without a save time rather than given the
+	// time of the migration
+	_, err := tx.Exec(`
+		ALTER TABLE build_events
+		ADD COLUMN save_time timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE build_events
+		ALTER COLUMN save_time SET DEFAULT now()
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddBuildTestReportPasses(tx migration.LimitedTx) error {
+	// the tests which passed in existing reports are unknown
+	_, err := tx.Exec(`
+		ALTER TABLE build_test_reports
+		ADD COLUMN passes text NOT NULL DEFAULT '[]'
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/db/migration"
+
+func AddPgTrgmExtension(tx migration.LimitedTx) error {
+	// the build log search indexes match the output of builds by trigrams
+	_, err := tx.Exec(`
+		CREATE EXTENSION IF NOT EXISTS pg_trgm
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -198,5 +198,24 @@ func New(strategy EncryptionStrategy) []migration.Migrator {
 		AddUniqueIndexesToMaterializedViews,
 		AddFailedStateToVolumes,
 		UseMd5ForResourceCacheVersions,
+		AddResourceChecks,
+		AddJobPriorities,
+		AddTeamQuotas,
+		AddBuildApprovals,
+		AddBuildRerunOf,
+		AddBuildInputOverrides,
+		AddJobSchedules,
+		AddBuildSupersededBy,
+		AddJobTriggerOn,
+		AddTaskResults,
+		AddBuildArtifacts,
+		AddBuildTestReports,
+		AddBuildMetadata,
+		AddBuildLogsArchived,
+		AddBuildDrainOffset,
+		AddBuildContainerHolds,
+		AddBuildEventsSaveTime,
+		AddBuildTestReportPasses,
+		AddPgTrgmExtension,
 	}
 }
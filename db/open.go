@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/migration"
 	"github.com/concourse/atc/db/migrations"
 	multierror "github.com/hashicorp/go-multierror"
@@ -22,6 +23,8 @@ import (
 type Conn interface {
 	Bus() NotificationsBus
 	EncryptionStrategy() EncryptionStrategy
+	LogStore() LogStore
+	BaseResourceTypeDefaults() map[string]atc.Source
 
 	Begin() (Tx, error)
 	Driver() driver.Driver
@@ -49,7 +52,7 @@ type Tx interface {
 	Stmt(stmt *sql.Stmt) *sql.Stmt
 }
 
-func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *EncryptionKey, oldKey *EncryptionKey) (Conn, error) {
+func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *EncryptionKey, oldKey *EncryptionKey, logStore LogStore, baseResourceTypeDefaults map[string]atc.Source) (Conn, error) {
 	for {
 		var strategy EncryptionStrategy
 		if newKey != nil {
@@ -91,8 +94,10 @@ func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *E
 		return &db{
 			DB: sqlDb,
 
-			bus:        NewNotificationsBus(listener, sqlDb),
-			encryption: strategy,
+			bus:                      NewNotificationsBus(listener, sqlDb),
+			encryption:               strategy,
+			logStore:                 logStore,
+			baseResourceTypeDefaults: baseResourceTypeDefaults,
 		}, nil
 	}
 }
@@ -312,8 +317,10 @@ func encryptWithNewKey(logger lager.Logger, sqlDB *sql.DB, newKey *EncryptionKey
 type db struct {
 	*sql.DB
 
-	bus        NotificationsBus
-	encryption EncryptionStrategy
+	bus                      NotificationsBus
+	encryption               EncryptionStrategy
+	logStore                 LogStore
+	baseResourceTypeDefaults map[string]atc.Source
 }
 
 func (db *db) Bus() NotificationsBus {
@@ -324,6 +331,18 @@ func (db *db) EncryptionStrategy() EncryptionStrategy {
 	return db.encryption
 }
 
+// LogStore returns the store to which the events of finished builds are
+// archived, or nil if they are kept in the database.
+func (db *db) LogStore() LogStore {
+	return db.logStore
+}
+
+// BaseResourceTypeDefaults returns the source defaults configured for
+// resources of each base resource type.
+func (db *db) BaseResourceTypeDefaults() map[string]atc.Source {
+	return db.baseResourceTypeDefaults
+}
+
 func (db *db) Close() error {
 	var errs error
 	dbErr := db.DB.Close()
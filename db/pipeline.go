@@ -41,6 +41,8 @@ type Pipeline interface {
 	Reload() (bool, error)
 
 	SetResourceCheckError(Resource, error) error
+	SaveResourceCheck(Resource, ResourceCheck) error
+	GetResourceChecks(resourceName string, page Page) ([]ResourceCheck, Pagination, bool, error)
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
 	GetResourceVersions(resourceName string, page Page) ([]SavedVersionedResource, Pagination, bool, error)
 
@@ -48,12 +50,17 @@ type Pipeline interface {
 
 	GetLatestVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
 	GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error)
+	GetVersionedResource(versionedResourceID int) (SavedVersionedResource, bool, error)
 
 	DisableVersionedResource(versionedResourceID int) error
 	EnableVersionedResource(versionedResourceID int) error
 	GetBuildsWithVersionAsInput(versionedResourceID int) ([]Build, error)
 	GetBuildsWithVersionAsOutput(versionedResourceID int) ([]Build, error)
 
+	// DeleteBuildEventsByBuildIDs removes the builds' events from the database,
+	// archiving them first if the connection has a LogStore. Builds which fail
+	// to archive keep their events, and the first failure is returned once the
+	// others are done.
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
 	// Needs test (from db/lock_test.go)
@@ -109,8 +116,20 @@ type pipeline struct {
 	paused        bool
 	public        bool
 
-	cachedAt   time.Time
-	versionsDB *algorithm.VersionsDB
+	cachedState         versionsDBState
+	cachedConfigVersion ConfigVersion
+	fullyLoadedAt       time.Time
+	versionsDB          *algorithm.VersionsDB
+
+	// passed constraints on jobs of other pipelines, as of the cached config
+	otherPipelinesPassed []string
+
+	// outputs of the other pipelines' jobs, as matched against versionsDB when
+	// those jobs had the given succeeded builds
+	otherOutputs         []algorithm.BuildOutput
+	otherOutputsDB       *algorithm.VersionsDB
+	otherOutputsBuilds   otherPipelinesBuilds
+	otherOutputsCachedAt time.Time
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -278,6 +297,124 @@ func (p *pipeline) SetResourceCheckError(resource Resource, cause error) error {
 	return err
 }
 
+func (p *pipeline) SaveResourceCheck(resource Resource, check ResourceCheck) error {
+	var checkError, stderr sql.NullString
+	if check.CheckError != "" {
+		checkError = sql.NullString{String: check.CheckError, Valid: true}
+	}
+
+	if check.Stderr != "" {
+		stderr = sql.NullString{String: truncateStderr(check.Stderr), Valid: true}
+	}
+
+	_, err := psql.Insert("resource_checks").
+		Columns("resource_id", "start_time", "end_time", "versions_found", "exit_status", "check_error", "stderr").
+		Values(resource.ID(), check.StartTime, check.EndTime, check.VersionsFound, check.ExitStatus, checkError, stderr).
+		RunWith(p.conn).
+		Exec()
+
+	return err
+}
+
+func (p *pipeline) GetResourceChecks(resourceName string, page Page) ([]ResourceCheck, Pagination, bool, error) {
+	var resourceID int
+	err := psql.Select("id").
+		From("resources").
+		Where(sq.Eq{
+			"name":        resourceName,
+			"pipeline_id": p.id,
+			"active":      true,
+		}).RunWith(p.conn).QueryRow().Scan(&resourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, Pagination{}, false, nil
+		}
+
+		return nil, Pagination{}, false, err
+	}
+
+	query := psql.Select("id, start_time, end_time, versions_found, exit_status, check_error, stderr").
+		From("resource_checks").
+		Where(sq.Eq{"resource_id": resourceID})
+
+	var reverse bool
+	if page.Since == 0 && page.Until == 0 {
+		query = query.OrderBy("id DESC").Limit(uint64(page.Limit))
+	} else if page.Until != 0 {
+		query = query.Where(sq.Gt{"id": page.Until}).OrderBy("id ASC").Limit(uint64(page.Limit))
+		reverse = true
+	} else {
+		query = query.Where(sq.Lt{"id": page.Since}).OrderBy("id DESC").Limit(uint64(page.Limit))
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	defer rows.Close()
+
+	checks := []ResourceCheck{}
+	for rows.Next() {
+		var (
+			check              ResourceCheck
+			checkError, stderr sql.NullString
+		)
+
+		err = rows.Scan(&check.ID, &check.StartTime, &check.EndTime, &check.VersionsFound, &check.ExitStatus, &checkError, &stderr)
+		if err != nil {
+			return nil, Pagination{}, false, err
+		}
+
+		check.CheckError = checkError.String
+		check.Stderr = stderr.String
+
+		checks = append(checks, check)
+	}
+
+	if reverse {
+		for i, j := 0, len(checks)-1; i < j; i, j = i+1, j-1 {
+			checks[i], checks[j] = checks[j], checks[i]
+		}
+	}
+
+	if len(checks) == 0 {
+		return checks, Pagination{}, true, nil
+	}
+
+	var minID, maxID int
+	err = psql.Select("COALESCE(MAX(id), 0)", "COALESCE(MIN(id), 0)").
+		From("resource_checks").
+		Where(sq.Eq{"resource_id": resourceID}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&maxID, &minID)
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	first := checks[0]
+	last := checks[len(checks)-1]
+
+	var pagination Pagination
+
+	if first.ID < maxID {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	if last.ID > minID {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return checks, pagination, true, nil
+}
+
 func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
 	builds := map[string][]Build{}
 
@@ -605,6 +742,42 @@ func (p *pipeline) GetVersionedResourceByVersion(atcVersion atc.Version, resourc
 	return svr, true, nil
 }
 
+func (p *pipeline) GetVersionedResource(versionedResourceID int) (SavedVersionedResource, bool, error) {
+	var versionBytes, metadataBytes string
+
+	svr := SavedVersionedResource{}
+
+	err := psql.Select("v.id", "v.enabled", "r.name", "v.type", "v.version", "v.metadata", "v.check_order").
+		From("versioned_resources v").
+		Join("resources r ON r.id = v.resource_id").
+		Where(sq.Eq{
+			"v.id":          versionedResourceID,
+			"r.pipeline_id": p.id,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&svr.ID, &svr.Enabled, &svr.Resource, &svr.Type, &versionBytes, &metadataBytes, &svr.CheckOrder)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedVersionedResource{}, false, nil
+		}
+
+		return SavedVersionedResource{}, false, err
+	}
+
+	err = json.Unmarshal([]byte(versionBytes), &svr.Version)
+	if err != nil {
+		return SavedVersionedResource{}, false, err
+	}
+
+	err = json.Unmarshal([]byte(metadataBytes), &svr.Metadata)
+	if err != nil {
+		return SavedVersionedResource{}, false, err
+	}
+
+	return svr, true, nil
+}
+
 func (p *pipeline) DisableVersionedResource(versionedResourceID int) error {
 	return p.toggleVersionedResource(versionedResourceID, false)
 }
@@ -682,6 +855,13 @@ func (p *pipeline) Resource(name string) (Resource, bool, error) {
 		return nil, false, err
 	}
 
+	resourceTypes, err := p.ResourceTypes()
+	if err != nil {
+		return nil, false, err
+	}
+
+	resource.applyDefaults(resourceTypes.Configs())
+
 	return resource, true, nil
 
 }
@@ -705,29 +885,21 @@ func (p *pipeline) Resources() (Resources, error) {
 		resources = append(resources, newResource)
 	}
 
-	return resources, nil
-}
-
-func (p *pipeline) ResourceTypes() (ResourceTypes, error) {
-	rows, err := resourceTypesQuery.Where(sq.Eq{"pipeline_id": p.id}).RunWith(p.conn).Query()
+	resourceTypes, err := p.ResourceTypes()
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	resourceTypes := []ResourceType{}
 
-	for rows.Next() {
-		resourceType := &resourceType{conn: p.conn}
-		err := scanResourceType(resourceType, rows)
-		if err != nil {
-			return nil, err
-		}
-
-		resourceTypes = append(resourceTypes, resourceType)
+	resourceTypeConfigs := resourceTypes.Configs()
+	for _, r := range resources {
+		r.(*resource).applyDefaults(resourceTypeConfigs)
 	}
 
-	return resourceTypes, nil
+	return resources, nil
+}
+
+func (p *pipeline) ResourceTypes() (ResourceTypes, error) {
+	return pipelineResourceTypes(p.conn, p.id)
 }
 
 func (p *pipeline) ResourceType(name string) (ResourceType, bool, error) {
@@ -897,16 +1069,86 @@ func (p *pipeline) Expose() error {
 	return err
 }
 
+// Rename renames the pipeline, carrying its team's pipeline quota over to the
+// new name.
 func (p *pipeline) Rename(name string) error {
-	_, err := psql.Update("pipelines").
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var oldName string
+	err = psql.Select("name").
+		From("pipelines").
+		Where(sq.Eq{"id": p.id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&oldName)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("pipelines").
 		Set("name", name).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
-		RunWith(p.conn).
+		RunWith(tx).
 		Exec()
+	if err != nil {
+		return err
+	}
 
-	return err
+	var quotaJSON sql.NullString
+	err = psql.Select("quota").
+		From("teams").
+		Where(sq.Eq{"id": p.teamID}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&quotaJSON)
+	if err != nil {
+		return err
+	}
+
+	if quotaJSON.Valid {
+		var quota atc.TeamQuota
+		err = json.Unmarshal([]byte(quotaJSON.String), &quota)
+		if err != nil {
+			return err
+		}
+
+		if pipelineQuota, found := quota.Pipelines[oldName]; found {
+			delete(quota.Pipelines, oldName)
+			quota.Pipelines[name] = pipelineQuota
+
+			newQuotaJSON, err := json.Marshal(quota)
+			if err != nil {
+				return err
+			}
+
+			_, err = psql.Update("teams").
+				Set("quota", string(newQuotaJSON)).
+				Where(sq.Eq{"id": p.teamID}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	p.name = name
+
+	return nil
 }
 
 func (p *pipeline) Destroy() error {
@@ -934,25 +1176,464 @@ func (p *pipeline) Destroy() error {
 	return tx.Commit()
 }
 
+// versionsDBCacheOverlap is how far before the last seen modification an
+// incremental update of the versions DB looks for changes. Rows are stamped
+// with the time their transaction began, so a slow transaction can commit
+// rows which are older than ones that were already loaded.
+const versionsDBCacheOverlap = time.Minute
+
+// versionsDBFullReloadInterval is how often the versions DB is loaded from
+// scratch regardless of what changed, as incremental updates can't tell
+// every late commit or deletion.
+const versionsDBFullReloadInterval = 5 * time.Minute
+
+// versionsDBState tells whether the rows making up the versions DB changed:
+// the row counts catch rows which were deleted or were committed with an
+// older modification time than the latest one.
+type versionsDBState struct {
+	latestModifiedTime time.Time
+	versions           int
+	buildInputs        int
+	buildOutputs       int
+}
+
+func (state versionsDBState) equal(other versionsDBState) bool {
+	return state.latestModifiedTime.Equal(other.latestModifiedTime) &&
+		state.versions == other.versions &&
+		state.buildInputs == other.buildInputs &&
+		state.buildOutputs == other.buildOutputs
+}
+
+// updatableFrom returns whether the versions DB in the given state can be
+// updated incrementally to this state, i.e. rows were only added or modified
+// since and at least one of them moved the latest modification time.
+func (state versionsDBState) updatableFrom(cached versionsDBState) bool {
+	return state.latestModifiedTime.After(cached.latestModifiedTime) &&
+		state.versions >= cached.versions &&
+		state.buildInputs >= cached.buildInputs &&
+		state.buildOutputs >= cached.buildOutputs
+}
+
+// otherPipelinesOutputsRefreshInterval is how long the outputs of other
+// pipelines' jobs are reused while none of their builds succeeded. Versions
+// being disabled in the other pipelines are only noticed when they are
+// reloaded.
+const otherPipelinesOutputsRefreshInterval = time.Minute
+
+// otherPipelinesBuilds summarizes the succeeded builds of the other
+// pipelines' jobs, to tell whether their outputs may have changed.
+type otherPipelinesBuilds struct {
+	count  int
+	latest int
+}
+
 func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	latestModifiedTime, err := p.getLatestModifiedTime()
+	db, err := p.loadOwnVersionsDB()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p.otherPipelinesPassed) == 0 {
+		return db, nil
+	}
+
+	// builds of other pipelines are kept apart so that scheduling reacts to
+	// them finishing without their changes invalidating the cache
+	return p.withOtherPipelinesBuildOutputs(db)
+}
+
+func (p *pipeline) loadOwnVersionsDB() (*algorithm.VersionsDB, error) {
+	state, err := p.getVersionsDBState()
+	if err != nil {
+		return nil, err
+	}
+
+	configVersion, err := p.getLatestConfigVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.versionsDB != nil &&
+		p.cachedConfigVersion == configVersion &&
+		time.Since(p.fullyLoadedAt) < versionsDBFullReloadInterval {
+		if state.equal(p.cachedState) {
+			return p.versionsDB, nil
+		}
+
+		if state.updatableFrom(p.cachedState) {
+			db, err := p.updateVersionsDB(p.versionsDB, p.cachedState.latestModifiedTime.Add(-versionsDBCacheOverlap))
+			if err != nil {
+				return nil, err
+			}
+
+			p.versionsDB = db
+			p.cachedState = state
+
+			return db, nil
+		}
+	}
+
+	db := &algorithm.VersionsDB{
+		JobIDs:      map[string]int{},
+		ResourceIDs: map[string]int{},
+	}
+
+	jobs, err := p.Jobs()
+	if err != nil {
+		return nil, err
+	}
+
+	ownJobNames := map[string]bool{}
+	for _, job := range jobs {
+		ownJobNames[job.Name()] = true
+	}
+
+	// jobs of pipelines set before job names were validated may contain the
+	// separator, in which case they take precedence over other pipelines
+	otherPipelinesPassed := []string{}
+	for _, job := range jobs {
+		for _, input := range job.Config().Inputs() {
+			for _, passed := range append(append([]string{}, input.Passed...), input.PassedAny...) {
+				if ownJobNames[passed] {
+					continue
+				}
+
+				pipelineName, _ := atc.SplitPassedJob(passed)
+				if pipelineName != "" {
+					otherPipelinesPassed = append(otherPipelinesPassed, passed)
+				}
+			}
+		}
+	}
+
+	db.BuildOutputs, err = p.loadBuildOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	db.BuildInputs, err = p.loadBuildInputs()
+	if err != nil {
+		return nil, err
+	}
+
+	db.ResourceVersions, err = p.loadResourceVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := psql.Select("j.name, j.id").
+		From("jobs j").
+		Where(sq.Eq{"j.pipeline_id": p.id}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var id int
+		err := rows.Scan(&name, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		db.JobIDs[name] = id
+	}
+
+	rows, err = psql.Select("r.name, r.id").
+		From("resources r").
+		Where(sq.Eq{"r.pipeline_id": p.id}).
+		RunWith(p.conn).
+		Query()
 	if err != nil {
 		return nil, err
 	}
 
-	if p.versionsDB != nil && p.cachedAt.Equal(latestModifiedTime) {
-		return p.versionsDB, nil
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var id int
+		err := rows.Scan(&name, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		db.ResourceIDs[name] = id
+	}
+
+	p.versionsDB = db
+	p.cachedState = state
+	p.cachedConfigVersion = configVersion
+	p.fullyLoadedAt = time.Now()
+	p.otherPipelinesPassed = otherPipelinesPassed
+
+	return db, nil
+}
+
+// withOtherPipelinesBuildOutputs returns a copy of the given versions DB which
+// also contains the outputs of the other pipelines' jobs referenced by passed
+// constraints, keyed by "pipeline/job". Versions of other pipelines are
+// matched to this pipeline's versions of the same version of a resource with
+// the same config, i.e. the same type and source.
+func (p *pipeline) withOtherPipelinesBuildOutputs(cached *algorithm.VersionsDB) (*algorithm.VersionsDB, error) {
+	jobConditions := sq.Or{}
+	for _, passed := range p.otherPipelinesPassed {
+		pipelineName, jobName := atc.SplitPassedJob(passed)
+		jobConditions = append(jobConditions, sq.Eq{
+			"p.name": pipelineName,
+			"j.name": jobName,
+		})
+	}
+
+	rows, err := psql.Select("p.name, j.name, j.id").
+		From("jobs j, pipelines p").
+		Where(sq.Expr("p.id = j.pipeline_id")).
+		Where(sq.Eq{
+			"p.team_id": p.teamID,
+			"j.active":  true,
+		}).
+		Where(jobConditions).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
 	}
 
+	defer rows.Close()
+
 	db := &algorithm.VersionsDB{
-		BuildOutputs:     []algorithm.BuildOutput{},
-		BuildInputs:      []algorithm.BuildInput{},
-		ResourceVersions: []algorithm.ResourceVersion{},
+		ResourceVersions: cached.ResourceVersions,
+		BuildOutputs:     make([]algorithm.BuildOutput, len(cached.BuildOutputs)),
+		BuildInputs:      cached.BuildInputs,
 		JobIDs:           map[string]int{},
-		ResourceIDs:      map[string]int{},
+		ResourceIDs:      cached.ResourceIDs,
+	}
+
+	copy(db.BuildOutputs, cached.BuildOutputs)
+
+	for name, id := range cached.JobIDs {
+		db.JobIDs[name] = id
+	}
+
+	jobIDs := []int{}
+	for rows.Next() {
+		var pipelineName, jobName string
+		var id int
+		err := rows.Scan(&pipelineName, &jobName, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		db.JobIDs[pipelineName+atc.PassedJobSeparator+jobName] = id
+		jobIDs = append(jobIDs, id)
+	}
+
+	if len(jobIDs) == 0 {
+		return db, nil
+	}
+
+	var builds otherPipelinesBuilds
+	err = psql.Select("count(*), COALESCE(max(id), 0)").
+		From("builds").
+		Where(sq.Eq{
+			"status": BuildStatusSucceeded,
+			"job_id": jobIDs,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&builds.count, &builds.latest)
+	if err != nil {
+		return nil, err
+	}
+
+	// matching the outputs is expensive, so it is only done again when this
+	// pipeline's versions or the other pipelines' succeeded builds changed
+	if p.otherOutputsDB != cached ||
+		p.otherOutputsBuilds != builds ||
+		time.Since(p.otherOutputsCachedAt) >= otherPipelinesOutputsRefreshInterval {
+		outputs, err := p.loadOtherPipelinesBuildOutputs(jobIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		p.otherOutputs = outputs
+		p.otherOutputsDB = cached
+		p.otherOutputsBuilds = builds
+		p.otherOutputsCachedAt = time.Now()
+	}
+
+	db.BuildOutputs = append(db.BuildOutputs, p.otherOutputs...)
+
+	return db, nil
+}
+
+func (p *pipeline) loadOtherPipelinesBuildOutputs(jobIDs []int) ([]algorithm.BuildOutput, error) {
+	rows, err := psql.Select("dv.id, dv.check_order, dr.id, o.build_id, b.job_id").
+		From("build_outputs o, builds b, versioned_resources v, resources r, versioned_resources dv, resources dr").
+		Where(sq.Expr("v.id = o.versioned_resource_id")).
+		Where(sq.Expr("b.id = o.build_id")).
+		Where(sq.Expr("r.id = v.resource_id")).
+		Where(sq.Expr("dr.id = dv.resource_id")).
+		Where(sq.Expr("dr.resource_config_id = r.resource_config_id")).
+		Where(sq.Expr("dv.type = v.type")).
+		Where(sq.Expr("md5(dv.version) = md5(v.version)")).
+		Where(sq.Eq{
+			"v.enabled":      true,
+			"dv.enabled":     true,
+			"b.status":       BuildStatusSucceeded,
+			"b.job_id":       jobIDs,
+			"dr.pipeline_id": p.id,
+		}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	outputs := []algorithm.BuildOutput{}
+	for rows.Next() {
+		var output algorithm.BuildOutput
+		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
+// updateVersionsDB returns a copy of the given versions DB with everything
+// modified after the given time reloaded. Versions which changed are
+// reloaded along with all their build inputs and outputs, and builds whose
+// inputs or outputs changed are reloaded as a whole. The given versions DB
+// is left untouched as it may still be in use.
+func (p *pipeline) updateVersionsDB(cached *algorithm.VersionsDB, since time.Time) (*algorithm.VersionsDB, error) {
+	changedVersionIDs, err := p.queryIDs(
+		psql.Select("v.id").
+			From("versioned_resources v, resources r").
+			Where(sq.Expr("r.id = v.resource_id")).
+			Where(sq.Eq{"r.pipeline_id": p.id}).
+			Where(sq.Expr("v.modified_time > ?::timestamp", since)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	changedOutputBuildIDs, err := p.queryIDs(
+		psql.Select("DISTINCT o.build_id").
+			From("build_outputs o, versioned_resources v, resources r").
+			Where(sq.Expr("v.id = o.versioned_resource_id")).
+			Where(sq.Expr("r.id = v.resource_id")).
+			Where(sq.Eq{"r.pipeline_id": p.id}).
+			Where(sq.Expr("o.modified_time > ?::timestamp", since)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	changedInputBuildIDs, err := p.queryIDs(
+		psql.Select("DISTINCT i.build_id").
+			From("build_inputs i, versioned_resources v, resources r").
+			Where(sq.Expr("v.id = i.versioned_resource_id")).
+			Where(sq.Expr("r.id = v.resource_id")).
+			Where(sq.Eq{"r.pipeline_id": p.id}).
+			Where(sq.Expr("i.modified_time > ?::timestamp", since)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &algorithm.VersionsDB{
+		JobIDs:      cached.JobIDs,
+		ResourceIDs: cached.ResourceIDs,
+	}
+
+	for _, version := range cached.ResourceVersions {
+		if !changedVersionIDs[version.VersionID] {
+			db.ResourceVersions = append(db.ResourceVersions, version)
+		}
+	}
+
+	for _, output := range cached.BuildOutputs {
+		if !changedVersionIDs[output.VersionID] && !changedOutputBuildIDs[output.BuildID] {
+			db.BuildOutputs = append(db.BuildOutputs, output)
+		}
+	}
+
+	for _, input := range cached.BuildInputs {
+		if !changedVersionIDs[input.VersionID] && !changedInputBuildIDs[input.BuildID] {
+			db.BuildInputs = append(db.BuildInputs, input)
+		}
+	}
+
+	if len(changedVersionIDs) > 0 {
+		versions, err := p.loadResourceVersions(sq.Eq{"v.id": idList(changedVersionIDs)})
+		if err != nil {
+			return nil, err
+		}
+
+		db.ResourceVersions = append(db.ResourceVersions, versions...)
 	}
 
-	rows, err := psql.Select("v.id, v.check_order, r.id, o.build_id, b.job_id").
+	outputConditions := sq.Or{}
+	if len(changedVersionIDs) > 0 {
+		outputConditions = append(outputConditions, sq.Eq{"v.id": idList(changedVersionIDs)})
+	}
+	if len(changedOutputBuildIDs) > 0 {
+		outputConditions = append(outputConditions, sq.Eq{"o.build_id": idList(changedOutputBuildIDs)})
+	}
+
+	if len(outputConditions) > 0 {
+		outputs, err := p.loadBuildOutputs(outputConditions)
+		if err != nil {
+			return nil, err
+		}
+
+		db.BuildOutputs = append(db.BuildOutputs, outputs...)
+	}
+
+	inputConditions := sq.Or{}
+	if len(changedVersionIDs) > 0 {
+		inputConditions = append(inputConditions, sq.Eq{"v.id": idList(changedVersionIDs)})
+	}
+	if len(changedInputBuildIDs) > 0 {
+		inputConditions = append(inputConditions, sq.Eq{"i.build_id": idList(changedInputBuildIDs)})
+	}
+
+	if len(inputConditions) > 0 {
+		inputs, err := p.loadBuildInputs(inputConditions)
+		if err != nil {
+			return nil, err
+		}
+
+		db.BuildInputs = append(db.BuildInputs, inputs...)
+	}
+
+	if db.ResourceVersions == nil {
+		db.ResourceVersions = []algorithm.ResourceVersion{}
+	}
+
+	if db.BuildOutputs == nil {
+		db.BuildOutputs = []algorithm.BuildOutput{}
+	}
+
+	if db.BuildInputs == nil {
+		db.BuildInputs = []algorithm.BuildInput{}
+	}
+
+	return db, nil
+}
+
+func (p *pipeline) loadBuildOutputs(conditions ...sq.Sqlizer) ([]algorithm.BuildOutput, error) {
+	query := psql.Select("v.id, v.check_order, r.id, o.build_id, b.job_id").
 		From("build_outputs o, builds b, versioned_resources v, resources r").
 		Where(sq.Expr("v.id = o.versioned_resource_id")).
 		Where(sq.Expr("b.id = o.build_id")).
@@ -961,15 +1642,21 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 			"v.enabled":     true,
 			"b.status":      BuildStatusSucceeded,
 			"r.pipeline_id": p.id,
-		}).
-		RunWith(p.conn).
-		Query()
+		})
+
+	for _, condition := range conditions {
+		query = query.Where(condition)
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
 	if err != nil {
 		return nil, err
 	}
 
 	defer rows.Close()
 
+	outputs := []algorithm.BuildOutput{}
+
 	for rows.Next() {
 		var output algorithm.BuildOutput
 		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
@@ -979,10 +1666,14 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 
 		output.ResourceVersion.CheckOrder = output.CheckOrder
 
-		db.BuildOutputs = append(db.BuildOutputs, output)
+		outputs = append(outputs, output)
 	}
 
-	rows, err = psql.Select("v.id, v.check_order, r.id, i.build_id, i.name, b.job_id").
+	return outputs, nil
+}
+
+func (p *pipeline) loadBuildInputs(conditions ...sq.Sqlizer) ([]algorithm.BuildInput, error) {
+	query := psql.Select("v.id, v.check_order, r.id, i.build_id, i.name, b.job_id").
 		From("build_inputs i, builds b, versioned_resources v, resources r").
 		Where(sq.Expr("v.id = i.versioned_resource_id")).
 		Where(sq.Expr("b.id = i.build_id")).
@@ -990,15 +1681,21 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		Where(sq.Eq{
 			"v.enabled":     true,
 			"r.pipeline_id": p.id,
-		}).
-		RunWith(p.conn).
-		Query()
+		})
+
+	for _, condition := range conditions {
+		query = query.Where(condition)
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
 	if err != nil {
 		return nil, err
 	}
 
 	defer rows.Close()
 
+	inputs := []algorithm.BuildInput{}
+
 	for rows.Next() {
 		var input algorithm.BuildInput
 		err := rows.Scan(&input.VersionID, &input.CheckOrder, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID)
@@ -1008,60 +1705,129 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 
 		input.ResourceVersion.CheckOrder = input.CheckOrder
 
-		db.BuildInputs = append(db.BuildInputs, input)
+		inputs = append(inputs, input)
 	}
 
-	rows, err = psql.Select("v.id, v.check_order, r.id").
+	return inputs, nil
+}
+
+func (p *pipeline) loadResourceVersions(conditions ...sq.Sqlizer) ([]algorithm.ResourceVersion, error) {
+	query := psql.Select("v.id, v.check_order, r.id").
 		From("versioned_resources v, resources r").
 		Where(sq.Expr("r.id = v.resource_id")).
 		Where(sq.Eq{
 			"v.enabled":     true,
 			"r.pipeline_id": p.id,
-		}).
-		RunWith(p.conn).
-		Query()
+		})
+
+	for _, condition := range conditions {
+		query = query.Where(condition)
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
 	if err != nil {
 		return nil, err
 	}
 
 	defer rows.Close()
 
+	versions := []algorithm.ResourceVersion{}
+
 	for rows.Next() {
-		var output algorithm.ResourceVersion
-		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID)
+		var version algorithm.ResourceVersion
+		err := rows.Scan(&version.VersionID, &version.CheckOrder, &version.ResourceID)
 		if err != nil {
 			return nil, err
 		}
 
-		db.ResourceVersions = append(db.ResourceVersions, output)
+		versions = append(versions, version)
 	}
 
-	rows, err = psql.Select("j.name, j.id").
-		From("jobs j").
-		Where(sq.Eq{"j.pipeline_id": p.id}).
-		RunWith(p.conn).
-		Query()
+	return versions, nil
+}
+
+func (p *pipeline) queryIDs(query sq.SelectBuilder) (map[int]bool, error) {
+	rows, err := query.RunWith(p.conn).Query()
 	if err != nil {
 		return nil, err
 	}
 
 	defer rows.Close()
 
+	ids := map[int]bool{}
+
 	for rows.Next() {
-		var name string
 		var id int
-		err := rows.Scan(&name, &id)
+		err := rows.Scan(&id)
 		if err != nil {
 			return nil, err
 		}
 
-		db.JobIDs[name] = id
+		ids[id] = true
 	}
 
-	rows, err = psql.Select("r.name, r.id").
-		From("resources r").
-		Where(sq.Eq{"r.pipeline_id": p.id}).
-		RunWith(p.conn).
+	return ids, nil
+}
+
+func idList(ids map[int]bool) []int {
+	list := make([]int, 0, len(ids))
+	for id := range ids {
+		list = append(list, id)
+	}
+
+	return list
+}
+
+func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
+	if len(buildIDs) == 0 {
+		return nil
+	}
+
+	store := p.conn.LogStore()
+	if store == nil {
+		return deleteBuildEvents(p.conn, buildIDs, false)
+	}
+
+	archivedBuildIDs, err := archivedBuilds(p.conn, buildIDs)
+	if err != nil {
+		return err
+	}
+
+	// each build is archived and deleted on its own, so that one which fails
+	// to archive keeps its events without holding back the others
+	var archiveErr error
+	for _, buildID := range buildIDs {
+		// archived again, it would be left with no events
+		if archivedBuildIDs[buildID] {
+			continue
+		}
+
+		err := archiveBuildEvents(p.conn, store, buildID)
+		if err != nil {
+			if archiveErr == nil {
+				archiveErr = err
+			}
+
+			continue
+		}
+
+		err = deleteBuildEvents(p.conn, []int{buildID}, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return archiveErr
+}
+
+func archivedBuilds(conn Conn, buildIDs []int) (map[int]bool, error) {
+	rows, err := psql.Select("id").
+		From("builds").
+		Where(sq.Eq{
+			"id":            buildIDs,
+			"logs_archived": true,
+		}).
+		RunWith(conn).
 		Query()
 	if err != nil {
 		return nil, err
@@ -1069,28 +1835,21 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 
 	defer rows.Close()
 
+	archived := map[int]bool{}
 	for rows.Next() {
-		var name string
 		var id int
-		err := rows.Scan(&name, &id)
+		err := rows.Scan(&id)
 		if err != nil {
 			return nil, err
 		}
 
-		db.ResourceIDs[name] = id
+		archived[id] = true
 	}
 
-	p.versionsDB = db
-	p.cachedAt = latestModifiedTime
-
-	return db, nil
+	return archived, rows.Err()
 }
 
-func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
-	if len(buildIDs) == 0 {
-		return nil
-	}
-
+func deleteBuildEvents(conn Conn, buildIDs []int, archived bool) error {
 	interfaceBuildIDs := make([]interface{}, len(buildIDs))
 	for i, buildID := range buildIDs {
 		interfaceBuildIDs[i] = buildID
@@ -1101,7 +1860,7 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 		indexStrings[i] = "$" + strconv.Itoa(i+1)
 	}
 
-	tx, err := p.conn.Begin()
+	tx, err := conn.Begin()
 	if err != nil {
 		return err
 	}
@@ -1125,6 +1884,17 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 		return err
 	}
 
+	if archived {
+		_, err = tx.Exec(`
+			UPDATE builds
+			SET logs_archived = true
+			WHERE id IN (`+strings.Join(indexStrings, ",")+`)
+		`, interfaceBuildIDs...)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = tx.Commit()
 	return err
 }
@@ -1275,8 +2045,8 @@ func (p *pipeline) saveInputTx(tx Tx, buildID int, input BuildInput) error {
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO build_inputs (build_id, versioned_resource_id, name)
-		SELECT $1, $2, $3
+		INSERT INTO build_inputs (build_id, versioned_resource_id, name, overridden)
+		SELECT $1, $2, $3, $4
 		WHERE NOT EXISTS (
 			SELECT 1
 			FROM build_inputs
@@ -1284,7 +2054,7 @@ func (p *pipeline) saveInputTx(tx Tx, buildID int, input BuildInput) error {
 			AND versioned_resource_id = $2
 			AND name = $3
 		)
-	`, buildID, svr.ID, input.Name)
+	`, buildID, svr.ID, input.Name, input.Overridden)
 
 	err = swallowUniqueViolation(err)
 
@@ -1584,8 +2354,8 @@ func (p *pipeline) toggleVersionedResource(versionedResourceID int, enable bool)
 	return nil
 }
 
-func (p *pipeline) getLatestModifiedTime() (time.Time, error) {
-	var max_modified_time time.Time
+func (p *pipeline) getVersionsDBState() (versionsDBState, error) {
+	var state versionsDBState
 
 	err := p.conn.QueryRow(`
 	SELECT
@@ -1593,36 +2363,55 @@ func (p *pipeline) getLatestModifiedTime() (time.Time, error) {
 			WHEN bo_max > vr_max AND bo_max > bi_max THEN bo_max
 			WHEN bi_max > vr_max THEN bi_max
 			ELSE vr_max
-		END
+		END,
+		vr_count,
+		bi_count,
+		bo_count
 	FROM
 		(
-			SELECT COALESCE(MAX(bo.modified_time), 'epoch') as bo_max
+			SELECT COALESCE(MAX(bo.modified_time), 'epoch') as bo_max, COUNT(*) as bo_count
 			FROM build_outputs bo
 			LEFT OUTER JOIN versioned_resources v ON v.id = bo.versioned_resource_id
 			LEFT OUTER JOIN resources r ON r.id = v.resource_id
 			WHERE r.pipeline_id = $1
 		) bo,
 		(
-			SELECT COALESCE(MAX(bi.modified_time), 'epoch') as bi_max
+			SELECT COALESCE(MAX(bi.modified_time), 'epoch') as bi_max, COUNT(*) as bi_count
 			FROM build_inputs bi
 			LEFT OUTER JOIN versioned_resources v ON v.id = bi.versioned_resource_id
 			LEFT OUTER JOIN resources r ON r.id = v.resource_id
 			WHERE r.pipeline_id = $1
 		) bi,
 		(
-			SELECT COALESCE(MAX(vr.modified_time), 'epoch') as vr_max
+			SELECT COALESCE(MAX(vr.modified_time), 'epoch') as vr_max, COUNT(*) as vr_count
 			FROM versioned_resources vr
 			LEFT OUTER JOIN resources r ON r.id = vr.resource_id
 			WHERE r.pipeline_id = $1
 		) vr
-	`, p.id).Scan(&max_modified_time)
+	`, p.id).Scan(&state.latestModifiedTime, &state.versions, &state.buildInputs, &state.buildOutputs)
+
+	return state, err
+}
+
+func (p *pipeline) getLatestConfigVersion() (ConfigVersion, error) {
+	var configVersion ConfigVersion
+
+	err := psql.Select("config_version").
+		From("pipelines").
+		Where(sq.Eq{"id": p.id}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&configVersion)
 
-	return max_modified_time, err
+	return configVersion, err
 }
 
 func (p *pipeline) getBuildsFrom(view string) (map[string]Build, error) {
+	// the views only determine which builds to return; the build itself is
+	// read from the builds table so that columns added after the views were
+	// created are available
 	rows, err := buildsQuery.
-		From(view + " b").
+		JoinClause("INNER JOIN " + view + " v ON v.id = b.id").
 		Where(sq.Eq{"b.pipeline_id": p.id}).
 		RunWith(p.conn).Query()
 	if err != nil {
@@ -62,6 +62,10 @@ type BuildInput struct {
 	VersionedResource
 
 	FirstOccurrence bool
+
+	// Overridden is set for inputs whose version was explicitly chosen when
+	// the build was triggered rather than determined by the scheduler.
+	Overridden bool
 }
 
 type BuildOutput struct {
@@ -3,11 +3,15 @@ package db_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -197,6 +201,33 @@ var _ = Describe("Pipeline", func() {
 			Expect(found).To(BeTrue())
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		Context("when the team has a quota for the pipeline", func() {
+			BeforeEach(func() {
+				err := team.UpdateQuota(atc.TeamQuota{
+					MaxRunningBuilds: 10,
+					Pipelines: map[string]atc.PipelineQuota{
+						pipeline.Name():  {MaxRunningBuilds: 2},
+						"other-pipeline": {MaxRunningBuilds: 3},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("carries the quota over to the new name", func() {
+				reloadedTeam, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(reloadedTeam.Quota()).To(Equal(atc.TeamQuota{
+					MaxRunningBuilds: 10,
+					Pipelines: map[string]atc.PipelineQuota{
+						"oopsies":        {MaxRunningBuilds: 2},
+						"other-pipeline": {MaxRunningBuilds: 3},
+					},
+				}))
+			})
+		})
 	})
 
 	Describe("GetLatestVersionedResource", func() {
@@ -534,6 +565,138 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("SaveResourceCheck/GetResourceChecks", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			var found bool
+			var err error
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the resource does not exist", func() {
+			It("returns false and no error", func() {
+				_, _, found, err := pipeline.GetResourceChecks("nope", db.Page{Limit: 2})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the resource has no checks", func() {
+			It("returns no checks", func() {
+				checks, pagination, found, err := pipeline.GetResourceChecks("some-resource", db.Page{Limit: 2})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(checks).To(BeEmpty())
+				Expect(pagination).To(Equal(db.Pagination{}))
+			})
+		})
+
+		Context("when the resource has been checked", func() {
+			var startTime time.Time
+
+			BeforeEach(func() {
+				startTime = time.Now().Truncate(time.Second)
+
+				for i := 0; i < 5; i++ {
+					err := pipeline.SaveResourceCheck(resource, db.ResourceCheck{
+						StartTime:     startTime.Add(time.Duration(i) * time.Minute),
+						EndTime:       startTime.Add(time.Duration(i)*time.Minute + 5*time.Second),
+						VersionsFound: i,
+					})
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				err := pipeline.SaveResourceCheck(resource, db.ResourceCheck{
+					StartTime:  startTime.Add(10 * time.Minute),
+					EndTime:    startTime.Add(11 * time.Minute),
+					ExitStatus: 1,
+					CheckError: "some-error",
+					Stderr:     "some-stderr",
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the most recent checks first, with a next page", func() {
+				checks, pagination, found, err := pipeline.GetResourceChecks("some-resource", db.Page{Limit: 2})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(checks).To(HaveLen(2))
+
+				Expect(checks[0].StartTime.Equal(startTime.Add(10 * time.Minute))).To(BeTrue())
+				Expect(checks[0].Duration()).To(Equal(time.Minute))
+				Expect(checks[0].ExitStatus).To(Equal(1))
+				Expect(checks[0].CheckError).To(Equal("some-error"))
+				Expect(checks[0].Stderr).To(Equal("some-stderr"))
+
+				Expect(checks[1].VersionsFound).To(Equal(4))
+				Expect(checks[1].Duration()).To(Equal(5 * time.Second))
+				Expect(checks[1].CheckError).To(BeEmpty())
+
+				Expect(pagination.Previous).To(BeNil())
+				Expect(pagination.Next).To(Equal(&db.Page{Since: checks[1].ID, Limit: 2}))
+			})
+
+			It("pages through older checks", func() {
+				firstPage, _, _, err := pipeline.GetResourceChecks("some-resource", db.Page{Limit: 2})
+				Expect(err).ToNot(HaveOccurred())
+
+				checks, pagination, found, err := pipeline.GetResourceChecks("some-resource", db.Page{Since: firstPage[1].ID, Limit: 2})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(checks).To(HaveLen(2))
+				Expect(checks[0].VersionsFound).To(Equal(3))
+				Expect(checks[1].VersionsFound).To(Equal(2))
+
+				Expect(pagination.Previous).To(Equal(&db.Page{Until: checks[0].ID, Limit: 2}))
+				Expect(pagination.Next).To(Equal(&db.Page{Since: checks[1].ID, Limit: 2}))
+
+				newerChecks, _, _, err := pipeline.GetResourceChecks("some-resource", *pagination.Previous)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newerChecks).To(Equal(firstPage))
+			})
+
+			Context("when the stderr is very large", func() {
+				It("keeps only the end of it", func() {
+					stderr := strings.Repeat("a", db.MaxResourceCheckStderrBytes) + "the-end"
+
+					err := pipeline.SaveResourceCheck(resource, db.ResourceCheck{
+						StartTime:  startTime,
+						EndTime:    startTime,
+						ExitStatus: 1,
+						Stderr:     stderr,
+					})
+					Expect(err).ToNot(HaveOccurred())
+
+					checks, _, _, err := pipeline.GetResourceChecks("some-resource", db.Page{Limit: 1})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(checks[0].Stderr).To(HaveLen(db.MaxResourceCheckStderrBytes))
+					Expect(checks[0].Stderr).To(HaveSuffix("the-end"))
+				})
+
+				It("does not split a multi-byte character", func() {
+					// "é" is two bytes, so the cut falls in the middle of one
+					stderr := strings.Repeat("é", db.MaxResourceCheckStderrBytes/2) + "a"
+
+					err := pipeline.SaveResourceCheck(resource, db.ResourceCheck{
+						StartTime:  startTime,
+						EndTime:    startTime,
+						ExitStatus: 1,
+						Stderr:     stderr,
+					})
+					Expect(err).ToNot(HaveOccurred())
+
+					checks, _, _, err := pipeline.GetResourceChecks("some-resource", db.Page{Limit: 1})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(utf8.ValidString(checks[0].Stderr)).To(BeTrue())
+					Expect(len(checks[0].Stderr)).To(BeNumerically("<=", db.MaxResourceCheckStderrBytes))
+				})
+			})
+		})
+	})
+
 	Describe("SaveResourceVersions", func() {
 		var (
 			originalVersionSlice []atc.Version
@@ -740,6 +903,26 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeFalse())
 		})
+
+		It("returns the SavedVersionedResource with the given ID", func() {
+			By("returning versions that exist")
+			actualSavedVersion, found, err := pipeline.GetVersionedResource(savedVersion2.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(actualSavedVersion).To(Equal(savedVersion2))
+
+			By("returning disabled versions")
+			disabledVersion, found, err := pipeline.GetVersionedResource(savedVersion2.ID + 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(disabledVersion.Version).To(Equal(db.ResourceVersion{"version": "v3"}))
+			Expect(disabledVersion.Enabled).To(BeFalse())
+
+			By("returning not found for versions that don't exist")
+			_, found, err = pipeline.GetVersionedResource(savedVersion2.ID + 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
 	})
 
 	Describe("Resource Versions", func() {
@@ -1969,6 +2152,419 @@ var _ = Describe("Pipeline", func() {
 				})
 			})
 		})
+
+		Context("when the cached VersionsDB is updated", func() {
+			var (
+				job       db.Job
+				savedVR1  db.SavedVersionedResource
+				savedVR2  db.SavedVersionedResource
+				initialDB *algorithm.VersionsDB
+			)
+
+			expectSameAsFullLoad := func(versionsDB *algorithm.VersionsDB) {
+				freshPipeline, found, err := team.Pipeline(pipeline.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				fullDB, err := freshPipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				ExpectWithOffset(1, versionsDB.ResourceVersions).To(ConsistOf(fullDB.ResourceVersions))
+				ExpectWithOffset(1, versionsDB.BuildOutputs).To(ConsistOf(fullDB.BuildOutputs))
+				ExpectWithOffset(1, versionsDB.BuildInputs).To(ConsistOf(fullDB.BuildInputs))
+				ExpectWithOffset(1, versionsDB.JobIDs).To(Equal(fullDB.JobIDs))
+				ExpectWithOffset(1, versionsDB.ResourceIDs).To(Equal(fullDB.ResourceIDs))
+			}
+
+			BeforeEach(func() {
+				var found bool
+				var err error
+				job, found, err = pipeline.Job("job-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = pipeline.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				savedVR1, found, err = pipeline.GetLatestVersionedResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveInput(db.BuildInput{
+					Name:              "some-input",
+					VersionedResource: savedVR1.VersionedResource,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveOutput(savedVR1.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				initialDB, err = pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("picks up new versions", func() {
+				err := pipeline.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				savedVR2, _, err = pipeline.GetLatestVersionedResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				savedResource, _, err := pipeline.Resource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.ResourceVersions).To(ContainElement(algorithm.ResourceVersion{
+					VersionID:  savedVR2.ID,
+					ResourceID: savedResource.ID(),
+					CheckOrder: savedVR2.CheckOrder,
+				}))
+
+				expectSameAsFullLoad(versionsDB)
+			})
+
+			It("picks up outputs once their build succeeds", func() {
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveOutput(savedVR1.VersionedResource, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.BuildOutputs).To(HaveLen(1))
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err = pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.BuildOutputs).To(HaveLen(2))
+
+				expectSameAsFullLoad(versionsDB)
+			})
+
+			It("drops everything about disabled versions", func() {
+				err := pipeline.DisableVersionedResource(savedVR1.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.ResourceVersions).To(BeEmpty())
+				Expect(versionsDB.BuildOutputs).To(BeEmpty())
+				Expect(versionsDB.BuildInputs).To(BeEmpty())
+
+				expectSameAsFullLoad(versionsDB)
+			})
+
+			It("replaces the inputs of builds whose inputs changed", func() {
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.UseInputs([]db.BuildInput{
+					{Name: "some-input", VersionedResource: savedVR1.VersionedResource},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.BuildInputs).To(HaveLen(2))
+
+				err = build.UseInputs([]db.BuildInput{
+					{Name: "some-renamed-input", VersionedResource: savedVR1.VersionedResource},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err = pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.BuildInputs).To(HaveLen(2))
+
+				expectSameAsFullLoad(versionsDB)
+			})
+
+			It("drops deleted build inputs", func() {
+				_, err := dbConn.Exec(`DELETE FROM build_inputs WHERE versioned_resource_id = $1`, savedVR1.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.BuildInputs).To(BeEmpty())
+
+				expectSameAsFullLoad(versionsDB)
+			})
+
+			It("picks up rows committed with a modification time older than the cached ones", func() {
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveInput(db.BuildInput{
+					Name:              "some-input",
+					VersionedResource: savedVR1.VersionedResource,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				// as if its transaction began long before it committed
+				_, err = dbConn.Exec(`UPDATE build_inputs SET modified_time = now() - interval '1 hour' WHERE build_id = $1`, build.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.BuildInputs).To(HaveLen(2))
+
+				expectSameAsFullLoad(versionsDB)
+			})
+
+			It("leaves the previously returned VersionsDB untouched", func() {
+				resourceVersions := initialDB.ResourceVersions
+
+				err := pipeline.DisableVersionedResource(savedVR1.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(initialDB.ResourceVersions).To(Equal(resourceVersions))
+				Expect(initialDB.ResourceVersions).To(HaveLen(1))
+			})
+
+			It("reloads everything when the config changes", func() {
+				config := pipelineConfig
+				config.Jobs = append(config.Jobs, atc.JobConfig{Name: "some-new-job"})
+
+				_, _, err := team.SavePipeline(pipeline.Name(), config, pipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB).NotTo(BeIdenticalTo(initialDB))
+				Expect(versionsDB.JobIDs).To(HaveKey("some-new-job"))
+
+				expectSameAsFullLoad(versionsDB)
+			})
+		})
+	})
+
+	Describe("VersionsDB with passed constraints on jobs of other pipelines", func() {
+		var (
+			upstreamPipeline   db.Pipeline
+			downstreamPipeline db.Pipeline
+			upstreamBuild      db.Build
+		)
+
+		saveVersion := func(pipeline db.Pipeline, resourceName string, version atc.Version) db.SavedVersionedResource {
+			err := pipeline.SaveResourceVersions(atc.ResourceConfig{
+				Name:   resourceName,
+				Type:   "some-type",
+				Source: atc.Source{"some": "source"},
+			}, []atc.Version{version})
+			Expect(err).NotTo(HaveOccurred())
+
+			savedVR, found, err := pipeline.GetVersionedResourceByVersion(version, resourceName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			return savedVR
+		}
+
+		// maps the resource to the config of its source, as checking it would
+		setResourceConfig := func(pipeline db.Pipeline, resourceName string, source atc.Source) {
+			session, err := resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(
+				logger,
+				"some-base-resource-type",
+				source,
+				creds.VersionedResourceTypes{},
+				db.ContainerOwnerExpiries{Min: time.Minute, Max: time.Minute},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			resource, found, err := pipeline.Resource(resourceName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = resource.SetResourceConfig(session.ResourceConfig().ID)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			var err error
+			upstreamPipeline, _, err = team.SavePipeline("upstream", atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "upstream-resource", Type: "some-type", Source: atc.Source{"some": "source"}},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "upstream-job",
+						Plan: atc.PlanSequence{{Get: "upstream-resource"}},
+					},
+				},
+			}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			downstreamPipeline, _, err = team.SavePipeline("downstream", atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "downstream-resource", Type: "some-type", Source: atc.Source{"some": "source"}},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "downstream-job",
+						Plan: atc.PlanSequence{
+							{Get: "downstream-resource", Passed: []string{"upstream/upstream-job"}},
+						},
+					},
+				},
+			}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			setResourceConfig(upstreamPipeline, "upstream-resource", atc.Source{"some": "source"})
+			setResourceConfig(downstreamPipeline, "downstream-resource", atc.Source{"some": "source"})
+
+			upstreamJob, found, err := upstreamPipeline.Job("upstream-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			upstreamBuild, err = upstreamJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			upstreamVR := saveVersion(upstreamPipeline, "upstream-resource", atc.Version{"version": "1"})
+			err = upstreamBuild.SaveOutput(upstreamVR.VersionedResource, false)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("includes the other pipeline's job by its qualified name", func() {
+			upstreamJob, _, err := upstreamPipeline.Job("upstream-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			versionsDB, err := downstreamPipeline.LoadVersionsDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versionsDB.JobIDs).To(HaveKeyWithValue("upstream/upstream-job", upstreamJob.ID()))
+		})
+
+		Context("when this pipeline has a job named like the qualified name (set before job names were validated)", func() {
+			BeforeEach(func() {
+				var err error
+				downstreamPipeline, _, err = team.SavePipeline("downstream", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "downstream-resource", Type: "some-type", Source: atc.Source{"some": "source"}},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "upstream/upstream-job",
+							Plan: atc.PlanSequence{{Get: "downstream-resource"}},
+						},
+						{
+							Name: "downstream-job",
+							Plan: atc.PlanSequence{
+								{Get: "downstream-resource", Passed: []string{"upstream/upstream-job"}},
+							},
+						},
+					},
+				}, downstreamPipeline.ConfigVersion(), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("refers to the job of this pipeline", func() {
+				ownJob, found, err := downstreamPipeline.Job("upstream/upstream-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				versionsDB, err := downstreamPipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.JobIDs).To(HaveKeyWithValue("upstream/upstream-job", ownJob.ID()))
+			})
+		})
+
+		It("does not include outputs of builds which have not succeeded", func() {
+			saveVersion(downstreamPipeline, "downstream-resource", atc.Version{"version": "1"})
+
+			versionsDB, err := downstreamPipeline.LoadVersionsDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versionsDB.BuildOutputs).To(BeEmpty())
+		})
+
+		Context("when the upstream build succeeds", func() {
+			BeforeEach(func() {
+				err := upstreamBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("includes its outputs as the matching versions of this pipeline", func() {
+				downstreamVR := saveVersion(downstreamPipeline, "downstream-resource", atc.Version{"version": "1"})
+				saveVersion(downstreamPipeline, "downstream-resource", atc.Version{"version": "2"})
+
+				downstreamResource, _, err := downstreamPipeline.Resource("downstream-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err := downstreamPipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.BuildOutputs).To(Equal([]algorithm.BuildOutput{
+					{
+						ResourceVersion: algorithm.ResourceVersion{
+							VersionID:  downstreamVR.ID,
+							ResourceID: downstreamResource.ID(),
+							CheckOrder: downstreamVR.CheckOrder,
+						},
+						BuildID: upstreamBuild.ID(),
+						JobID:   versionsDB.JobIDs["upstream/upstream-job"],
+					},
+				}))
+			})
+
+			It("does not match versions of resources with another config", func() {
+				setResourceConfig(downstreamPipeline, "downstream-resource", atc.Source{"some": "other-source"})
+				saveVersion(downstreamPipeline, "downstream-resource", atc.Version{"version": "1"})
+
+				versionsDB, err := downstreamPipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.BuildOutputs).To(BeEmpty())
+			})
+
+			It("picks up the outputs even when this pipeline's versions DB is cached", func() {
+				downstreamVR := saveVersion(downstreamPipeline, "downstream-resource", atc.Version{"version": "1"})
+
+				_, err := downstreamPipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamJob, _, err := upstreamPipeline.Job("upstream-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				laterBuild, err := upstreamJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamVR := saveVersion(upstreamPipeline, "upstream-resource", atc.Version{"version": "2"})
+				downstreamVR2 := saveVersion(downstreamPipeline, "downstream-resource", atc.Version{"version": "2"})
+
+				err = laterBuild.SaveOutput(upstreamVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = laterBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				versionsDB, err := downstreamPipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				outputVersions := []int{}
+				for _, output := range versionsDB.BuildOutputs {
+					outputVersions = append(outputVersions, output.VersionID)
+				}
+
+				Expect(outputVersions).To(ConsistOf(downstreamVR.ID, downstreamVR2.ID))
+			})
+		})
 	})
 
 	Describe("Dashboard", func() {
@@ -2321,6 +2917,182 @@ var _ = Describe("Pipeline", func() {
 			// Not required behavior, just a sanity check for what I think will happen
 			Expect(build4DB.ReapTime()).To(Equal(build1DB.ReapTime()))
 		})
+
+		Context("when the connection has a log store", func() {
+			var (
+				fakeLogStore *dbfakes.FakeLogStore
+				archived     map[int][]event.Envelope
+
+				archivingPipeline db.Pipeline
+				archivingTeam     db.Team
+			)
+
+			BeforeEach(func() {
+				fakeLogStore = new(dbfakes.FakeLogStore)
+
+				archived = map[int][]event.Envelope{}
+				fakeLogStore.ArchiveStub = func(buildID int, events db.EventSource) error {
+					for {
+						ev, err := events.Next()
+						if err == db.ErrEndOfBuildEventStream {
+							return nil
+						}
+
+						if err != nil {
+							return err
+						}
+
+						archived[buildID] = append(archived[buildID], ev)
+					}
+				}
+
+				archivingTeamFactory := db.NewTeamFactory(logStoreConn{Conn: dbConn, store: fakeLogStore}, lockFactory)
+
+				var found bool
+				var err error
+				archivingTeam, found, err = archivingTeamFactory.FindTeam(team.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				archivingPipeline, found, err = archivingTeam.Pipeline(pipeline.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("archives the builds' events before deleting them", func() {
+				build, err := archivingTeam.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEvent(event.Log{Payload: "log 1"})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = archivingPipeline.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLogStore.ArchiveCallCount()).To(Equal(1))
+				Expect(archived[build.ID()]).To(HaveLen(2))
+				Expect(archived[build.ID()][0]).To(Equal(envelope(event.Log{Payload: "log 1"})))
+
+				By("reading the events of the reloaded build from the log store")
+				fakeEventSource := new(dbfakes.FakeEventSource)
+				fakeLogStore.EventsReturns(fakeEventSource, nil)
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				events, err := build.Events(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(Equal(fakeEventSource))
+
+				buildID, from := fakeLogStore.EventsArgsForCall(0)
+				Expect(buildID).To(Equal(build.ID()))
+				Expect(from).To(Equal(uint(1)))
+			})
+
+			It("does not archive builds which were already archived again", func() {
+				build, err := archivingTeam.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = archivingPipeline.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = archivingPipeline.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLogStore.ArchiveCallCount()).To(Equal(1))
+			})
+
+			It("fails to read the archived events without a log store", func() {
+				build, err := archivingTeam.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = archivingPipeline.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+				Expect(err).NotTo(HaveOccurred())
+
+				unarchivedBuild, found, err := buildFactory.Build(build.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = unarchivedBuild.Events(0)
+				Expect(err).To(Equal(db.ErrNoLogStore))
+			})
+
+			Context("when archiving fails", func() {
+				BeforeEach(func() {
+					fakeLogStore.ArchiveStub = nil
+					fakeLogStore.ArchiveReturns(errors.New("nope"))
+				})
+
+				It("keeps the builds' events", func() {
+					build, err := archivingTeam.CreateOneOffBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					err = build.SaveEvent(event.Log{Payload: "log 1"})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = build.Finish(db.BuildStatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = archivingPipeline.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+					Expect(err).To(MatchError("nope"))
+
+					events, err := build.Events(0)
+					Expect(err).NotTo(HaveOccurred())
+					defer events.Close()
+
+					ev, err := events.Next()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ev).To(Equal(envelope(event.Log{Payload: "log 1"})))
+				})
+
+				It("still deletes the events of the builds which were archived", func() {
+					failingBuild, err := archivingTeam.CreateOneOffBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					err = failingBuild.Finish(db.BuildStatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
+					build, err := archivingTeam.CreateOneOffBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					err = build.Finish(db.BuildStatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
+					fakeLogStore.ArchiveStub = func(buildID int, events db.EventSource) error {
+						if buildID == failingBuild.ID() {
+							return errors.New("nope")
+						}
+
+						return nil
+					}
+
+					err = archivingPipeline.DeleteBuildEventsByBuildIDs([]int{failingBuild.ID(), build.ID()})
+					Expect(err).To(MatchError("nope"))
+
+					Expect(fakeLogStore.ArchiveCallCount()).To(Equal(2))
+
+					found, err := failingBuild.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(failingBuild.ReapTime()).To(BeZero())
+
+					found, err = build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.ReapTime()).NotTo(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("Jobs", func() {
@@ -2522,3 +3294,13 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 })
+
+type logStoreConn struct {
+	db.Conn
+
+	store db.LogStore
+}
+
+func (conn logStoreConn) LogStore() db.LogStore {
+	return conn.store
+}
@@ -119,9 +119,22 @@ func (r *resource) Reload() (bool, error) {
 		return false, err
 	}
 
+	resourceTypes, err := pipelineResourceTypes(r.conn, r.pipelineID)
+	if err != nil {
+		return false, err
+	}
+
+	r.applyDefaults(resourceTypes.Configs())
+
 	return true, nil
 }
 
+// applyDefaults merges the defaults configured for the resource's type, either
+// on the pipeline or on the base resource type, underneath its source.
+func (r *resource) applyDefaults(resourceTypes atc.ResourceTypes) {
+	r.source = r.source.Merge(resourceTypes.DefaultsFor(r.type_, r.conn.BaseResourceTypeDefaults()))
+}
+
 func (r *resource) Pause() error {
 	_, err := psql.Update("resources").
 		Set("paused", true).
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// MaxResourceCheckStderrBytes bounds how much of a check's stderr is kept.
+// The tail is kept, as that's usually where the error is.
+const MaxResourceCheckStderrBytes = 16 * 1024
+
+type ResourceCheck struct {
+	ID            int
+	StartTime     time.Time
+	EndTime       time.Time
+	VersionsFound int
+	ExitStatus    int
+	CheckError    string
+	Stderr        string
+}
+
+func (check ResourceCheck) Duration() time.Duration {
+	return check.EndTime.Sub(check.StartTime)
+}
+
+func truncateStderr(stderr string) string {
+	if len(stderr) <= MaxResourceCheckStderrBytes {
+		return stderr
+	}
+
+	// start on a rune boundary rather than splitting a multi-byte rune
+	start := len(stderr) - MaxResourceCheckStderrBytes
+	for start < len(stderr) && !utf8.RuneStart(stderr[start]) {
+		start++
+	}
+
+	return stderr[start:]
+}
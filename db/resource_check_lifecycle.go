@@ -0,0 +1,31 @@
+package db
+
+//go:generate counterfeiter . ResourceCheckLifecycle
+
+type ResourceCheckLifecycle interface {
+	CleanUpResourceChecks(checksToRetain int) error
+}
+
+type resourceCheckLifecycle struct {
+	conn Conn
+}
+
+func NewResourceCheckLifecycle(conn Conn) ResourceCheckLifecycle {
+	return resourceCheckLifecycle{
+		conn: conn,
+	}
+}
+
+func (lifecycle resourceCheckLifecycle) CleanUpResourceChecks(checksToRetain int) error {
+	_, err := lifecycle.conn.Exec(`
+		DELETE FROM resource_checks rc
+		USING (
+			SELECT id, row_number() OVER (PARTITION BY resource_id ORDER BY id DESC) AS rank
+			FROM resource_checks
+		) ranked
+		WHERE rc.id = ranked.id
+		AND ranked.rank > $1
+	`, checksToRetain)
+
+	return err
+}
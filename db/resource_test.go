@@ -76,6 +76,88 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("resource type defaults", func() {
+		var defaultsPipeline db.Pipeline
+
+		BeforeEach(func() {
+			defaultsTeamFactory := db.NewTeamFactory(baseResourceTypeDefaultsConn{
+				Conn: dbConn,
+				defaults: map[string]atc.Source{
+					"s3":  {"region": "us-east-1", "bucket": "default-bucket"},
+					"git": {"branch": "master"},
+				},
+			}, lockFactory)
+
+			defaultsTeam, found, err := defaultsTeamFactory.FindTeam(defaultTeam.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			var created bool
+			defaultsPipeline, created, err = defaultsTeam.SavePipeline(
+				"pipeline-with-defaults",
+				atc.Config{
+					ResourceTypes: atc.ResourceTypes{
+						{
+							Name:     "git",
+							Type:     "docker-image",
+							Source:   atc.Source{"repository": "custom/git-resource"},
+							Defaults: atc.Source{"branch": "develop"},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-s3",
+							Type:   "s3",
+							Source: atc.Source{"bucket": "some-bucket"},
+						},
+						{
+							Name:   "some-git",
+							Type:   "git",
+							Source: atc.Source{"uri": "some-uri"},
+						},
+					},
+				},
+				0,
+				db.PipelineUnpaused,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+		})
+
+		It("merges the base resource type defaults under the source", func() {
+			resource, found, err := defaultsPipeline.Resource("some-s3")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resource.Source()).To(Equal(atc.Source{
+				"region": "us-east-1",
+				"bucket": "some-bucket",
+			}))
+		})
+
+		It("prefers the defaults of a pipeline resource type shadowing the base type", func() {
+			resources, err := defaultsPipeline.Resources()
+			Expect(err).ToNot(HaveOccurred())
+
+			resource, found := resources.Lookup("some-git")
+			Expect(found).To(BeTrue())
+			Expect(resource.Source()).To(Equal(atc.Source{
+				"uri":    "some-uri",
+				"branch": "develop",
+			}))
+		})
+
+		It("keeps the defaults applied when reloading", func() {
+			resource, found, err := defaultsPipeline.Resource("some-s3")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			found, err = resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resource.Source()).To(HaveKeyWithValue("region", "us-east-1"))
+		})
+	})
+
 	Describe("(Pipeline).Resource", func() {
 		var (
 			err      error
@@ -168,3 +250,13 @@ var _ = Describe("Resource", func() {
 	})
 
 })
+
+type baseResourceTypeDefaultsConn struct {
+	db.Conn
+
+	defaults map[string]atc.Source
+}
+
+func (conn baseResourceTypeDefaultsConn) BaseResourceTypeDefaults() map[string]atc.Source {
+	return conn.defaults
+}
@@ -25,6 +25,7 @@ type ResourceType interface {
 	Type() string
 	Privileged() bool
 	Source() atc.Source
+	Defaults() atc.Source
 
 	SetResourceConfig(int) error
 
@@ -63,6 +64,7 @@ func (resourceTypes ResourceTypes) Configs() atc.ResourceTypes {
 			Type:       r.Type(),
 			Source:     r.Source(),
 			Privileged: r.Privileged(),
+			Defaults:   r.Defaults(),
 		})
 	}
 
@@ -73,22 +75,46 @@ var resourceTypesQuery = psql.Select("id, name, type, config, version, nonce").
 	From("resource_types").
 	Where(sq.Eq{"active": true})
 
+func pipelineResourceTypes(conn Conn, pipelineID int) (ResourceTypes, error) {
+	rows, err := resourceTypesQuery.Where(sq.Eq{"pipeline_id": pipelineID}).RunWith(conn).Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resourceTypes := []ResourceType{}
+
+	for rows.Next() {
+		resourceType := &resourceType{conn: conn}
+		err := scanResourceType(resourceType, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+
+	return resourceTypes, nil
+}
+
 type resourceType struct {
 	id         int
 	name       string
 	type_      string
 	privileged bool
 	source     atc.Source
+	defaults   atc.Source
 	version    atc.Version
 
 	conn Conn
 }
 
-func (t *resourceType) ID() int            { return t.id }
-func (t *resourceType) Name() string       { return t.name }
-func (t *resourceType) Type() string       { return t.type_ }
-func (t *resourceType) Privileged() bool   { return t.privileged }
-func (t *resourceType) Source() atc.Source { return t.source }
+func (t *resourceType) ID() int              { return t.id }
+func (t *resourceType) Name() string         { return t.name }
+func (t *resourceType) Type() string         { return t.type_ }
+func (t *resourceType) Privileged() bool     { return t.privileged }
+func (t *resourceType) Source() atc.Source   { return t.source }
+func (t *resourceType) Defaults() atc.Source { return t.defaults }
 
 func (t *resourceType) Version() atc.Version { return t.version }
 func (t *resourceType) SaveVersion(version atc.Version) error {
@@ -181,6 +207,7 @@ func scanResourceType(t *resourceType, row scannable) error {
 	}
 
 	t.source = config.Source
+	t.defaults = config.Defaults
 	t.privileged = config.Privileged
 
 	return nil
@@ -21,9 +21,10 @@ var _ = Describe("ResourceType", func() {
 			atc.Config{
 				ResourceTypes: atc.ResourceTypes{
 					{
-						Name:   "some-type",
-						Type:   "docker-image",
-						Source: atc.Source{"some": "repository"},
+						Name:     "some-type",
+						Type:     "docker-image",
+						Source:   atc.Source{"some": "repository"},
+						Defaults: atc.Source{"some-default": "value"},
 					},
 					{
 						Name:       "some-other-type",
@@ -62,6 +63,7 @@ var _ = Describe("ResourceType", func() {
 					Expect(t.Name()).To(Equal("some-type"))
 					Expect(t.Type()).To(Equal("docker-image"))
 					Expect(t.Source()).To(Equal(atc.Source{"some": "repository"}))
+					Expect(t.Defaults()).To(Equal(atc.Source{"some-default": "value"}))
 					Expect(t.Version()).To(BeNil())
 				case "some-other-type":
 					Expect(t.Name()).To(Equal("some-other-type"))
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// results are kept in worker task caches whose paths can not clash with the
+// cache paths of task configs
+const taskResultPathPrefix = ".task-results/"
+
+// TaskResultOutputPath returns the worker task cache path under which the
+// given output of a task run with the given cache key is kept.
+func TaskResultOutputPath(cacheKey string, outputName string) string {
+	return taskResultPathPrefix + cacheKey + "/" + outputName
+}
+
+// TaskResult is a successful run of a task step which can be reused by later
+// builds of the job, instead of running the task again.
+type TaskResult struct {
+	BuildID   int
+	BuildName string
+}
+
+//go:generate counterfeiter . TaskResultFactory
+
+type TaskResultFactory interface {
+	Find(jobID int, stepName string, cacheKey string) (TaskResult, bool, error)
+	Save(jobID int, stepName string, cacheKey string, buildID int) error
+}
+
+type taskResultFactory struct {
+	conn Conn
+}
+
+func NewTaskResultFactory(conn Conn) TaskResultFactory {
+	return &taskResultFactory{
+		conn: conn,
+	}
+}
+
+func (f *taskResultFactory) Find(jobID int, stepName string, cacheKey string) (TaskResult, bool, error) {
+	var result TaskResult
+	err := psql.Select("tr.build_id, b.name").
+		From("task_results tr").
+		Join("builds b ON b.id = tr.build_id").
+		Where(sq.Eq{
+			"tr.job_id":    jobID,
+			"tr.step_name": stepName,
+			"tr.cache_key": cacheKey,
+		}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&result.BuildID, &result.BuildName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return TaskResult{}, false, nil
+		}
+
+		return TaskResult{}, false, err
+	}
+
+	return result, true, nil
+}
+
+// Save replaces the step's result, releasing the outputs kept for the previous
+// one for garbage collection.
+func (f *taskResultFactory) Save(jobID int, stepName string, cacheKey string, buildID int) error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = psql.Delete("task_results").
+		Where(sq.Eq{
+			"job_id":    jobID,
+			"step_name": stepName,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("task_results").
+		Columns("job_id", "step_name", "cache_key", "build_id").
+		Values(jobID, stepName, cacheKey, buildID).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("worker_task_caches").
+		Where(sq.Eq{
+			"job_id":    jobID,
+			"step_name": stepName,
+		}).
+		Where(sq.Expr("path LIKE ?", taskResultPathPrefix+"%")).
+		Where(sq.Expr("path NOT LIKE ?", TaskResultOutputPath(cacheKey, "%"))).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
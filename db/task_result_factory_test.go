@@ -0,0 +1,114 @@
+package db_test
+
+import (
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskResultFactory", func() {
+	var (
+		taskResultFactory db.TaskResultFactory
+		build             db.Build
+	)
+
+	BeforeEach(func() {
+		taskResultFactory = db.NewTaskResultFactory(dbConn)
+
+		var err error
+		build, err = defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Find", func() {
+		Context("when no result was saved", func() {
+			It("returns false", func() {
+				_, found, err := taskResultFactory.Find(defaultJob.ID(), "some-step", "some-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when a result was saved", func() {
+			BeforeEach(func() {
+				err := taskResultFactory.Save(defaultJob.ID(), "some-step", "some-key", build.ID())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the build which produced it", func() {
+				result, found, err := taskResultFactory.Find(defaultJob.ID(), "some-step", "some-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(result).To(Equal(db.TaskResult{
+					BuildID:   build.ID(),
+					BuildName: build.Name(),
+				}))
+			})
+
+			It("does not return it for another cache key", func() {
+				_, found, err := taskResultFactory.Find(defaultJob.ID(), "some-step", "some-other-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("does not return it for another step", func() {
+				_, found, err := taskResultFactory.Find(defaultJob.ID(), "some-other-step", "some-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Save", func() {
+		var otherBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			otherBuild, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = taskResultFactory.Save(defaultJob.ID(), "some-step", "some-key", build.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, path := range []string{
+				db.TaskResultOutputPath("some-key", "some-output"),
+				db.TaskResultOutputPath("some-other-key", "some-output"),
+				"some-cache-path",
+			} {
+				_, err = workerTaskCacheFactory.FindOrCreate(defaultJob.ID(), "some-step", path, defaultWorker.Name())
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			err = taskResultFactory.Save(defaultJob.ID(), "some-step", "some-other-key", otherBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("replaces the previous result of the step", func() {
+			_, found, err := taskResultFactory.Find(defaultJob.ID(), "some-step", "some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			result, found, err := taskResultFactory.Find(defaultJob.ID(), "some-step", "some-other-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(result.BuildID).To(Equal(otherBuild.ID()))
+		})
+
+		It("removes the task caches keeping the outputs of the previous result", func() {
+			_, found, err := workerTaskCacheFactory.Find(defaultJob.ID(), "some-step", db.TaskResultOutputPath("some-key", "some-output"), defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("keeps the task caches of the new result and of the task config", func() {
+			_, found, err := workerTaskCacheFactory.Find(defaultJob.ID(), "some-step", db.TaskResultOutputPath("some-other-key", "some-output"), defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, found, err = workerTaskCacheFactory.Find(defaultJob.ID(), "some-step", "some-cache-path", defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+})
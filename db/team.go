@@ -32,6 +32,13 @@ type Team interface {
 	BasicAuth() *atc.BasicAuth
 	Auth() map[string]*json.RawMessage
 
+	DefaultJobPriority() int
+	Quota() atc.TeamQuota
+	QuotaUsage() (atc.TeamQuotaUsage, error)
+	AcquireQuotaLock(logger lager.Logger) (lock.Lock, bool, error)
+
+	PendingJobs() (Jobs, error)
+
 	Delete() error
 
 	SavePipeline(
@@ -49,6 +56,7 @@ type Team interface {
 
 	CreateOneOffBuild() (Build, error)
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
+	SearchBuildLogs(BuildLogSearch) ([]BuildLogMatch, error)
 
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
@@ -67,6 +75,8 @@ type Team interface {
 
 	UpdateBasicAuth(basicAuth *atc.BasicAuth) error
 	UpdateProviderAuth(auth map[string]*json.RawMessage) error
+	UpdateDefaultJobPriority(priority int) error
+	UpdateQuota(quota atc.TeamQuota) error
 
 	CreatePipe(string, string) error
 	GetPipe(string) (Pipe, error)
@@ -83,6 +93,10 @@ type team struct {
 	basicAuth *atc.BasicAuth
 
 	auth map[string]*json.RawMessage
+
+	defaultJobPriority int
+
+	quota atc.TeamQuota
 }
 
 func (t *team) ID() int                           { return t.id }
@@ -90,6 +104,8 @@ func (t *team) Name() string                      { return t.name }
 func (t *team) Admin() bool                       { return t.admin }
 func (t *team) BasicAuth() *atc.BasicAuth         { return t.basicAuth }
 func (t *team) Auth() map[string]*json.RawMessage { return t.auth }
+func (t *team) DefaultJobPriority() int           { return t.defaultJobPriority }
+func (t *team) Quota() atc.TeamQuota              { return t.quota }
 
 func (t *team) Delete() error {
 	tx, err := t.conn.Begin()
@@ -741,6 +757,12 @@ func (t *team) PrivateAndPublicBuilds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(newBuildsQuery, page, t.conn, t.lockFactory)
 }
 
+// SearchBuildLogs searches the output of the team's builds, newest build
+// first.
+func (t *team) SearchBuildLogs(search BuildLogSearch) ([]BuildLogMatch, error) {
+	return searchBuildLogs(t.conn, t.id, search)
+}
+
 func (t *team) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -772,7 +794,7 @@ func (t *team) UpdateBasicAuth(basicAuth *atc.BasicAuth) error {
 		UPDATE teams
 		SET basic_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, auth, nonce
+		RETURNING id, name, admin, basic_auth, auth, nonce, default_job_priority, quota
 	`
 
 	params := []interface{}{encryptedBasicAuth, t.name}
@@ -796,12 +818,148 @@ func (t *team) UpdateProviderAuth(auth map[string]*json.RawMessage) error {
 		UPDATE teams
 		SET auth = $1, nonce = $3
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, auth, nonce
+		RETURNING id, name, admin, basic_auth, auth, nonce, default_job_priority, quota
 	`
 	params := []interface{}{string(encryptedAuth), t.name, nonce}
 	return t.queryTeam(query, params)
 }
 
+func (t *team) UpdateDefaultJobPriority(priority int) error {
+	query := `
+		UPDATE teams
+		SET default_job_priority = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, auth, nonce, default_job_priority, quota
+	`
+
+	params := []interface{}{priority, t.name}
+
+	return t.queryTeam(query, params)
+}
+
+func (t *team) UpdateQuota(quota atc.TeamQuota) error {
+	quotaJSON, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE teams
+		SET quota = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, auth, nonce, default_job_priority, quota
+	`
+
+	params := []interface{}{string(quotaJSON), t.name}
+
+	return t.queryTeam(query, params)
+}
+
+// AcquireQuotaLock serializes checking the team's quota and scheduling a
+// build within it across all of the team's pipelines, so that they can't
+// overrun the quota together.
+func (t *team) AcquireQuotaLock(logger lager.Logger) (lock.Lock, bool, error) {
+	return t.lockFactory.Acquire(
+		logger.Session("lock", lager.Data{
+			"team": t.name,
+		}),
+		lock.NewTeamQuotaLockID(t.id),
+	)
+}
+
+// QuotaUsage counts the team's running builds, both overall and per
+// pipeline, and its active containers. Builds which have been scheduled but
+// not yet started count as running, as they do for max_in_flight.
+func (t *team) QuotaUsage() (atc.TeamQuotaUsage, error) {
+	usage := atc.TeamQuotaUsage{
+		Pipelines: map[string]atc.PipelineQuotaUsage{},
+	}
+
+	rows, err := psql.Select("COALESCE(p.name, ''), COUNT(*)").
+		From("builds b").
+		LeftJoin("pipelines p ON p.id = b.pipeline_id").
+		Where(sq.Eq{"b.team_id": t.id}).
+		Where(sq.Or{
+			sq.Eq{"b.status": BuildStatusStarted},
+			sq.Eq{"b.status": BuildStatusPending, "b.scheduled": true},
+		}).
+		GroupBy("p.name").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return atc.TeamQuotaUsage{}, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var pipelineName string
+		var runningBuilds int
+		err = rows.Scan(&pipelineName, &runningBuilds)
+		if err != nil {
+			return atc.TeamQuotaUsage{}, err
+		}
+
+		usage.RunningBuilds += runningBuilds
+
+		if pipelineName != "" {
+			usage.Pipelines[pipelineName] = atc.PipelineQuotaUsage{
+				RunningBuilds: runningBuilds,
+			}
+		}
+	}
+
+	err = psql.Select("COUNT(*)").
+		From("containers").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"state":   []string{ContainerStateCreating, ContainerStateCreated},
+		}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&usage.Containers)
+	if err != nil {
+		return atc.TeamQuotaUsage{}, err
+	}
+
+	usage.HeldContainers, err = countHeldContainers(t.conn, t.id, 0)
+	if err != nil {
+		return atc.TeamQuotaUsage{}, err
+	}
+
+	return usage, nil
+}
+
+// PendingJobs returns the jobs, across all of the team's pipelines, which have
+// pending builds that are ready to be started: those of paused jobs and
+// pipelines, those awaiting approval, and those whose inputs are not yet
+// determined are left out.
+func (t *team) PendingJobs() (Jobs, error) {
+	rows, err := jobsQuery.
+		Where(sq.Eq{
+			"p.team_id": t.id,
+			"p.paused":  false,
+			"j.active":  true,
+			"j.paused":  false,
+		}).
+		Where(sq.Expr(`EXISTS (
+			SELECT 1
+			FROM builds b
+			WHERE b.job_id = j.id
+			AND b.status = 'pending'
+			AND NOT b.scheduled
+			AND (b.approval_status IS NULL OR b.approval_status = 'approved')
+			AND (j.inputs_determined OR b.manually_triggered OR b.rerun_of IS NOT NULL)
+		)`)).
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanJobs(t.conn, t.lockFactory, rows)
+}
+
 func (t *team) CreatePipe(pipeGUID string, url string) error {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -876,9 +1034,10 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int) error {
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE jobs
-		SET config = $3, interruptible = $4, active = true, nonce = $5
+		SET config = $3, interruptible = $4, active = true, nonce = $5,
+			trigger_on_since = CASE WHEN $6 THEN trigger_on_since END
 		WHERE name = $1 AND pipeline_id = $2
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce)
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, len(job.TriggerOn) > 0)
 	if err != nil {
 		return err
 	}
@@ -1072,7 +1231,7 @@ func scanPipelines(conn Conn, lockFactory lock.LockFactory, rows *sql.Rows) ([]P
 }
 
 func (t *team) queryTeam(query string, params []interface{}) error {
-	var basicAuth, providerAuth, nonce sql.NullString
+	var basicAuth, providerAuth, nonce, quota sql.NullString
 
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -1087,6 +1246,8 @@ func (t *team) queryTeam(query string, params []interface{}) error {
 		&basicAuth,
 		&providerAuth,
 		&nonce,
+		&t.defaultJobPriority,
+		&quota,
 	)
 	if err != nil {
 		return err
@@ -1122,6 +1283,13 @@ func (t *team) queryTeam(query string, params []interface{}) error {
 		}
 	}
 
+	if quota.Valid {
+		err = json.Unmarshal([]byte(quota.String), &t.quota)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -73,10 +73,25 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	var defaultJobPriority int
+	if t.DefaultJobPriority != nil {
+		defaultJobPriority = *t.DefaultJobPriority
+	}
+
+	var quota sql.NullString
+	if t.Quota != nil {
+		quotaJSON, err := json.Marshal(t.Quota)
+		if err != nil {
+			return nil, err
+		}
+
+		quota = sql.NullString{String: string(quotaJSON), Valid: true}
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, basic_auth, auth, nonce, admin").
-		Values(t.Name, encryptedBasicAuthJSON, encryptedAuth, nonce, admin).
-		Suffix("RETURNING id, name, admin, basic_auth, auth, nonce").
+		Columns("name, basic_auth, auth, nonce, admin, default_job_priority, quota").
+		Values(t.Name, encryptedBasicAuthJSON, encryptedAuth, nonce, admin, defaultJobPriority, quota).
+		Suffix("RETURNING id, name, admin, basic_auth, auth, nonce, default_job_priority, quota").
 		RunWith(tx).
 		QueryRow()
 
@@ -120,7 +135,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, basic_auth, auth, nonce").
+	row := psql.Select("id, name, admin, basic_auth, auth, nonce, default_job_priority, quota").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -139,7 +154,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, basic_auth, auth, nonce").
+	rows, err := psql.Select("id, name, admin, basic_auth, auth, nonce, default_job_priority, quota").
 		From("teams").
 		RunWith(factory.conn).
 		Query()
@@ -196,7 +211,7 @@ func (factory *teamFactory) CreateDefaultTeamIfNotExists() (Team, error) {
 }
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var basicAuth, providerAuth, nonce sql.NullString
+	var basicAuth, providerAuth, nonce, quota sql.NullString
 
 	err := rows.Scan(
 		&t.id,
@@ -205,6 +220,8 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		&basicAuth,
 		&providerAuth,
 		&nonce,
+		&t.defaultJobPriority,
+		&quota,
 	)
 
 	if basicAuth.Valid {
@@ -233,5 +250,12 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
+	if quota.Valid {
+		err = json.Unmarshal([]byte(quota.String), &t.quota)
+		if err != nil {
+			return err
+		}
+	}
+
 	return err
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
 	uuid "github.com/nu7hatch/gouuid"
 
 	. "github.com/onsi/ginkgo"
@@ -773,6 +774,244 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("UpdateDefaultJobPriority", func() {
+		It("starts out with a default job priority of zero", func() {
+			Expect(team.DefaultJobPriority()).To(BeZero())
+		})
+
+		It("saves the default job priority", func() {
+			err := team.UpdateDefaultJobPriority(42)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(team.DefaultJobPriority()).To(Equal(42))
+
+			reloadedTeam, found, err := teamFactory.FindTeam("some-team")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloadedTeam.DefaultJobPriority()).To(Equal(42))
+		})
+
+		It("does not change the other team", func() {
+			err := team.UpdateDefaultJobPriority(42)
+			Expect(err).NotTo(HaveOccurred())
+
+			reloadedTeam, found, err := teamFactory.FindTeam("some-other-team")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloadedTeam.DefaultJobPriority()).To(BeZero())
+		})
+	})
+
+	Describe("UpdateQuota", func() {
+		It("starts out without a quota", func() {
+			Expect(team.Quota()).To(Equal(atc.TeamQuota{}))
+		})
+
+		It("saves the quota", func() {
+			quota := atc.TeamQuota{
+				MaxRunningBuilds: 10,
+				MaxContainers:    50,
+				Pipelines: map[string]atc.PipelineQuota{
+					"some-pipeline": {MaxRunningBuilds: 2},
+				},
+			}
+
+			err := team.UpdateQuota(quota)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(team.Quota()).To(Equal(quota))
+
+			reloadedTeam, found, err := teamFactory.FindTeam("some-team")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloadedTeam.Quota()).To(Equal(quota))
+		})
+	})
+
+	Describe("QuotaUsage", func() {
+		var job db.Job
+
+		BeforeEach(func() {
+			var found bool
+			var err error
+			job, found, err = defaultPipeline.Job("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("counts nothing when the team is idle", func() {
+			usage, err := defaultTeam.QuotaUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(Equal(atc.TeamQuotaUsage{
+				Pipelines: map[string]atc.PipelineQuotaUsage{},
+			}))
+		})
+
+		Context("when the team has builds", func() {
+			BeforeEach(func() {
+				_, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				scheduledBuild, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				scheduled, err := scheduledBuild.Schedule()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				startedBuild, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err := startedBuild.Start("some-engine", `{}`, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				finishedBuild, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = finishedBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				oneOffBuild, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err = oneOffBuild.Start("some-engine", `{}`, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				otherTeamBuild, err := otherTeam.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err = otherTeamBuild.Start("some-engine", `{}`, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+
+			It("counts scheduled and started builds of the team", func() {
+				usage, err := defaultTeam.QuotaUsage()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usage.RunningBuilds).To(Equal(3))
+				Expect(usage.Pipelines).To(Equal(map[string]atc.PipelineQuotaUsage{
+					"default-pipeline": {RunningBuilds: 2},
+				}))
+			})
+		})
+
+		Context("when the team has containers", func() {
+			BeforeEach(func() {
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				owner := db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-plan"))
+
+				_, err = defaultTeam.CreateContainer(defaultWorker.Name(), owner, fullMetadata)
+				Expect(err).NotTo(HaveOccurred())
+
+				creatingContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), owner, fullMetadata)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = creatingContainer.Created()
+				Expect(err).NotTo(HaveOccurred())
+
+				creatingContainer, err = defaultTeam.CreateContainer(defaultWorker.Name(), owner, fullMetadata)
+				Expect(err).NotTo(HaveOccurred())
+
+				createdContainer, err := creatingContainer.Created()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = createdContainer.Destroying()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("counts the creating and created containers", func() {
+				usage, err := defaultTeam.QuotaUsage()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usage.Containers).To(Equal(2))
+			})
+		})
+
+		Context("when the team has held containers", func() {
+			BeforeEach(func() {
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				owner := db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-plan"))
+
+				_, err = defaultTeam.CreateContainer(defaultWorker.Name(), owner, fullMetadata)
+				Expect(err).NotTo(HaveOccurred())
+
+				held, err := build.HoldContainers(time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(held).To(BeTrue())
+			})
+
+			It("counts them", func() {
+				usage, err := defaultTeam.QuotaUsage()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usage.HeldContainers).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("PendingJobs", func() {
+		It("returns nothing when there are no pending builds", func() {
+			jobs, err := defaultTeam.PendingJobs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobs).To(BeEmpty())
+		})
+
+		Context("when a job has a pending build", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = defaultJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the job", func() {
+				jobs, err := defaultTeam.PendingJobs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(jobs).To(HaveLen(1))
+				Expect(jobs[0].ID()).To(Equal(defaultJob.ID()))
+			})
+
+			It("does not return it for other teams", func() {
+				jobs, err := otherTeam.PendingJobs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(jobs).To(BeEmpty())
+			})
+
+			It("does not return the job once the build is scheduled", func() {
+				scheduled, err := build.Schedule()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				jobs, err := defaultTeam.PendingJobs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(jobs).To(BeEmpty())
+			})
+
+			It("does not return the job when it is paused", func() {
+				err := defaultJob.Pause()
+				Expect(err).NotTo(HaveOccurred())
+
+				jobs, err := defaultTeam.PendingJobs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(jobs).To(BeEmpty())
+			})
+
+			It("does not return the job when its pipeline is paused", func() {
+				err := defaultPipeline.Pause()
+				Expect(err).NotTo(HaveOccurred())
+
+				jobs, err := defaultTeam.PendingJobs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(jobs).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Pipelines", func() {
 		var (
 			pipelines []db.Pipeline
@@ -1150,6 +1389,130 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("SearchBuildLogs", func() {
+		var (
+			jobBuild    db.Build
+			oneOffBuild db.Build
+		)
+
+		BeforeEach(func() {
+			pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			}, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			job, found, err := pipeline.Job("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			jobBuild, err = job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			oneOffBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeamBuild, err := otherTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			logs := map[db.Build][]string{
+				jobBuild:       {"compiling\npanic: oh", " no\n", "goroutine 1\n"},
+				oneOffBuild:    {"PANIC in one-off\n"},
+				otherTeamBuild: {"panic elsewhere\n"},
+			}
+
+			for build, payloads := range logs {
+				started, err := build.Start("some-engine", "{}", atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				for _, payload := range payloads {
+					err := build.SaveEvent(event.Log{
+						Origin:  event.Origin{ID: "some-task"},
+						Payload: payload,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				err = build.SaveEvent(event.Log{
+					Origin:  event.Origin{ID: "some-other-step"},
+					Payload: "unrelated\n",
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns the matching lines of the team's builds with their context, newest first", func() {
+			matches, err := team.SearchBuildLogs(db.BuildLogSearch{
+				Query:        "panic",
+				Limit:        10,
+				ContextLines: 1,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(Equal([]db.BuildLogMatch{
+				{
+					BuildID:   oneOffBuild.ID(),
+					BuildName: oneOffBuild.Name(),
+					Line:      "PANIC in one-off",
+					Before:    []string{},
+					After:     []string{},
+				},
+				{
+					BuildID:      jobBuild.ID(),
+					BuildName:    jobBuild.Name(),
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					Line:         "panic: oh no",
+					Before:       []string{"compiling"},
+					After:        []string{"goroutine 1"},
+				},
+			}))
+		})
+
+		It("matches within words", func() {
+			matches, err := team.SearchBuildLogs(db.BuildLogSearch{
+				Query: "orouti",
+				Limit: 10,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].Line).To(Equal("goroutine 1"))
+		})
+
+		It("searches only the given pipeline and job", func() {
+			matches, err := team.SearchBuildLogs(db.BuildLogSearch{
+				Query:        "panic",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				Limit:        10,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].BuildID).To(Equal(jobBuild.ID()))
+		})
+
+		It("searches only builds started within the time range", func() {
+			matches, err := team.SearchBuildLogs(db.BuildLogSearch{
+				Query: "panic",
+				Until: time.Now().Add(-time.Hour),
+				Limit: 10,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+
+		It("returns at most the limit", func() {
+			matches, err := team.SearchBuildLogs(db.BuildLogSearch{
+				Query: "panic",
+				Limit: 1,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].BuildID).To(Equal(oneOffBuild.ID()))
+		})
+	})
+
 	Describe("SavePipeline", func() {
 		type SerialGroup struct {
 			JobID int
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// TestReport summarizes the test reports written by one of a build's steps.
+type TestReport struct {
+	StepName string
+	Total    int
+	Passed   int
+	Failed   int
+	Skipped  int
+
+	// Passes and Failures are the names of the tests which passed and failed.
+	// Reports saved before the passed tests were recorded have no Passes.
+	Passes   []string
+	Failures []string
+}
+
+// BuildTestReports are the test reports of one of a job's builds.
+type BuildTestReports struct {
+	BuildID   int
+	BuildName string
+	Status    BuildStatus
+	Reports   []TestReport
+}
+
+// FlakyTest is a test which went from failing to passing and back, or the
+// other way round, across a job's builds.
+type FlakyTest struct {
+	StepName string
+	Name     string
+
+	// Failures is the number of builds the test failed in, out of the Builds
+	// which reported it.
+	Failures int
+	Builds   int
+}
+
+var testReportsQuery = psql.Select("r.step_name, r.total, r.passed, r.failed, r.skipped, r.passes, r.failures").
+	From("build_test_reports r")
+
+func scanTestReport(row scannable) (TestReport, error) {
+	var (
+		report   TestReport
+		passes   string
+		failures string
+	)
+
+	err := row.Scan(&report.StepName, &report.Total, &report.Passed, &report.Failed, &report.Skipped, &passes, &failures)
+	if err != nil {
+		return TestReport{}, err
+	}
+
+	err = json.Unmarshal([]byte(passes), &report.Passes)
+	if err != nil {
+		return TestReport{}, err
+	}
+
+	err = json.Unmarshal([]byte(failures), &report.Failures)
+	if err != nil {
+		return TestReport{}, err
+	}
+
+	return report, nil
+}
+
+// prefixedRow scans the given columns before those scanned by the caller.
+type prefixedRow struct {
+	row     scannable
+	columns []interface{}
+}
+
+func (row prefixedRow) Scan(dest ...interface{}) error {
+	return row.row.Scan(append(row.columns, dest...)...)
+}
+
+// FlakyTests finds the flaky tests in a job's history, ordered newest build
+// first. A test is followed from the first build which reported it, and one
+// which failed and was then fixed is not flaky; it must have changed outcome
+// at least twice.
+func FlakyTests(history []BuildTestReports) []FlakyTest {
+	type testKey struct {
+		stepName string
+		name     string
+	}
+
+	type testOutcomes struct {
+		builds   int
+		failures int
+		flips    int
+		failed   bool
+	}
+
+	tests := map[testKey]*testOutcomes{}
+
+	record := func(key testKey, failed bool) {
+		outcomes, found := tests[key]
+		if !found {
+			outcomes = &testOutcomes{}
+			tests[key] = outcomes
+		}
+
+		if outcomes.builds != 0 && outcomes.failed != failed {
+			outcomes.flips++
+		}
+
+		outcomes.builds++
+		outcomes.failed = failed
+
+		if failed {
+			outcomes.failures++
+		}
+	}
+
+	for i := len(history) - 1; i >= 0; i-- {
+		for _, report := range history[i].Reports {
+			for _, name := range report.Passes {
+				record(testKey{stepName: report.StepName, name: name}, false)
+			}
+
+			for _, name := range report.Failures {
+				record(testKey{stepName: report.StepName, name: name}, true)
+			}
+		}
+	}
+
+	flakyTests := []FlakyTest{}
+	for key, outcomes := range tests {
+		if outcomes.flips < 2 {
+			continue
+		}
+
+		flakyTests = append(flakyTests, FlakyTest{
+			StepName: key.stepName,
+			Name:     key.name,
+			Failures: outcomes.failures,
+			Builds:   outcomes.builds,
+		})
+	}
+
+	sort.Sort(byStepAndTestName(flakyTests))
+
+	return flakyTests
+}
+
+type byStepAndTestName []FlakyTest
+
+func (tests byStepAndTestName) Len() int      { return len(tests) }
+func (tests byStepAndTestName) Swap(i, j int) { tests[i], tests[j] = tests[j], tests[i] }
+func (tests byStepAndTestName) Less(i, j int) bool {
+	if tests[i].StepName != tests[j].StepName {
+		return tests[i].StepName < tests[j].StepName
+	}
+
+	return tests[i].Name < tests[j].Name
+}
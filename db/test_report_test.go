@@ -0,0 +1,72 @@
+package db_test
+
+import (
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlakyTests", func() {
+	report := func(passes []string, failures ...string) []db.TestReport {
+		return []db.TestReport{{StepName: "unit", Passes: passes, Failures: failures}}
+	}
+
+	It("finds the tests which failed, passed and failed again", func() {
+		flakyTests := db.FlakyTests([]db.BuildTestReports{
+			{BuildID: 5, Reports: report([]string{"TestFixed"}, "TestFlaky")},
+			{BuildID: 4, Reports: report([]string{"TestFlaky", "TestFixed"})},
+			{BuildID: 3, Reports: report(nil, "TestFlaky", "TestFixed")},
+			{BuildID: 2, Reports: report(nil, "TestFixed")},
+			{BuildID: 1, Reports: report(nil)},
+		})
+
+		Expect(flakyTests).To(Equal([]db.FlakyTest{
+			{StepName: "unit", Name: "TestFlaky", Failures: 2, Builds: 3},
+		}))
+	})
+
+	It("finds the tests which passed, failed and passed again", func() {
+		flakyTests := db.FlakyTests([]db.BuildTestReports{
+			{BuildID: 3, Reports: report([]string{"TestFlaky"})},
+			{BuildID: 2, Reports: report(nil, "TestFlaky")},
+			{BuildID: 1, Reports: report([]string{"TestFlaky"})},
+		})
+
+		Expect(flakyTests).To(Equal([]db.FlakyTest{
+			{StepName: "unit", Name: "TestFlaky", Failures: 1, Builds: 3},
+		}))
+	})
+
+	It("only counts the builds which reported the test", func() {
+		flakyTests := db.FlakyTests([]db.BuildTestReports{
+			{BuildID: 5, Reports: report(nil, "TestFlaky")},
+			{BuildID: 4, Reports: []db.TestReport{{StepName: "integration", Passes: []string{"TestFlaky"}}}},
+			{BuildID: 3, Reports: report(nil)},
+			{BuildID: 2, Reports: report([]string{"TestFlaky"})},
+			{BuildID: 1, Reports: report(nil, "TestFlaky")},
+		})
+
+		Expect(flakyTests).To(Equal([]db.FlakyTest{
+			{StepName: "unit", Name: "TestFlaky", Failures: 2, Builds: 3},
+		}))
+	})
+
+	It("does not consider new tests which failed and were then fixed flaky", func() {
+		flakyTests := db.FlakyTests([]db.BuildTestReports{
+			{BuildID: 3, Reports: report([]string{"TestExisting", "TestNew"})},
+			{BuildID: 2, Reports: report([]string{"TestExisting"}, "TestNew")},
+			{BuildID: 1, Reports: report([]string{"TestExisting"})},
+		})
+
+		Expect(flakyTests).To(BeEmpty())
+	})
+
+	It("does not consider tests which keep failing flaky", func() {
+		flakyTests := db.FlakyTests([]db.BuildTestReports{
+			{BuildID: 2, Reports: report(nil, "TestBroken")},
+			{BuildID: 1, Reports: report(nil, "TestBroken")},
+		})
+
+		Expect(flakyTests).To(BeEmpty())
+	})
+})
@@ -52,6 +52,7 @@ const (
 	VolumeTypeResource     VolumeType = "resource"
 	VolumeTypeResourceType VolumeType = "resource-type"
 	VolumeTypeTaskCache    VolumeType = "task-cache"
+	VolumeTypeArtifact     VolumeType = "artifact"
 	VolumeTypeUknown       VolumeType = "unknown" // for migration to life
 )
 
@@ -147,6 +148,7 @@ type CreatedVolume interface {
 	WorkerName() string
 	InitializeResourceCache(*UsedResourceCache) error
 	InitializeTaskCache(int, string, string) error
+	InitializeArtifact(int, string) error
 	ContainerHandle() string
 	ParentHandle() string
 	ResourceType() (*VolumeResourceType, error)
@@ -434,6 +436,62 @@ func (volume *createdVolume) InitializeTaskCache(jobID int, stepName string, pat
 	return tx.Commit()
 }
 
+// InitializeArtifact keeps the volume as the named artifact of the build,
+// replacing any volume kept for it before.
+func (volume *createdVolume) InitializeArtifact(buildID int, name string) error {
+	var artifactID int
+
+	err := safeFindOrCreate(volume.conn, func(tx Tx) error {
+		var err error
+		artifactID, err = buildArtifact{
+			BuildID: buildID,
+			Name:    name,
+		}.FindOrCreate(tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	tx, err := volume.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	// release the volume kept before for gc
+	_, err = psql.Update("volumes").
+		Set("build_artifact_id", nil).
+		Where(sq.Eq{"build_artifact_id": artifactID}).
+		Where(sq.NotEq{"id": volume.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rows, err := psql.Update("volumes").
+		Set("build_artifact_id", artifactID).
+		Where(sq.Eq{"id": volume.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrVolumeMissing
+	}
+
+	return tx.Commit()
+}
+
 func (volume *createdVolume) CreateChildForContainer(container CreatingContainer, mountPath string) (CreatingVolume, error) {
 	tx, err := volume.conn.Begin()
 	if err != nil {
@@ -24,6 +24,8 @@ type VolumeFactory interface {
 	FindTaskCacheVolume(teamID int, uwtc *UsedWorkerTaskCache) (CreatingVolume, CreatedVolume, error)
 	CreateTaskCacheVolume(teamID int, uwtc *UsedWorkerTaskCache) (CreatingVolume, error)
 
+	CreateArtifactVolume(teamID int, workerName string, buildID int, name string) (CreatingVolume, error)
+
 	FindVolumesForContainer(CreatedContainer) ([]CreatedVolume, error)
 	GetOrphanedVolumes() ([]CreatedVolume, []DestroyingVolume, error)
 
@@ -192,6 +194,43 @@ func (factory *volumeFactory) CreateTaskCacheVolume(teamID int, uwtc *UsedWorker
 	return volume, nil
 }
 
+// CreateArtifactVolume creates a volume to keep as the named artifact of the
+// build, releasing any volume kept for it before.
+func (factory *volumeFactory) CreateArtifactVolume(teamID int, workerName string, buildID int, name string) (CreatingVolume, error) {
+	var artifactID int
+
+	err := safeFindOrCreate(factory.conn, func(tx Tx) error {
+		var err error
+		artifactID, err = buildArtifact{
+			BuildID: buildID,
+			Name:    name,
+		}.FindOrCreate(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// release the volume kept before for gc
+	_, err = psql.Update("volumes").
+		Set("build_artifact_id", nil).
+		Where(sq.Eq{"build_artifact_id": artifactID}).
+		RunWith(factory.conn).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	return factory.createVolume(
+		teamID,
+		workerName,
+		map[string]interface{}{
+			"build_artifact_id": artifactID,
+		},
+		VolumeTypeArtifact,
+	)
+}
+
 func (factory *volumeFactory) FindResourceCacheVolume(workerName string, resourceCache *UsedResourceCache) (CreatedVolume, bool, error) {
 	workerResourceCache, found, err := WorkerResourceCache{
 		WorkerName:    workerName,
@@ -246,6 +285,7 @@ func (factory *volumeFactory) GetOrphanedVolumes() ([]CreatedVolume, []Destroyin
 			"v.worker_base_resource_type_id": nil,
 			"v.container_id":                 nil,
 			"v.worker_task_cache_id":         nil,
+			"v.build_artifact_id":            nil,
 		}).
 		Where(sq.Or{
 			sq.Eq{"v.state": string(VolumeStateCreated)},
@@ -432,6 +472,7 @@ var volumeColumns = []string{
 	when v.worker_resource_cache_id is not NULL then 'resource'
 	when v.container_id is not NULL then 'container'
 	when v.worker_task_cache_id is not NULL then 'task-cache'
+	when v.build_artifact_id is not NULL then 'artifact'
 	else 'unknown'
 end`,
 }
@@ -428,4 +428,56 @@ var _ = Describe("VolumeFactory", func() {
 			})
 		})
 	})
+
+	Describe("CreateArtifactVolume", func() {
+		It("creates a volume kept as the build's artifact", func() {
+			creatingVolume, err := volumeFactory.CreateArtifactVolume(defaultTeam.ID(), defaultWorker.Name(), build.ID(), "some-artifact")
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdVolume.Type()).To(Equal(db.VolumeTypeArtifact))
+			Expect(createdVolume.ParentHandle()).To(BeEmpty())
+
+			artifact, found, err := build.Artifact("some-artifact")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(artifact.VolumeHandle).To(Equal(createdVolume.Handle()))
+		})
+
+		Context("when a volume was kept for the artifact before", func() {
+			var previousVolume db.CreatedVolume
+
+			BeforeEach(func() {
+				creatingVolume, err := volumeFactory.CreateArtifactVolume(defaultTeam.ID(), defaultWorker.Name(), build.ID(), "some-artifact")
+				Expect(err).NotTo(HaveOccurred())
+
+				previousVolume, err = creatingVolume.Created()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("releases the previous volume for gc", func() {
+				creatingVolume, err := volumeFactory.CreateArtifactVolume(defaultTeam.ID(), defaultWorker.Name(), build.ID(), "some-artifact")
+				Expect(err).NotTo(HaveOccurred())
+
+				createdVolume, err := creatingVolume.Created()
+				Expect(err).NotTo(HaveOccurred())
+
+				artifact, found, err := build.Artifact("some-artifact")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(artifact.VolumeHandle).To(Equal(createdVolume.Handle()))
+
+				createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+				Expect(err).NotTo(HaveOccurred())
+
+				orphanedHandles := []string{}
+				for _, volume := range createdVolumes {
+					orphanedHandles = append(orphanedHandles, volume.Handle())
+				}
+
+				Expect(orphanedHandles).To(ContainElement(previousVolume.Handle()))
+			})
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
@@ -14,21 +15,29 @@ type dbActionsBuildEventsDelegate struct {
 	build               db.Build
 	eventOrigin         event.Origin
 	implicitOutputsRepo *implicitOutputsRepo
+	redactor            *creds.Redactor
+	logWriters          *logWriters
 }
 
 func NewDBActionsBuildEventsDelegate(
 	build db.Build,
 	eventOrigin event.Origin,
 	implicitOutputsRepo *implicitOutputsRepo,
+	redactor *creds.Redactor,
+	logWriters *logWriters,
 ) exec.ActionsBuildEventsDelegate {
 	return &dbActionsBuildEventsDelegate{
 		build:               build,
 		eventOrigin:         eventOrigin,
 		implicitOutputsRepo: implicitOutputsRepo,
+		redactor:            redactor,
+		logWriters:          logWriters,
 	}
 }
 
 func (d *dbActionsBuildEventsDelegate) ActionCompleted(logger lager.Logger, action exec.Action) {
+	d.flushLogs(logger)
+
 	switch a := action.(type) {
 	case *exec.TaskAction:
 		exitStatus := a.ExitStatus()
@@ -138,13 +147,27 @@ func (d *dbActionsBuildEventsDelegate) ActionCompleted(logger lager.Logger, acti
 }
 
 func (d *dbActionsBuildEventsDelegate) Failed(logger lager.Logger, errVal error) {
+	d.flushLogs(logger)
+
+	// resource script failures include the script's stderr
+	message := d.redactor.Redact(errVal.Error())
+
 	err := d.build.SaveEvent(event.Error{
-		Message: errVal.Error(),
+		Message: message,
 		Origin:  d.eventOrigin,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-error-event", err)
 	}
 
-	logger.Info("errored", lager.Data{"error": errVal.Error()})
+	logger.Info("errored", lager.Data{"error": message})
+}
+
+// flushLogs saves the output held back by the step's log writers, now that
+// the step is done writing it.
+func (d *dbActionsBuildEventsDelegate) flushLogs(logger lager.Logger) {
+	err := d.logWriters.Flush(d.eventOrigin.ID)
+	if err != nil {
+		logger.Error("failed-to-flush-logs", err)
+	}
 }
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
@@ -43,3 +45,43 @@ func (d *dbTaskBuildEventsDelegate) Starting(logger lager.Logger, taskConfig atc
 		logger.Error("failed-to-save-initialize-task-event", err)
 	}
 }
+
+func (d *dbTaskBuildEventsDelegate) ResultReused(logger lager.Logger, result db.TaskResult) {
+	err := d.build.SaveEvent(event.TaskResultReused{
+		Time:      time.Now().Unix(),
+		Origin:    d.eventOrigin,
+		BuildID:   result.BuildID,
+		BuildName: result.BuildName,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-task-result-reused-event", err)
+	}
+}
+
+func (d *dbTaskBuildEventsDelegate) MetadataEmitted(logger lager.Logger, metadata []atc.MetadataField) {
+	err := d.build.SaveMetadata(db.NewResourceMetadataFields(metadata))
+	if err != nil {
+		logger.Error("failed-to-save-build-metadata", err)
+	}
+}
+
+func (d *dbTaskBuildEventsDelegate) TestsReported(logger lager.Logger, report db.TestReport) {
+	err := d.build.SaveTestReport(report)
+	if err != nil {
+		logger.Error("failed-to-save-test-report", err)
+		return
+	}
+
+	err = d.build.SaveEvent(event.TestReport{
+		Time:     time.Now().Unix(),
+		Origin:   d.eventOrigin,
+		Total:    report.Total,
+		Passed:   report.Passed,
+		Failed:   report.Failed,
+		Skipped:  report.Skipped,
+		Failures: report.Failures,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-test-report-event", err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 	"github.com/tedsuo/ifrit"
 )
@@ -128,6 +129,19 @@ func (build *execBuild) Abort(lager.Logger) error {
 }
 
 func (build *execBuild) Resume(logger lager.Logger) {
+	buildID := build.dbBuild.ID()
+
+	ctx, span := tracing.StartSpan(tracing.BuildContext(buildID), "engine.build", tracing.Attrs{
+		"team":     build.stepMetadata.TeamName,
+		"pipeline": build.stepMetadata.PipelineName,
+		"job":      build.stepMetadata.JobName,
+		"build-id": strconv.Itoa(buildID),
+		"build":    build.stepMetadata.BuildName,
+	})
+
+	tracing.RegisterBuild(buildID, ctx)
+	defer tracing.ForgetBuild(buildID)
+
 	stepFactory := build.buildStepFactory(logger, build.metadata.Plan)
 	source := stepFactory.Using(worker.NewArtifactRepository())
 
@@ -142,6 +156,7 @@ func (build *execBuild) Resume(logger lager.Logger) {
 		select {
 		case <-build.releaseCh:
 			logger.Info("releasing")
+			tracing.End(span, nil)
 			return
 		case err := <-exited:
 			if !aborted {
@@ -149,6 +164,7 @@ func (build *execBuild) Resume(logger lager.Logger) {
 			}
 
 			build.delegate.Finish(logger.Session("finish"), err, exec.Success(succeeded), aborted)
+			tracing.End(span, err)
 			return
 
 		case sig := <-build.signals:
@@ -191,15 +207,15 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 	}
 
 	if plan.Task != nil {
-		return build.buildTaskStep(logger, plan)
+		return build.traced(plan, "task", plan.Task.Name, build.buildTaskStep(logger, plan))
 	}
 
 	if plan.Get != nil {
-		return build.buildGetStep(logger, plan)
+		return build.traced(plan, "get", plan.Get.Name, build.buildGetStep(logger, plan))
 	}
 
 	if plan.Put != nil {
-		return build.buildPutStep(logger, plan)
+		return build.traced(plan, "put", plan.Put.Name, build.buildPutStep(logger, plan))
 	}
 
 	if plan.Retry != nil {
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
@@ -28,22 +29,49 @@ type BuildDelegateFactory interface {
 
 type buildDelegateFactory struct{}
 
+// NewBuildDelegateFactory constructs a factory for delegates which redact the
+// values of the credentials interpolated for each build from its logs, apart
+// from those of the variables its job lists as unredacted_vars.
 func NewBuildDelegateFactory() BuildDelegateFactory {
 	return buildDelegateFactory{}
 }
 
 func (factory buildDelegateFactory) Delegate(build db.Build) BuildDelegate {
-	return newBuildDelegate(build)
+	return newBuildDelegate(build, creds.NewRedactor(unredactedVariables(build)))
+}
+
+// unredactedVariables returns the variables listed as unredacted by the
+// build's job. If they can not be looked up every value is redacted.
+func unredactedVariables(build db.Build) []string {
+	if build.JobName() == "" {
+		return nil
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil || !found {
+		return nil
+	}
+
+	job, found, err := pipeline.Job(build.JobName())
+	if err != nil || !found {
+		return nil
+	}
+
+	return job.Config().UnredactedVars
 }
 
 type delegate struct {
 	build               db.Build
+	redactor            *creds.Redactor
+	logWriters          *logWriters
 	implicitOutputsRepo *implicitOutputsRepo
 }
 
-func newBuildDelegate(build db.Build) BuildDelegate {
+func newBuildDelegate(build db.Build, redactor *creds.Redactor) BuildDelegate {
 	return &delegate{
-		build: build,
+		build:      build,
+		redactor:   redactor,
+		logWriters: newLogWriters(),
 
 		implicitOutputsRepo: &implicitOutputsRepo{
 			outputs: make(map[string]implicitOutput),
@@ -55,7 +83,13 @@ func newBuildDelegate(build db.Build) BuildDelegate {
 func (delegate *delegate) DBActionsBuildEventsDelegate(
 	planID atc.PlanID,
 ) exec.ActionsBuildEventsDelegate {
-	return NewDBActionsBuildEventsDelegate(delegate.build, event.Origin{ID: event.OriginID(planID)}, delegate.implicitOutputsRepo)
+	return NewDBActionsBuildEventsDelegate(
+		delegate.build,
+		event.Origin{ID: event.OriginID(planID)},
+		delegate.implicitOutputsRepo,
+		delegate.redactor,
+		delegate.logWriters,
+	)
 }
 
 func (delegate *delegate) DBTaskBuildEventsDelegate(
@@ -66,12 +100,19 @@ func (delegate *delegate) DBTaskBuildEventsDelegate(
 
 func (delegate *delegate) ImageFetchingDelegate(planID atc.PlanID) exec.ImageFetchingDelegate {
 	return &imageFetchingDelegate{
-		build:  delegate.build,
-		planID: planID,
+		build:      delegate.build,
+		planID:     planID,
+		redactor:   delegate.redactor,
+		logWriters: delegate.logWriters,
 	}
 }
 
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.Success, aborted bool) {
+	flushErr := delegate.logWriters.FlushAll()
+	if flushErr != nil {
+		logger.Error("failed-to-flush-logs", flushErr)
+	}
+
 	if aborted {
 		delegate.saveStatus(logger, atc.StatusAborted)
 
@@ -79,7 +120,7 @@ func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.
 	} else if err != nil {
 		delegate.saveStatus(logger, atc.StatusErrored)
 
-		logger.Info("errored", lager.Data{"error": err.Error()})
+		logger.Info("errored", lager.Data{"error": delegate.redactor.Redact(err.Error())})
 	} else if bool(succeeded) {
 		delegate.saveStatus(logger, atc.StatusSucceeded)
 
@@ -31,7 +31,19 @@ var _ = Describe("BuildDelegate", func() {
 	BeforeEach(func() {
 		factory = NewBuildDelegateFactory()
 
+		fakeJob := new(dbfakes.FakeJob)
+		fakeJob.ConfigReturns(atc.JobConfig{
+			Name:           "some-job",
+			UnredactedVars: []string{"some-public-var"},
+		})
+
+		fakePipeline := new(dbfakes.FakePipeline)
+		fakePipeline.JobReturns(fakeJob, true, nil)
+
 		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
 		delegate = factory.Delegate(fakeBuild)
 
 		logger = lagertest.NewTestLogger("test")
@@ -104,4 +116,117 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 	})
+
+	Describe("ImageFetchingDelegate", func() {
+		var imageFetchingDelegate exec.ImageFetchingDelegate
+
+		savedLogs := func(origin event.Origin) string {
+			logs := ""
+			for i := 0; i < fakeBuild.SaveEventCallCount(); i++ {
+				log, ok := fakeBuild.SaveEventArgsForCall(i).(event.Log)
+				if ok && log.Origin == origin {
+					logs += log.Payload
+				}
+			}
+
+			return logs
+		}
+
+		BeforeEach(func() {
+			imageFetchingDelegate = delegate.ImageFetchingDelegate(atc.PlanID(originID))
+
+			imageFetchingDelegate.SecretTracker().Track("some-secret", "super-secret-value")
+			imageFetchingDelegate.SecretTracker().Track("some-public-var", "public-value")
+		})
+
+		It("redacts the credentials interpolated for the build from its logs", func() {
+			_, err := imageFetchingDelegate.Stdout().Write([]byte("super-secret-value c3VwZXItc2VjcmV0LXZhbHVl public-value"))
+			Expect(err).NotTo(HaveOccurred())
+
+			delegate.DBActionsBuildEventsDelegate(atc.PlanID(originID)).ActionCompleted(logger, nil)
+
+			Expect(savedLogs(event.Origin{
+				Source: event.OriginSourceStdout,
+				ID:     originID,
+			})).To(Equal("((redacted)) ((redacted)) public-value"))
+		})
+
+		Context("when the build does not belong to a job", func() {
+			BeforeEach(func() {
+				fakeBuild.JobNameReturns("")
+
+				delegate = factory.Delegate(fakeBuild)
+				imageFetchingDelegate = delegate.ImageFetchingDelegate(atc.PlanID(originID))
+
+				imageFetchingDelegate.SecretTracker().Track("some-public-var", "public-value")
+			})
+
+			It("redacts the values of every variable", func() {
+				_, err := imageFetchingDelegate.Stdout().Write([]byte("public-value"))
+				Expect(err).NotTo(HaveOccurred())
+
+				delegate.DBActionsBuildEventsDelegate(atc.PlanID(originID)).ActionCompleted(logger, nil)
+
+				Expect(savedLogs(event.Origin{
+					Source: event.OriginSourceStdout,
+					ID:     originID,
+				})).To(Equal("((redacted))"))
+			})
+		})
+
+		It("redacts credentials tracked by other steps of the build", func() {
+			otherDelegate := delegate.ImageFetchingDelegate(atc.PlanID("some-other-origin-id"))
+
+			_, err := otherDelegate.Stderr().Write([]byte("super-secret-value"))
+			Expect(err).NotTo(HaveOccurred())
+
+			delegate.DBActionsBuildEventsDelegate(atc.PlanID("some-other-origin-id")).ActionCompleted(logger, nil)
+
+			Expect(savedLogs(event.Origin{
+				Source: event.OriginSourceStderr,
+				ID:     event.OriginID("some-other-origin-id"),
+			})).To(Equal("((redacted))"))
+		})
+
+		It("redacts credentials split across writes", func() {
+			stdout := imageFetchingDelegate.Stdout()
+
+			_, err := stdout.Write([]byte("some output leading up to the password: super-sec"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = stdout.Write([]byte("ret-value and then some more output\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			delegate.DBActionsBuildEventsDelegate(atc.PlanID(originID)).ActionCompleted(logger, nil)
+
+			Expect(savedLogs(event.Origin{
+				Source: event.OriginSourceStdout,
+				ID:     originID,
+			})).To(Equal("some output leading up to the password: ((redacted)) and then some more output\n"))
+		})
+
+		It("saves the held back output when the build finishes", func() {
+			_, err := imageFetchingDelegate.Stdout().Write([]byte("super-sec"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+
+			delegate.Finish(logger, nil, exec.Success(true), false)
+
+			Expect(savedLogs(event.Origin{
+				Source: event.OriginSourceStdout,
+				ID:     originID,
+			})).To(Equal("super-sec"))
+		})
+
+		It("redacts the credentials from the errors of the build's steps", func() {
+			delegate.DBActionsBuildEventsDelegate(atc.PlanID(originID)).Failed(logger, errors.New("script failed: super-secret-value"))
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Error{
+				Message: "script failed: ((redacted))",
+				Origin:  event.Origin{ID: originID},
+			}))
+		})
+	})
 })
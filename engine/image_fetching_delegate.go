@@ -6,14 +6,17 @@ import (
 	"unicode/utf8"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
 
 type imageFetchingDelegate struct {
-	build  db.Build
-	planID atc.PlanID
+	build      db.Build
+	planID     atc.PlanID
+	redactor   *creds.Redactor
+	logWriters *logWriters
 }
 
 func (delegate *imageFetchingDelegate) ImageVersionDetermined(resourceCache *db.UsedResourceCache) error {
@@ -21,53 +24,134 @@ func (delegate *imageFetchingDelegate) ImageVersionDetermined(resourceCache *db.
 }
 
 func (delegate *imageFetchingDelegate) Stdout() io.Writer {
-	return newDBEventWriter(
+	return delegate.logWriters.Writer(
 		delegate.build,
 		event.Origin{
 			Source: event.OriginSourceStdout,
 			ID:     event.OriginID(delegate.planID),
 		},
+		delegate.redactor,
 	)
 }
 
 func (delegate *imageFetchingDelegate) Stderr() io.Writer {
-	return newDBEventWriter(
+	return delegate.logWriters.Writer(
 		delegate.build,
 		event.Origin{
 			Source: event.OriginSourceStderr,
 			ID:     event.OriginID(delegate.planID),
 		},
+		delegate.redactor,
 	)
 }
 
-func newDBEventWriter(build db.Build, origin event.Origin) io.Writer {
-	return &dbEventWriter{
-		build:  build,
-		origin: origin,
+func (delegate *imageFetchingDelegate) SecretTracker() creds.SecretTracker {
+	return delegate.redactor
+}
+
+// logWriters keeps track of the log writers of a build's steps, so that the
+// text they hold back can be flushed once each step is done writing.
+type logWriters struct {
+	writers map[event.OriginID][]*dbEventWriter
+	lock    sync.Mutex
+}
+
+func newLogWriters() *logWriters {
+	return &logWriters{
+		writers: map[event.OriginID][]*dbEventWriter{},
+	}
+}
+
+func (writers *logWriters) Writer(build db.Build, origin event.Origin, redactor *creds.Redactor) io.Writer {
+	writer := &dbEventWriter{
+		build:    build,
+		origin:   origin,
+		redactor: redactor,
+	}
+
+	writers.lock.Lock()
+	writers.writers[origin.ID] = append(writers.writers[origin.ID], writer)
+	writers.lock.Unlock()
+
+	return writer
+}
+
+// Flush saves the text held back by the writers of the step.
+func (writers *logWriters) Flush(id event.OriginID) error {
+	writers.lock.Lock()
+	stepWriters := writers.writers[id]
+	delete(writers.writers, id)
+	writers.lock.Unlock()
+
+	for _, writer := range stepWriters {
+		err := writer.Flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FlushAll saves the text held back by the writers of every step.
+func (writers *logWriters) FlushAll() error {
+	writers.lock.Lock()
+	ids := []event.OriginID{}
+	for id := range writers.writers {
+		ids = append(ids, id)
+	}
+	writers.lock.Unlock()
+
+	for _, id := range ids {
+		err := writers.Flush(id)
+		if err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
+// dbEventWriter saves the text written to it as log events, with the values
+// of credentials redacted. Output is split at arbitrary points, so the end of
+// each write which could be the start of a credential is held back until the
+// next write, or until the writer is flushed.
 type dbEventWriter struct {
 	build db.Build
 
-	origin event.Origin
+	origin   event.Origin
+	redactor *creds.Redactor
 
-	dangling []byte
+	pending []byte
+	lock    sync.Mutex
 }
 
 func (writer *dbEventWriter) Write(data []byte) (int, error) {
-	text := append(writer.dangling, data...)
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	text := append(writer.pending, data...)
+
+	// hold back a trailing partial rune until the rest of it is written
+	complete := len(text)
+	for i := len(text) - 1; i >= 0 && i >= len(text)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(text[i]) {
+			if !utf8.FullRune(text[i:]) {
+				complete = i
+			}
+			break
+		}
+	}
+
+	redacted, rest := writer.redactor.RedactPrefix(string(text[:complete]))
+	writer.pending = append([]byte(rest), text[complete:]...)
 
-	checkEncoding, _ := utf8.DecodeLastRune(text)
-	if checkEncoding == utf8.RuneError {
-		writer.dangling = text
+	if redacted == "" {
 		return len(data), nil
 	}
 
-	writer.dangling = nil
-
 	err := writer.build.SaveEvent(event.Log{
-		Payload: string(text),
+		Payload: redacted,
 		Origin:  writer.origin,
 	})
 	if err != nil {
@@ -77,6 +161,24 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 	return len(data), nil
 }
 
+// Flush saves whatever text is held back.
+func (writer *dbEventWriter) Flush() error {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	if len(writer.pending) == 0 {
+		return nil
+	}
+
+	text := string(writer.pending)
+	writer.pending = nil
+
+	return writer.build.SaveEvent(event.Log{
+		Payload: writer.redactor.Redact(text),
+		Origin:  writer.origin,
+	})
+}
+
 type implicitOutput struct {
 	resourceType string
 	info         exec.VersionInfo
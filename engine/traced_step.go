@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/tracing"
+	"github.com/concourse/atc/worker"
+)
+
+func (build *execBuild) traced(plan atc.Plan, stepType string, name string, stepFactory exec.StepFactory) exec.StepFactory {
+	return tracedStepFactory{
+		buildID:     build.dbBuild.ID(),
+		planID:      plan.ID,
+		stepType:    stepType,
+		name:        name,
+		stepFactory: stepFactory,
+	}
+}
+
+// tracedStepFactory runs a get, put or task step within a span, as a child of
+// the build's span; the steps composing them are not traced. The span is
+// registered for the duration of the step so that the work done for it, e.g.
+// on workers, is traced as part of it.
+type tracedStepFactory struct {
+	buildID  int
+	planID   atc.PlanID
+	stepType string
+	name     string
+
+	stepFactory exec.StepFactory
+}
+
+func (factory tracedStepFactory) Using(repo *worker.ArtifactRepository) exec.Step {
+	return tracedStep{
+		Step: factory.stepFactory.Using(repo),
+
+		factory: factory,
+	}
+}
+
+type tracedStep struct {
+	exec.Step
+
+	factory tracedStepFactory
+}
+
+func (step tracedStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	factory := step.factory
+
+	attrs := tracing.Attrs{
+		"build-id": strconv.Itoa(factory.buildID),
+		"plan-id":  string(factory.planID),
+	}
+
+	if factory.name != "" {
+		attrs["name"] = factory.name
+	}
+
+	ctx, span := tracing.StartSpan(tracing.BuildContext(factory.buildID), "exec."+factory.stepType, attrs)
+
+	tracing.RegisterStep(factory.buildID, string(factory.planID), ctx)
+	defer tracing.ForgetStep(factory.buildID, string(factory.planID))
+
+	err := step.Step.Run(signals, ready)
+
+	tracing.End(span, err)
+
+	return err
+}
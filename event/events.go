@@ -78,6 +78,29 @@ type StartTask struct {
 func (StartTask) EventType() atc.EventType  { return EventTypeStartTask }
 func (StartTask) Version() atc.EventVersion { return "5.0" }
 
+type TaskResultReused struct {
+	Time      int64  `json:"time"`
+	Origin    Origin `json:"origin"`
+	BuildID   int    `json:"build_id"`
+	BuildName string `json:"build_name"`
+}
+
+func (TaskResultReused) EventType() atc.EventType  { return EventTypeTaskResultReused }
+func (TaskResultReused) Version() atc.EventVersion { return "1.0" }
+
+type TestReport struct {
+	Time     int64    `json:"time"`
+	Origin   Origin   `json:"origin"`
+	Total    int      `json:"total"`
+	Passed   int      `json:"passed"`
+	Failed   int      `json:"failed"`
+	Skipped  int      `json:"skipped"`
+	Failures []string `json:"failures"`
+}
+
+func (TestReport) EventType() atc.EventType  { return EventTypeTestReport }
+func (TestReport) Version() atc.EventVersion { return "1.0" }
+
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
 	Time   int64           `json:"time"`
@@ -86,6 +109,26 @@ type Status struct {
 func (Status) EventType() atc.EventType  { return EventTypeStatus }
 func (Status) Version() atc.EventVersion { return "1.0" }
 
+type Approval struct {
+	Status    atc.ApprovalStatus `json:"status"`
+	Approvers []string           `json:"approvers,omitempty"`
+	Approver  string             `json:"approver,omitempty"`
+	Comment   string             `json:"comment,omitempty"`
+	Time      int64              `json:"time"`
+
+	// Inputs are the versions the build will run with once approved.
+	Inputs []ApprovalInput `json:"inputs,omitempty"`
+}
+
+type ApprovalInput struct {
+	Name     string      `json:"name"`
+	Resource string      `json:"resource"`
+	Version  atc.Version `json:"version"`
+}
+
+func (Approval) EventType() atc.EventType  { return EventTypeApproval }
+func (Approval) Version() atc.EventVersion { return "1.0" }
+
 type Log struct {
 	Origin  Origin `json:"origin"`
 	Payload string `json:"payload"`
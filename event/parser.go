@@ -36,11 +36,14 @@ func init() {
 	registerEvent(InitializeTask{})
 	registerEvent(StartTask{})
 	registerEvent(FinishTask{})
+	registerEvent(TaskResultReused{})
+	registerEvent(TestReport{})
 	registerEvent(FinishGet{})
 	registerEvent(FinishPut{})
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
+	registerEvent(Approval{})
 
 	// deprecated:
 	registerEvent(InitializeV10{})
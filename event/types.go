@@ -21,6 +21,12 @@ const (
 	// task execution finished
 	EventTypeFinishTask atc.EventType = "finish-task"
 
+	// task result of an earlier build reused instead of running the task
+	EventTypeTaskResultReused atc.EventType = "task-result-reused"
+
+	// test reports of a task summarized
+	EventTypeTestReport atc.EventType = "test-report"
+
 	// finished getting something
 	EventTypeFinishGet atc.EventType = "finish-get"
 
@@ -29,4 +35,7 @@ const (
 
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// approval requested, granted, rejected or timed out
+	EventTypeApproval atc.EventType = "approval"
 )
@@ -5,6 +5,7 @@ import (
 	"io"
 	"sync"
 
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 )
@@ -39,6 +40,15 @@ type FakeImageFetchingDelegate struct {
 	stderrReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	SecretTrackerStub        func() creds.SecretTracker
+	secretTrackerMutex       sync.RWMutex
+	secretTrackerArgsForCall []struct{}
+	secretTrackerReturns     struct {
+		result1 creds.SecretTracker
+	}
+	secretTrackerReturnsOnCall map[int]struct {
+		result1 creds.SecretTracker
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -171,6 +181,46 @@ func (fake *FakeImageFetchingDelegate) StderrReturnsOnCall(i int, result1 io.Wri
 	}{result1}
 }
 
+func (fake *FakeImageFetchingDelegate) SecretTracker() creds.SecretTracker {
+	fake.secretTrackerMutex.Lock()
+	ret, specificReturn := fake.secretTrackerReturnsOnCall[len(fake.secretTrackerArgsForCall)]
+	fake.secretTrackerArgsForCall = append(fake.secretTrackerArgsForCall, struct{}{})
+	fake.recordInvocation("SecretTracker", []interface{}{})
+	fake.secretTrackerMutex.Unlock()
+	if fake.SecretTrackerStub != nil {
+		return fake.SecretTrackerStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.secretTrackerReturns.result1
+}
+
+func (fake *FakeImageFetchingDelegate) SecretTrackerCallCount() int {
+	fake.secretTrackerMutex.RLock()
+	defer fake.secretTrackerMutex.RUnlock()
+	return len(fake.secretTrackerArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) SecretTrackerReturns(result1 creds.SecretTracker) {
+	fake.SecretTrackerStub = nil
+	fake.secretTrackerReturns = struct {
+		result1 creds.SecretTracker
+	}{result1}
+}
+
+func (fake *FakeImageFetchingDelegate) SecretTrackerReturnsOnCall(i int, result1 creds.SecretTracker) {
+	fake.SecretTrackerStub = nil
+	if fake.secretTrackerReturnsOnCall == nil {
+		fake.secretTrackerReturnsOnCall = make(map[int]struct {
+			result1 creds.SecretTracker
+		})
+	}
+	fake.secretTrackerReturnsOnCall[i] = struct {
+		result1 creds.SecretTracker
+	}{result1}
+}
+
 func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -180,6 +230,8 @@ func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{}
 	defer fake.stdoutMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
+	fake.secretTrackerMutex.RLock()
+	defer fake.secretTrackerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 )
 
@@ -22,6 +23,24 @@ type FakeTaskBuildEventsDelegate struct {
 		arg1 lager.Logger
 		arg2 atc.TaskConfig
 	}
+	ResultReusedStub        func(lager.Logger, db.TaskResult)
+	resultReusedMutex       sync.RWMutex
+	resultReusedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.TaskResult
+	}
+	TestsReportedStub        func(lager.Logger, db.TestReport)
+	testsReportedMutex       sync.RWMutex
+	testsReportedArgsForCall []struct {
+		logger lager.Logger
+		report db.TestReport
+	}
+	MetadataEmittedStub        func(lager.Logger, []atc.MetadataField)
+	metadataEmittedMutex       sync.RWMutex
+	metadataEmittedArgsForCall []struct {
+		logger   lager.Logger
+		metadata []atc.MetadataField
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -76,6 +95,86 @@ func (fake *FakeTaskBuildEventsDelegate) StartingArgsForCall(i int) (lager.Logge
 	return fake.startingArgsForCall[i].arg1, fake.startingArgsForCall[i].arg2
 }
 
+func (fake *FakeTaskBuildEventsDelegate) ResultReused(arg1 lager.Logger, arg2 db.TaskResult) {
+	fake.resultReusedMutex.Lock()
+	fake.resultReusedArgsForCall = append(fake.resultReusedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.TaskResult
+	}{arg1, arg2})
+	fake.recordInvocation("ResultReused", []interface{}{arg1, arg2})
+	fake.resultReusedMutex.Unlock()
+	if fake.ResultReusedStub != nil {
+		fake.ResultReusedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskBuildEventsDelegate) ResultReusedCallCount() int {
+	fake.resultReusedMutex.RLock()
+	defer fake.resultReusedMutex.RUnlock()
+	return len(fake.resultReusedArgsForCall)
+}
+
+func (fake *FakeTaskBuildEventsDelegate) ResultReusedArgsForCall(i int) (lager.Logger, db.TaskResult) {
+	fake.resultReusedMutex.RLock()
+	defer fake.resultReusedMutex.RUnlock()
+	return fake.resultReusedArgsForCall[i].arg1, fake.resultReusedArgsForCall[i].arg2
+}
+
+func (fake *FakeTaskBuildEventsDelegate) TestsReported(logger lager.Logger, report db.TestReport) {
+	fake.testsReportedMutex.Lock()
+	fake.testsReportedArgsForCall = append(fake.testsReportedArgsForCall, struct {
+		logger lager.Logger
+		report db.TestReport
+	}{logger, report})
+	fake.recordInvocation("TestsReported", []interface{}{logger, report})
+	fake.testsReportedMutex.Unlock()
+	if fake.TestsReportedStub != nil {
+		fake.TestsReportedStub(logger, report)
+	}
+}
+
+func (fake *FakeTaskBuildEventsDelegate) TestsReportedCallCount() int {
+	fake.testsReportedMutex.RLock()
+	defer fake.testsReportedMutex.RUnlock()
+	return len(fake.testsReportedArgsForCall)
+}
+
+func (fake *FakeTaskBuildEventsDelegate) TestsReportedArgsForCall(i int) (lager.Logger, db.TestReport) {
+	fake.testsReportedMutex.RLock()
+	defer fake.testsReportedMutex.RUnlock()
+	return fake.testsReportedArgsForCall[i].logger, fake.testsReportedArgsForCall[i].report
+}
+
+func (fake *FakeTaskBuildEventsDelegate) MetadataEmitted(logger lager.Logger, metadata []atc.MetadataField) {
+	var metadataCopy []atc.MetadataField
+	if metadata != nil {
+		metadataCopy = make([]atc.MetadataField, len(metadata))
+		copy(metadataCopy, metadata)
+	}
+	fake.metadataEmittedMutex.Lock()
+	fake.metadataEmittedArgsForCall = append(fake.metadataEmittedArgsForCall, struct {
+		logger   lager.Logger
+		metadata []atc.MetadataField
+	}{logger, metadataCopy})
+	fake.recordInvocation("MetadataEmitted", []interface{}{logger, metadataCopy})
+	fake.metadataEmittedMutex.Unlock()
+	if fake.MetadataEmittedStub != nil {
+		fake.MetadataEmittedStub(logger, metadata)
+	}
+}
+
+func (fake *FakeTaskBuildEventsDelegate) MetadataEmittedCallCount() int {
+	fake.metadataEmittedMutex.RLock()
+	defer fake.metadataEmittedMutex.RUnlock()
+	return len(fake.metadataEmittedArgsForCall)
+}
+
+func (fake *FakeTaskBuildEventsDelegate) MetadataEmittedArgsForCall(i int) (lager.Logger, []atc.MetadataField) {
+	fake.metadataEmittedMutex.RLock()
+	defer fake.metadataEmittedMutex.RUnlock()
+	return fake.metadataEmittedArgsForCall[i].logger, fake.metadataEmittedArgsForCall[i].metadata
+}
+
 func (fake *FakeTaskBuildEventsDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -83,6 +182,12 @@ func (fake *FakeTaskBuildEventsDelegate) Invocations() map[string][][]interface{
 	defer fake.initializingMutex.RUnlock()
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
+	fake.resultReusedMutex.RLock()
+	defer fake.resultReusedMutex.RUnlock()
+	fake.testsReportedMutex.RLock()
+	defer fake.testsReportedMutex.RUnlock()
+	fake.metadataEmittedMutex.RLock()
+	defer fake.metadataEmittedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 )
 
@@ -58,6 +59,10 @@ type ImageFetchingDelegate interface {
 	ImageVersionDetermined(*db.UsedResourceCache) error
 	Stdout() io.Writer
 	Stderr() io.Writer
+
+	// SecretTracker records the credentials interpolated for the step so that
+	// they are redacted from what it writes to Stdout and Stderr.
+	SecretTracker() creds.SecretTracker
 }
 
 // Privileged is used to indicate whether the given step should run with
@@ -19,6 +19,7 @@ type gardenFactory struct {
 	resourceFetcher        resource.Fetcher
 	resourceFactory        resource.ResourceFactory
 	dbResourceCacheFactory db.ResourceCacheFactory
+	dbTaskResultFactory    db.TaskResultFactory
 	variablesFactory       creds.VariablesFactory
 
 	putActions map[atc.PlanID]*PutAction
@@ -29,6 +30,7 @@ func NewGardenFactory(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
+	dbTaskResultFactory db.TaskResultFactory,
 	variablesFactory creds.VariablesFactory,
 ) Factory {
 	return &gardenFactory{
@@ -36,6 +38,7 @@ func NewGardenFactory(
 		resourceFetcher:        resourceFetcher,
 		resourceFactory:        resourceFactory,
 		dbResourceCacheFactory: dbResourceCacheFactory,
+		dbTaskResultFactory:    dbTaskResultFactory,
 		variablesFactory:       variablesFactory,
 		putActions:             map[atc.PlanID]*PutAction{},
 	}
@@ -81,7 +84,10 @@ func (factory *gardenFactory) Get(
 ) StepFactory {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("get")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := creds.NewTrackedVariables(
+		factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()),
+		imageFetchingDelegate.SecretTracker(),
+	)
 
 	getAction := &GetAction{
 		Type:          plan.Get.Type,
@@ -122,7 +128,10 @@ func (factory *gardenFactory) Put(
 ) StepFactory {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := creds.NewTrackedVariables(
+		factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()),
+		imageFetchingDelegate.SecretTracker(),
+	)
 
 	putAction := &PutAction{
 		Type:     plan.Put.Type,
@@ -188,7 +197,10 @@ func (factory *gardenFactory) Task(
 		Action: fetchConfigAction,
 	}
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := creds.NewTrackedVariables(
+		factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()),
+		imageFetchingDelegate.SecretTracker(),
+	)
 
 	taskAction := &TaskAction{
 		privileged:    Privileged(plan.Task.Privileged),
@@ -199,10 +211,13 @@ func (factory *gardenFactory) Task(
 
 		artifactsRoot:     workingDirectory,
 		imageArtifactName: plan.Task.ImageArtifactName,
+		cacheResult:       plan.Task.CacheResult,
+		artifacts:         plan.Task.Artifacts,
 
 		buildEventsDelegate:   taskBuildEventsDelegate,
 		imageFetchingDelegate: imageFetchingDelegate,
 		workerPool:            factory.workerClient,
+		taskResultFactory:     factory.dbTaskResultFactory,
 		teamID:                build.TeamID(),
 		buildID:               build.ID(),
 		jobID:                 build.JobID(),
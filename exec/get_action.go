@@ -2,6 +2,7 @@ package exec
 
 import (
 	"archive/tar"
+	"fmt"
 	"io"
 	"os"
 
@@ -169,6 +170,13 @@ func (s *getArtifactSource) VolumeOn(worker worker.Worker) (worker.Volume, bool,
 	return s.resourceInstance.FindOn(s.logger.Session("volume-on"), worker)
 }
 
+// Identity identifies the resource's data by its resource cache, which
+// covers the fetched version along with the source and params used to fetch
+// it.
+func (s *getArtifactSource) Identity() string {
+	return fmt.Sprintf("resource-cache:%d", s.resourceInstance.ResourceCache().ID)
+}
+
 // StreamTo streams the resource's data to the destination.
 func (s *getArtifactSource) StreamTo(destination worker.ArtifactDestination) error {
 	out, err := s.versionedSource.StreamOut(".")
@@ -2,12 +2,16 @@ package exec
 
 import (
 	"archive/tar"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"code.cloudfoundry.org/garden"
@@ -15,6 +19,8 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/testreport"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 )
 
@@ -22,6 +28,20 @@ const taskProcessID = "task"
 const taskProcessPropertyName = "concourse:task-process"
 const taskExitStatusPropertyName = "concourse:exit-status"
 
+// TaskMetadataFileName is the file in the task's working directory from which
+// metadata about the build is read once the task exits. It has the format of
+// the metadata emitted by resources, e.g. [{"name":"url","value":"..."}].
+const TaskMetadataFileName = "build-metadata.json"
+
+// Limits on the metadata read from TaskMetadataFileName, as it is saved to
+// the build and shown with it.
+const (
+	MaxTaskMetadataBytes       = 64 * 1024
+	MaxTaskMetadataFields      = 50
+	MaxTaskMetadataNameLength  = 128
+	MaxTaskMetadataValueLength = 4096
+)
+
 // MissingInputsError is returned when any of the task's required inputs are
 // missing.
 type MissingInputsError struct {
@@ -43,6 +63,16 @@ func (err MissingTaskImageSourceError) Error() string {
 make sure there's a corresponding 'get' step, or a task that produces it as an output`, err.SourceName)
 }
 
+// MissingTaskOutputError is returned when an artifact is configured for an
+// output which the task does not have.
+type MissingTaskOutputError struct {
+	OutputName string
+}
+
+func (err MissingTaskOutputError) Error() string {
+	return fmt.Sprintf("artifact references an output which the task does not have: %s", err.OutputName)
+}
+
 type TaskImageSourceParametersError struct {
 	Err error
 }
@@ -71,6 +101,9 @@ func (s *FetchConfigActionTaskConfigSource) GetTaskConfig() (atc.TaskConfig, err
 type TaskBuildEventsDelegate interface {
 	Initializing(lager.Logger, atc.TaskConfig)
 	Starting(lager.Logger, atc.TaskConfig)
+	ResultReused(lager.Logger, db.TaskResult)
+	TestsReported(lager.Logger, db.TestReport)
+	MetadataEmitted(lager.Logger, []atc.MetadataField)
 }
 
 // TaskAction executes a TaskConfig, whose inputs will be fetched from the
@@ -85,10 +118,13 @@ type TaskAction struct {
 	// TODO: replace with RootFSSource
 	artifactsRoot     string
 	imageArtifactName string
+	cacheResult       bool
+	artifacts         []string
 
 	buildEventsDelegate   TaskBuildEventsDelegate
 	imageFetchingDelegate ImageFetchingDelegate
 	workerPool            worker.Client
+	taskResultFactory     db.TaskResultFactory
 	teamID                int
 	buildID               int
 	jobID                 int
@@ -111,9 +147,12 @@ func NewTaskAction(
 	outputMapping map[string]string,
 	artifactsRoot string,
 	imageArtifactName string,
+	cacheResult bool,
+	artifacts []string,
 	buildEventsDelegate TaskBuildEventsDelegate,
 	imageFetchingDelegate ImageFetchingDelegate,
 	workerPool worker.Client,
+	taskResultFactory db.TaskResultFactory,
 	teamID int,
 	buildID int,
 	jobID int,
@@ -131,9 +170,12 @@ func NewTaskAction(
 		outputMapping:         outputMapping,
 		artifactsRoot:         artifactsRoot,
 		imageArtifactName:     imageArtifactName,
+		cacheResult:           cacheResult,
+		artifacts:             artifacts,
 		buildEventsDelegate:   buildEventsDelegate,
 		imageFetchingDelegate: imageFetchingDelegate,
 		workerPool:            workerPool,
+		taskResultFactory:     taskResultFactory,
 		teamID:                teamID,
 		buildID:               buildID,
 		jobID:                 jobID,
@@ -161,6 +203,11 @@ func NewTaskAction(
 // are registered with the worker.ArtifactRepository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
 // name of the task.
+//
+// If the task step caches its result and an earlier build of the job ran the
+// task successfully with the same config, params, image and inputs, the
+// outputs of that run are registered instead, without running the script.
+// Metadata written by that run is not emitted again for the build reusing it.
 func (action *TaskAction) Run(
 	logger lager.Logger,
 	repository *worker.ArtifactRepository,
@@ -176,11 +223,36 @@ func (action *TaskAction) Run(
 
 	action.buildEventsDelegate.Initializing(logger, config)
 
+	for _, artifact := range action.artifacts {
+		if _, found := taskOutput(config, artifact); !found {
+			return MissingTaskOutputError{OutputName: artifact}
+		}
+	}
+
 	containerSpec, err := action.containerSpec(logger, repository, config)
 	if err != nil {
 		return err
 	}
 
+	var resultKey string
+	if action.cachesResult(config) {
+		resultKey, err = action.resultKey(logger, containerSpec, config)
+		if err != nil {
+			return err
+		}
+
+		if resultKey != "" {
+			reused, err := action.reuseResult(logger, repository, config, resultKey)
+			if err != nil {
+				return err
+			}
+
+			if reused {
+				return nil
+			}
+		}
+	}
+
 	container, err := action.workerPool.FindOrCreateContainer(
 		logger,
 		signals,
@@ -238,6 +310,7 @@ func (action *TaskAction) Run(
 			Args: config.Run.Args,
 
 			Dir: path.Join(action.artifactsRoot, config.Run.Dir),
+			Env: tracing.Env(tracing.StepContext(action.buildID, string(action.planID))),
 			TTY: &garden.TTYSpec{},
 		}, processIO)
 	}
@@ -284,6 +357,15 @@ func (action *TaskAction) Run(
 			return err
 		}
 
+		err = action.keepArtifacts(logger, config, container)
+		if err != nil {
+			return err
+		}
+
+		action.reportTests(logger, config, container)
+
+		action.emitMetadata(logger, config, container)
+
 		action.exitStatus = ExitStatus(processStatus)
 
 		err = container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", processStatus))
@@ -291,6 +373,13 @@ func (action *TaskAction) Run(
 			return err
 		}
 
+		if resultKey != "" && processStatus == 0 {
+			err = action.saveResult(logger, config, container, resultKey)
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}
 }
@@ -383,23 +472,385 @@ func (action *TaskAction) containerSpec(logger lager.Logger, repository *worker.
 	return containerSpec, nil
 }
 
-func (action *TaskAction) registerOutputs(logger lager.Logger, repository *worker.ArtifactRepository, config atc.TaskConfig, container worker.Container) error {
+// results are only cached for builds of jobs, like task caches. Tasks which
+// keep artifacts or report tests are always run, as both are recorded from
+// the task's container for the build running it.
+func (action *TaskAction) cachesResult(config atc.TaskConfig) bool {
+	if !action.cacheResult || action.jobID == 0 {
+		return false
+	}
+
+	return len(action.artifacts) == 0 && len(config.Reports) == 0
+}
+
+type taskResultIdentity struct {
+	Config     atc.TaskConfig    `json:"config"`
+	Env        []string          `json:"env"`
+	Privileged bool              `json:"privileged"`
+	Image      string            `json:"image"`
+	Inputs     map[string]string `json:"inputs"`
+}
+
+// resultKey hashes everything that determines the result of the task. An
+// empty key is returned if the task's image or inputs can not be identified
+// without running it, in which case its result is not cached.
+func (action *TaskAction) resultKey(logger lager.Logger, containerSpec worker.ContainerSpec, config atc.TaskConfig) (string, error) {
+	image, reason, err := action.imageIdentity(containerSpec.ImageSpec)
+	if err != nil {
+		return "", err
+	}
+
+	inputs := map[string]string{}
+	for _, input := range containerSpec.Inputs {
+		// caches only speed the task up and do not determine its result
+		if _, isCache := input.(*taskCacheInputSource); isCache {
+			continue
+		}
+
+		identifiable, ok := input.Source().(identifiableArtifactSource)
+		if !ok {
+			reason = fmt.Sprintf("the input at %s can not be identified", input.DestinationPath())
+			break
+		}
+
+		inputs[input.DestinationPath()] = identifiable.Identity()
+	}
+
+	if reason != "" {
+		logger.Info("result-not-cacheable", lager.Data{"reason": reason})
+		fmt.Fprintf(action.imageFetchingDelegate.Stderr(), "not caching the task's result: %s\n", reason)
+		return "", nil
+	}
+
+	env := make([]string, len(containerSpec.Env))
+	copy(env, containerSpec.Env)
+	sort.Strings(env)
+
+	payload, err := json.Marshal(taskResultIdentity{
+		Config:     config,
+		Env:        env,
+		Privileged: bool(action.privileged),
+		Image:      image,
+		Inputs:     inputs,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(payload)), nil
+}
+
+func (action *TaskAction) imageIdentity(imageSpec worker.ImageSpec) (string, string, error) {
+	switch {
+	case imageSpec.ImageArtifactSource != nil:
+		identifiable, ok := imageSpec.ImageArtifactSource.(identifiableArtifactSource)
+		if !ok {
+			return "", fmt.Sprintf("the image artifact %s can not be identified", imageSpec.ImageArtifactName), nil
+		}
+
+		return identifiable.Identity(), "", nil
+
+	case imageSpec.ImageResource != nil:
+		// the latest version is only known once the image is fetched
+		if imageSpec.ImageResource.Version == nil {
+			return "", "the version of the image resource is not pinned", nil
+		}
+
+		source, err := imageSpec.ImageResource.Source.Evaluate()
+		if err != nil {
+			return "", "", err
+		}
+
+		payload, err := json.Marshal(atc.ImageResource{
+			Type:    imageSpec.ImageResource.Type,
+			Source:  source,
+			Params:  imageSpec.ImageResource.Params,
+			Version: imageSpec.ImageResource.Version,
+		})
+		if err != nil {
+			return "", "", err
+		}
+
+		return string(payload), "", nil
+
+	default:
+		return imageSpec.ImageURL, "", nil
+	}
+}
+
+func (action *TaskAction) reuseResult(logger lager.Logger, repository *worker.ArtifactRepository, config atc.TaskConfig, resultKey string) (bool, error) {
+	result, found, err := action.taskResultFactory.Find(action.jobID, action.stepName, resultKey)
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	workers, err := action.workerPool.RunningWorkers(logger)
+	if err != nil {
+		return false, err
+	}
+
+	sources := map[worker.ArtifactName]worker.ArtifactSource{}
+	for _, output := range config.Outputs {
+		volume, found, err := action.findResultVolume(logger, workers, resultKey, output.Name)
+		if err != nil {
+			return false, err
+		}
+
+		if !found {
+			logger.Info("result-output-missing", lager.Data{"output": output.Name, "build": result.BuildID})
+			return false, nil
+		}
+
+		sources[action.outputArtifactName(output.Name)] = newTaskArtifactSource(logger, volume)
+	}
+
+	for name, source := range sources {
+		repository.RegisterSource(name, source)
+	}
+
+	logger.Info("reusing-result", lager.Data{"build": result.BuildID})
+
+	fmt.Fprintf(action.imageFetchingDelegate.Stdout(), "result reused from build %s\n", result.BuildName)
+	action.buildEventsDelegate.ResultReused(logger, result)
+
+	action.exitStatus = ExitStatus(0)
+
+	return true, nil
+}
+
+func (action *TaskAction) findResultVolume(logger lager.Logger, workers []worker.Worker, resultKey string, outputName string) (worker.Volume, bool, error) {
+	path := db.TaskResultOutputPath(resultKey, outputName)
+
+	for _, w := range workers {
+		volume, found, err := w.FindVolumeForTaskCache(logger, action.teamID, action.jobID, action.stepName, path)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if found {
+			return volume, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// saveResult keeps the output volumes of the run as task caches, so they are
+// not garbage collected with the container, and records them for later builds.
+func (action *TaskAction) saveResult(logger lager.Logger, config atc.TaskConfig, container worker.Container, resultKey string) error {
+	logger.Debug("saving-result", lager.Data{"outputs": config.Outputs})
+
 	volumeMounts := container.VolumeMounts()
 
-	logger.Debug("registering-outputs", lager.Data{"outputs": config.Outputs})
+	for _, output := range config.Outputs {
+		outputPath := artifactsPath(output, action.artifactsRoot)
+
+		for _, mount := range volumeMounts {
+			if mount.MountPath == outputPath {
+				err := mount.Volume.InitializeTaskCache(logger, action.jobID, action.stepName, db.TaskResultOutputPath(resultKey, output.Name), bool(action.privileged))
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
 
+	return action.taskResultFactory.Save(action.jobID, action.stepName, resultKey, action.buildID)
+}
+
+// keepArtifacts keeps the volumes of the outputs configured as artifacts
+// after the build finishes, whether or not the task succeeded.
+func (action *TaskAction) keepArtifacts(logger lager.Logger, config atc.TaskConfig, container worker.Container) error {
+	if len(action.artifacts) == 0 {
+		return nil
+	}
+
+	logger.Debug("keeping-artifacts", lager.Data{"artifacts": action.artifacts})
+
+	volumeMounts := container.VolumeMounts()
+
+	for _, artifact := range action.artifacts {
+		// checked before running the task
+		output, _ := taskOutput(config, artifact)
+
+		outputPath := artifactsPath(output, action.artifactsRoot)
+
+		for _, mount := range volumeMounts {
+			if mount.MountPath == outputPath {
+				err := mount.Volume.InitializeArtifact(logger, action.buildID, string(action.outputArtifactName(output.Name)), bool(action.privileged))
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// reportTests summarizes the test reports written by the task. Reports which
+// are missing or cannot be parsed do not fail the task.
+func (action *TaskAction) reportTests(logger lager.Logger, config atc.TaskConfig, container worker.Container) {
+	if len(config.Reports) == 0 {
+		return
+	}
+
+	logger.Debug("reporting-tests", lager.Data{"reports": config.Reports})
+
+	volumeMounts := container.VolumeMounts()
+
+	summary := testreport.Summary{
+		Passes:   []string{},
+		Failures: []string{},
+	}
+
+	for _, report := range config.Reports {
+		// checked when validating the config
+		output, reportPath, _ := config.ReportOutput(report)
+
+		outputPath := artifactsPath(output, action.artifactsRoot)
+
+		for _, mount := range volumeMounts {
+			if mount.MountPath != outputPath {
+				continue
+			}
+
+			reportSummary, err := action.parseReport(logger, mount.Volume, reportPath, report.Format)
+			if err != nil {
+				logger.Info("failed-to-parse-report", lager.Data{"report": report.Path, "error": err.Error()})
+				fmt.Fprintf(action.imageFetchingDelegate.Stderr(), "failed to parse test report %s: %s\n", report.Path, err)
+				continue
+			}
+
+			summary = summary.Merge(reportSummary)
+		}
+	}
+
+	action.buildEventsDelegate.TestsReported(logger, db.TestReport{
+		StepName: action.stepName,
+		Total:    summary.Total,
+		Passed:   summary.Passed,
+		Failed:   summary.Failed,
+		Skipped:  summary.Skipped,
+		Passes:   summary.Passes,
+		Failures: summary.Failures,
+	})
+}
+
+// emitMetadata saves the metadata written by the task to the build. Most
+// tasks do not write any, and invalid metadata does not fail the task.
+func (action *TaskAction) emitMetadata(logger lager.Logger, config atc.TaskConfig, container worker.Container) {
+	metadataPath := path.Join(action.artifactsRoot, config.Run.Dir, TaskMetadataFileName)
+
+	out, err := container.StreamOut(garden.StreamOutSpec{
+		Path: metadataPath,
+	})
+	if err != nil {
+		logger.Debug("no-metadata-written", lager.Data{"error": err.Error()})
+		return
+	}
+
+	defer out.Close()
+
+	tarReader := tar.NewReader(out)
+
+	_, err = tarReader.Next()
+	if err != nil {
+		logger.Debug("no-metadata-written", lager.Data{"error": err.Error()})
+		return
+	}
+
+	// read one byte past the limit to tell whether the file exceeds it
+	contents, err := ioutil.ReadAll(io.LimitReader(tarReader, MaxTaskMetadataBytes+1))
+	if err != nil {
+		logger.Debug("no-metadata-written", lager.Data{"error": err.Error()})
+		return
+	}
+
+	if len(contents) > MaxTaskMetadataBytes {
+		action.rejectMetadata(logger, fmt.Sprintf("%s is larger than %d bytes", TaskMetadataFileName, MaxTaskMetadataBytes))
+		return
+	}
+
+	var metadata []atc.MetadataField
+	err = json.Unmarshal(contents, &metadata)
+	if err != nil {
+		action.rejectMetadata(logger, fmt.Sprintf("failed to parse %s: %s", TaskMetadataFileName, err))
+		return
+	}
+
+	if len(metadata) > MaxTaskMetadataFields {
+		action.rejectMetadata(logger, fmt.Sprintf("%s has more than %d fields", TaskMetadataFileName, MaxTaskMetadataFields))
+		return
+	}
+
+	for _, field := range metadata {
+		if len(field.Name) > MaxTaskMetadataNameLength {
+			action.rejectMetadata(logger, fmt.Sprintf("%s field name '%s...' is longer than %d bytes", TaskMetadataFileName, field.Name[:MaxTaskMetadataNameLength], MaxTaskMetadataNameLength))
+			return
+		}
+
+		if len(field.Value) > MaxTaskMetadataValueLength {
+			action.rejectMetadata(logger, fmt.Sprintf("%s field '%s' is longer than %d bytes", TaskMetadataFileName, field.Name, MaxTaskMetadataValueLength))
+			return
+		}
+	}
+
+	action.buildEventsDelegate.MetadataEmitted(logger, metadata)
+}
+
+// rejectMetadata reports why the task's metadata was not saved to its
+// stderr, without failing the task.
+func (action *TaskAction) rejectMetadata(logger lager.Logger, reason string) {
+	logger.Info("invalid-metadata", lager.Data{"reason": reason})
+	fmt.Fprintln(action.imageFetchingDelegate.Stderr(), reason)
+}
+
+func (action *TaskAction) parseReport(logger lager.Logger, volume worker.Volume, reportPath string, format string) (testreport.Summary, error) {
+	file, err := newTaskArtifactSource(logger, volume).StreamFile(reportPath)
+	if err != nil {
+		return testreport.Summary{}, err
+	}
+
+	defer file.Close()
+
+	return testreport.Parse(format, file)
+}
+
+func taskOutput(config atc.TaskConfig, name string) (atc.TaskOutputConfig, bool) {
 	for _, output := range config.Outputs {
-		outputName := output.Name
-		if destinationName, ok := action.outputMapping[output.Name]; ok {
-			outputName = destinationName
+		if output.Name == name {
+			return output, true
 		}
+	}
+
+	return atc.TaskOutputConfig{}, false
+}
+
+func (action *TaskAction) outputArtifactName(outputName string) worker.ArtifactName {
+	if destinationName, ok := action.outputMapping[outputName]; ok {
+		return worker.ArtifactName(destinationName)
+	}
+
+	return worker.ArtifactName(outputName)
+}
 
+func (action *TaskAction) registerOutputs(logger lager.Logger, repository *worker.ArtifactRepository, config atc.TaskConfig, container worker.Container) error {
+	volumeMounts := container.VolumeMounts()
+
+	logger.Debug("registering-outputs", lager.Data{"outputs": config.Outputs})
+
+	for _, output := range config.Outputs {
 		outputPath := artifactsPath(output, action.artifactsRoot)
 
 		for _, mount := range volumeMounts {
 			if mount.MountPath == outputPath {
 				source := newTaskArtifactSource(logger, mount.Volume)
-				repository.RegisterSource(worker.ArtifactName(outputName), source)
+				repository.RegisterSource(action.outputArtifactName(output.Name), source)
 			}
 		}
 	}
@@ -437,6 +888,13 @@ func (TaskAction) envForParams(params map[string]string) []string {
 	return env
 }
 
+// identifiableArtifactSource is implemented by artifact sources whose content
+// is identified without streaming it, which is required to cache the result
+// of the tasks using them.
+type identifiableArtifactSource interface {
+	Identity() string
+}
+
 type taskArtifactSource struct {
 	logger lager.Logger
 	volume worker.Volume
@@ -486,6 +944,11 @@ func (src *taskArtifactSource) VolumeOn(w worker.Worker) (worker.Volume, bool, e
 	return w.LookupVolume(src.logger, src.volume.Handle())
 }
 
+// task outputs are never written to once the task is done
+func (src *taskArtifactSource) Identity() string {
+	return "volume:" + src.volume.Handle()
+}
+
 type taskInputSource struct {
 	config        atc.TaskInputConfig
 	source        worker.ArtifactSource
@@ -2,7 +2,9 @@ package exec_test
 
 import (
 	"archive/tar"
+	"context"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -20,6 +22,7 @@ import (
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
@@ -32,11 +35,14 @@ var _ = Describe("TaskAction", func() {
 	var (
 		fakeWorkerClient           *workerfakes.FakeClient
 		fakeDBResourceCacheFactory *dbfakes.FakeResourceCacheFactory
+		fakeTaskResultFactory      *dbfakes.FakeTaskResultFactory
 
 		stdoutBuf *gbytes.Buffer
 		stderrBuf *gbytes.Buffer
 
 		imageArtifactName string
+		cacheResult       bool
+		artifacts         []string
 		containerMetadata db.ContainerMetadata
 
 		fakeBuildEventsDelegate     *execfakes.FakeActionsBuildEventsDelegate
@@ -65,6 +71,7 @@ var _ = Describe("TaskAction", func() {
 	BeforeEach(func() {
 		fakeWorkerClient = new(workerfakes.FakeClient)
 		fakeDBResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
+		fakeTaskResultFactory = new(dbfakes.FakeTaskResultFactory)
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -99,6 +106,8 @@ var _ = Describe("TaskAction", func() {
 		inputMapping = nil
 		outputMapping = nil
 		imageArtifactName = ""
+		cacheResult = false
+		artifacts = nil
 
 		variables = template.StaticVariables{
 			"source-param": "super-secret-source",
@@ -120,9 +129,12 @@ var _ = Describe("TaskAction", func() {
 			outputMapping,
 			"some-artifact-root",
 			imageArtifactName,
+			cacheResult,
+			artifacts,
 			fakeTaskBuildEventsDelegate,
 			fakeImageFetchingDelegate,
 			fakeWorkerClient,
+			fakeTaskResultFactory,
 			teamID,
 			buildID,
 			jobID,
@@ -174,6 +186,7 @@ var _ = Describe("TaskAction", func() {
 			BeforeEach(func() {
 				fakeContainer = new(workerfakes.FakeContainer)
 				fakeContainer.HandleReturns("some-handle")
+				fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
 				fakeWorkerClient.FindOrCreateContainerReturns(fakeContainer, nil)
 			})
 
@@ -484,6 +497,28 @@ var _ = Describe("TaskAction", func() {
 					Expect(io.Stderr).To(Equal(stderrBuf))
 				})
 
+				Context("when the step is being traced", func() {
+					BeforeEach(func() {
+						sc, ok := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+						Expect(ok).To(BeTrue())
+
+						tracing.RegisterStep(buildID, string(planID), tracing.ContextWithSpanContext(context.Background(), sc))
+					})
+
+					AfterEach(func() {
+						tracing.ForgetStep(buildID, string(planID))
+					})
+
+					It("propagates the step's trace context to the process", func() {
+						Expect(fakeContainer.RunCallCount()).To(Equal(1))
+
+						spec, _ := fakeContainer.RunArgsForCall(0)
+						Expect(spec.Env).To(Equal([]string{
+							"TRACEPARENT=00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+						}))
+					})
+				})
+
 				Context("when privileged", func() {
 					BeforeEach(func() {
 						privileged = true
@@ -1083,6 +1118,612 @@ var _ = Describe("TaskAction", func() {
 					})
 				})
 
+				Context("when artifacts are configured", func() {
+					var fakeOutputVolume *workerfakes.FakeVolume
+
+					BeforeEach(func() {
+						artifacts = []string{"some-output"}
+						outputMapping = map[string]string{"some-output": "some-mapped-output"}
+
+						configSource.GetTaskConfigReturns(atc.TaskConfig{
+							Run: atc.TaskRunConfig{
+								Path: "ls",
+							},
+							Outputs: []atc.TaskOutputConfig{
+								{Name: "some-output"},
+								{Name: "some-other-output"},
+							},
+						}, nil)
+
+						fakeOutputVolume = new(workerfakes.FakeVolume)
+
+						fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+							{
+								Volume:    fakeOutputVolume,
+								MountPath: "some-artifact-root/some-output/",
+							},
+							{
+								Volume:    new(workerfakes.FakeVolume),
+								MountPath: "some-artifact-root/some-other-output/",
+							},
+						})
+					})
+
+					Context("when the process exits nonzero", func() {
+						BeforeEach(func() {
+							fakeProcess.WaitReturns(1, nil)
+						})
+
+						It("keeps the output volumes as artifacts of the build under their mapped names", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Expect(fakeOutputVolume.InitializeArtifactCallCount()).To(Equal(1))
+							_, actualBuildID, name, _ := fakeOutputVolume.InitializeArtifactArgsForCall(0)
+							Expect(actualBuildID).To(Equal(buildID))
+							Expect(name).To(Equal("some-mapped-output"))
+						})
+					})
+
+					Context("when keeping an artifact fails", func() {
+						disaster := errors.New("nope")
+
+						BeforeEach(func() {
+							fakeProcess.WaitReturns(0, nil)
+							fakeOutputVolume.InitializeArtifactReturns(disaster)
+						})
+
+						It("exits with the error", func() {
+							Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+						})
+					})
+
+					Context("when an artifact is not an output of the task", func() {
+						BeforeEach(func() {
+							artifacts = []string{"bogus-output"}
+						})
+
+						It("exits with an error before running the task", func() {
+							Eventually(process.Wait()).Should(Receive(Equal(exec.MissingTaskOutputError{OutputName: "bogus-output"})))
+							Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the task has test reports", func() {
+					var (
+						fakeOutputVolume *workerfakes.FakeVolume
+						tarBuffer        *gbytes.Buffer
+					)
+
+					BeforeEach(func() {
+						configSource.GetTaskConfigReturns(atc.TaskConfig{
+							Run: atc.TaskRunConfig{
+								Path: "ls",
+							},
+							Outputs: []atc.TaskOutputConfig{
+								{Name: "some-output", Path: "some/output"},
+							},
+							Reports: []atc.TaskReportConfig{
+								{Path: "some/output/report.json", Format: "go-test"},
+							},
+						}, nil)
+
+						fakeOutputVolume = new(workerfakes.FakeVolume)
+
+						fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+							{
+								Volume:    fakeOutputVolume,
+								MountPath: "some-artifact-root/some/output/",
+							},
+						})
+
+						fakeProcess.WaitReturns(1, nil)
+
+						tarBuffer = gbytes.NewBuffer()
+						fakeOutputVolume.StreamOutReturns(tarBuffer, nil)
+					})
+
+					Context("when the report is written", func() {
+						BeforeEach(func() {
+							report := `{"Action":"pass","Package":"some/pkg","Test":"TestPasses"}
+{"Action":"fail","Package":"some/pkg","Test":"TestFails"}
+`
+
+							tarWriter := tar.NewWriter(tarBuffer)
+
+							err := tarWriter.WriteHeader(&tar.Header{
+								Name: "report.json",
+								Mode: 0644,
+								Size: int64(len(report)),
+							})
+							Expect(err).NotTo(HaveOccurred())
+
+							_, err = tarWriter.Write([]byte(report))
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("reports the summary of the tests of the step", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Expect(fakeOutputVolume.StreamOutArgsForCall(0)).To(Equal("report.json"))
+
+							Expect(fakeTaskBuildEventsDelegate.TestsReportedCallCount()).To(Equal(1))
+							_, report := fakeTaskBuildEventsDelegate.TestsReportedArgsForCall(0)
+							Expect(report).To(Equal(db.TestReport{
+								StepName: "some-task",
+								Total:    2,
+								Passed:   1,
+								Failed:   1,
+								Passes:   []string{"some/pkg.TestPasses"},
+								Failures: []string{"some/pkg.TestFails"},
+							}))
+						})
+					})
+
+					Context("when the report is missing", func() {
+						It("does not error the step", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+							Expect(taskAction.ExitStatus()).To(Equal(exec.ExitStatus(1)))
+						})
+
+						It("writes the failure to stderr and reports an empty summary", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Expect(stderrBuf).To(gbytes.Say("failed to parse test report some/output/report.json"))
+
+							Expect(fakeTaskBuildEventsDelegate.TestsReportedCallCount()).To(Equal(1))
+							_, report := fakeTaskBuildEventsDelegate.TestsReportedArgsForCall(0)
+							Expect(report.Total).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the task writes build metadata", func() {
+					var tarBuffer *gbytes.Buffer
+
+					writeMetadata := func(metadata string) {
+						tarWriter := tar.NewWriter(tarBuffer)
+
+						err := tarWriter.WriteHeader(&tar.Header{
+							Name: exec.TaskMetadataFileName,
+							Mode: 0644,
+							Size: int64(len(metadata)),
+						})
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = tarWriter.Write([]byte(metadata))
+						Expect(err).NotTo(HaveOccurred())
+					}
+
+					BeforeEach(func() {
+						configSource.GetTaskConfigReturns(atc.TaskConfig{
+							Run: atc.TaskRunConfig{
+								Path: "ls",
+								Dir:  "some-dir",
+							},
+						}, nil)
+
+						fakeProcess.WaitReturns(0, nil)
+
+						tarBuffer = gbytes.NewBuffer()
+						fakeContainer.StreamOutReturns(tarBuffer, nil)
+					})
+
+					Context("when the metadata is valid", func() {
+						BeforeEach(func() {
+							writeMetadata(`[{"name":"url","value":"https://example.com"}]`)
+						})
+
+						It("reads the metadata file from the task's working directory", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Expect(fakeContainer.StreamOutCallCount()).To(Equal(1))
+							Expect(fakeContainer.StreamOutArgsForCall(0).Path).To(Equal("some-artifact-root/some-dir/build-metadata.json"))
+						})
+
+						It("emits the metadata", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Expect(fakeTaskBuildEventsDelegate.MetadataEmittedCallCount()).To(Equal(1))
+							_, metadata := fakeTaskBuildEventsDelegate.MetadataEmittedArgsForCall(0)
+							Expect(metadata).To(Equal([]atc.MetadataField{
+								{Name: "url", Value: "https://example.com"},
+							}))
+						})
+					})
+
+					Context("when the metadata is invalid", func() {
+						BeforeEach(func() {
+							writeMetadata(`{"url":`)
+						})
+
+						It("writes the failure to stderr without failing the step", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Expect(stderrBuf).To(gbytes.Say("failed to parse build-metadata.json"))
+							Expect(fakeTaskBuildEventsDelegate.MetadataEmittedCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the metadata file is too large", func() {
+						BeforeEach(func() {
+							writeMetadata(`[{"name":"big","value":"` + strings.Repeat("x", exec.MaxTaskMetadataBytes) + `"}]`)
+						})
+
+						It("writes the failure to stderr without emitting any metadata", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Expect(stderrBuf).To(gbytes.Say("build-metadata.json is larger than"))
+							Expect(fakeTaskBuildEventsDelegate.MetadataEmittedCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the metadata has too many fields", func() {
+						BeforeEach(func() {
+							fields := []string{}
+							for i := 0; i <= exec.MaxTaskMetadataFields; i++ {
+								fields = append(fields, fmt.Sprintf(`{"name":"field-%d","value":"some-value"}`, i))
+							}
+
+							writeMetadata("[" + strings.Join(fields, ",") + "]")
+						})
+
+						It("writes the failure to stderr without emitting any metadata", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Expect(stderrBuf).To(gbytes.Say("build-metadata.json has more than"))
+							Expect(fakeTaskBuildEventsDelegate.MetadataEmittedCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when a field's value is too long", func() {
+						BeforeEach(func() {
+							writeMetadata(`[{"name":"long","value":"` + strings.Repeat("x", exec.MaxTaskMetadataValueLength+1) + `"}]`)
+						})
+
+						It("writes the failure to stderr without emitting any metadata", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Expect(stderrBuf).To(gbytes.Say("build-metadata.json field 'long' is longer than"))
+							Expect(fakeTaskBuildEventsDelegate.MetadataEmittedCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the task does not write build metadata", func() {
+					BeforeEach(func() {
+						fakeProcess.WaitReturns(0, nil)
+					})
+
+					It("does not emit any metadata", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+						Expect(fakeTaskBuildEventsDelegate.MetadataEmittedCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the task caches its result", func() {
+					var (
+						fakeOutputVolume *workerfakes.FakeVolume
+						fakeWorker       *workerfakes.FakeWorker
+					)
+
+					BeforeEach(func() {
+						cacheResult = true
+
+						configSource.GetTaskConfigReturns(atc.TaskConfig{
+							Platform:  "some-platform",
+							RootfsURI: "some-image",
+							Params:    map[string]string{"SOME": "params"},
+							Run: atc.TaskRunConfig{
+								Path: "ls",
+							},
+							Outputs: []atc.TaskOutputConfig{
+								{Name: "some-output"},
+							},
+						}, nil)
+
+						fakeProcess.WaitReturns(0, nil)
+
+						fakeOutputVolume = new(workerfakes.FakeVolume)
+						fakeOutputVolume.HandleReturns("some-output-handle")
+
+						fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+							{
+								Volume:    fakeOutputVolume,
+								MountPath: "some-artifact-root/some-output/",
+							},
+						})
+
+						fakeWorker = new(workerfakes.FakeWorker)
+						fakeWorkerClient.RunningWorkersReturns([]worker.Worker{fakeWorker}, nil)
+					})
+
+					Context("when no result was saved for the task", func() {
+						BeforeEach(func() {
+							fakeTaskResultFactory.FindReturns(db.TaskResult{}, false, nil)
+						})
+
+						JustBeforeEach(func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+						})
+
+						It("looks the result up by a key of the task", func() {
+							Expect(fakeTaskResultFactory.FindCallCount()).To(Equal(1))
+							actualJobID, stepName, cacheKey := fakeTaskResultFactory.FindArgsForCall(0)
+							Expect(actualJobID).To(Equal(jobID))
+							Expect(stepName).To(Equal("some-task"))
+							Expect(cacheKey).To(MatchRegexp("^[0-9a-f]{64}$"))
+						})
+
+						It("runs the task", func() {
+							Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+							Expect(fakeContainer.RunCallCount()).To(Equal(1))
+						})
+
+						It("keeps the outputs as task caches", func() {
+							_, cacheKey, _ := fakeTaskResultFactory.FindArgsForCall(0)
+
+							Expect(fakeOutputVolume.InitializeTaskCacheCallCount()).To(Equal(1))
+							_, actualJobID, stepName, path, privileged := fakeOutputVolume.InitializeTaskCacheArgsForCall(0)
+							Expect(actualJobID).To(Equal(jobID))
+							Expect(stepName).To(Equal("some-task"))
+							Expect(path).To(Equal(db.TaskResultOutputPath(cacheKey, "some-output")))
+							Expect(privileged).To(BeFalse())
+						})
+
+						It("saves the result", func() {
+							_, cacheKey, _ := fakeTaskResultFactory.FindArgsForCall(0)
+
+							Expect(fakeTaskResultFactory.SaveCallCount()).To(Equal(1))
+							actualJobID, stepName, savedCacheKey, actualBuildID := fakeTaskResultFactory.SaveArgsForCall(0)
+							Expect(actualJobID).To(Equal(jobID))
+							Expect(stepName).To(Equal("some-task"))
+							Expect(savedCacheKey).To(Equal(cacheKey))
+							Expect(actualBuildID).To(Equal(buildID))
+						})
+
+						Context("when the process exits nonzero", func() {
+							BeforeEach(func() {
+								fakeProcess.WaitReturns(1, nil)
+							})
+
+							It("does not save the result", func() {
+								Expect(fakeOutputVolume.InitializeTaskCacheCallCount()).To(BeZero())
+								Expect(fakeTaskResultFactory.SaveCallCount()).To(BeZero())
+							})
+						})
+					})
+
+					Context("when a result was saved for the task", func() {
+						var fakeResultVolume *workerfakes.FakeVolume
+
+						BeforeEach(func() {
+							fakeTaskResultFactory.FindReturns(db.TaskResult{
+								BuildID:   41,
+								BuildName: "7",
+							}, true, nil)
+
+							fakeResultVolume = new(workerfakes.FakeVolume)
+							fakeResultVolume.HandleReturns("some-result-handle")
+						})
+
+						Context("when its outputs are found on a worker", func() {
+							BeforeEach(func() {
+								fakeWorker.FindVolumeForTaskCacheReturns(fakeResultVolume, true, nil)
+							})
+
+							JustBeforeEach(func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+							})
+
+							It("does not run the task", func() {
+								Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(BeZero())
+							})
+
+							It("looks the outputs up in the task caches of the result", func() {
+								_, cacheKey, _ := fakeTaskResultFactory.FindArgsForCall(0)
+
+								Expect(fakeWorker.FindVolumeForTaskCacheCallCount()).To(Equal(1))
+								_, actualTeamID, actualJobID, stepName, path := fakeWorker.FindVolumeForTaskCacheArgsForCall(0)
+								Expect(actualTeamID).To(Equal(teamID))
+								Expect(actualJobID).To(Equal(jobID))
+								Expect(stepName).To(Equal("some-task"))
+								Expect(path).To(Equal(db.TaskResultOutputPath(cacheKey, "some-output")))
+							})
+
+							It("registers the outputs of the result as sources", func() {
+								artifactSource, found := artifactRepository.SourceFor("some-output")
+								Expect(found).To(BeTrue())
+
+								fakeWorker.LookupVolumeReturns(fakeResultVolume, true, nil)
+
+								volume, found, err := artifactSource.VolumeOn(fakeWorker)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(found).To(BeTrue())
+								Expect(volume).To(Equal(fakeResultVolume))
+
+								_, handle := fakeWorker.LookupVolumeArgsForCall(0)
+								Expect(handle).To(Equal("some-result-handle"))
+							})
+
+							It("succeeds", func() {
+								Expect(taskAction.ExitStatus()).To(Equal(exec.ExitStatus(0)))
+								Expect(actionStep.Succeeded()).To(BeTrue())
+							})
+
+							It("reports the build whose result is reused", func() {
+								Expect(fakeTaskBuildEventsDelegate.ResultReusedCallCount()).To(Equal(1))
+								_, result := fakeTaskBuildEventsDelegate.ResultReusedArgsForCall(0)
+								Expect(result).To(Equal(db.TaskResult{
+									BuildID:   41,
+									BuildName: "7",
+								}))
+
+								Expect(stdoutBuf).To(gbytes.Say("result reused from build 7"))
+							})
+
+							It("does not save the result again", func() {
+								Expect(fakeTaskResultFactory.SaveCallCount()).To(BeZero())
+							})
+
+							It("does not emit the metadata of the reused run", func() {
+								Expect(fakeTaskBuildEventsDelegate.MetadataEmittedCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when its outputs are no longer found", func() {
+							BeforeEach(func() {
+								fakeWorker.FindVolumeForTaskCacheReturns(nil, false, nil)
+							})
+
+							JustBeforeEach(func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+							})
+
+							It("runs the task", func() {
+								Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+								Expect(fakeTaskBuildEventsDelegate.ResultReusedCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when looking up its outputs fails", func() {
+							disaster := errors.New("nope")
+
+							BeforeEach(func() {
+								fakeWorker.FindVolumeForTaskCacheReturns(nil, false, disaster)
+							})
+
+							It("exits with the error", func() {
+								Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+							})
+						})
+
+						Context("when the task keeps artifacts", func() {
+							BeforeEach(func() {
+								artifacts = []string{"some-output"}
+								fakeWorker.FindVolumeForTaskCacheReturns(fakeResultVolume, true, nil)
+							})
+
+							JustBeforeEach(func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+							})
+
+							It("runs the task without looking up the result", func() {
+								Expect(fakeTaskResultFactory.FindCallCount()).To(BeZero())
+								Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+								Expect(fakeTaskResultFactory.SaveCallCount()).To(BeZero())
+							})
+
+							It("keeps the artifacts of the run", func() {
+								Expect(fakeOutputVolume.InitializeArtifactCallCount()).To(Equal(1))
+							})
+						})
+
+						Context("when the task reports tests", func() {
+							BeforeEach(func() {
+								configSource.GetTaskConfigReturns(atc.TaskConfig{
+									Platform:  "some-platform",
+									RootfsURI: "some-image",
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+									},
+									Outputs: []atc.TaskOutputConfig{
+										{Name: "some-output"},
+									},
+									Reports: []atc.TaskReportConfig{
+										{Path: "some-output/report.json", Format: "go-test"},
+									},
+								}, nil)
+
+								fakeOutputVolume.StreamOutReturns(gbytes.NewBuffer(), nil)
+								fakeWorker.FindVolumeForTaskCacheReturns(fakeResultVolume, true, nil)
+							})
+
+							JustBeforeEach(func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+							})
+
+							It("runs the task without looking up the result", func() {
+								Expect(fakeTaskResultFactory.FindCallCount()).To(BeZero())
+								Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+								Expect(fakeTaskResultFactory.SaveCallCount()).To(BeZero())
+							})
+
+							It("reports the tests of the run", func() {
+								Expect(fakeTaskBuildEventsDelegate.TestsReportedCallCount()).To(Equal(1))
+							})
+						})
+					})
+
+					Context("when an input can not be identified", func() {
+						BeforeEach(func() {
+							configSource.GetTaskConfigReturns(atc.TaskConfig{
+								RootfsURI: "some-image",
+								Run: atc.TaskRunConfig{
+									Path: "ls",
+								},
+								Inputs: []atc.TaskInputConfig{
+									{Name: "some-input"},
+								},
+							}, nil)
+
+							artifactRepository.RegisterSource("some-input", new(workerfakes.FakeArtifactSource))
+						})
+
+						JustBeforeEach(func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+						})
+
+						It("runs the task without looking up a result", func() {
+							Expect(fakeTaskResultFactory.FindCallCount()).To(BeZero())
+							Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+							Expect(fakeTaskResultFactory.SaveCallCount()).To(BeZero())
+						})
+
+						It("tells why the result is not cached", func() {
+							Expect(stderrBuf).To(gbytes.Say("not caching the task's result: the input at some-artifact-root/some-input can not be identified"))
+						})
+					})
+
+					Context("when the version of the image resource is not pinned", func() {
+						BeforeEach(func() {
+							configSource.GetTaskConfigReturns(atc.TaskConfig{
+								ImageResource: &atc.ImageResource{
+									Type:   "docker",
+									Source: atc.Source{"some": "source"},
+								},
+								Run: atc.TaskRunConfig{
+									Path: "ls",
+								},
+							}, nil)
+						})
+
+						JustBeforeEach(func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+						})
+
+						It("runs the task without looking up a result", func() {
+							Expect(fakeTaskResultFactory.FindCallCount()).To(BeZero())
+							Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when the task does not belong to a job (one-off build)", func() {
+						BeforeEach(func() {
+							jobID = 0
+						})
+
+						JustBeforeEach(func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+						})
+
+						It("does not cache the result", func() {
+							Expect(fakeTaskResultFactory.FindCallCount()).To(BeZero())
+							Expect(fakeTaskResultFactory.SaveCallCount()).To(BeZero())
+						})
+					})
+				})
+
 				Context("when an image artifact name is specified", func() {
 					BeforeEach(func() {
 						imageArtifactName = "some-image-artifact"
@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type buildArtifactCollector struct {
+	logger                 lager.Logger
+	buildArtifactLifecycle db.BuildArtifactLifecycle
+	retention              time.Duration
+}
+
+func NewBuildArtifactCollector(
+	logger lager.Logger,
+	buildArtifactLifecycle db.BuildArtifactLifecycle,
+	retention time.Duration,
+) Collector {
+	return &buildArtifactCollector{
+		logger:                 logger.Session("build-artifact-collector"),
+		buildArtifactLifecycle: buildArtifactLifecycle,
+		retention:              retention,
+	}
+}
+
+func (bac *buildArtifactCollector) Run() error {
+	err := bac.buildArtifactLifecycle.RemoveExpiredArtifacts(bac.retention)
+	if err != nil {
+		bac.logger.Error("unable-to-remove-expired-build-artifacts", err)
+		return err
+	}
+
+	return nil
+}
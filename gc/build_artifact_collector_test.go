@@ -0,0 +1,58 @@
+package gc_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildArtifactCollector", func() {
+	var (
+		collector gc.Collector
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("build-artifact-collector")
+		collector = gc.NewBuildArtifactCollector(logger, db.NewBuildArtifactLifecycle(dbConn), time.Hour)
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			_, err := psql.Insert("build_artifacts").
+				Columns("build_id", "name", "created_at").
+				Values(defaultBuild.ID(), "expired-artifact", time.Now().Add(-2*time.Hour)).
+				Values(defaultBuild.ID(), "recent-artifact", time.Now()).
+				RunWith(dbConn).
+				Exec()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			Expect(collector.Run()).To(Succeed())
+		})
+
+		It("removes only the artifacts older than the retention", func() {
+			rows, err := psql.Select("name").
+				From("build_artifacts").
+				RunWith(dbConn).
+				Query()
+			Expect(err).NotTo(HaveOccurred())
+
+			defer rows.Close()
+
+			names := []string{}
+			for rows.Next() {
+				var name string
+				Expect(rows.Scan(&name)).To(Succeed())
+				names = append(names, name)
+			}
+
+			Expect(names).To(ConsistOf("recent-artifact"))
+		})
+	})
+})
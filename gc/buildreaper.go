@@ -13,17 +13,24 @@ type buildReaper struct {
 	logger          lager.Logger
 	pipelineFactory db.PipelineFactory
 	batchSize       int
+	waitForDrain    bool
 }
 
+// NewBuildReaper returns a BuildReaper which deletes the events of builds
+// beyond the jobs' build_logs_to_retain. If waitForDrain is set, as it is
+// while a syslog drainer is configured, builds whose events have not all been
+// drained yet are kept until they are.
 func NewBuildReaper(
 	logger lager.Logger,
 	pipelineFactory db.PipelineFactory,
 	batchSize int,
+	waitForDrain bool,
 ) BuildReaper {
 	return &buildReaper{
 		logger:          logger,
 		pipelineFactory: pipelineFactory,
 		batchSize:       batchSize,
+		waitForDrain:    waitForDrain,
 	}
 }
 
@@ -115,6 +122,10 @@ func (br *buildReaper) Run() error {
 					break
 				}
 
+				if br.waitForDrain && !build.IsDrained() {
+					break
+				}
+
 				buildIDsToDelete = append(buildIDsToDelete, build.ID())
 			}
 
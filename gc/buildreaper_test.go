@@ -19,11 +19,13 @@ var _ = Describe("BuildReaper", func() {
 		buildReaper         BuildReaper
 		fakePipelineFactory *dbfakes.FakePipelineFactory
 		batchSize           int
+		waitForDrain        bool
 	)
 
 	BeforeEach(func() {
 		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
 		batchSize = 5
+		waitForDrain = false
 	})
 
 	JustBeforeEach(func() {
@@ -32,6 +34,7 @@ var _ = Describe("BuildReaper", func() {
 			buildReaperLogger,
 			fakePipelineFactory,
 			batchSize,
+			waitForDrain,
 		)
 	})
 
@@ -229,6 +232,56 @@ var _ = Describe("BuildReaper", func() {
 				})
 			})
 
+			Context("when the builds we want to reap have not been drained", func() {
+				BeforeEach(func() {
+					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+						if page == (db.Page{Limit: 10}) {
+							return []db.Build{sb(25), sb(24), sb(23), sb(22), sb(21), sb(20), sb(19), sb(18), sb(17), sb(16)}, db.Pagination{}, nil
+						} else if page == (db.Page{Until: 5, Limit: 5}) {
+							return []db.Build{
+								drainedBuild(10),
+								sb(9),
+								drainedBuild(8),
+								drainedBuild(7),
+								drainedBuild(6),
+							}, db.Pagination{}, nil
+						} else {
+							Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+						}
+						return nil, db.Pagination{}, nil
+					}
+				})
+
+				Context("when waiting for builds to be drained", func() {
+					BeforeEach(func() {
+						waitForDrain = true
+					})
+
+					It("reaps all builds before the first undrained build", func() {
+						err := buildReaper.Run()
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+						actualBuildIDs := fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)
+						Expect(actualBuildIDs).To(ConsistOf(6, 7, 8))
+
+						Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+						Expect(fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)).To(Equal(9))
+					})
+				})
+
+				Context("when not waiting for builds to be drained", func() {
+					It("reaps them", func() {
+						err := buildReaper.Run()
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+						actualBuildIDs := fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)
+						Expect(actualBuildIDs).To(ConsistOf(6, 7, 8, 9, 10))
+					})
+				})
+			})
+
 			Context("when no builds need to be reaped", func() {
 				BeforeEach(func() {
 					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
@@ -547,3 +600,10 @@ func runningBuild(id int) db.Build {
 	build.IsRunningReturns(true)
 	return build
 }
+
+func drainedBuild(id int) db.Build {
+	build := new(dbfakes.FakeBuild)
+	build.IDReturns(id)
+	build.IsDrainedReturns(true)
+	return build
+}
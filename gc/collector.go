@@ -18,6 +18,8 @@ type aggregateCollector struct {
 	volumeCollector                     Collector
 	containerCollector                  Collector
 	resourceConfigCheckSessionCollector Collector
+	resourceCheckCollector              Collector
+	buildArtifactCollector              Collector
 }
 
 func NewCollector(
@@ -30,6 +32,8 @@ func NewCollector(
 	volumes Collector,
 	containers Collector,
 	resourceConfigCheckSessionCollector Collector,
+	resourceCheckCollector Collector,
+	buildArtifactCollector Collector,
 ) Collector {
 	return &aggregateCollector{
 		logger:                              logger,
@@ -41,6 +45,8 @@ func NewCollector(
 		volumeCollector:                     volumes,
 		containerCollector:                  containers,
 		resourceConfigCheckSessionCollector: resourceConfigCheckSessionCollector,
+		resourceCheckCollector:              resourceCheckCollector,
+		buildArtifactCollector:              buildArtifactCollector,
 	}
 }
 
@@ -77,6 +83,16 @@ func (c *aggregateCollector) Run() error {
 		c.logger.Error("resource-config-check-session-collector", err)
 	}
 
+	err = c.resourceCheckCollector.Run()
+	if err != nil {
+		c.logger.Error("resource-check-collector", err)
+	}
+
+	err = c.buildArtifactCollector.Run()
+	if err != nil {
+		c.logger.Error("build-artifact-collector", err)
+	}
+
 	err = c.containerCollector.Run()
 	if err != nil {
 		c.logger.Error("container-collector", err)
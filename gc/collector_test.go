@@ -24,6 +24,8 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector                     *gcfakes.FakeCollector
 		fakeContainerCollector                  *gcfakes.FakeCollector
 		fakeResourceConfigCheckSessionCollector *gcfakes.FakeCollector
+		fakeResourceCheckCollector              *gcfakes.FakeCollector
+		fakeBuildArtifactCollector              *gcfakes.FakeCollector
 
 		err      error
 		disaster error
@@ -39,6 +41,8 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector = new(gcfakes.FakeCollector)
 		fakeContainerCollector = new(gcfakes.FakeCollector)
 		fakeResourceConfigCheckSessionCollector = new(gcfakes.FakeCollector)
+		fakeResourceCheckCollector = new(gcfakes.FakeCollector)
+		fakeBuildArtifactCollector = new(gcfakes.FakeCollector)
 
 		subject = NewCollector(
 			logger,
@@ -50,6 +54,8 @@ var _ = Describe("Aggregate Collector", func() {
 			fakeVolumeCollector,
 			fakeContainerCollector,
 			fakeResourceConfigCheckSessionCollector,
+			fakeResourceCheckCollector,
+			fakeBuildArtifactCollector,
 		)
 
 		disaster = errors.New("disaster")
@@ -81,6 +87,46 @@ var _ = Describe("Aggregate Collector", func() {
 				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeResourceCheckCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeBuildArtifactCollector.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		It("runs the resource check collector", func() {
+			Expect(fakeResourceCheckCollector.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when the resource check collector errors", func() {
+			BeforeEach(func() {
+				fakeResourceCheckCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("runs the rest of collectors", func() {
+				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		It("runs the build artifact collector", func() {
+			Expect(fakeBuildArtifactCollector.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when the build artifact collector errors", func() {
+			BeforeEach(func() {
+				fakeBuildArtifactCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("runs the rest of collectors", func() {
+				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 			})
 		})
 
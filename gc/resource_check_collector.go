@@ -0,0 +1,34 @@
+package gc
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type resourceCheckCollector struct {
+	logger                 lager.Logger
+	resourceCheckLifecycle db.ResourceCheckLifecycle
+	checksToRetain         int
+}
+
+func NewResourceCheckCollector(
+	logger lager.Logger,
+	resourceCheckLifecycle db.ResourceCheckLifecycle,
+	checksToRetain int,
+) Collector {
+	return &resourceCheckCollector{
+		logger:                 logger.Session("resource-check-collector"),
+		resourceCheckLifecycle: resourceCheckLifecycle,
+		checksToRetain:         checksToRetain,
+	}
+}
+
+func (rcc *resourceCheckCollector) Run() error {
+	err := rcc.resourceCheckLifecycle.CleanUpResourceChecks(rcc.checksToRetain)
+	if err != nil {
+		rcc.logger.Error("unable-to-clean-up-resource-checks", err)
+		return err
+	}
+
+	return nil
+}
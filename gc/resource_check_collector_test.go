@@ -0,0 +1,63 @@
+package gc_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceCheckCollector", func() {
+	var (
+		collector gc.Collector
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("resource-check-collector")
+		collector = gc.NewResourceCheckCollector(logger, db.NewResourceCheckLifecycle(dbConn), 3)
+	})
+
+	Describe("Run", func() {
+		var savedIDs []int
+
+		BeforeEach(func() {
+			for i := 0; i < 5; i++ {
+				err := defaultPipeline.SaveResourceCheck(usedResource, db.ResourceCheck{
+					StartTime:     time.Now(),
+					EndTime:       time.Now(),
+					VersionsFound: i,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			checks, _, found, err := defaultPipeline.GetResourceChecks("some-resource", db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(checks).To(HaveLen(5))
+
+			savedIDs = nil
+			for _, check := range checks {
+				savedIDs = append(savedIDs, check.ID)
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(collector.Run()).To(Succeed())
+		})
+
+		It("keeps only the most recent checks of each resource", func() {
+			checks, _, found, err := defaultPipeline.GetResourceChecks("some-resource", db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(checks).To(HaveLen(3))
+
+			Expect(checks[0].ID).To(Equal(savedIDs[0]))
+			Expect(checks[1].ID).To(Equal(savedIDs[1]))
+			Expect(checks[2].ID).To(Equal(savedIDs[2]))
+		})
+	})
+})
@@ -11,6 +11,7 @@ type Job struct {
 	NextBuild            *Build `json:"next_build"`
 	FinishedBuild        *Build `json:"finished_build"`
 	TransitionBuild      *Build `json:"transition_build,omitempty"`
+	NextScheduleTime     int64  `json:"next_schedule_time,omitempty"`
 
 	Inputs  []JobInput  `json:"inputs"`
 	Outputs []JobOutput `json:"outputs"`
@@ -19,13 +20,14 @@ type Job struct {
 }
 
 type JobInput struct {
-	Name     string         `json:"name"`
-	Resource string         `json:"resource"`
-	Passed   []string       `json:"passed,omitempty"`
-	Trigger  bool           `json:"trigger"`
-	Version  *VersionConfig `json:"version,omitempty"`
-	Params   Params         `json:"params,omitempty"`
-	Tags     Tags           `json:"tags,omitempty"`
+	Name      string         `json:"name"`
+	Resource  string         `json:"resource"`
+	Passed    []string       `json:"passed,omitempty"`
+	PassedAny []string       `json:"passed_any,omitempty"`
+	Trigger   bool           `json:"trigger"`
+	Version   *VersionConfig `json:"version,omitempty"`
+	Params    Params         `json:"params,omitempty"`
+	Tags      Tags           `json:"tags,omitempty"`
 }
 
 type JobOutput struct {
@@ -42,3 +44,44 @@ type BuildInput struct {
 	Version  Version  `json:"version"`
 	Tags     []string `json:"tags,omitempty"`
 }
+
+type JobSchedulingExplanation struct {
+	JobName        string `json:"job_name"`
+	Paused         bool   `json:"paused"`
+	PipelinePaused bool   `json:"pipeline_paused"`
+
+	InputsSatisfied bool                         `json:"inputs_satisfied"`
+	TriggerChanged  bool                         `json:"trigger_changed"`
+	Inputs          []InputSchedulingExplanation `json:"inputs"`
+
+	NextPendingBuild string   `json:"next_pending_build,omitempty"`
+	MaxInFlight      int      `json:"max_in_flight,omitempty"`
+	SerialGroups     []string `json:"serial_groups,omitempty"`
+	RunningBuilds    []string `json:"running_builds,omitempty"`
+	BlockedBy        string   `json:"blocked_by,omitempty"`
+}
+
+type InputSchedulingExplanation struct {
+	Name      string   `json:"name"`
+	Resource  string   `json:"resource"`
+	Passed    []string `json:"passed,omitempty"`
+	PassedAny []string `json:"passed_any,omitempty"`
+	Trigger   bool     `json:"trigger"`
+
+	Resolved   bool    `json:"resolved"`
+	Reason     string  `json:"reason,omitempty"`
+	VersionID  int     `json:"version_id,omitempty"`
+	Version    Version `json:"version,omitempty"`
+	NewVersion bool    `json:"new_version"`
+
+	Candidates []VersionCandidateExplanation `json:"candidates"`
+}
+
+type VersionCandidateExplanation struct {
+	ID        int      `json:"id"`
+	Version   Version  `json:"version"`
+	Enabled   bool     `json:"enabled"`
+	Selected  bool     `json:"selected"`
+	Reason    string   `json:"reason,omitempty"`
+	NotPassed []string `json:"not_passed,omitempty"`
+}
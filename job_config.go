@@ -1,5 +1,12 @@
 package atc
 
+import (
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
 type JobConfig struct {
 	Name   string `yaml:"name" json:"name" mapstructure:"name"`
 	Public bool   `yaml:"public,omitempty" json:"public,omitempty" mapstructure:"public"`
@@ -7,9 +14,29 @@ type JobConfig struct {
 	DisableManualTrigger bool     `yaml:"disable_manual_trigger,omitempty" json:"disable_manual_trigger,omitempty" mapstructure:"disable_manual_trigger"`
 	Serial               bool     `yaml:"serial,omitempty" json:"serial,omitempty" mapstructure:"serial"`
 	Interruptible        bool     `yaml:"interruptible,omitempty" json:"interruptible,omitempty" mapstructure:"interruptible"`
+	SupersedePending     bool     `yaml:"supersede_pending,omitempty" json:"supersede_pending,omitempty" mapstructure:"supersede_pending"`
 	SerialGroups         []string `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
+	Priority             *int     `yaml:"priority,omitempty" json:"priority,omitempty" mapstructure:"priority"`
+
+	Approval *ApprovalConfig `yaml:"approval,omitempty" json:"approval,omitempty" mapstructure:"approval"`
+	Schedule *ScheduleConfig `yaml:"schedule,omitempty" json:"schedule,omitempty" mapstructure:"schedule"`
+
+	TriggerOn []TriggerOnConfig `yaml:"trigger_on,omitempty" json:"trigger_on,omitempty" mapstructure:"trigger_on"`
+
+	// AllowInputOverrides lets anyone who may trigger builds of the job, i.e.
+	// members of the pipeline's team, trigger them with explicit input
+	// versions. Admin teams may always do so.
+	AllowInputOverrides bool `yaml:"allow_input_overrides,omitempty" json:"allow_input_overrides,omitempty" mapstructure:"allow_input_overrides"`
+
+	// HoldContainersOnFailure is how long the containers of a failed or
+	// errored build are kept for debugging, e.g. "30m".
+	HoldContainersOnFailure string `yaml:"hold_containers_on_failure,omitempty" json:"hold_containers_on_failure,omitempty" mapstructure:"hold_containers_on_failure"`
+
+	// UnredactedVars names the variables whose values are printed as they are
+	// in the logs of the job's builds, e.g. because they are not secret.
+	UnredactedVars []string `yaml:"unredacted_vars,omitempty" json:"unredacted_vars,omitempty" mapstructure:"unredacted_vars"`
 
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
@@ -18,6 +45,93 @@ type JobConfig struct {
 	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
 }
 
+// MaxContainerHold is the longest the containers of a build may be held.
+const MaxContainerHold = 24 * time.Hour
+
+// HoldContainersOnFailureDuration returns how long the containers of a failed
+// build are held, or zero if they are not.
+func (config JobConfig) HoldContainersOnFailureDuration() time.Duration {
+	if config.HoldContainersOnFailure == "" {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(config.HoldContainersOnFailure)
+	if err != nil {
+		return 0
+	}
+
+	return duration
+}
+
+// ApprovalConfig makes builds of a job wait for one of the approvers to
+// approve them before they are started. When no approvers are listed, any
+// member of the pipeline's team may approve.
+//
+// Approvers are team names, not individual users: the ATC only knows which
+// team a request is authenticated as, so any member of an approving team may
+// approve, and the approval is recorded against the team.
+type ApprovalConfig struct {
+	Approvers []string `yaml:"approvers,omitempty" json:"approvers,omitempty" mapstructure:"approvers"`
+	Timeout   string   `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
+}
+
+// TimeoutDuration returns the configured timeout, or zero if the approval
+// never times out.
+func (config ApprovalConfig) TimeoutDuration() time.Duration {
+	if config.Timeout == "" {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(config.Timeout)
+	if err != nil {
+		return 0
+	}
+
+	return duration
+}
+
+// ScheduleConfig triggers builds of a job at the times matched by a standard
+// five-field cron expression, evaluated in the given location (UTC by
+// default).
+type ScheduleConfig struct {
+	Cron     string `yaml:"cron" json:"cron" mapstructure:"cron"`
+	Location string `yaml:"location,omitempty" json:"location,omitempty" mapstructure:"location"`
+}
+
+// Next returns the first time matched by the schedule after the given time.
+func (config ScheduleConfig) Next(after time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(config.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	location := time.UTC
+	if config.Location != "" {
+		location, err = time.LoadLocation(config.Location)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return schedule.Next(after.In(location)), nil
+}
+
+// TriggerOnConfig triggers a build of a job whenever a build of another job
+// of the pipeline finishes with one of the given statuses (succeeded by
+// default).
+type TriggerOnConfig struct {
+	Job      string        `yaml:"job" json:"job" mapstructure:"job"`
+	Statuses []BuildStatus `yaml:"statuses,omitempty" json:"statuses,omitempty" mapstructure:"statuses"`
+}
+
+func (config TriggerOnConfig) TriggerStatuses() []BuildStatus {
+	if len(config.Statuses) == 0 {
+		return []BuildStatus{StatusSucceeded}
+	}
+
+	return config.Statuses
+}
+
 func (config JobConfig) Hooks() Hooks {
 	return Hooks{config.Failure, config.Ensure, config.Success}
 }
@@ -113,6 +227,28 @@ func (config JobConfig) OutputPlans() []PlanConfig {
 	return outputs
 }
 
+// PassedJobSeparator separates the pipeline from the job in passed
+// constraints on jobs of other pipelines of the same team, e.g.
+// "other-pipeline/some-job". Their outputs only satisfy the constraint for
+// resources with the same type and source.
+//
+// Job names can not contain the separator. Pipelines which were set before
+// cross-pipeline constraints existed and have jobs with a "/" in their name
+// keep running as they are, but have to rename those jobs (and the passed
+// constraints on them) before they can be set again.
+const PassedJobSeparator = "/"
+
+// SplitPassedJob returns the pipeline and job referenced by a passed
+// constraint. The pipeline is empty for jobs of the same pipeline.
+func SplitPassedJob(passed string) (string, string) {
+	parts := strings.SplitN(passed, PassedJobSeparator, 2)
+	if len(parts) == 1 {
+		return "", passed
+	}
+
+	return parts[0], parts[1]
+}
+
 func (config JobConfig) Inputs() []JobInput {
 	var inputs []JobInput
 
@@ -126,13 +262,14 @@ func (config JobConfig) Inputs() []JobInput {
 			}
 
 			inputs = append(inputs, JobInput{
-				Name:     get,
-				Resource: resource,
-				Passed:   plan.Passed,
-				Version:  plan.Version,
-				Trigger:  plan.Trigger,
-				Params:   plan.Params,
-				Tags:     plan.Tags,
+				Name:      get,
+				Resource:  resource,
+				Passed:    plan.Passed,
+				PassedAny: plan.PassedAny,
+				Version:   plan.Version,
+				Trigger:   plan.Trigger,
+				Params:    plan.Params,
+				Tags:      plan.Tags,
 			})
 		}
 	}
@@ -1,6 +1,8 @@
 package atc_test
 
 import (
+	"time"
+
 	"github.com/concourse/atc"
 
 	. "github.com/onsi/ginkgo"
@@ -709,3 +711,35 @@ var _ = Describe("JobConfig", func() {
 		})
 	})
 })
+
+var _ = Describe("ScheduleConfig", func() {
+	Describe("Next", func() {
+		var after time.Time
+
+		BeforeEach(func() {
+			after = time.Date(2017, time.October, 6, 12, 30, 0, 0, time.UTC) // a friday
+		})
+
+		It("returns the next matching time in UTC by default", func() {
+			next, err := atc.ScheduleConfig{Cron: "0 9 * * 1-5"}.Next(after)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(BeTemporally("==", time.Date(2017, time.October, 9, 9, 0, 0, 0, time.UTC)))
+		})
+
+		It("evaluates the cron expression in the configured location", func() {
+			next, err := atc.ScheduleConfig{Cron: "0 15 * * *", Location: "America/New_York"}.Next(after)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(BeTemporally("==", time.Date(2017, time.October, 6, 19, 0, 0, 0, time.UTC)))
+		})
+
+		It("returns an error for an invalid cron expression", func() {
+			_, err := atc.ScheduleConfig{Cron: "nope"}.Next(after)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns an error for an unknown location", func() {
+			_, err := atc.ScheduleConfig{Cron: "* * * * *", Location: "Nowhere/Special"}.Next(after)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
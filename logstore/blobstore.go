@@ -0,0 +1,16 @@
+package logstore
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound is returned by a Blobstore when there is no blob with the
+// given key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// Blobstore stores opaque blobs by key. Keys are slash-separated paths.
+type Blobstore interface {
+	Put(key string, contents []byte) error
+	Get(key string) (io.ReadCloser, error)
+}
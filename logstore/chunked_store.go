@@ -0,0 +1,246 @@
+package logstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+// DefaultChunkSize is the number of events stored in each chunk by default.
+const DefaultChunkSize = 1000
+
+// chunkIndex is stored alongside a build's chunks, so that they are read
+// back with the chunk size they were written with even when it has since
+// been reconfigured.
+type chunkIndex struct {
+	ChunkSize int `json:"chunk_size"`
+}
+
+type chunkedStore struct {
+	blobstore Blobstore
+	chunkSize int
+}
+
+// NewChunkedStore archives each build's events to the blobstore as a
+// sequence of gzipped chunks of JSON-encoded events, so that reading from an
+// offset only fetches the chunks from the one containing it onwards.
+func NewChunkedStore(blobstore Blobstore, chunkSize int) db.LogStore {
+	return &chunkedStore{
+		blobstore: blobstore,
+		chunkSize: chunkSize,
+	}
+}
+
+func (store *chunkedStore) Archive(buildID int, events db.EventSource) error {
+	chunk := 0
+	buffer := []event.Envelope{}
+
+	for {
+		ev, err := events.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		buffer = append(buffer, ev)
+
+		if len(buffer) == store.chunkSize {
+			err := store.writeChunk(buildID, chunk, buffer)
+			if err != nil {
+				return err
+			}
+
+			chunk++
+			buffer = buffer[:0]
+		}
+	}
+
+	if len(buffer) > 0 {
+		err := store.writeChunk(buildID, chunk, buffer)
+		if err != nil {
+			return err
+		}
+	}
+
+	index, err := json.Marshal(chunkIndex{ChunkSize: store.chunkSize})
+	if err != nil {
+		return err
+	}
+
+	return store.blobstore.Put(indexKey(buildID), index)
+}
+
+func (store *chunkedStore) Events(buildID int, from uint) (db.EventSource, error) {
+	chunkSize, err := store.archivedChunkSize(buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chunkedEventSource{
+		store:   store,
+		buildID: buildID,
+		chunk:   int(from) / chunkSize,
+		skip:    int(from) % chunkSize,
+	}, nil
+}
+
+// archivedChunkSize returns the chunk size the build's events were archived
+// with. Archives without an index predate it, and are assumed to have been
+// written with the configured chunk size.
+func (store *chunkedStore) archivedChunkSize(buildID int) (int, error) {
+	blob, err := store.blobstore.Get(indexKey(buildID))
+	if err == ErrBlobNotFound {
+		return store.chunkSize, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer blob.Close()
+
+	var index chunkIndex
+	err = json.NewDecoder(blob).Decode(&index)
+	if err != nil {
+		return 0, err
+	}
+
+	if index.ChunkSize <= 0 {
+		return 0, fmt.Errorf("invalid chunk size in index of build %d: %d", buildID, index.ChunkSize)
+	}
+
+	return index.ChunkSize, nil
+}
+
+func (store *chunkedStore) writeChunk(buildID int, chunk int, events []event.Envelope) error {
+	buf := new(bytes.Buffer)
+
+	gz := gzip.NewWriter(buf)
+
+	encoder := json.NewEncoder(gz)
+	for _, ev := range events {
+		err := encoder.Encode(ev)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := gz.Close()
+	if err != nil {
+		return err
+	}
+
+	return store.blobstore.Put(chunkKey(buildID, chunk), buf.Bytes())
+}
+
+func chunkKey(buildID int, chunk int) string {
+	return fmt.Sprintf("build-events/%d/%d.json.gz", buildID, chunk)
+}
+
+func indexKey(buildID int) string {
+	return fmt.Sprintf("build-events/%d/index.json", buildID)
+}
+
+type chunkedEventSource struct {
+	store   *chunkedStore
+	buildID int
+
+	chunk int
+	skip  int
+
+	blob    io.ReadCloser
+	gz      *gzip.Reader
+	decoder *json.Decoder
+}
+
+func (source *chunkedEventSource) Next() (event.Envelope, error) {
+	for {
+		if source.decoder == nil {
+			found, err := source.openChunk()
+			if err != nil {
+				return event.Envelope{}, err
+			}
+
+			if !found {
+				return event.Envelope{}, db.ErrEndOfBuildEventStream
+			}
+		}
+
+		var ev event.Envelope
+		err := source.decoder.Decode(&ev)
+		if err == io.EOF {
+			err := source.closeChunk()
+			if err != nil {
+				return event.Envelope{}, err
+			}
+
+			source.chunk++
+			continue
+		}
+
+		if err != nil {
+			return event.Envelope{}, err
+		}
+
+		if source.skip > 0 {
+			source.skip--
+			continue
+		}
+
+		return ev, nil
+	}
+}
+
+func (source *chunkedEventSource) Close() error {
+	if source.blob == nil {
+		return nil
+	}
+
+	return source.closeChunk()
+}
+
+func (source *chunkedEventSource) openChunk() (bool, error) {
+	blob, err := source.store.blobstore.Get(chunkKey(source.buildID, source.chunk))
+	if err == ErrBlobNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	gz, err := gzip.NewReader(blob)
+	if err != nil {
+		blob.Close()
+		return false, err
+	}
+
+	source.blob = blob
+	source.gz = gz
+	source.decoder = json.NewDecoder(gz)
+
+	return true, nil
+}
+
+func (source *chunkedEventSource) closeChunk() error {
+	gzErr := source.gz.Close()
+	err := source.blob.Close()
+
+	source.blob = nil
+	source.gz = nil
+	source.decoder = nil
+
+	if gzErr != nil {
+		return gzErr
+	}
+
+	return err
+}
@@ -0,0 +1,157 @@
+package logstore_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/logstore"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChunkedStore", func() {
+	var (
+		dir   string
+		store db.LogStore
+
+		events []event.Envelope
+	)
+
+	logEvent := func(i int) event.Envelope {
+		payload, err := json.Marshal(event.Log{Payload: fmt.Sprintf("log %d", i)})
+		Expect(err).NotTo(HaveOccurred())
+
+		data := json.RawMessage(payload)
+
+		return event.Envelope{
+			Data:    &data,
+			Event:   event.EventTypeLog,
+			Version: atc.EventVersion("5.0"),
+		}
+	}
+
+	eventSource := func(events []event.Envelope) db.EventSource {
+		source := new(dbfakes.FakeEventSource)
+
+		next := 0
+		source.NextStub = func() (event.Envelope, error) {
+			if next == len(events) {
+				return event.Envelope{}, db.ErrEndOfBuildEventStream
+			}
+
+			next++
+			return events[next-1], nil
+		}
+
+		return source
+	}
+
+	readAll := func(source db.EventSource) []event.Envelope {
+		read := []event.Envelope{}
+
+		for {
+			ev, err := source.Next()
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+
+			read = append(read, ev)
+		}
+
+		Expect(source.Close()).To(Succeed())
+
+		return read
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "logstore")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = logstore.NewChunkedStore(logstore.NewLocalBlobstore(dir), 2)
+
+		events = []event.Envelope{}
+		for i := 0; i < 5; i++ {
+			events = append(events, logEvent(i))
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Context("when a build's events have been archived", func() {
+		BeforeEach(func() {
+			Expect(store.Archive(42, eventSource(events))).To(Succeed())
+		})
+
+		It("stores them in compressed chunks", func() {
+			chunks, err := filepath.Glob(filepath.Join(dir, "build-events", "42", "*.json.gz"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(chunks).To(HaveLen(3))
+		})
+
+		It("reads them back", func() {
+			source, err := store.Events(42, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(readAll(source)).To(Equal(events))
+		})
+
+		It("reads them back from an offset", func() {
+			source, err := store.Events(42, 3)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(readAll(source)).To(Equal(events[3:]))
+		})
+
+		It("reads nothing from beyond the last event", func() {
+			source, err := store.Events(42, 5)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(readAll(source)).To(BeEmpty())
+		})
+
+		Context("when the chunk size has been reconfigured since", func() {
+			BeforeEach(func() {
+				store = logstore.NewChunkedStore(logstore.NewLocalBlobstore(dir), 3)
+			})
+
+			It("reads them back from an offset with the chunk size they were archived with", func() {
+				source, err := store.Events(42, 3)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(readAll(source)).To(Equal(events[3:]))
+			})
+		})
+	})
+
+	Context("when a build's events have not been archived", func() {
+		It("reads nothing", func() {
+			source, err := store.Events(42, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(readAll(source)).To(BeEmpty())
+		})
+	})
+
+	Context("when reading the events to archive fails", func() {
+		It("returns the error", func() {
+			source := new(dbfakes.FakeEventSource)
+			source.NextReturns(event.Envelope{}, errors.New("nope"))
+
+			Expect(store.Archive(42, source)).To(MatchError("nope"))
+		})
+	})
+})
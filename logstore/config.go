@@ -0,0 +1,74 @@
+package logstore
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/concourse/atc/db"
+)
+
+// Config configures where the events of builds beyond their job's
+// build_logs_to_retain are archived.
+type Config struct {
+	Dir string `long:"dir" description:"Directory to archive build logs to."`
+
+	S3Bucket          string `long:"s3-bucket"            description:"S3 bucket to archive build logs to."`
+	S3Prefix          string `long:"s3-prefix"            description:"Prefix for the keys of archived build logs in the S3 bucket."`
+	S3Region          string `long:"s3-region"            default:"us-east-1" description:"Region of the S3 bucket."`
+	S3Endpoint        string `long:"s3-endpoint"          description:"Endpoint of an S3-compatible store, used instead of AWS."`
+	S3AccessKeyID     string `long:"s3-access-key-id"     description:"Access key ID for the S3 bucket. If omitted, the default AWS credentials are used."`
+	S3SecretAccessKey string `long:"s3-secret-access-key" description:"Secret access key for the S3 bucket."`
+
+	ChunkSize int `long:"chunk-size" default:"1000" description:"Number of build events stored in each compressed chunk."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.Dir != "" || config.S3Bucket != ""
+}
+
+// LogStore constructs the configured store, or returns nil if build logs are
+// not archived.
+func (config Config) LogStore() (db.LogStore, error) {
+	if !config.IsConfigured() {
+		return nil, nil
+	}
+
+	if config.Dir != "" && config.S3Bucket != "" {
+		return nil, errors.New("only one of a directory or an S3 bucket may be configured for archiving build logs")
+	}
+
+	chunkSize := config.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	if config.Dir != "" {
+		return NewChunkedStore(NewLocalBlobstore(config.Dir), chunkSize), nil
+	}
+
+	awsConfig := aws.NewConfig().WithRegion(config.S3Region)
+
+	if config.S3Endpoint != "" {
+		awsConfig = awsConfig.
+			WithEndpoint(config.S3Endpoint).
+			WithS3ForcePathStyle(true)
+	}
+
+	if config.S3AccessKeyID != "" {
+		awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(
+			config.S3AccessKeyID,
+			config.S3SecretAccessKey,
+			"",
+		))
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChunkedStore(NewS3Blobstore(s3.New(sess), config.S3Bucket, config.S3Prefix), chunkSize), nil
+}
@@ -0,0 +1,67 @@
+package logstore
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+type localBlobstore struct {
+	dir string
+}
+
+// NewLocalBlobstore stores blobs as files beneath the given directory.
+func NewLocalBlobstore(dir string) Blobstore {
+	return &localBlobstore{
+		dir: dir,
+	}
+}
+
+func (store *localBlobstore) Put(key string, contents []byte) error {
+	path := store.path(key)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a partially written blob is
+	// never read
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(contents)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (store *localBlobstore) Get(key string) (io.ReadCloser, error) {
+	file, err := os.Open(store.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrBlobNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (store *localBlobstore) path(key string) string {
+	return filepath.Join(store.dir, filepath.FromSlash(key))
+}
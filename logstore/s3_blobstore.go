@@ -0,0 +1,53 @@
+package logstore
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+type s3Blobstore struct {
+	client s3iface.S3API
+	bucket string
+	prefix string
+}
+
+// NewS3Blobstore stores blobs as objects in an S3-compatible bucket, with
+// keys beneath the given prefix.
+func NewS3Blobstore(client s3iface.S3API, bucket string, prefix string) Blobstore {
+	return &s3Blobstore{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}
+}
+
+func (store *s3Blobstore) Put(key string, contents []byte) error {
+	_, err := store.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.prefix + key),
+		Body:   bytes.NewReader(contents),
+	})
+
+	return err
+}
+
+func (store *s3Blobstore) Get(key string) (io.ReadCloser, error) {
+	output, err := store.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.prefix + key),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrBlobNotFound
+		}
+
+		return nil, err
+	}
+
+	return output.Body, nil
+}
@@ -268,6 +268,61 @@ func (event BuildStarted) Emit(logger lager.Logger) {
 	)
 }
 
+type BuildQueueTime struct {
+	PipelineName string
+	JobName      string
+	Priority     int
+	Duration     time.Duration
+}
+
+func (event BuildQueueTime) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("build-queue-time"),
+		Event{
+			Name:  "build queue time (ms)",
+			Value: ms(event.Duration),
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"pipeline": event.PipelineName,
+				"job":      event.JobName,
+				"priority": strconv.Itoa(event.Priority),
+			},
+		},
+	)
+}
+
+type TeamQuotaUsage struct {
+	TeamName      string
+	RunningBuilds int
+	Containers    int
+}
+
+func (event TeamQuotaUsage) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("team-running-builds"),
+		Event{
+			Name:  "team running builds",
+			Value: event.RunningBuilds,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"team": event.TeamName,
+			},
+		},
+	)
+
+	emit(
+		logger.Session("team-containers"),
+		Event{
+			Name:  "team containers",
+			Value: event.Containers,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"team": event.TeamName,
+			},
+		},
+	)
+}
+
 type BuildFinished struct {
 	PipelineName  string
 	JobName       string
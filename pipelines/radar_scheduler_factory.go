@@ -15,6 +15,8 @@ import (
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/inputmapper/inputconfig"
 	"github.com/concourse/atc/scheduler/maxinflight"
+	"github.com/concourse/atc/scheduler/priority"
+	"github.com/concourse/atc/scheduler/quota"
 )
 
 //go:generate counterfeiter . RadarSchedulerFactory
@@ -29,6 +31,8 @@ type radarSchedulerFactory struct {
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory
 	interval                          time.Duration
 	engine                            engine.Engine
+	teamFactory                       db.TeamFactory
+	maxActiveContainersPerWorker      int
 }
 
 func NewRadarSchedulerFactory(
@@ -36,12 +40,17 @@ func NewRadarSchedulerFactory(
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory,
 	interval time.Duration,
 	engine engine.Engine,
+	teamFactory db.TeamFactory,
+	maxActiveContainersPerWorker int,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
 		resourceFactory:                   resourceFactory,
 		resourceConfigCheckSessionFactory: resourceConfigCheckSessionFactory,
-		interval: interval,
-		engine:   engine,
+		interval:                          interval,
+		engine:                            engine,
+		teamFactory:                       teamFactory,
+
+		maxActiveContainersPerWorker: maxActiveContainersPerWorker,
 	}
 }
 
@@ -63,12 +72,15 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalU
 		pipeline,
 		inputconfig.NewTransformer(pipeline),
 	)
+	priorityGate := priority.NewGate(pipeline, rsf.teamFactory, rsf.maxActiveContainersPerWorker)
 	return &scheduler.Scheduler{
 		Pipeline:    pipeline,
 		InputMapper: inputMapper,
 		BuildStarter: scheduler.NewBuildStarter(
 			pipeline,
 			maxinflight.NewUpdater(pipeline),
+			quota.NewUpdater(pipeline, rsf.teamFactory),
+			priorityGate,
 			factory.NewBuildFactory(
 				pipeline.ID(),
 				atc.NewPlanFactory(time.Now().Unix()),
@@ -77,6 +89,7 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalU
 			inputMapper,
 			rsf.engine,
 		),
-		Scanner: scanner,
+		Scanner:      scanner,
+		PriorityGate: priorityGate,
 	}
 }
@@ -87,6 +87,8 @@ type TaskPlan struct {
 	InputMapping      map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
+	CacheResult       bool              `json:"cache_result,omitempty"`
+	Artifacts         []string          `json:"artifacts,omitempty"`
 
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
@@ -118,6 +118,8 @@ func (runner *Runner) OpenConn() db.Conn {
 		runner.DataSourceName(),
 		nil,
 		nil,
+		nil,
+		nil,
 	)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -1,6 +1,8 @@
 package radar
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -12,6 +14,7 @@ import (
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 )
 
@@ -289,7 +292,15 @@ func (scanner *resourceScanner) scan(
 	fromVersion atc.Version,
 	resourceTypes creds.VersionedResourceTypes,
 	source atc.Source,
-) error {
+) (err error) {
+	_, span := tracing.StartSpan(context.Background(), "radar.scan", tracing.Attrs{
+		"team":     scanner.dbPipeline.TeamName(),
+		"pipeline": scanner.dbPipeline.Name(),
+		"resource": savedResource.Name(),
+		"type":     savedResource.Type(),
+	})
+	defer func() { tracing.End(span, err) }()
+
 	pipelinePaused, err := scanner.dbPipeline.CheckPaused()
 	if err != nil {
 		logger.Error("failed-to-check-if-pipeline-paused", err)
@@ -331,6 +342,8 @@ func (scanner *resourceScanner) scan(
 		Env:    metadata.Env(),
 	}
 
+	startTime := scanner.clock.Now()
+
 	res, err := scanner.resourceFactory.NewResource(
 		logger,
 		nil,
@@ -348,6 +361,7 @@ func (scanner *resourceScanner) scan(
 		if setErr != nil {
 			logger.Error("failed-to-set-check-error", err)
 		}
+		scanner.saveCheck(logger, savedResource, startTime, nil, "", err)
 		return err
 	}
 
@@ -355,13 +369,16 @@ func (scanner *resourceScanner) scan(
 		"from": fromVersion,
 	})
 
-	newVersions, err := res.Check(source, fromVersion)
+	stderr := new(bytes.Buffer)
+	newVersions, err := res.Check(source, fromVersion, stderr)
 
 	setErr := scanner.dbPipeline.SetResourceCheckError(savedResource, err)
 	if setErr != nil {
 		logger.Error("failed-to-set-check-error", err)
 	}
 
+	scanner.saveCheck(logger, savedResource, startTime, newVersions, stderr.String(), err)
+
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
@@ -395,6 +412,34 @@ func (scanner *resourceScanner) scan(
 	return nil
 }
 
+func (scanner *resourceScanner) saveCheck(
+	logger lager.Logger,
+	savedResource db.Resource,
+	startTime time.Time,
+	versions []atc.Version,
+	stderr string,
+	checkErr error,
+) {
+	check := db.ResourceCheck{
+		StartTime:     startTime,
+		EndTime:       scanner.clock.Now(),
+		VersionsFound: len(versions),
+		Stderr:        stderr,
+	}
+
+	if rErr, ok := checkErr.(resource.ErrResourceScriptFailed); ok {
+		check.ExitStatus = rErr.ExitStatus
+		check.Stderr = rErr.Stderr
+	} else if checkErr != nil {
+		check.CheckError = checkErr.Error()
+	}
+
+	err := scanner.dbPipeline.SaveResourceCheck(savedResource, check)
+	if err != nil {
+		logger.Error("failed-to-save-check", err)
+	}
+}
+
 func swallowErrResourceScriptFailed(err error) error {
 	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
 		return nil
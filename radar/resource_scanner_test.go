@@ -2,6 +2,7 @@ package radar_test
 
 import (
 	"errors"
+	"io"
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
@@ -249,7 +250,7 @@ var _ = Describe("ResourceScanner", func() {
 
 			Context("when there is no current version", func() {
 				It("checks from nil", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -268,7 +269,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 			})
@@ -292,7 +293,7 @@ var _ = Describe("ResourceScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(source atc.Source, from atc.Version, stderr io.Writer) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(resourceConfig.Source))
@@ -641,13 +642,24 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(err).To(BeNil())
 			})
 
+			It("records the check", func() {
+				Expect(fakeDBPipeline.SaveResourceCheckCallCount()).To(Equal(1))
+
+				savedResourceArg, check := fakeDBPipeline.SaveResourceCheckArgsForCall(0)
+				Expect(savedResourceArg.Name()).To(Equal("some-resource"))
+				Expect(check.StartTime).To(Equal(epoch))
+				Expect(check.EndTime).To(Equal(epoch))
+				Expect(check.ExitStatus).To(Equal(0))
+				Expect(check.CheckError).To(BeEmpty())
+			})
+
 			Context("when there is no current version", func() {
 				BeforeEach(func() {
 					fakeDBPipeline.GetLatestVersionedResourceReturns(db.SavedVersionedResource{}, false, nil)
 				})
 
 				It("checks from nil", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -682,7 +694,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 
@@ -716,7 +728,7 @@ var _ = Describe("ResourceScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(source atc.Source, from atc.Version, stderr io.Writer) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(resourceConfig.Source))
@@ -745,6 +757,31 @@ var _ = Describe("ResourceScanner", func() {
 					}))
 
 				})
+
+				It("records the number of versions found", func() {
+					Expect(fakeDBPipeline.SaveResourceCheckCallCount()).To(Equal(1))
+
+					_, check := fakeDBPipeline.SaveResourceCheckArgsForCall(0)
+					Expect(check.VersionsFound).To(Equal(3))
+				})
+			})
+
+			Context("when the check succeeds and writes to stderr", func() {
+				BeforeEach(func() {
+					fakeResource.CheckStub = func(source atc.Source, from atc.Version, stderr io.Writer) ([]atc.Version, error) {
+						_, err := stderr.Write([]byte("some-warning"))
+						Expect(err).NotTo(HaveOccurred())
+						return []atc.Version{{"version": "1"}}, nil
+					}
+				})
+
+				It("records the stderr of the check", func() {
+					Expect(fakeDBPipeline.SaveResourceCheckCallCount()).To(Equal(1))
+
+					_, check := fakeDBPipeline.SaveResourceCheckArgsForCall(0)
+					Expect(check.VersionsFound).To(Equal(1))
+					Expect(check.Stderr).To(Equal("some-warning"))
+				})
 			})
 
 			Context("when checking fails internally", func() {
@@ -758,6 +795,13 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(scanErr).To(Equal(disaster))
 				})
 
+				It("records the check with the error", func() {
+					Expect(fakeDBPipeline.SaveResourceCheckCallCount()).To(Equal(1))
+
+					_, check := fakeDBPipeline.SaveResourceCheckArgsForCall(0)
+					Expect(check.CheckError).To(Equal("nope"))
+				})
+
 				It("sets the resource's check error", func() {
 					Expect(fakeDBPipeline.SetResourceCheckErrorCallCount()).To(Equal(1))
 
@@ -768,7 +812,10 @@ var _ = Describe("ResourceScanner", func() {
 			})
 
 			Context("when checking fails with ErrResourceScriptFailed", func() {
-				scriptFail := resource.ErrResourceScriptFailed{}
+				scriptFail := resource.ErrResourceScriptFailed{
+					ExitStatus: 2,
+					Stderr:     "some-stderr",
+				}
 
 				BeforeEach(func() {
 					fakeResource.CheckReturns(nil, scriptFail)
@@ -778,6 +825,15 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(scanErr).NotTo(HaveOccurred())
 				})
 
+				It("records the exit status and stderr of the check", func() {
+					Expect(fakeDBPipeline.SaveResourceCheckCallCount()).To(Equal(1))
+
+					_, check := fakeDBPipeline.SaveResourceCheckArgsForCall(0)
+					Expect(check.ExitStatus).To(Equal(2))
+					Expect(check.Stderr).To(Equal("some-stderr"))
+					Expect(check.CheckError).To(BeEmpty())
+				})
+
 				It("sets the resource's check error", func() {
 					Expect(fakeDBPipeline.SetResourceCheckErrorCallCount()).To(Equal(1))
 
@@ -814,7 +870,7 @@ var _ = Describe("ResourceScanner", func() {
 
 			Context("when fromVersion is nil", func() {
 				It("checks from nil", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -827,7 +883,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 			})
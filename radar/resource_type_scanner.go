@@ -1,6 +1,7 @@
 package radar
 
 import (
+	"context"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -8,6 +9,7 @@ import (
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 )
 
@@ -130,7 +132,15 @@ func (scanner *resourceTypeScanner) ScanFromVersion(logger lager.Logger, resourc
 	return nil
 }
 
-func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resourceTypeName string, savedResourceType db.ResourceType, resourceConfigCheckSession db.ResourceConfigCheckSession, versionedResourceTypes creds.VersionedResourceTypes, source atc.Source) error {
+func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resourceTypeName string, savedResourceType db.ResourceType, resourceConfigCheckSession db.ResourceConfigCheckSession, versionedResourceTypes creds.VersionedResourceTypes, source atc.Source) (err error) {
+	_, span := tracing.StartSpan(context.Background(), "radar.scan-resource-type", tracing.Attrs{
+		"team":          scanner.dbPipeline.TeamName(),
+		"pipeline":      scanner.dbPipeline.Name(),
+		"resource-type": resourceTypeName,
+		"type":          savedResourceType.Type(),
+	})
+	defer func() { tracing.End(span, err) }()
+
 	resourceSpec := worker.ContainerSpec{
 		ImageSpec: worker.ImageSpec{
 			ResourceType: savedResourceType.Type(),
@@ -155,7 +165,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 		return err
 	}
 
-	newVersions, err := res.Check(source, atc.Version(savedResourceType.Version()))
+	newVersions, err := res.Check(source, atc.Version(savedResourceType.Version()), nil)
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
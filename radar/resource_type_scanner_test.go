@@ -2,6 +2,7 @@ package radar_test
 
 import (
 	"errors"
+	"io"
 	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
@@ -223,7 +224,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("checks from nil", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -235,7 +236,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 				It("checks with it", func() {
 					Expect(fakeResource.CheckCallCount()).To(Equal(1))
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "42"}))
 				})
 			})
@@ -259,7 +260,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(source atc.Source, from atc.Version, stderr io.Writer) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(atc.Source{"custom": "some-secret-sauce"}))
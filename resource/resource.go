@@ -15,7 +15,7 @@ import (
 type Resource interface {
 	Get(worker.Volume, IOConfig, atc.Source, atc.Params, atc.Version, <-chan os.Signal, chan<- struct{}) (VersionedSource, error)
 	Put(IOConfig, atc.Source, atc.Params, <-chan os.Signal, chan<- struct{}) (VersionedSource, error)
-	Check(atc.Source, atc.Version) ([]atc.Version, error)
+	Check(atc.Source, atc.Version, io.Writer) ([]atc.Version, error)
 	Container() worker.Container
 }
 
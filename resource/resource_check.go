@@ -1,6 +1,9 @@
 package resource
 
 import (
+	"bytes"
+	"io"
+
 	"github.com/concourse/atc"
 	"github.com/tedsuo/ifrit"
 )
@@ -10,20 +13,34 @@ type checkRequest struct {
 	Version atc.Version `json:"version"`
 }
 
-func (resource *resource) Check(source atc.Source, fromVersion atc.Version) ([]atc.Version, error) {
+// Check runs the resource's check script, writing its stderr to the given
+// writer if any, whether or not it succeeds.
+func (resource *resource) Check(source atc.Source, fromVersion atc.Version, stderr io.Writer) ([]atc.Version, error) {
 	var versions []atc.Version
 
+	var logDest io.Writer
+	captured := new(bytes.Buffer)
+	if stderr != nil {
+		logDest = io.MultiWriter(captured, stderr)
+	}
+
 	checking := ifrit.Invoke(resource.runScript(
 		"/opt/resource/check",
 		nil,
 		checkRequest{source, fromVersion},
 		&versions,
-		nil,
+		logDest,
 		false,
 	))
 
 	err := <-checking.Wait()
 	if err != nil {
+		// stderr went to the writer rather than into the error
+		if scriptErr, ok := err.(ErrResourceScriptFailed); ok && logDest != nil {
+			scriptErr.Stderr = captured.String()
+			return nil, scriptErr
+		}
+
 		return nil, err
 	}
 
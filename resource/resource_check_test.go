@@ -1,7 +1,9 @@
 package resource_test
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"io/ioutil"
 
 	"code.cloudfoundry.org/garden"
@@ -24,6 +26,8 @@ var _ = Describe("Resource Check", func() {
 
 		checkScriptProcess *gardenfakes.FakeProcess
 
+		checkStderr io.Writer
+
 		checkResult []atc.Version
 		checkErr    error
 	)
@@ -42,6 +46,8 @@ var _ = Describe("Resource Check", func() {
 			return checkScriptExitStatus, nil
 		}
 
+		checkStderr = nil
+
 		checkResult = nil
 		checkErr = nil
 	})
@@ -61,7 +67,7 @@ var _ = Describe("Resource Check", func() {
 			return checkScriptProcess, nil
 		}
 
-		checkResult, checkErr = resourceForContainer.Check(source, version)
+		checkResult, checkErr = resourceForContainer.Check(source, version, checkStderr)
 	})
 
 	It("runs /opt/resource/check the request on stdin", func() {
@@ -77,6 +83,21 @@ var _ = Describe("Resource Check", func() {
 		Expect(string(request)).To(Equal(`{"source":{"some":"source"},"version":{"some":"version"}}`))
 	})
 
+	Context("when given a writer for stderr", func() {
+		var stderrBuf *bytes.Buffer
+
+		BeforeEach(func() {
+			checkScriptStderr = "some-warning"
+			stderrBuf = new(bytes.Buffer)
+			checkStderr = stderrBuf
+		})
+
+		It("writes stderr to it when the check succeeds", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+			Expect(stderrBuf.String()).To(Equal("some-warning"))
+		})
+	})
+
 	Context("when /check outputs versions", func() {
 		BeforeEach(func() {
 			checkScriptStdout = `[{"ver":"abc"}, {"ver":"def"}, {"ver":"ghi"}]`
@@ -118,6 +139,20 @@ var _ = Describe("Resource Check", func() {
 			Expect(checkErr.Error()).To(ContainSubstring("exit status 9"))
 			Expect(checkErr.Error()).To(ContainSubstring("some-stderr"))
 		})
+
+		Context("when given a writer for stderr", func() {
+			var stderrBuf *bytes.Buffer
+
+			BeforeEach(func() {
+				stderrBuf = new(bytes.Buffer)
+				checkStderr = stderrBuf
+			})
+
+			It("writes stderr to it and still returns it in the error", func() {
+				Expect(stderrBuf.String()).To(Equal("some-stderr"))
+				Expect(checkErr.Error()).To(ContainSubstring("some-stderr"))
+			})
+		})
 	})
 
 	Context("when the output of /opt/resource/check is malformed", func() {
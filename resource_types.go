@@ -2,6 +2,59 @@ package atc
 
 type Source map[string]interface{}
 
+// Merge returns a copy of the source with any keys missing from it filled in
+// from the given defaults. Nested maps are merged recursively; values set on
+// the source always take precedence over the defaults.
+func (source Source) Merge(defaults Source) Source {
+	if len(defaults) == 0 {
+		return source
+	}
+
+	merged := Source{}
+	for key, val := range defaults {
+		merged[key] = val
+	}
+
+	for key, val := range source {
+		merged[key] = mergeValue(val, merged[key])
+	}
+
+	return merged
+}
+
+func mergeValue(val interface{}, def interface{}) interface{} {
+	valMap, ok := asStringMap(val)
+	if !ok {
+		return val
+	}
+
+	defMap, ok := asStringMap(def)
+	if !ok {
+		return val
+	}
+
+	return map[string]interface{}(Source(valMap).Merge(Source(defMap)))
+}
+
+func asStringMap(val interface{}) (map[string]interface{}, bool) {
+	switch m := val.(type) {
+	case Source:
+		return m, true
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		sanitized, err := sanitize(m)
+		if err != nil {
+			return nil, false
+		}
+
+		sMap, ok := sanitized.(map[string]interface{})
+		return sMap, ok
+	}
+
+	return nil, false
+}
+
 type Params map[string]interface{}
 
 type Version map[string]string
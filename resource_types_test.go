@@ -0,0 +1,74 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Source", func() {
+	Describe("Merge", func() {
+		It("fills in keys missing from the source", func() {
+			source := Source{"uri": "some-uri"}
+			merged := source.Merge(Source{"uri": "default-uri", "branch": "master"})
+			Expect(merged).To(Equal(Source{"uri": "some-uri", "branch": "master"}))
+		})
+
+		It("merges nested maps recursively", func() {
+			source := Source{
+				"registry": map[string]interface{}{"host": "some-host"},
+			}
+
+			merged := source.Merge(Source{
+				"registry": map[interface{}]interface{}{
+					"host": "default-host",
+					"port": 443,
+				},
+			})
+
+			Expect(merged).To(Equal(Source{
+				"registry": map[string]interface{}{
+					"host": "some-host",
+					"port": 443,
+				},
+			}))
+		})
+
+		It("does not merge into values that are not maps", func() {
+			source := Source{"registry": "some-registry"}
+			merged := source.Merge(Source{"registry": map[string]interface{}{"host": "default-host"}})
+			Expect(merged).To(Equal(Source{"registry": "some-registry"}))
+		})
+
+		It("does not modify the source", func() {
+			source := Source{"uri": "some-uri"}
+			source.Merge(Source{"branch": "master"})
+			Expect(source).To(Equal(Source{"uri": "some-uri"}))
+		})
+	})
+})
+
+var _ = Describe("ResourceTypes", func() {
+	Describe("DefaultsFor", func() {
+		baseDefaults := map[string]Source{
+			"git": {"branch": "master"},
+		}
+
+		It("returns the defaults of the base resource type", func() {
+			Expect(ResourceTypes{}.DefaultsFor("git", baseDefaults)).To(Equal(Source{"branch": "master"}))
+		})
+
+		It("returns nil when no defaults are configured", func() {
+			Expect(ResourceTypes{}.DefaultsFor("s3", baseDefaults)).To(BeNil())
+		})
+
+		It("prefers the defaults of a pipeline resource type shadowing the base type", func() {
+			types := ResourceTypes{
+				{Name: "git", Type: "docker-image", Defaults: Source{"branch": "develop"}},
+			}
+
+			Expect(types.DefaultsFor("git", baseDefaults)).To(Equal(Source{"branch": "develop"}))
+		})
+	})
+})
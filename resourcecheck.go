@@ -8,3 +8,14 @@ type CheckResponseBody struct {
 	ExitStatus int    `json:"exit_status"`
 	Stderr     string `json:"stderr"`
 }
+
+type ResourceCheck struct {
+	ID            int    `json:"id"`
+	StartTime     int64  `json:"start_time"`
+	EndTime       int64  `json:"end_time"`
+	Duration      int64  `json:"duration_ms"`
+	VersionsFound int    `json:"versions_found"`
+	ExitStatus    int    `json:"exit_status"`
+	CheckError    string `json:"check_error,omitempty"`
+	Stderr        string `json:"stderr,omitempty"`
+}
@@ -6,26 +6,36 @@ const (
 	SaveConfig = "SaveConfig"
 	GetConfig  = "GetConfig"
 
-	GetBuild            = "GetBuild"
-	GetBuildPlan        = "GetBuildPlan"
-	CreateBuild         = "CreateBuild"
-	ListBuilds          = "ListBuilds"
-	BuildEvents         = "BuildEvents"
-	BuildResources      = "BuildResources"
-	AbortBuild          = "AbortBuild"
-	GetBuildPreparation = "GetBuildPreparation"
-
-	GetJob         = "GetJob"
-	CreateJobBuild = "CreateJobBuild"
-	ListJobs       = "ListJobs"
-	ListJobBuilds  = "ListJobBuilds"
-	ListJobInputs  = "ListJobInputs"
-	GetJobBuild    = "GetJobBuild"
-	PauseJob       = "PauseJob"
-	UnpauseJob     = "UnpauseJob"
-	GetVersionsDB  = "GetVersionsDB"
-	JobBadge       = "JobBadge"
-	MainJobBadge   = "MainJobBadge"
+	GetBuild             = "GetBuild"
+	GetBuildPlan         = "GetBuildPlan"
+	CreateBuild          = "CreateBuild"
+	ListBuilds           = "ListBuilds"
+	BuildEvents          = "BuildEvents"
+	BuildResources       = "BuildResources"
+	AbortBuild           = "AbortBuild"
+	HoldBuildContainers  = "HoldBuildContainers"
+	ApproveBuild         = "ApproveBuild"
+	RejectBuild          = "RejectBuild"
+	GetBuildPreparation  = "GetBuildPreparation"
+	ListBuildArtifacts   = "ListBuildArtifacts"
+	GetBuildArtifact     = "GetBuildArtifact"
+	ListBuildTestReports = "ListBuildTestReports"
+	SearchBuildLogs      = "SearchBuildLogs"
+
+	GetJob            = "GetJob"
+	CreateJobBuild    = "CreateJobBuild"
+	RerunJobBuild     = "RerunJobBuild"
+	ListJobs          = "ListJobs"
+	ListJobBuilds     = "ListJobBuilds"
+	ListJobInputs     = "ListJobInputs"
+	ExplainJob        = "ExplainJob"
+	GetJobTestHistory = "GetJobTestHistory"
+	GetJobBuild       = "GetJobBuild"
+	PauseJob          = "PauseJob"
+	UnpauseJob        = "UnpauseJob"
+	GetVersionsDB     = "GetVersionsDB"
+	JobBadge          = "JobBadge"
+	MainJobBadge      = "MainJobBadge"
 
 	ListResources        = "ListResources"
 	GetResource          = "GetResource"
@@ -33,6 +43,7 @@ const (
 	UnpauseResource      = "UnpauseResource"
 	CheckResource        = "CheckResource"
 	CheckResourceWebHook = "CheckResourceWebHook"
+	ListResourceChecks   = "ListResourceChecks"
 
 	ListResourceVersions          = "ListResourceVersions"
 	EnableResourceVersion         = "EnableResourceVersion"
@@ -80,9 +91,10 @@ const (
 	GetAuthToken    = "GetAuthToken"
 	GetUser         = "GetUser"
 
-	ListTeams   = "ListTeams"
-	SetTeam     = "SetTeam"
-	DestroyTeam = "DestroyTeam"
+	ListTeams    = "ListTeams"
+	SetTeam      = "SetTeam"
+	DestroyTeam  = "DestroyTeam"
+	GetTeamQuota = "GetTeamQuota"
 )
 
 var Routes = rata.Routes([]rata.Route{
@@ -96,14 +108,24 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/hold-containers", Method: "PUT", Name: HoldBuildContainers},
+	{Path: "/api/v1/builds/:build_id/approve", Method: "PUT", Name: ApproveBuild},
+	{Path: "/api/v1/builds/:build_id/reject", Method: "PUT", Name: RejectBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/artifacts/:artifact_name", Method: "GET", Name: GetBuildArtifact},
+	{Path: "/api/v1/builds/:build_id/test-reports", Method: "GET", Name: ListBuildTestReports},
+	{Path: "/api/v1/teams/:team_name/builds/log-search", Method: "GET", Name: SearchBuildLogs},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduling", Method: "GET", Name: ExplainJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/test-history", Method: "GET", Name: GetJobTestHistory},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "POST", Name: RerunJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
@@ -128,6 +150,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", Method: "GET", Name: ListResourceChecks},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
@@ -166,4 +189,5 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
+	{Path: "/api/v1/teams/:team_name/quota", Method: "GET", Name: GetTeamQuota},
 })
@@ -1,12 +1,21 @@
 package scheduler
 
 import (
+	"context"
+	"strconv"
+	"time"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/maxinflight"
+	"github.com/concourse/atc/scheduler/priority"
+	"github.com/concourse/atc/scheduler/quota"
+	"github.com/concourse/atc/tracing"
 )
 
 //go:generate counterfeiter . BuildStarter
@@ -30,6 +39,8 @@ type BuildFactory interface {
 func NewBuildStarter(
 	pipeline db.Pipeline,
 	maxInFlightUpdater maxinflight.Updater,
+	quotaUpdater quota.Updater,
+	priorityGate priority.Gate,
 	factory BuildFactory,
 	scanner Scanner,
 	inputMapper inputmapper.InputMapper,
@@ -38,6 +49,8 @@ func NewBuildStarter(
 	return &buildStarter{
 		pipeline:           pipeline,
 		maxInFlightUpdater: maxInFlightUpdater,
+		quotaUpdater:       quotaUpdater,
+		priorityGate:       priorityGate,
 		factory:            factory,
 		scanner:            scanner,
 		inputMapper:        inputMapper,
@@ -48,6 +61,8 @@ func NewBuildStarter(
 type buildStarter struct {
 	pipeline           db.Pipeline
 	maxInFlightUpdater maxinflight.Updater
+	quotaUpdater       quota.Updater
+	priorityGate       priority.Gate
 	factory            BuildFactory
 	execEngine         engine.Engine
 	scanner            Scanner
@@ -62,7 +77,16 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 	nextPendingBuildsForJob []db.Build,
 ) error {
 	for _, nextPendingBuild := range nextPendingBuildsForJob {
-		started, err := s.tryStartNextPendingBuild(logger, nextPendingBuild, job, resources, resourceTypes)
+		ctx, span := tracing.StartSpan(context.Background(), "scheduler.start-build", tracing.Attrs{
+			"team":     job.TeamName(),
+			"pipeline": job.PipelineName(),
+			"job":      job.Name(),
+			"build-id": strconv.Itoa(nextPendingBuild.ID()),
+			"build":    nextPendingBuild.Name(),
+		})
+
+		started, err := s.tryStartNextPendingBuild(ctx, logger, nextPendingBuild, job, resources, resourceTypes)
+		tracing.End(span, err)
 		if err != nil {
 			return err
 		}
@@ -76,6 +100,7 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 }
 
 func (s *buildStarter) tryStartNextPendingBuild(
+	ctx context.Context,
 	logger lager.Logger,
 	nextPendingBuild db.Build,
 	job db.Job,
@@ -95,35 +120,16 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	if nextPendingBuild.IsManuallyTriggered() {
-		jobBuildInputs := job.Config().Inputs()
-		for _, input := range jobBuildInputs {
-			scanLog := logger.Session("scan", lager.Data{
-				"input":    input.Name,
-				"resource": input.Resource,
-			})
-
-			err := s.scanner.Scan(scanLog, input.Resource)
-			if err != nil {
-				return false, err
-			}
-		}
-
-		versions, err := s.pipeline.LoadVersionsDB()
-		if err != nil {
-			logger.Error("failed-to-load-versions-db", err)
-			return false, err
-		}
-
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job)
-		if err != nil {
-			return false, err
-		}
+	waitForHigherPriority, err := s.priorityGate.WaitForHigherPriorityBuilds(logger, job)
+	if err != nil {
+		return false, err
+	}
+	if waitForHigherPriority {
+		return false, nil
 	}
 
-	buildInputs, found, err := job.GetNextBuildInputs()
+	buildInputs, found, err := s.determineBuildInputs(logger, nextPendingBuild, job)
 	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
 		return false, err
 	}
 	if !found {
@@ -143,7 +149,34 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
+	if job.Config().Approval != nil {
+		approved, err := s.checkApproval(logger, nextPendingBuild, *job.Config().Approval, buildInputs)
+		if err != nil {
+			return false, err
+		}
+
+		if !approved {
+			return false, nil
+		}
+	}
+
+	// checked last, so that the team's quota lock is only held while
+	// scheduling rather than while the build's inputs are checked
+	reachedTeamQuota, quotaLock, err := s.quotaUpdater.UpdateTeamQuotaReached(logger, job)
+	if err != nil {
+		return false, err
+	}
+	if reachedTeamQuota {
+		return false, nil
+	}
+
 	updated, err := nextPendingBuild.Schedule()
+
+	// the build now counts towards the quota
+	if quotaLock != nil {
+		quotaLock.Release()
+	}
+
 	if err != nil {
 		logger.Error("failed-to-update-build-to-scheduled", err)
 		return false, err
@@ -154,9 +187,11 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	err = nextPendingBuild.UseInputs(buildInputs)
-	if err != nil {
-		return false, err
+	if !hasPinnedInputs(nextPendingBuild) {
+		err = nextPendingBuild.UseInputs(buildInputs)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	resourceConfigs := atc.ResourceConfigs{}
@@ -187,7 +222,169 @@ func (s *buildStarter) tryStartNextPendingBuild(
 
 	logger.Info("starting")
 
+	if !nextPendingBuild.CreateTime().IsZero() {
+		metric.BuildQueueTime{
+			PipelineName: job.PipelineName(),
+			JobName:      job.Name(),
+			Priority:     job.Priority(),
+			Duration:     time.Since(nextPendingBuild.CreateTime()),
+		}.Emit(logger)
+	}
+
+	// the build's span is started as a child of this one when it resumes
+	tracing.RegisterBuild(nextPendingBuild.ID(), ctx)
+
 	go createdBuild.Resume(logger)
 
 	return true, nil
 }
+
+func (s *buildStarter) determineBuildInputs(
+	logger lager.Logger,
+	nextPendingBuild db.Build,
+	job db.Job,
+) ([]db.BuildInput, bool, error) {
+	if hasPinnedInputs(nextPendingBuild) {
+		buildInputs, _, err := nextPendingBuild.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-pinned-build-inputs", err)
+			return nil, false, err
+		}
+
+		return buildInputs, true, nil
+	}
+
+	if nextPendingBuild.IsManuallyTriggered() {
+		jobBuildInputs := job.Config().Inputs()
+		for _, input := range jobBuildInputs {
+			scanLog := logger.Session("scan", lager.Data{
+				"input":    input.Name,
+				"resource": input.Resource,
+			})
+
+			err := s.scanner.Scan(scanLog, input.Resource)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		versions, err := s.pipeline.LoadVersionsDB()
+		if err != nil {
+			logger.Error("failed-to-load-versions-db", err)
+			return nil, false, err
+		}
+
+		inputOverrides, err := nextPendingBuild.InputOverrides()
+		if err != nil {
+			logger.Error("failed-to-get-input-overrides", err)
+			return nil, false, err
+		}
+
+		if len(inputOverrides) != 0 {
+			return s.overriddenBuildInputs(logger, versions, job, inputOverrides)
+		}
+
+		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	buildInputs, found, err := job.GetNextBuildInputs()
+	if err != nil {
+		logger.Error("failed-to-get-next-build-inputs", err)
+		return nil, false, err
+	}
+
+	return buildInputs, found, nil
+}
+
+func (s *buildStarter) overriddenBuildInputs(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	inputOverrides map[string]int,
+) ([]db.BuildInput, bool, error) {
+	mapping, ok, err := s.inputMapper.ResolveInputMappingWithOverrides(logger, versions, job, inputOverrides)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !ok {
+		return nil, false, nil
+	}
+
+	buildInputs := []db.BuildInput{}
+	for _, input := range job.Config().Inputs() {
+		inputVersion, found := mapping[input.Name]
+		if !found {
+			return nil, false, nil
+		}
+
+		savedVersion, found, err := s.pipeline.GetVersionedResource(inputVersion.VersionID)
+		if err != nil {
+			logger.Error("failed-to-get-versioned-resource", err)
+			return nil, false, err
+		}
+
+		if !found {
+			return nil, false, nil
+		}
+
+		_, overridden := inputOverrides[input.Name]
+
+		buildInputs = append(buildInputs, db.BuildInput{
+			Name:              input.Name,
+			VersionedResource: savedVersion.VersionedResource,
+			FirstOccurrence:   inputVersion.FirstOccurrence,
+			Overridden:        overridden,
+		})
+	}
+
+	return buildInputs, true, nil
+}
+
+// hasPinnedInputs reports whether the build's inputs were saved before it was
+// scheduled: reruns keep the inputs copied over from the build they rerun, and
+// builds awaiting approval keep the inputs shown to their approvers.
+func hasPinnedInputs(build db.Build) bool {
+	return build.RerunOf() != 0 || build.ApprovalStatus() != ""
+}
+
+func (s *buildStarter) checkApproval(logger lager.Logger, build db.Build, config atc.ApprovalConfig, buildInputs []db.BuildInput) (bool, error) {
+	switch build.ApprovalStatus() {
+	case atc.ApprovalStatusApproved:
+		return true, nil
+
+	case atc.ApprovalStatusAwaiting:
+		deadline := build.ApprovalDeadline()
+		if deadline.IsZero() || time.Now().Before(deadline) {
+			return false, nil
+		}
+
+		_, err := build.ResolveApproval(atc.ApprovalStatusTimedOut, "", "")
+		if err != nil {
+			logger.Error("failed-to-time-out-approval", err)
+			return false, err
+		}
+
+		return false, nil
+
+	case "":
+		var deadline time.Time
+		if timeout := config.TimeoutDuration(); timeout > 0 {
+			deadline = time.Now().Add(timeout)
+		}
+
+		_, err := build.RequestApproval(config.Approvers, deadline, buildInputs)
+		if err != nil {
+			logger.Error("failed-to-request-approval", err)
+			return false, err
+		}
+
+		return false, nil
+
+	default:
+		return false, nil
+	}
+}
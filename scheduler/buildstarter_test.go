@@ -2,6 +2,8 @@ package scheduler_test
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -9,11 +11,14 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
 	"github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/atc/scheduler/maxinflight/maxinflightfakes"
+	"github.com/concourse/atc/scheduler/priority/priorityfakes"
+	"github.com/concourse/atc/scheduler/quota/quotafakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
 
 	. "github.com/onsi/ginkgo"
@@ -24,6 +29,8 @@ var _ = Describe("I'm a BuildStarter", func() {
 	var (
 		fakePipeline     *dbfakes.FakePipeline
 		fakeUpdater      *maxinflightfakes.FakeUpdater
+		fakeQuotaUpdater *quotafakes.FakeUpdater
+		fakePriorityGate *priorityfakes.FakeGate
 		fakeFactory      *schedulerfakes.FakeBuildFactory
 		fakeEngine       *enginefakes.FakeEngine
 		pendingBuilds    []db.Build
@@ -39,13 +46,15 @@ var _ = Describe("I'm a BuildStarter", func() {
 	BeforeEach(func() {
 		fakePipeline = new(dbfakes.FakePipeline)
 		fakeUpdater = new(maxinflightfakes.FakeUpdater)
+		fakeQuotaUpdater = new(quotafakes.FakeUpdater)
+		fakePriorityGate = new(priorityfakes.FakeGate)
 		fakeFactory = new(schedulerfakes.FakeBuildFactory)
 		fakeEngine = new(enginefakes.FakeEngine)
 		fakeScanner = new(schedulerfakes.FakeScanner)
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
 
-		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
+		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeQuotaUpdater, fakePriorityGate, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
 
 		disaster = errors.New("bad thing")
 	})
@@ -109,6 +118,65 @@ var _ = Describe("I'm a BuildStarter", func() {
 				})
 			})
 
+			Context("when the team quota is reached", func() {
+				BeforeEach(func() {
+					fakeQuotaUpdater.UpdateTeamQuotaReachedReturns(true, nil, nil)
+				})
+
+				It("updates the team quota for the job", func() {
+					Expect(fakeQuotaUpdater.UpdateTeamQuotaReachedCallCount()).To(Equal(1))
+					_, actualJob := fakeQuotaUpdater.UpdateTeamQuotaReachedArgsForCall(0)
+					Expect(actualJob.Name()).To(Equal(job.Name()))
+				})
+
+				It("checks the quota only once the resources have been checked", func() {
+					Expect(fakeScanner.ScanCallCount()).To(Equal(2))
+				})
+
+				It("leaves the build pending", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when updating the team quota fails", func() {
+				BeforeEach(func() {
+					fakeQuotaUpdater.UpdateTeamQuotaReachedReturns(false, nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(Equal(disaster))
+				})
+			})
+
+			Context("when the build must wait for builds of higher priority jobs", func() {
+				BeforeEach(func() {
+					fakePriorityGate.WaitForHigherPriorityBuildsReturns(true, nil)
+				})
+
+				It("checks the gate for the job", func() {
+					Expect(fakePriorityGate.WaitForHigherPriorityBuildsCallCount()).To(Equal(1))
+					_, actualJob := fakePriorityGate.WaitForHigherPriorityBuildsArgsForCall(0)
+					Expect(actualJob.Name()).To(Equal(job.Name()))
+				})
+
+				It("leaves the build pending", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(fakeScanner.ScanCallCount()).To(BeZero())
+					Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when checking for builds of higher priority jobs fails", func() {
+				BeforeEach(func() {
+					fakePriorityGate.WaitForHigherPriorityBuildsReturns(false, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(Equal(disaster))
+				})
+			})
+
 			Context("when max in flight is not reached", func() {
 				BeforeEach(func() {
 					fakeUpdater.UpdateMaxInFlightReachedReturns(false, nil)
@@ -118,6 +186,22 @@ var _ = Describe("I'm a BuildStarter", func() {
 					Expect(fakeScanner.ScanCallCount()).To(Equal(2))
 				})
 
+				Context("when the team's quota is locked", func() {
+					BeforeEach(func() {
+						fakeQuotaUpdater.UpdateTeamQuotaReachedReturns(false, new(lockfakes.FakeLock), nil)
+
+						fakeScanner.ScanStub = func(lager.Logger, string) error {
+							defer GinkgoRecover()
+							Expect(fakeQuotaUpdater.UpdateTeamQuotaReachedCallCount()).To(BeZero())
+							return nil
+						}
+					})
+
+					It("does not hold the lock while checking resources", func() {
+						Expect(fakeScanner.ScanCallCount()).To(Equal(2))
+					})
+				})
+
 				Context("when resource checking fails", func() {
 					BeforeEach(func() {
 						fakeScanner.ScanReturns(disaster)
@@ -230,6 +314,86 @@ var _ = Describe("I'm a BuildStarter", func() {
 								Expect(tryStartErr).NotTo(HaveOccurred())
 							})
 						})
+
+						Context("when the build has input overrides", func() {
+							BeforeEach(func() {
+								createdBuild.InputOverridesReturns(map[string]int{"input-1": 5}, nil)
+								createdBuild.ScheduleReturns(true, nil)
+
+								fakeInputMapper.ResolveInputMappingWithOverridesReturns(algorithm.InputMapping{
+									"input-1": algorithm.InputVersion{VersionID: 5, FirstOccurrence: true},
+									"input-2": algorithm.InputVersion{VersionID: 6, FirstOccurrence: false},
+								}, true, nil)
+
+								fakePipeline.GetVersionedResourceStub = func(id int) (db.SavedVersionedResource, bool, error) {
+									return db.SavedVersionedResource{
+										ID: id,
+										VersionedResource: db.VersionedResource{
+											Resource: fmt.Sprintf("input-%d", id-4),
+											Version:  db.ResourceVersion{"version": fmt.Sprintf("v%d", id)},
+										},
+									}, true, nil
+								}
+
+								fakeFactory.CreateReturns(atc.Plan{Task: &atc.TaskPlan{ConfigPath: "some-task-1.yml"}}, nil)
+								fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+							})
+
+							It("resolves the inputs with the overrides instead of saving the next input mapping", func() {
+								Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+								Expect(job.GetNextBuildInputsCallCount()).To(BeZero())
+
+								Expect(fakeInputMapper.ResolveInputMappingWithOverridesCallCount()).To(Equal(1))
+								_, actualVersionsDB, actualJob, actualOverrides := fakeInputMapper.ResolveInputMappingWithOverridesArgsForCall(0)
+								Expect(actualVersionsDB).To(Equal(versionsDB))
+								Expect(actualJob).To(Equal(job))
+								Expect(actualOverrides).To(Equal(map[string]int{"input-1": 5}))
+							})
+
+							It("uses the resolved inputs, marking the overridden ones", func() {
+								Expect(tryStartErr).NotTo(HaveOccurred())
+								Expect(createdBuild.UseInputsCallCount()).To(Equal(1))
+								Expect(createdBuild.UseInputsArgsForCall(0)).To(Equal([]db.BuildInput{
+									{
+										Name: "input-1",
+										VersionedResource: db.VersionedResource{
+											Resource: "input-1",
+											Version:  db.ResourceVersion{"version": "v5"},
+										},
+										FirstOccurrence: true,
+										Overridden:      true,
+									},
+									{
+										Name: "input-2",
+										VersionedResource: db.VersionedResource{
+											Resource: "input-2",
+											Version:  db.ResourceVersion{"version": "v6"},
+										},
+									},
+								}))
+							})
+
+							Context("when the inputs do not resolve", func() {
+								BeforeEach(func() {
+									fakeInputMapper.ResolveInputMappingWithOverridesReturns(nil, false, nil)
+								})
+
+								It("leaves the build pending", func() {
+									Expect(tryStartErr).NotTo(HaveOccurred())
+									Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when resolving the inputs fails", func() {
+								BeforeEach(func() {
+									fakeInputMapper.ResolveInputMappingWithOverridesReturns(nil, false, disaster)
+								})
+
+								It("returns the error", func() {
+									Expect(tryStartErr).To(Equal(disaster))
+								})
+							})
+						})
 					})
 				})
 			})
@@ -504,6 +668,29 @@ var _ = Describe("I'm a BuildStarter", func() {
 										Eventually(engineBuild2.ResumeCallCount).Should(Equal(1))
 										Eventually(engineBuild3.ResumeCallCount).Should(Equal(1))
 									})
+
+									Context("when the team's quota is locked", func() {
+										var fakeQuotaLock *lockfakes.FakeLock
+										var scheduledWhenReleased []int
+
+										BeforeEach(func() {
+											fakeQuotaLock = new(lockfakes.FakeLock)
+											fakeQuotaUpdater.UpdateTeamQuotaReachedReturns(false, fakeQuotaLock, nil)
+
+											scheduledWhenReleased = []int{}
+											fakeQuotaLock.ReleaseStub = func() error {
+												scheduledWhenReleased = append(
+													scheduledWhenReleased,
+													pendingBuild1.ScheduleCallCount()+pendingBuild2.ScheduleCallCount()+pendingBuild3.ScheduleCallCount(),
+												)
+												return nil
+											}
+										})
+
+										It("releases the lock once each build is scheduled", func() {
+											Expect(scheduledWhenReleased).To(Equal([]int{1, 2, 3}))
+										})
+									})
 								})
 							})
 						})
@@ -570,6 +757,169 @@ var _ = Describe("I'm a BuildStarter", func() {
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
+
+					Context("when the job requires approval", func() {
+						BeforeEach(func() {
+							job.ConfigReturns(atc.JobConfig{
+								Name: "some-job",
+								Approval: &atc.ApprovalConfig{
+									Approvers: []string{"some-approvers"},
+									Timeout:   "1h",
+								},
+							})
+						})
+
+						Context("when approval has not been requested yet", func() {
+							It("requests approval with a deadline", func() {
+								Expect(tryStartErr).NotTo(HaveOccurred())
+								Expect(pendingBuild1.RequestApprovalCallCount()).To(Equal(1))
+								approvers, deadline, _ := pendingBuild1.RequestApprovalArgsForCall(0)
+								Expect(approvers).To(Equal([]string{"some-approvers"}))
+								Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+							})
+
+							It("pins the inputs which are to be approved", func() {
+								_, _, inputs := pendingBuild1.RequestApprovalArgsForCall(0)
+								Expect(inputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
+							})
+
+							It("does not schedule the build", func() {
+								Expect(pendingBuild1.ScheduleCallCount()).To(BeZero())
+								Expect(pendingBuild2.RequestApprovalCallCount()).To(BeZero())
+							})
+
+							Context("when requesting approval fails", func() {
+								BeforeEach(func() {
+									pendingBuild1.RequestApprovalReturns(false, disaster)
+								})
+
+								itReturnsTheError()
+							})
+						})
+
+						Context("when the build is awaiting approval", func() {
+							BeforeEach(func() {
+								pendingBuild1.ApprovalStatusReturns(atc.ApprovalStatusAwaiting)
+								pendingBuild1.ApprovalDeadlineReturns(time.Now().Add(time.Minute))
+							})
+
+							It("does not schedule the build", func() {
+								Expect(tryStartErr).NotTo(HaveOccurred())
+								Expect(pendingBuild1.RequestApprovalCallCount()).To(BeZero())
+								Expect(pendingBuild1.ResolveApprovalCallCount()).To(BeZero())
+								Expect(pendingBuild1.ScheduleCallCount()).To(BeZero())
+							})
+
+							Context("when the approval has timed out", func() {
+								BeforeEach(func() {
+									pendingBuild1.ApprovalDeadlineReturns(time.Now().Add(-time.Minute))
+								})
+
+								It("times out the approval", func() {
+									Expect(tryStartErr).NotTo(HaveOccurred())
+									Expect(pendingBuild1.ResolveApprovalCallCount()).To(Equal(1))
+									status, approver, _ := pendingBuild1.ResolveApprovalArgsForCall(0)
+									Expect(status).To(Equal(atc.ApprovalStatusTimedOut))
+									Expect(approver).To(BeEmpty())
+									Expect(pendingBuild1.ScheduleCallCount()).To(BeZero())
+								})
+							})
+						})
+
+						Context("when the build has been rejected", func() {
+							BeforeEach(func() {
+								pendingBuild1.ApprovalStatusReturns(atc.ApprovalStatusRejected)
+							})
+
+							It("does not schedule the build", func() {
+								Expect(tryStartErr).NotTo(HaveOccurred())
+								Expect(pendingBuild1.ScheduleCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the build has been approved", func() {
+							var approvedInputs []db.BuildInput
+
+							BeforeEach(func() {
+								approvedInputs = []db.BuildInput{{Name: "some-approved-input"}}
+
+								pendingBuild1.ApprovalStatusReturns(atc.ApprovalStatusApproved)
+								pendingBuild1.ResourcesReturns(approvedInputs, nil, nil)
+							})
+
+							It("schedules the build", func() {
+								Expect(pendingBuild1.ScheduleCallCount()).To(Equal(1))
+							})
+
+							It("runs the build with the approved inputs rather than the latest ones", func() {
+								Expect(pendingBuild1.UseInputsCallCount()).To(BeZero())
+
+								Expect(fakeFactory.CreateCallCount()).To(BeNumerically(">=", 1))
+								_, _, _, actualInputs := fakeFactory.CreateArgsForCall(0)
+								Expect(actualInputs).To(Equal(approvedInputs))
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Context("when rerunning a build", func() {
+			var rerunInputs []db.BuildInput
+
+			BeforeEach(func() {
+				job = new(dbfakes.FakeJob)
+				job.NameReturns("some-job")
+				job.ConfigReturns(atc.JobConfig{Name: "some-job", Plan: atc.PlanSequence{{Get: "some-input"}}})
+
+				rerunInputs = []db.BuildInput{{Name: "some-input"}}
+
+				createdBuild.RerunOfReturns(12)
+				createdBuild.ResourcesReturns(rerunInputs, nil, nil)
+				createdBuild.ScheduleReturns(true, nil)
+
+				fakeFactory.CreateReturns(atc.Plan{Task: &atc.TaskPlan{ConfigPath: "some-task-1.yml"}}, nil)
+				fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					job,
+					db.Resources{resource},
+					versionedResourceTypes,
+					pendingBuilds,
+				)
+			})
+
+			It("does not check resources or compute the next inputs", func() {
+				Expect(fakeScanner.ScanCallCount()).To(BeZero())
+				Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(job.GetNextBuildInputsCallCount()).To(BeZero())
+			})
+
+			It("does not overwrite the inputs copied from the rerun build", func() {
+				Expect(createdBuild.UseInputsCallCount()).To(BeZero())
+			})
+
+			It("creates the build plan with the inputs of the rerun build", func() {
+				Expect(tryStartErr).NotTo(HaveOccurred())
+				Expect(fakeFactory.CreateCallCount()).To(Equal(1))
+				_, _, _, actualBuildInputs := fakeFactory.CreateArgsForCall(0)
+				Expect(actualBuildInputs).To(Equal(rerunInputs))
+			})
+
+			Context("when getting the inputs of the rerun build fails", func() {
+				BeforeEach(func() {
+					createdBuild.ResourcesReturns(nil, nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(Equal(disaster))
+				})
+
+				It("does not schedule the build", func() {
+					Expect(createdBuild.ScheduleCallCount()).To(BeZero())
 				})
 			})
 		})
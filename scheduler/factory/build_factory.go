@@ -237,6 +237,8 @@ func (factory *buildFactory) constructUnhookedPlan(
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
+			CacheResult:       planConfig.CacheResult,
+			Artifacts:         planConfig.Artifacts,
 
 			VersionedResourceTypes: resourceTypes,
 		})
@@ -181,5 +181,59 @@ var _ = Describe("Factory Task", func() {
 				Expect(actual).To(testhelpers.MatchPlan(expected))
 			})
 		})
+
+		Context("when cache_result is specified", func() {
+			BeforeEach(func() {
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:           "some-task",
+							TaskConfigPath: "some-input/task.yml",
+							CacheResult:    true,
+						},
+					},
+				}
+			})
+
+			It("creates a build plan that caches the task's result", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name: "some-task",
+					VersionedResourceTypes: resourceTypes,
+					ConfigPath:             "some-input/task.yml",
+					CacheResult:            true,
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+
+		Context("when artifacts are specified", func() {
+			BeforeEach(func() {
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:           "some-task",
+							TaskConfigPath: "some-input/task.yml",
+							Artifacts:      []string{"some-output"},
+						},
+					},
+				}
+			})
+
+			It("creates a build plan that keeps the artifacts", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name: "some-task",
+					VersionedResourceTypes: resourceTypes,
+					ConfigPath:             "some-input/task.yml",
+					Artifacts:              []string{"some-output"},
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
 	})
 })
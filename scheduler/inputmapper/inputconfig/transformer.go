@@ -47,12 +47,18 @@ func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName st
 			jobs[db.JobIDs[passedJobName]] = struct{}{}
 		}
 
+		passedAnyJobs := algorithm.JobSet{}
+		for _, passedJobName := range input.PassedAny {
+			passedAnyJobs[db.JobIDs[passedJobName]] = struct{}{}
+		}
+
 		inputConfigs = append(inputConfigs, algorithm.InputConfig{
 			Name:            input.Name,
 			UseEveryVersion: input.Version.Every,
 			PinnedVersionID: pinnedVersionID,
 			ResourceID:      db.ResourceIDs[input.Resource],
 			Passed:          jobs,
+			PassedAny:       passedAnyJobs,
 			JobID:           db.JobIDs[jobName],
 		})
 	}
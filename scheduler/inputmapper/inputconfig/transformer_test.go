@@ -59,6 +59,7 @@ var _ = Describe("Transformer", func() {
 						PinnedVersionID: 0,
 						ResourceID:      11,
 						Passed:          algorithm.JobSet{},
+						PassedAny:       algorithm.JobSet{},
 						JobID:           1,
 					}))
 				})
@@ -81,6 +82,30 @@ var _ = Describe("Transformer", func() {
 						PinnedVersionID: 0,
 						ResourceID:      11,
 						Passed:          algorithm.JobSet{1: struct{}{}, 2: struct{}{}},
+						PassedAny:       algorithm.JobSet{},
+						JobID:           1,
+					}))
+				})
+			})
+
+			Context("when an input has passed_any constraints", func() {
+				BeforeEach(func() {
+					jobInputs = []atc.JobInput{{
+						Name:      "job-input-1",
+						Resource:  "r1",
+						Version:   &atc.VersionConfig{Latest: true},
+						PassedAny: []string{"j1", "j2"},
+					}}
+				})
+
+				It("expresses them as a JobSet", func() {
+					Expect(algorithmInputs).To(ConsistOf(algorithm.InputConfig{
+						Name:            "job-input-1",
+						UseEveryVersion: false,
+						PinnedVersionID: 0,
+						ResourceID:      11,
+						Passed:          algorithm.JobSet{},
+						PassedAny:       algorithm.JobSet{1: struct{}{}, 2: struct{}{}},
 						JobID:           1,
 					}))
 				})
@@ -102,6 +127,7 @@ var _ = Describe("Transformer", func() {
 						PinnedVersionID: 0,
 						ResourceID:      11,
 						Passed:          algorithm.JobSet{},
+						PassedAny:       algorithm.JobSet{},
 						JobID:           1,
 					}))
 				})
@@ -155,6 +181,7 @@ var _ = Describe("Transformer", func() {
 							PinnedVersionID: 0,
 							ResourceID:      12,
 							Passed:          algorithm.JobSet{},
+							PassedAny:       algorithm.JobSet{},
 							JobID:           1,
 						}))
 					})
@@ -173,6 +200,7 @@ var _ = Describe("Transformer", func() {
 								PinnedVersionID: 99,
 								ResourceID:      11,
 								Passed:          algorithm.JobSet{},
+								PassedAny:       algorithm.JobSet{},
 								JobID:           1,
 							},
 							algorithm.InputConfig{
@@ -181,6 +209,7 @@ var _ = Describe("Transformer", func() {
 								PinnedVersionID: 0,
 								ResourceID:      12,
 								Passed:          algorithm.JobSet{},
+								PassedAny:       algorithm.JobSet{},
 								JobID:           1,
 							},
 						))
@@ -208,6 +237,7 @@ var _ = Describe("Transformer", func() {
 					PinnedVersionID: 0,
 					ResourceID:      0,
 					Passed:          algorithm.JobSet{0: struct{}{}},
+					PassedAny:       algorithm.JobSet{},
 					JobID:           0,
 				}))
 			})
@@ -15,6 +15,20 @@ type InputMapper interface {
 		versions *algorithm.VersionsDB,
 		job db.Job,
 	) (algorithm.InputMapping, error)
+
+	ExplainNextInputMapping(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job db.Job,
+		candidates map[string][]int,
+	) ([]algorithm.InputExplanation, error)
+
+	ResolveInputMappingWithOverrides(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job db.Job,
+		inputOverrides map[string]int,
+	) (algorithm.InputMapping, bool, error)
 }
 
 func NewInputMapper(pipeline db.Pipeline, transformer inputconfig.Transformer) InputMapper {
@@ -83,3 +97,63 @@ func (i *inputMapper) SaveNextInputMapping(
 
 	return resolvedMapping, nil
 }
+
+func (i *inputMapper) ExplainNextInputMapping(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	candidates map[string][]int,
+) ([]algorithm.InputExplanation, error) {
+	logger = logger.Session("explain-next-input-mapping")
+
+	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), job.Config().Inputs())
+	if err != nil {
+		logger.Error("failed-to-get-algorithm-input-configs", err)
+		return nil, err
+	}
+
+	return algorithmInputConfigs.Explain(versions, candidates), nil
+}
+
+// ResolveInputMappingWithOverrides resolves the job's inputs with the
+// overridden inputs pinned to the given versioned resources and their passed
+// constraints dropped. Unlike SaveNextInputMapping, the result is not saved
+// as the job's next input mapping, as it only applies to a single build.
+func (i *inputMapper) ResolveInputMappingWithOverrides(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	inputOverrides map[string]int,
+) (algorithm.InputMapping, bool, error) {
+	logger = logger.Session("resolve-input-mapping-with-overrides")
+
+	inputConfigs := job.Config().Inputs()
+
+	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), inputConfigs)
+	if err != nil {
+		logger.Error("failed-to-get-algorithm-input-configs", err)
+		return nil, false, err
+	}
+
+	for idx, inputConfig := range algorithmInputConfigs {
+		versionedResourceID, overridden := inputOverrides[inputConfig.Name]
+		if !overridden {
+			continue
+		}
+
+		inputConfig.PinnedVersionID = versionedResourceID
+		inputConfig.UseEveryVersion = false
+		inputConfig.Passed = algorithm.JobSet{}
+		inputConfig.PassedAny = algorithm.JobSet{}
+
+		algorithmInputConfigs[idx] = inputConfig
+	}
+
+	mapping, ok := algorithmInputConfigs.Resolve(versions)
+	if !ok || len(mapping) < len(inputConfigs) {
+		// inputs with missing pinned versions are left out by the transformer
+		return nil, false, nil
+	}
+
+	return mapping, true, nil
+}
@@ -378,4 +378,171 @@ var _ = Describe("Inputmapper", func() {
 			})
 		})
 	})
+
+	Describe("ExplainNextInputMapping", func() {
+		var (
+			versionsDB   *algorithm.VersionsDB
+			fakeJob      *dbfakes.FakeJob
+			explanations []algorithm.InputExplanation
+			explainErr   error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1},
+				ResourceIDs: map[string]int{"a": 11},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					{VersionID: 2, ResourceID: 11, CheckOrder: 2},
+				},
+			}
+
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{Get: "alias", Resource: "a"},
+				},
+			})
+
+			fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+				{Name: "alias", ResourceID: 11, Passed: algorithm.JobSet{}, JobID: 1},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			explanations, explainErr = inputMapper.ExplainNextInputMapping(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				fakeJob,
+				map[string][]int{"alias": {2, 1}},
+			)
+		})
+
+		It("transforms the job's input configs", func() {
+			Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(1))
+			actualVersionsDB, actualJobName, actualInputs := fakeTransformer.TransformInputConfigsArgsForCall(0)
+			Expect(actualVersionsDB).To(Equal(versionsDB))
+			Expect(actualJobName).To(Equal("some-job"))
+			Expect(actualInputs).To(Equal(fakeJob.Config().Inputs()))
+		})
+
+		It("explains the candidates without saving anything", func() {
+			Expect(explainErr).NotTo(HaveOccurred())
+			Expect(explanations).To(HaveLen(1))
+			Expect(explanations[0].VersionID).To(Equal(2))
+			Expect(explanations[0].Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 2, Selected: true},
+				{VersionID: 1, Reason: algorithm.ReasonNotLatest},
+			}))
+
+			Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+			Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(BeZero())
+		})
+
+		Context("when transforming the input configs fails", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("ResolveInputMappingWithOverrides", func() {
+		var (
+			versionsDB     *algorithm.VersionsDB
+			fakeJob        *dbfakes.FakeJob
+			inputOverrides map[string]int
+			inputMapping   algorithm.InputMapping
+			resolved       bool
+			resolveErr     error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+				ResourceIDs: map[string]int{"a": 11, "b": 12},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					{VersionID: 2, ResourceID: 11, CheckOrder: 2},
+					{VersionID: 3, ResourceID: 12, CheckOrder: 1},
+				},
+				BuildOutputs: []algorithm.BuildOutput{
+					{
+						ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+						BuildID:         98,
+						JobID:           2,
+					},
+					{
+						ResourceVersion: algorithm.ResourceVersion{VersionID: 3, ResourceID: 12, CheckOrder: 1},
+						BuildID:         98,
+						JobID:           2,
+					},
+				},
+			}
+
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{Get: "alias", Resource: "a", Passed: []string{"upstream"}},
+					{Get: "b", Passed: []string{"upstream"}},
+				},
+			})
+
+			fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+				{Name: "alias", ResourceID: 11, Passed: algorithm.JobSet{2: {}}, JobID: 1},
+				{Name: "b", ResourceID: 12, Passed: algorithm.JobSet{2: {}}, JobID: 1},
+			}, nil)
+
+			inputOverrides = map[string]int{"alias": 2}
+		})
+
+		JustBeforeEach(func() {
+			inputMapping, resolved, resolveErr = inputMapper.ResolveInputMappingWithOverrides(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				fakeJob,
+				inputOverrides,
+			)
+		})
+
+		It("pins the overridden inputs and ignores their passed constraints", func() {
+			Expect(resolveErr).NotTo(HaveOccurred())
+			Expect(resolved).To(BeTrue())
+			Expect(inputMapping).To(Equal(algorithm.InputMapping{
+				"alias": algorithm.InputVersion{VersionID: 2, FirstOccurrence: true},
+				"b":     algorithm.InputVersion{VersionID: 3, FirstOccurrence: true},
+			}))
+		})
+
+		It("does not save anything for the job", func() {
+			Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+			Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(BeZero())
+		})
+
+		Context("when the overridden version does not exist", func() {
+			BeforeEach(func() {
+				inputOverrides = map[string]int{"alias": 42}
+			})
+
+			It("does not resolve", func() {
+				Expect(resolveErr).NotTo(HaveOccurred())
+				Expect(resolved).To(BeFalse())
+			})
+		})
+
+		Context("when transforming the input configs fails", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(resolveErr).To(Equal(disaster))
+			})
+		})
+	})
 })
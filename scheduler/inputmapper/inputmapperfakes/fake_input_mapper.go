@@ -26,6 +26,40 @@ type FakeInputMapper struct {
 		result1 algorithm.InputMapping
 		result2 error
 	}
+	ExplainNextInputMappingStub        func(logger lager.Logger, versions *algorithm.VersionsDB, job db.Job, candidates map[string][]int) ([]algorithm.InputExplanation, error)
+	explainNextInputMappingMutex       sync.RWMutex
+	explainNextInputMappingArgsForCall []struct {
+		logger     lager.Logger
+		versions   *algorithm.VersionsDB
+		job        db.Job
+		candidates map[string][]int
+	}
+	explainNextInputMappingReturns struct {
+		result1 []algorithm.InputExplanation
+		result2 error
+	}
+	explainNextInputMappingReturnsOnCall map[int]struct {
+		result1 []algorithm.InputExplanation
+		result2 error
+	}
+	ResolveInputMappingWithOverridesStub        func(logger lager.Logger, versions *algorithm.VersionsDB, job db.Job, inputOverrides map[string]int) (algorithm.InputMapping, bool, error)
+	resolveInputMappingWithOverridesMutex       sync.RWMutex
+	resolveInputMappingWithOverridesArgsForCall []struct {
+		logger         lager.Logger
+		versions       *algorithm.VersionsDB
+		job            db.Job
+		inputOverrides map[string]int
+	}
+	resolveInputMappingWithOverridesReturns struct {
+		result1 algorithm.InputMapping
+		result2 bool
+		result3 error
+	}
+	resolveInputMappingWithOverridesReturnsOnCall map[int]struct {
+		result1 algorithm.InputMapping
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -83,11 +117,126 @@ func (fake *FakeInputMapper) SaveNextInputMappingReturnsOnCall(i int, result1 al
 	}{result1, result2}
 }
 
+func (fake *FakeInputMapper) ExplainNextInputMapping(logger lager.Logger, versions *algorithm.VersionsDB, job db.Job, candidates map[string][]int) ([]algorithm.InputExplanation, error) {
+	fake.explainNextInputMappingMutex.Lock()
+	ret, specificReturn := fake.explainNextInputMappingReturnsOnCall[len(fake.explainNextInputMappingArgsForCall)]
+	fake.explainNextInputMappingArgsForCall = append(fake.explainNextInputMappingArgsForCall, struct {
+		logger     lager.Logger
+		versions   *algorithm.VersionsDB
+		job        db.Job
+		candidates map[string][]int
+	}{logger, versions, job, candidates})
+	fake.recordInvocation("ExplainNextInputMapping", []interface{}{logger, versions, job, candidates})
+	fake.explainNextInputMappingMutex.Unlock()
+	if fake.ExplainNextInputMappingStub != nil {
+		return fake.ExplainNextInputMappingStub(logger, versions, job, candidates)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.explainNextInputMappingReturns.result1, fake.explainNextInputMappingReturns.result2
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingCallCount() int {
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
+	return len(fake.explainNextInputMappingArgsForCall)
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, db.Job, map[string][]int) {
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
+	return fake.explainNextInputMappingArgsForCall[i].logger, fake.explainNextInputMappingArgsForCall[i].versions, fake.explainNextInputMappingArgsForCall[i].job, fake.explainNextInputMappingArgsForCall[i].candidates
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingReturns(result1 []algorithm.InputExplanation, result2 error) {
+	fake.ExplainNextInputMappingStub = nil
+	fake.explainNextInputMappingReturns = struct {
+		result1 []algorithm.InputExplanation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingReturnsOnCall(i int, result1 []algorithm.InputExplanation, result2 error) {
+	fake.ExplainNextInputMappingStub = nil
+	if fake.explainNextInputMappingReturnsOnCall == nil {
+		fake.explainNextInputMappingReturnsOnCall = make(map[int]struct {
+			result1 []algorithm.InputExplanation
+			result2 error
+		})
+	}
+	fake.explainNextInputMappingReturnsOnCall[i] = struct {
+		result1 []algorithm.InputExplanation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInputMapper) ResolveInputMappingWithOverrides(logger lager.Logger, versions *algorithm.VersionsDB, job db.Job, inputOverrides map[string]int) (algorithm.InputMapping, bool, error) {
+	fake.resolveInputMappingWithOverridesMutex.Lock()
+	ret, specificReturn := fake.resolveInputMappingWithOverridesReturnsOnCall[len(fake.resolveInputMappingWithOverridesArgsForCall)]
+	fake.resolveInputMappingWithOverridesArgsForCall = append(fake.resolveInputMappingWithOverridesArgsForCall, struct {
+		logger         lager.Logger
+		versions       *algorithm.VersionsDB
+		job            db.Job
+		inputOverrides map[string]int
+	}{logger, versions, job, inputOverrides})
+	fake.recordInvocation("ResolveInputMappingWithOverrides", []interface{}{logger, versions, job, inputOverrides})
+	fake.resolveInputMappingWithOverridesMutex.Unlock()
+	if fake.ResolveInputMappingWithOverridesStub != nil {
+		return fake.ResolveInputMappingWithOverridesStub(logger, versions, job, inputOverrides)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.resolveInputMappingWithOverridesReturns.result1, fake.resolveInputMappingWithOverridesReturns.result2, fake.resolveInputMappingWithOverridesReturns.result3
+}
+
+func (fake *FakeInputMapper) ResolveInputMappingWithOverridesCallCount() int {
+	fake.resolveInputMappingWithOverridesMutex.RLock()
+	defer fake.resolveInputMappingWithOverridesMutex.RUnlock()
+	return len(fake.resolveInputMappingWithOverridesArgsForCall)
+}
+
+func (fake *FakeInputMapper) ResolveInputMappingWithOverridesArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, db.Job, map[string]int) {
+	fake.resolveInputMappingWithOverridesMutex.RLock()
+	defer fake.resolveInputMappingWithOverridesMutex.RUnlock()
+	return fake.resolveInputMappingWithOverridesArgsForCall[i].logger, fake.resolveInputMappingWithOverridesArgsForCall[i].versions, fake.resolveInputMappingWithOverridesArgsForCall[i].job, fake.resolveInputMappingWithOverridesArgsForCall[i].inputOverrides
+}
+
+func (fake *FakeInputMapper) ResolveInputMappingWithOverridesReturns(result1 algorithm.InputMapping, result2 bool, result3 error) {
+	fake.ResolveInputMappingWithOverridesStub = nil
+	fake.resolveInputMappingWithOverridesReturns = struct {
+		result1 algorithm.InputMapping
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeInputMapper) ResolveInputMappingWithOverridesReturnsOnCall(i int, result1 algorithm.InputMapping, result2 bool, result3 error) {
+	fake.ResolveInputMappingWithOverridesStub = nil
+	if fake.resolveInputMappingWithOverridesReturnsOnCall == nil {
+		fake.resolveInputMappingWithOverridesReturnsOnCall = make(map[int]struct {
+			result1 algorithm.InputMapping
+			result2 bool
+			result3 error
+		})
+	}
+	fake.resolveInputMappingWithOverridesReturnsOnCall[i] = struct {
+		result1 algorithm.InputMapping
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeInputMapper) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
+	fake.resolveInputMappingWithOverridesMutex.RLock()
+	defer fake.resolveInputMappingWithOverridesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
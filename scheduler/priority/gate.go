@@ -0,0 +1,241 @@
+package priority
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . Gate
+
+// Gate holds back the pending builds of a job while the team's capacity is
+// saturated and jobs of higher priority, in any of the team's pipelines,
+// have pending builds which are free to start and waiting for it.
+type Gate interface {
+	WaitForHigherPriorityBuilds(logger lager.Logger, job db.Job) (bool, error)
+
+	// Refresh starts a scheduling tick. The team's pending jobs and capacity
+	// are looked up once per tick, as starting builds in order of priority
+	// uses up capacity and pending builds alike.
+	Refresh()
+}
+
+// NewGate constructs a gate for the jobs of the pipeline. Workers are
+// considered busy once they have the given number of active containers; zero
+// means workers are never considered busy.
+func NewGate(pipeline db.Pipeline, teamFactory db.TeamFactory, maxActiveContainersPerWorker int) Gate {
+	return &gate{
+		pipeline:                     pipeline,
+		teamFactory:                  teamFactory,
+		maxActiveContainersPerWorker: maxActiveContainersPerWorker,
+	}
+}
+
+type gate struct {
+	pipeline                     db.Pipeline
+	teamFactory                  db.TeamFactory
+	maxActiveContainersPerWorker int
+
+	tick tickLookups
+}
+
+// tickLookups are the lookups made during the current scheduling tick.
+type tickLookups struct {
+	team      db.Team
+	teamFound bool
+
+	pendingJobs db.Jobs
+	startable   map[int]bool
+
+	quotaUsage *atc.TeamQuotaUsage
+	workers    []db.Worker
+}
+
+func (g *gate) Refresh() {
+	g.tick = tickLookups{}
+}
+
+func (g *gate) WaitForHigherPriorityBuilds(logger lager.Logger, job db.Job) (bool, error) {
+	logger = logger.Session("wait-for-higher-priority-builds", lager.Data{"job-name": job.Name()})
+
+	team, found, err := g.findTeam()
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		return false, err
+	}
+
+	if !found {
+		logger.Info("team-not-found")
+		return false, nil
+	}
+
+	pendingJobs, err := g.pendingJobs(team)
+	if err != nil {
+		logger.Error("failed-to-get-pending-jobs", err)
+		return false, err
+	}
+
+	higherPriorityJobs := 0
+	for _, pendingJob := range pendingJobs {
+		if pendingJob.Priority() <= job.Priority() {
+			continue
+		}
+
+		startable, err := g.canStart(logger, pendingJob)
+		if err != nil {
+			return false, err
+		}
+
+		if startable {
+			higherPriorityJobs++
+		}
+	}
+
+	if higherPriorityJobs == 0 {
+		return false, nil
+	}
+
+	saturated, err := g.isSaturated(logger, team, higherPriorityJobs)
+	if err != nil {
+		return false, err
+	}
+
+	if saturated {
+		logger.Info("waiting", lager.Data{"higher-priority-jobs": higherPriorityJobs})
+	}
+
+	return saturated, nil
+}
+
+func (g *gate) findTeam() (db.Team, bool, error) {
+	if g.tick.teamFound {
+		return g.tick.team, true, nil
+	}
+
+	team, found, err := g.teamFactory.FindTeam(g.pipeline.TeamName())
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	g.tick.team = team
+	g.tick.teamFound = true
+
+	return team, true, nil
+}
+
+func (g *gate) pendingJobs(team db.Team) (db.Jobs, error) {
+	if g.tick.pendingJobs != nil {
+		return g.tick.pendingJobs, nil
+	}
+
+	pendingJobs, err := team.PendingJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	g.tick.pendingJobs = pendingJobs
+
+	return pendingJobs, nil
+}
+
+// canStart reports whether the job's next pending build is held back by
+// neither its max in flight nor its serial groups, which would keep it from
+// using up the team's capacity.
+func (g *gate) canStart(logger lager.Logger, job db.Job) (bool, error) {
+	if startable, found := g.tick.startable[job.ID()]; found {
+		return startable, nil
+	}
+
+	startable, err := g.checkStartable(logger, job)
+	if err != nil {
+		return false, err
+	}
+
+	if g.tick.startable == nil {
+		g.tick.startable = map[int]bool{}
+	}
+
+	g.tick.startable[job.ID()] = startable
+
+	return startable, nil
+}
+
+func (g *gate) checkStartable(logger lager.Logger, job db.Job) (bool, error) {
+	maxInFlight := job.Config().MaxInFlight()
+	if maxInFlight == 0 {
+		return true, nil
+	}
+
+	serialGroups := job.Config().GetSerialGroups()
+
+	builds, err := job.GetRunningBuildsBySerialGroup(serialGroups)
+	if err != nil {
+		logger.Error("failed-to-get-running-builds-by-serial-group", err)
+		return false, err
+	}
+
+	if len(builds) >= maxInFlight {
+		return false, nil
+	}
+
+	nextPendingBuild, found, err := job.GetNextPendingBuildBySerialGroup(serialGroups)
+	if err != nil {
+		logger.Error("failed-to-get-next-pending-build-by-serial-group", err)
+		return false, err
+	}
+
+	// another job's build is next in line in the serial groups
+	return found && nextPendingBuild.JobID() == job.ID(), nil
+}
+
+// isSaturated reports whether the team's quota or its workers leave room for
+// no more than the pending builds of the higher priority jobs.
+func (g *gate) isSaturated(logger lager.Logger, team db.Team, builds int) (bool, error) {
+	quota := team.Quota()
+	if !quota.IsZero() {
+		if g.tick.quotaUsage == nil {
+			usage, err := team.QuotaUsage()
+			if err != nil {
+				logger.Error("failed-to-get-team-quota-usage", err)
+				return false, err
+			}
+
+			g.tick.quotaUsage = &usage
+		}
+
+		if quota.Saturated(*g.tick.quotaUsage, builds) {
+			return true, nil
+		}
+	}
+
+	if g.maxActiveContainersPerWorker == 0 {
+		return false, nil
+	}
+
+	if g.tick.workers == nil {
+		workers, err := team.Workers()
+		if err != nil {
+			logger.Error("failed-to-get-workers", err)
+			return false, err
+		}
+
+		g.tick.workers = workers
+	}
+
+	freeContainers := 0
+	for _, worker := range g.tick.workers {
+		if worker.State() != db.WorkerStateRunning {
+			continue
+		}
+
+		if free := g.maxActiveContainersPerWorker - worker.ActiveContainers(); free > 0 {
+			freeContainers += free
+		}
+	}
+
+	return freeContainers <= builds, nil
+}
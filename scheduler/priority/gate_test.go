@@ -0,0 +1,249 @@
+package priority_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/scheduler/priority"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Gate", func() {
+	var (
+		fakePipeline    *dbfakes.FakePipeline
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		lowPriorityJob  *dbfakes.FakeJob
+		highPriorityJob *dbfakes.FakeJob
+
+		maxActiveContainersPerWorker int
+
+		gate     priority.Gate
+		disaster error
+
+		wait    bool
+		waitErr error
+	)
+
+	BeforeEach(func() {
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.TeamNameReturns("some-team")
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("some-team")
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		lowPriorityJob = new(dbfakes.FakeJob)
+		lowPriorityJob.NameReturns("low-priority-job")
+		lowPriorityJob.PriorityReturns(1)
+
+		// the higher priority job is in another of the team's pipelines
+		highPriorityJob = new(dbfakes.FakeJob)
+		highPriorityJob.NameReturns("high-priority-job")
+		highPriorityJob.PipelineNameReturns("other-pipeline")
+		highPriorityJob.PriorityReturns(10)
+
+		maxActiveContainersPerWorker = 0
+
+		disaster = errors.New("bad thing")
+	})
+
+	JustBeforeEach(func() {
+		gate = priority.NewGate(fakePipeline, fakeTeamFactory, maxActiveContainersPerWorker)
+		wait, waitErr = gate.WaitForHigherPriorityBuilds(lagertest.NewTestLogger("test"), lowPriorityJob)
+	})
+
+	itDoesNotWait := func() {
+		It("does not wait", func() {
+			Expect(waitErr).NotTo(HaveOccurred())
+			Expect(wait).To(BeFalse())
+		})
+	}
+
+	itWaits := func() {
+		It("holds back the lower priority build", func() {
+			Expect(waitErr).NotTo(HaveOccurred())
+			Expect(wait).To(BeTrue())
+		})
+	}
+
+	Context("when finding the team fails", func() {
+		BeforeEach(func() {
+			fakeTeamFactory.FindTeamReturns(nil, false, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(waitErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when no jobs of higher priority have pending builds", func() {
+		BeforeEach(func() {
+			fakeTeam.PendingJobsReturns(db.Jobs{lowPriorityJob}, nil)
+			fakeTeam.QuotaReturns(atc.TeamQuota{MaxRunningBuilds: 1})
+			fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{RunningBuilds: 0}, nil)
+		})
+
+		itDoesNotWait()
+	})
+
+	Context("when a job of higher priority has pending builds", func() {
+		BeforeEach(func() {
+			fakeTeam.PendingJobsReturns(db.Jobs{lowPriorityJob, highPriorityJob}, nil)
+		})
+
+		Context("when the team has neither a quota nor busy workers", func() {
+			itDoesNotWait()
+		})
+
+		Context("when the team's quota leaves room only for the higher priority build", func() {
+			BeforeEach(func() {
+				fakeTeam.QuotaReturns(atc.TeamQuota{MaxRunningBuilds: 3})
+				fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{RunningBuilds: 2}, nil)
+			})
+
+			itWaits()
+
+			It("looks up the team's pending jobs and quota usage once per tick", func() {
+				logger := lagertest.NewTestLogger("test")
+
+				_, err := gate.WaitForHigherPriorityBuilds(logger, lowPriorityJob)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeTeamFactory.FindTeamCallCount()).To(Equal(1))
+				Expect(fakeTeam.PendingJobsCallCount()).To(Equal(1))
+				Expect(fakeTeam.QuotaUsageCallCount()).To(Equal(1))
+
+				gate.Refresh()
+
+				_, err = gate.WaitForHigherPriorityBuilds(logger, lowPriorityJob)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeTeamFactory.FindTeamCallCount()).To(Equal(2))
+				Expect(fakeTeam.PendingJobsCallCount()).To(Equal(2))
+				Expect(fakeTeam.QuotaUsageCallCount()).To(Equal(2))
+			})
+
+			Context("when the higher priority job is limited in flight", func() {
+				var nextPendingBuild *dbfakes.FakeBuild
+
+				BeforeEach(func() {
+					highPriorityJob.IDReturns(42)
+					highPriorityJob.ConfigReturns(atc.JobConfig{Name: "high-priority-job", RawMaxInFlight: 2})
+
+					nextPendingBuild = new(dbfakes.FakeBuild)
+					nextPendingBuild.JobIDReturns(42)
+					highPriorityJob.GetNextPendingBuildBySerialGroupReturns(nextPendingBuild, true, nil)
+				})
+
+				Context("when it has room for another build", func() {
+					BeforeEach(func() {
+						highPriorityJob.GetRunningBuildsBySerialGroupReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+					})
+
+					itWaits()
+
+					It("checks the job's serial groups", func() {
+						Expect(highPriorityJob.GetRunningBuildsBySerialGroupArgsForCall(0)).To(Equal([]string{"high-priority-job"}))
+					})
+				})
+
+				Context("when it has reached its max in flight", func() {
+					BeforeEach(func() {
+						highPriorityJob.GetRunningBuildsBySerialGroupReturns([]db.Build{new(dbfakes.FakeBuild), new(dbfakes.FakeBuild)}, nil)
+					})
+
+					itDoesNotWait()
+				})
+
+				Context("when another job's build is next in its serial groups", func() {
+					BeforeEach(func() {
+						nextPendingBuild.JobIDReturns(43)
+					})
+
+					itDoesNotWait()
+				})
+
+				Context("when getting its running builds fails", func() {
+					BeforeEach(func() {
+						highPriorityJob.GetRunningBuildsBySerialGroupReturns(nil, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(waitErr).To(Equal(disaster))
+					})
+				})
+			})
+		})
+
+		Context("when the team's quota leaves room for both builds", func() {
+			BeforeEach(func() {
+				fakeTeam.QuotaReturns(atc.TeamQuota{MaxRunningBuilds: 5})
+				fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{RunningBuilds: 2}, nil)
+			})
+
+			itDoesNotWait()
+		})
+
+		Context("when counting the team's quota usage fails", func() {
+			BeforeEach(func() {
+				fakeTeam.QuotaReturns(atc.TeamQuota{MaxRunningBuilds: 3})
+				fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{}, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(waitErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when workers are considered busy past a number of containers", func() {
+			var busyWorker, idleWorker *dbfakes.FakeWorker
+
+			BeforeEach(func() {
+				maxActiveContainersPerWorker = 10
+
+				busyWorker = new(dbfakes.FakeWorker)
+				busyWorker.StateReturns(db.WorkerStateRunning)
+				busyWorker.ActiveContainersReturns(12)
+
+				idleWorker = new(dbfakes.FakeWorker)
+				idleWorker.StateReturns(db.WorkerStateRunning)
+				idleWorker.ActiveContainersReturns(9)
+			})
+
+			Context("when the workers are saturated", func() {
+				BeforeEach(func() {
+					fakeTeam.WorkersReturns([]db.Worker{busyWorker, idleWorker}, nil)
+				})
+
+				itWaits()
+			})
+
+			Context("when the workers have room to spare", func() {
+				BeforeEach(func() {
+					idleWorker.ActiveContainersReturns(2)
+					fakeTeam.WorkersReturns([]db.Worker{busyWorker, idleWorker}, nil)
+				})
+
+				itDoesNotWait()
+			})
+
+			Context("when getting the workers fails", func() {
+				BeforeEach(func() {
+					fakeTeam.WorkersReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(waitErr).To(Equal(disaster))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,112 @@
+package quota
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/metric"
+)
+
+//go:generate counterfeiter . Updater
+
+type Updater interface {
+	// UpdateTeamQuotaReached returns whether the job's team has reached its
+	// quota. When it has not, the returned lock (if any) keeps the team's
+	// other pipelines from starting builds within the same room, and must be
+	// released once the build has been scheduled or given up on.
+	UpdateTeamQuotaReached(logger lager.Logger, job db.Job) (bool, lock.Lock, error)
+}
+
+func NewUpdater(pipeline db.Pipeline, teamFactory db.TeamFactory) Updater {
+	return &updater{
+		pipeline:    pipeline,
+		teamFactory: teamFactory,
+	}
+}
+
+type updater struct {
+	pipeline    db.Pipeline
+	teamFactory db.TeamFactory
+}
+
+func (u *updater) UpdateTeamQuotaReached(logger lager.Logger, job db.Job) (bool, lock.Lock, error) {
+	logger = logger.Session("is-team-quota-reached", lager.Data{"job-name": job.Name()})
+
+	team, found, err := u.teamFactory.FindTeam(u.pipeline.TeamName())
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		return false, nil, err
+	}
+
+	if !found {
+		logger.Info("team-not-found")
+		return false, nil, u.setTeamQuotaReached(logger, job, false)
+	}
+
+	quota := team.Quota()
+	if quota.IsZero() {
+		return false, nil, u.setTeamQuotaReached(logger, job, false)
+	}
+
+	quotaLock, acquired, err := team.AcquireQuotaLock(logger)
+	if err != nil {
+		logger.Error("failed-to-acquire-team-quota-lock", err)
+		return false, nil, err
+	}
+
+	if !acquired {
+		// another pipeline is starting a build within the quota; whether
+		// there is room left is only known once it is done
+		logger.Info("team-quota-lock-held")
+		return true, nil, nil
+	}
+
+	reached, err := u.isTeamQuotaReached(logger, team, job)
+	if err == nil {
+		err = u.setTeamQuotaReached(logger, job, reached)
+	}
+
+	if err != nil || reached {
+		quotaLock.Release()
+		return reached, nil, err
+	}
+
+	return false, quotaLock, nil
+}
+
+func (u *updater) setTeamQuotaReached(logger lager.Logger, job db.Job, reached bool) error {
+	err := job.SetTeamQuotaReached(reached)
+	if err != nil {
+		logger.Error("failed-to-set-team-quota-reached", err)
+		return err
+	}
+
+	return nil
+}
+
+func (u *updater) isTeamQuotaReached(logger lager.Logger, team db.Team, job db.Job) (bool, error) {
+	quota := team.Quota()
+
+	usage, err := team.QuotaUsage()
+	if err != nil {
+		logger.Error("failed-to-get-team-quota-usage", err)
+		return false, err
+	}
+
+	metric.TeamQuotaUsage{
+		TeamName:      team.Name(),
+		RunningBuilds: usage.RunningBuilds,
+		Containers:    usage.Containers,
+	}.Emit(logger)
+
+	if quota.Reached(job.PipelineName(), usage) {
+		logger.Info("team-quota-reached", lager.Data{
+			"running-builds": usage.RunningBuilds,
+			"containers":     usage.Containers,
+		})
+
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,230 @@
+package quota_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/atc/scheduler/quota"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Updater", func() {
+	var (
+		fakePipeline    *dbfakes.FakePipeline
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeLock        *lockfakes.FakeLock
+		fakeJob         *dbfakes.FakeJob
+		updater         quota.Updater
+		disaster        error
+	)
+
+	BeforeEach(func() {
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.TeamNameReturns("some-team")
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("some-team")
+
+		fakeLock = new(lockfakes.FakeLock)
+		fakeTeam.AcquireQuotaLockReturns(fakeLock, true, nil)
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.NameReturns("some-job")
+		fakeJob.PipelineNameReturns("some-pipeline")
+
+		updater = quota.NewUpdater(fakePipeline, fakeTeamFactory)
+		disaster = errors.New("bad thing")
+	})
+
+	Describe("UpdateTeamQuotaReached", func() {
+		var reached bool
+		var quotaLock lock.Lock
+		var updateErr error
+
+		JustBeforeEach(func() {
+			reached, quotaLock, updateErr = updater.UpdateTeamQuotaReached(
+				lagertest.NewTestLogger("test"),
+				fakeJob,
+			)
+		})
+
+		itReturnsFalseAndNoError := func() {
+			It("returns false and no error", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(reached).To(BeFalse())
+				Expect(fakeJob.SetTeamQuotaReachedCallCount()).To(Equal(1))
+				Expect(fakeJob.SetTeamQuotaReachedArgsForCall(0)).To(BeFalse())
+			})
+		}
+
+		itReturnsTrueAndNoError := func() {
+			It("returns true and no error", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(reached).To(BeTrue())
+				Expect(fakeJob.SetTeamQuotaReachedCallCount()).To(Equal(1))
+				Expect(fakeJob.SetTeamQuotaReachedArgsForCall(0)).To(BeTrue())
+			})
+		}
+
+		itReturnsTheError := func() {
+			It("returns the error", func() {
+				Expect(updateErr).To(Equal(disaster))
+				Expect(fakeJob.SetTeamQuotaReachedCallCount()).To(Equal(0))
+			})
+		}
+
+		It("looks up the pipeline's team", func() {
+			Expect(fakeTeamFactory.FindTeamCallCount()).To(Equal(1))
+			Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+		})
+
+		Context("when finding the team fails", func() {
+			BeforeEach(func() {
+				fakeTeamFactory.FindTeamReturns(nil, false, disaster)
+			})
+
+			itReturnsTheError()
+		})
+
+		Context("when the team is not found", func() {
+			BeforeEach(func() {
+				fakeTeamFactory.FindTeamReturns(nil, false, nil)
+			})
+
+			itReturnsFalseAndNoError()
+		})
+
+		Context("when the team has no quota", func() {
+			itReturnsFalseAndNoError()
+
+			It("does not count the team's usage", func() {
+				Expect(fakeTeam.QuotaUsageCallCount()).To(BeZero())
+			})
+
+			It("does not lock the team's quota", func() {
+				Expect(fakeTeam.AcquireQuotaLockCallCount()).To(BeZero())
+				Expect(quotaLock).To(BeNil())
+			})
+		})
+
+		Context("when the team has a quota", func() {
+			BeforeEach(func() {
+				fakeTeam.QuotaReturns(atc.TeamQuota{
+					MaxRunningBuilds: 5,
+					Pipelines: map[string]atc.PipelineQuota{
+						"some-pipeline": {MaxRunningBuilds: 2},
+					},
+				})
+			})
+
+			Context("when counting the usage fails", func() {
+				BeforeEach(func() {
+					fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{}, disaster)
+				})
+
+				itReturnsTheError()
+
+				It("releases the lock", func() {
+					Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when another pipeline holds the team's quota lock", func() {
+				BeforeEach(func() {
+					fakeTeam.AcquireQuotaLockReturns(nil, false, nil)
+				})
+
+				It("returns true without updating the flag", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(reached).To(BeTrue())
+					Expect(quotaLock).To(BeNil())
+					Expect(fakeJob.SetTeamQuotaReachedCallCount()).To(BeZero())
+				})
+
+				It("does not count the team's usage", func() {
+					Expect(fakeTeam.QuotaUsageCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when locking the team's quota fails", func() {
+				BeforeEach(func() {
+					fakeTeam.AcquireQuotaLockReturns(nil, false, disaster)
+				})
+
+				itReturnsTheError()
+			})
+
+			Context("when the usage is under the quota", func() {
+				BeforeEach(func() {
+					fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{
+						RunningBuilds: 4,
+						Pipelines: map[string]atc.PipelineQuotaUsage{
+							"some-pipeline": {RunningBuilds: 1},
+						},
+					}, nil)
+				})
+
+				itReturnsFalseAndNoError()
+
+				It("returns the held lock", func() {
+					Expect(quotaLock).To(Equal(fakeLock))
+					Expect(fakeLock.ReleaseCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the team is at its quota", func() {
+				BeforeEach(func() {
+					fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{
+						RunningBuilds: 5,
+					}, nil)
+				})
+
+				itReturnsTrueAndNoError()
+
+				It("releases the lock", func() {
+					Expect(quotaLock).To(BeNil())
+					Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the pipeline is at its quota", func() {
+				BeforeEach(func() {
+					fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{
+						RunningBuilds: 2,
+						Pipelines: map[string]atc.PipelineQuotaUsage{
+							"some-pipeline": {RunningBuilds: 2},
+						},
+					}, nil)
+				})
+
+				itReturnsTrueAndNoError()
+			})
+
+			Context("when setting the team quota reached flag fails", func() {
+				BeforeEach(func() {
+					fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{}, nil)
+					fakeJob.SetTeamQuotaReachedReturns(disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(updateErr).To(Equal(disaster))
+				})
+
+				It("releases the lock", func() {
+					Expect(quotaLock).To(BeNil())
+					Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+				})
+			})
+		})
+	})
+})
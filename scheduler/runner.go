@@ -30,7 +30,25 @@ type BuildScheduler interface {
 		resourceTypes atc.VersionedResourceTypes,
 	) (db.Build, Waiter, error)
 
+	TriggerImmediatelyWithInputOverrides(
+		logger lager.Logger,
+		job db.Job,
+		inputOverrides map[string]int,
+		resources db.Resources,
+		resourceTypes atc.VersionedResourceTypes,
+	) (db.Build, Waiter, error)
+
+	RerunImmediately(
+		logger lager.Logger,
+		job db.Job,
+		rerunBuild db.Build,
+		resources db.Resources,
+		resourceTypes atc.VersionedResourceTypes,
+	) (db.Build, Waiter, error)
+
 	SaveNextInputMapping(logger lager.Logger, job db.Job) error
+
+	ExplainJob(logger lager.Logger, job db.Job, candidateLimit int) (atc.JobSchedulingExplanation, error)
 }
 
 var errPipelineRemoved = errors.New("pipeline removed")
@@ -1,6 +1,8 @@
 package scheduler
 
 import (
+	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/inputmapper"
+	"github.com/concourse/atc/scheduler/priority"
 )
 
 type Scheduler struct {
@@ -16,6 +19,10 @@ type Scheduler struct {
 	InputMapper  inputmapper.InputMapper
 	BuildStarter BuildStarter
 	Scanner      Scanner
+
+	// PriorityGate is the gate used by the BuildStarter, refreshed before
+	// each round of starting builds.
+	PriorityGate priority.Gate
 }
 
 //go:generate counterfeiter . Scanner
@@ -36,6 +43,12 @@ func (s *Scheduler) Schedule(
 	for _, job := range jobs {
 		jStart := time.Now()
 		err := s.ensurePendingBuildExists(logger, versions, job)
+		if err == nil && job.Config().Schedule != nil {
+			err = s.triggerScheduledBuild(logger, job, jStart)
+		}
+		if err == nil && len(job.Config().TriggerOn) > 0 {
+			err = s.triggerOnUpstreamBuilds(logger, job, jStart)
+		}
 		jobSchedulingTime[job.Name()] = time.Since(jStart)
 
 		if err != nil {
@@ -49,7 +62,14 @@ func (s *Scheduler) Schedule(
 		return jobSchedulingTime, err
 	}
 
-	for _, job := range jobs {
+	nextPendingBuilds, err = s.timeOutApprovals(logger, nextPendingBuilds)
+	if err != nil {
+		return jobSchedulingTime, err
+	}
+
+	s.PriorityGate.Refresh()
+
+	for _, job := range jobsByPriority(jobs) {
 		jStart := time.Now()
 		nextPendingBuildsForJob, ok := nextPendingBuilds[job.Name()]
 		if !ok {
@@ -67,6 +87,132 @@ func (s *Scheduler) Schedule(
 	return jobSchedulingTime, nil
 }
 
+// timeOutApprovals aborts the pending builds whose approval deadline has
+// passed, whether or not they are next in line to be started, and returns
+// the rest.
+func (s *Scheduler) timeOutApprovals(logger lager.Logger, pendingBuilds map[string][]db.Build) (map[string][]db.Build, error) {
+	now := time.Now()
+
+	remaining := map[string][]db.Build{}
+	for jobName, builds := range pendingBuilds {
+		for _, build := range builds {
+			deadline := build.ApprovalDeadline()
+			if build.ApprovalStatus() != atc.ApprovalStatusAwaiting || deadline.IsZero() || now.Before(deadline) {
+				remaining[jobName] = append(remaining[jobName], build)
+				continue
+			}
+
+			_, err := build.ResolveApproval(atc.ApprovalStatusTimedOut, "", "")
+			if err != nil {
+				logger.Error("failed-to-time-out-approval", err, lager.Data{"build": build.ID()})
+				return nil, err
+			}
+		}
+	}
+
+	return remaining, nil
+}
+
+// triggerScheduledBuild creates a pending build once the job's schedule has
+// fired since it was last evaluated. Fire times missed while the job was
+// paused, or while the ATC was down, result in a single build.
+func (s *Scheduler) triggerScheduledBuild(logger lager.Logger, job db.Job, now time.Time) error {
+	logger = logger.Session("trigger-scheduled-build", lager.Data{"job": job.Name()})
+
+	lastScheduleTime := job.LastScheduleTime()
+	if lastScheduleTime.IsZero() {
+		err := job.InitLastScheduleTime(now)
+		if err != nil {
+			logger.Error("failed-to-init-last-schedule-time", err)
+			return err
+		}
+
+		return nil
+	}
+
+	nextScheduleTime, err := job.Config().Schedule.Next(lastScheduleTime)
+	if err != nil {
+		// the config is validated, so this can only be an unknown time zone
+		// on this particular ATC; don't hold up the rest of the pipeline
+		logger.Error("failed-to-evaluate-schedule", err)
+		return nil
+	}
+
+	if now.Before(nextScheduleTime) || job.Paused() {
+		return nil
+	}
+
+	_, created, err := job.CreateScheduledBuild(nextScheduleTime)
+	if err != nil {
+		logger.Error("failed-to-create-scheduled-build", err)
+		return err
+	}
+
+	if created {
+		logger.Info("created-scheduled-build", lager.Data{"schedule-time": nextScheduleTime})
+	}
+
+	return nil
+}
+
+// triggerOnUpstreamBuilds creates a pending build for each build of the jobs
+// in the job's trigger_on config that finished with a matching status since
+// the config was first evaluated. Builds which finish while the job is paused
+// do not trigger it.
+func (s *Scheduler) triggerOnUpstreamBuilds(logger lager.Logger, job db.Job, now time.Time) error {
+	logger = logger.Session("trigger-on-upstream-builds", lager.Data{"job": job.Name()})
+
+	if job.TriggerOnSince().IsZero() {
+		err := job.InitTriggerOnSince(now)
+		if err != nil {
+			logger.Error("failed-to-init-trigger-on-since", err)
+			return err
+		}
+
+		return nil
+	}
+
+	if job.Paused() {
+		err := job.SkipTriggersUntil(now)
+		if err != nil {
+			logger.Error("failed-to-skip-triggers", err)
+			return err
+		}
+
+		return nil
+	}
+
+	builds, err := job.CreateTriggeredBuilds()
+	if err != nil {
+		logger.Error("failed-to-create-triggered-builds", err)
+		return err
+	}
+
+	for _, build := range builds {
+		logger.Info("created-triggered-build", lager.Data{"build": build.ID(), "triggered-by": build.TriggeredBy()})
+	}
+
+	return nil
+}
+
+// jobsByPriority orders the jobs so that pending builds of higher priority
+// jobs are started, and thus claim worker capacity, before those of lower
+// priority jobs. Jobs of equal priority keep their configured order.
+func jobsByPriority(jobs []db.Job) []db.Job {
+	sorted := make([]db.Job, len(jobs))
+	copy(sorted, jobs)
+
+	sort.Stable(byPriority(sorted))
+
+	return sorted
+}
+
+type byPriority []db.Job
+
+func (jobs byPriority) Len() int           { return len(jobs) }
+func (jobs byPriority) Swap(i, j int)      { jobs[i], jobs[j] = jobs[j], jobs[i] }
+func (jobs byPriority) Less(i, j int) bool { return jobs[i].Priority() > jobs[j].Priority() }
+
 func (s *Scheduler) ensurePendingBuildExists(
 	logger lager.Logger,
 	versions *algorithm.VersionsDB,
@@ -82,6 +228,10 @@ func (s *Scheduler) ensurePendingBuildExists(
 
 		//trigger: true, and the version has not been used
 		if ok && inputVersion.FirstOccurrence && inputConfig.Trigger {
+			if job.Config().SupersedePending {
+				return s.supersedePendingBuilds(logger, job)
+			}
+
 			err := job.EnsurePendingBuildExists()
 			if err != nil {
 				logger.Error("failed-to-ensure-pending-build-exists", err)
@@ -95,6 +245,20 @@ func (s *Scheduler) ensurePendingBuildExists(
 	return nil
 }
 
+func (s *Scheduler) supersedePendingBuilds(logger lager.Logger, job db.Job) error {
+	build, created, err := job.SupersedePendingBuilds()
+	if err != nil {
+		logger.Error("failed-to-supersede-pending-builds", err, lager.Data{"job": job.Name()})
+		return err
+	}
+
+	if created {
+		logger.Debug("created-superseding-build", lager.Data{"job": job.Name(), "build": build.ID()})
+	}
+
+	return nil
+}
+
 type Waiter interface {
 	Wait()
 }
@@ -112,6 +276,55 @@ func (s *Scheduler) TriggerImmediately(
 		logger.Error("failed-to-create-job-build", err)
 		return nil, nil, err
 	}
+
+	return build, s.startPendingBuilds(logger, job, resources, resourceTypes), nil
+}
+
+func (s *Scheduler) TriggerImmediatelyWithInputOverrides(
+	logger lager.Logger,
+	job db.Job,
+	inputOverrides map[string]int,
+	resources db.Resources,
+	resourceTypes atc.VersionedResourceTypes,
+) (db.Build, Waiter, error) {
+	logger = logger.Session("trigger-immediately-with-input-overrides", lager.Data{"job_name": job.Name()})
+
+	build, err := job.CreateBuildWithInputOverrides(inputOverrides)
+	if err != nil {
+		logger.Error("failed-to-create-job-build", err)
+		return nil, nil, err
+	}
+
+	return build, s.startPendingBuilds(logger, job, resources, resourceTypes), nil
+}
+
+func (s *Scheduler) RerunImmediately(
+	logger lager.Logger,
+	job db.Job,
+	rerunBuild db.Build,
+	resources db.Resources,
+	resourceTypes atc.VersionedResourceTypes,
+) (db.Build, Waiter, error) {
+	logger = logger.Session("rerun-immediately", lager.Data{
+		"job_name": job.Name(),
+		"build_id": rerunBuild.ID(),
+	})
+
+	build, err := job.RerunBuild(rerunBuild)
+	if err != nil {
+		logger.Error("failed-to-create-rerun-build", err)
+		return nil, nil, err
+	}
+
+	return build, s.startPendingBuilds(logger, job, resources, resourceTypes), nil
+}
+
+func (s *Scheduler) startPendingBuilds(
+	logger lager.Logger,
+	job db.Job,
+	resources db.Resources,
+	resourceTypes atc.VersionedResourceTypes,
+) Waiter {
 	wg := new(sync.WaitGroup)
 	wg.Add(1)
 
@@ -131,7 +344,7 @@ func (s *Scheduler) TriggerImmediately(
 		}
 	}()
 
-	return build, wg, nil
+	return wg
 }
 
 func (s *Scheduler) SaveNextInputMapping(logger lager.Logger, job db.Job) error {
@@ -144,3 +357,199 @@ func (s *Scheduler) SaveNextInputMapping(logger lager.Logger, job db.Job) error
 	_, err = s.InputMapper.SaveNextInputMapping(logger, versions, job)
 	return err
 }
+
+// ExplainJob reports why the job's next build is or isn't being scheduled:
+// how each input resolved against its most recent candidate versions, and
+// whether max-in-flight or serial groups are holding back the next pending
+// build.
+func (s *Scheduler) ExplainJob(logger lager.Logger, job db.Job, candidateLimit int) (atc.JobSchedulingExplanation, error) {
+	logger = logger.Session("explain-job", lager.Data{"job-name": job.Name()})
+
+	explanation := atc.JobSchedulingExplanation{
+		JobName: job.Name(),
+		Paused:  job.Paused(),
+		Inputs:  []atc.InputSchedulingExplanation{},
+	}
+
+	pipelinePaused, err := s.Pipeline.CheckPaused()
+	if err != nil {
+		logger.Error("failed-to-check-if-pipeline-is-paused", err)
+		return atc.JobSchedulingExplanation{}, err
+	}
+
+	explanation.PipelinePaused = pipelinePaused
+
+	versions, err := s.Pipeline.LoadVersionsDB()
+	if err != nil {
+		logger.Error("failed-to-load-versions-db", err)
+		return atc.JobSchedulingExplanation{}, err
+	}
+
+	jobInputs := job.Config().Inputs()
+
+	savedVersions := map[int]db.SavedVersionedResource{}
+	candidates := map[string][]int{}
+	for _, input := range jobInputs {
+		resourceVersions, _, _, err := s.Pipeline.GetResourceVersions(input.Resource, db.Page{Limit: candidateLimit})
+		if err != nil {
+			logger.Error("failed-to-get-resource-versions", err, lager.Data{"resource": input.Resource})
+			return atc.JobSchedulingExplanation{}, err
+		}
+
+		for _, version := range resourceVersions {
+			savedVersions[version.ID] = version
+			candidates[input.Name] = append(candidates[input.Name], version.ID)
+		}
+	}
+
+	inputExplanations, err := s.InputMapper.ExplainNextInputMapping(logger, versions, job, candidates)
+	if err != nil {
+		return atc.JobSchedulingExplanation{}, err
+	}
+
+	explained := map[string]algorithm.InputExplanation{}
+	for _, inputExplanation := range inputExplanations {
+		explained[inputExplanation.Name] = inputExplanation
+	}
+
+	jobNames := map[int]string{}
+	for name, id := range versions.JobIDs {
+		jobNames[id] = name
+	}
+
+	explanation.InputsSatisfied = true
+
+	for _, input := range jobInputs {
+		inputExplanation := atc.InputSchedulingExplanation{
+			Name:       input.Name,
+			Resource:   input.Resource,
+			Passed:     input.Passed,
+			PassedAny:  input.PassedAny,
+			Trigger:    input.Trigger,
+			Candidates: []atc.VersionCandidateExplanation{},
+		}
+
+		algorithmExplanation, found := explained[input.Name]
+		if !found {
+			// the transformer skips inputs whose pinned version does not exist
+			inputExplanation.Reason = "pinned version not found"
+			explanation.InputsSatisfied = false
+			explanation.Inputs = append(explanation.Inputs, inputExplanation)
+			continue
+		}
+
+		if algorithmExplanation.Resolved {
+			inputExplanation.Resolved = true
+			inputExplanation.VersionID = algorithmExplanation.VersionID
+			inputExplanation.NewVersion = algorithmExplanation.FirstOccurrence
+
+			if saved, found := savedVersions[algorithmExplanation.VersionID]; found {
+				inputExplanation.Version = atc.Version(saved.Version)
+			}
+		} else {
+			explanation.InputsSatisfied = false
+
+			if len(candidates[input.Name]) == 0 {
+				inputExplanation.Reason = "no versions available"
+			}
+		}
+
+		for _, candidate := range algorithmExplanation.Candidates {
+			saved := savedVersions[candidate.VersionID]
+
+			var notPassed []string
+			for jobID := range candidate.NotPassed {
+				notPassed = append(notPassed, jobNames[jobID])
+			}
+
+			sort.Strings(notPassed)
+
+			inputExplanation.Candidates = append(inputExplanation.Candidates, atc.VersionCandidateExplanation{
+				ID:        candidate.VersionID,
+				Version:   atc.Version(saved.Version),
+				Enabled:   saved.Enabled,
+				Selected:  candidate.Selected,
+				Reason:    string(candidate.Reason),
+				NotPassed: notPassed,
+			})
+		}
+
+		explanation.Inputs = append(explanation.Inputs, inputExplanation)
+	}
+
+	if explanation.InputsSatisfied {
+		for _, input := range explanation.Inputs {
+			if input.Trigger && input.NewVersion {
+				explanation.TriggerChanged = true
+				break
+			}
+		}
+	}
+
+	err = s.explainPendingBuilds(logger, job, &explanation)
+	if err != nil {
+		return atc.JobSchedulingExplanation{}, err
+	}
+
+	return explanation, nil
+}
+
+func (s *Scheduler) explainPendingBuilds(logger lager.Logger, job db.Job, explanation *atc.JobSchedulingExplanation) error {
+	pendingBuilds, err := job.GetPendingBuilds()
+	if err != nil {
+		logger.Error("failed-to-get-pending-builds", err)
+		return err
+	}
+
+	maxInFlight := job.Config().MaxInFlight()
+	if maxInFlight == 0 {
+		if len(pendingBuilds) > 0 {
+			explanation.NextPendingBuild = pendingBuilds[0].Name()
+		}
+
+		return nil
+	}
+
+	serialGroups := job.Config().GetSerialGroups()
+
+	explanation.MaxInFlight = maxInFlight
+	explanation.SerialGroups = job.Config().SerialGroups
+
+	runningBuilds, err := job.GetRunningBuildsBySerialGroup(serialGroups)
+	if err != nil {
+		logger.Error("failed-to-get-running-builds-by-serial-group", err)
+		return err
+	}
+
+	for _, build := range runningBuilds {
+		explanation.RunningBuilds = append(explanation.RunningBuilds, build.JobName()+"/"+build.Name())
+	}
+
+	if len(pendingBuilds) == 0 {
+		return nil
+	}
+
+	nextPendingBuild := pendingBuilds[0]
+	explanation.NextPendingBuild = nextPendingBuild.Name()
+
+	if len(runningBuilds) >= maxInFlight {
+		explanation.BlockedBy = fmt.Sprintf("max in flight of %d reached", maxInFlight)
+		return nil
+	}
+
+	nextMostPendingBuild, found, err := job.GetNextPendingBuildBySerialGroup(serialGroups)
+	if err != nil {
+		logger.Error("failed-to-get-next-pending-build-by-serial-group", err)
+		return err
+	}
+
+	if found && nextMostPendingBuild.ID() != nextPendingBuild.ID() {
+		explanation.BlockedBy = fmt.Sprintf(
+			"build %s/%s is ahead in the serial group",
+			nextMostPendingBuild.JobName(),
+			nextMostPendingBuild.Name(),
+		)
+	}
+
+	return nil
+}
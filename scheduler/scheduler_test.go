@@ -2,6 +2,7 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
@@ -10,6 +11,7 @@ import (
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/inputmapper/inputmapperfakes"
+	"github.com/concourse/atc/scheduler/priority/priorityfakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -21,6 +23,7 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
 		fakeBuildStarter *schedulerfakes.FakeBuildStarter
 		fakeScanner      *schedulerfakes.FakeScanner
+		fakePriorityGate *priorityfakes.FakeGate
 
 		scheduler *Scheduler
 
@@ -32,12 +35,14 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
 		fakeScanner = new(schedulerfakes.FakeScanner)
+		fakePriorityGate = new(priorityfakes.FakeGate)
 
 		scheduler = &Scheduler{
 			Pipeline:     fakePipeline,
 			InputMapper:  fakeInputMapper,
 			BuildStarter: fakeBuildStarter,
 			Scanner:      fakeScanner,
+			PriorityGate: fakePriorityGate,
 		}
 
 		disaster = errors.New("bad thing")
@@ -165,6 +170,77 @@ var _ = Describe("Scheduler", func() {
 						Expect(fakeJob2.EnsurePendingBuildExistsCallCount()).To(BeZero())
 					})
 				})
+
+				Context("when the approval of a pending build has expired", func() {
+					var expiredBuild *dbfakes.FakeBuild
+
+					BeforeEach(func() {
+						expiredBuild = new(dbfakes.FakeBuild)
+						expiredBuild.ApprovalStatusReturns(atc.ApprovalStatusAwaiting)
+						expiredBuild.ApprovalDeadlineReturns(time.Now().Add(-time.Minute))
+
+						awaitingBuild := new(dbfakes.FakeBuild)
+						awaitingBuild.ApprovalStatusReturns(atc.ApprovalStatusAwaiting)
+						awaitingBuild.ApprovalDeadlineReturns(time.Now().Add(time.Hour))
+
+						nextPendingBuildsJob1 = []db.Build{awaitingBuild, expiredBuild}
+						fakePipeline.GetAllPendingBuildsReturns(map[string][]db.Build{
+							"some-job-1": nextPendingBuildsJob1,
+						}, nil)
+					})
+
+					It("times it out even though it is not next in line", func() {
+						Expect(expiredBuild.ResolveApprovalCallCount()).To(Equal(1))
+						status, approver, comment := expiredBuild.ResolveApprovalArgsForCall(0)
+						Expect(status).To(Equal(atc.ApprovalStatusTimedOut))
+						Expect(approver).To(BeEmpty())
+						Expect(comment).To(BeEmpty())
+					})
+
+					It("does not try to start it", func() {
+						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+						_, _, _, _, actualPendingBuilds := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+						Expect(actualPendingBuilds).To(Equal(nextPendingBuildsJob1[:1]))
+					})
+
+					Context("when timing it out fails", func() {
+						BeforeEach(func() {
+							expiredBuild.ResolveApprovalReturns(false, disaster)
+						})
+
+						It("returns the error", func() {
+							Expect(scheduleErr).To(Equal(disaster))
+						})
+					})
+				})
+
+				It("refreshes the priority gate before starting builds", func() {
+					Expect(fakePriorityGate.RefreshCallCount()).To(Equal(1))
+				})
+
+				Context("when a later job has a higher priority", func() {
+					BeforeEach(func() {
+						fakeJob.PriorityReturns(0)
+						fakeJob2.PriorityReturns(100)
+					})
+
+					It("starts the pending builds of the higher priority job first", func() {
+						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(2))
+
+						_, actualJob, _, _, actualPendingBuilds := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+						Expect(actualJob.Name()).To(Equal(fakeJob2.Name()))
+						Expect(actualPendingBuilds).To(Equal(nextPendingBuildsJob2))
+
+						_, actualJob, _, _, actualPendingBuilds = fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(1)
+						Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
+						Expect(actualPendingBuilds).To(Equal(nextPendingBuildsJob1))
+					})
+
+					It("still saves the input mappings in configured order", func() {
+						_, _, actualJob := fakeInputMapper.SaveNextInputMappingArgsForCall(0)
+						Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
+					})
+				})
 			})
 		})
 
@@ -249,6 +325,225 @@ var _ = Describe("Scheduler", func() {
 						Expect(scheduleErr).NotTo(HaveOccurred())
 					})
 				})
+
+				Context("when the job supersedes pending builds", func() {
+					BeforeEach(func() {
+						fakeJob.ConfigReturns(atc.JobConfig{
+							SupersedePending: true,
+							Plan: atc.PlanSequence{
+								{Get: "a", Trigger: true},
+								{Get: "b", Trigger: false},
+							},
+						})
+
+						fakeJob.SupersedePendingBuildsReturns(new(dbfakes.FakeBuild), true, nil)
+					})
+
+					It("supersedes the pending builds instead of ensuring one exists", func() {
+						Expect(fakeJob.SupersedePendingBuildsCallCount()).To(Equal(1))
+						Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+					})
+
+					It("starts all pending builds and returns no error", func() {
+						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+						Expect(scheduleErr).NotTo(HaveOccurred())
+					})
+
+					Context("when superseding the pending builds fails", func() {
+						BeforeEach(func() {
+							fakeJob.SupersedePendingBuildsReturns(nil, false, disaster)
+						})
+
+						It("returns the error", func() {
+							Expect(scheduleErr).To(Equal(disaster))
+						})
+					})
+				})
+			})
+
+			Context("when the job supersedes pending builds and no first occurrence input has trigger: true", func() {
+				BeforeEach(func() {
+					fakeJob.ConfigReturns(atc.JobConfig{
+						SupersedePending: true,
+						Plan: atc.PlanSequence{
+							{Get: "a", Trigger: true},
+						},
+					})
+
+					fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{
+						"a": algorithm.InputVersion{VersionID: 1, FirstOccurrence: false},
+					}, nil)
+				})
+
+				It("does not supersede the pending builds", func() {
+					Expect(fakeJob.SupersedePendingBuildsCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the job has a schedule", func() {
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("some-job")
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name:     "some-job",
+					Schedule: &atc.ScheduleConfig{Cron: "*/5 * * * *"},
+				})
+
+				fakeJobs = []db.Job{fakeJob}
+
+				fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+			})
+
+			Context("when the schedule has not been evaluated yet", func() {
+				BeforeEach(func() {
+					fakeJob.LastScheduleTimeReturns(time.Time{})
+				})
+
+				It("starts evaluating the schedule from now without creating a build", func() {
+					Expect(fakeJob.InitLastScheduleTimeCallCount()).To(Equal(1))
+					Expect(fakeJob.InitLastScheduleTimeArgsForCall(0)).To(BeTemporally("~", time.Now(), time.Minute))
+					Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+				})
+
+				Context("when initializing the schedule fails", func() {
+					BeforeEach(func() {
+						fakeJob.InitLastScheduleTimeReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+			})
+
+			Context("when the schedule has not fired since it was last evaluated", func() {
+				BeforeEach(func() {
+					fakeJob.LastScheduleTimeReturns(time.Now())
+				})
+
+				It("does not create a build", func() {
+					Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the schedule has fired since it was last evaluated", func() {
+				var lastScheduleTime time.Time
+
+				BeforeEach(func() {
+					lastScheduleTime = time.Date(2017, time.October, 6, 12, 31, 0, 0, time.UTC)
+					fakeJob.LastScheduleTimeReturns(lastScheduleTime)
+				})
+
+				It("creates a build for the first fire time after the last evaluation", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(1))
+					Expect(fakeJob.CreateScheduledBuildArgsForCall(0)).To(BeTemporally("==", time.Date(2017, time.October, 6, 12, 35, 0, 0, time.UTC)))
+				})
+
+				Context("when the job is paused", func() {
+					BeforeEach(func() {
+						fakeJob.PausedReturns(true)
+					})
+
+					It("does not create a build", func() {
+						Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when creating the build fails", func() {
+					BeforeEach(func() {
+						fakeJob.CreateScheduledBuildReturns(nil, false, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+			})
+		})
+
+		Context("when the job is triggered on builds of other jobs", func() {
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("some-job")
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name:      "some-job",
+					TriggerOn: []atc.TriggerOnConfig{{Job: "some-other-job"}},
+				})
+
+				fakeJobs = []db.Job{fakeJob}
+
+				fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+			})
+
+			Context("when the job has not been triggered on builds yet", func() {
+				BeforeEach(func() {
+					fakeJob.TriggerOnSinceReturns(time.Time{})
+				})
+
+				It("only considers builds finishing from now on", func() {
+					Expect(fakeJob.InitTriggerOnSinceCallCount()).To(Equal(1))
+					Expect(fakeJob.InitTriggerOnSinceArgsForCall(0)).To(BeTemporally("~", time.Now(), time.Minute))
+					Expect(fakeJob.CreateTriggeredBuildsCallCount()).To(BeZero())
+				})
+
+				Context("when initializing fails", func() {
+					BeforeEach(func() {
+						fakeJob.InitTriggerOnSinceReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+			})
+
+			Context("when the job is being triggered on builds", func() {
+				BeforeEach(func() {
+					fakeJob.TriggerOnSinceReturns(time.Now().Add(-time.Hour))
+					fakeJob.CreateTriggeredBuildsReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+				})
+
+				It("creates the triggered builds", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.InitTriggerOnSinceCallCount()).To(BeZero())
+					Expect(fakeJob.CreateTriggeredBuildsCallCount()).To(Equal(1))
+				})
+
+				Context("when creating the builds fails", func() {
+					BeforeEach(func() {
+						fakeJob.CreateTriggeredBuildsReturns(nil, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+
+				Context("when the job is paused", func() {
+					BeforeEach(func() {
+						fakeJob.PausedReturns(true)
+					})
+
+					It("skips the builds finishing until now instead of creating builds", func() {
+						Expect(scheduleErr).NotTo(HaveOccurred())
+						Expect(fakeJob.CreateTriggeredBuildsCallCount()).To(BeZero())
+
+						Expect(fakeJob.SkipTriggersUntilCallCount()).To(Equal(1))
+						Expect(fakeJob.SkipTriggersUntilArgsForCall(0)).To(BeTemporally("~", time.Now(), time.Minute))
+					})
+
+					Context("when skipping fails", func() {
+						BeforeEach(func() {
+							fakeJob.SkipTriggersUntilReturns(disaster)
+						})
+
+						It("returns the error", func() {
+							Expect(scheduleErr).To(Equal(disaster))
+						})
+					})
+				})
 			})
 		})
 	})
@@ -347,6 +642,148 @@ var _ = Describe("Scheduler", func() {
 		})
 	})
 
+	Describe("TriggerImmediatelyWithInputOverrides", func() {
+		var (
+			fakeJob        *dbfakes.FakeJob
+			triggeredBuild db.Build
+			triggerErr     error
+		)
+
+		BeforeEach(func() {
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+		})
+
+		JustBeforeEach(func() {
+			var waiter Waiter
+			triggeredBuild, waiter, triggerErr = scheduler.TriggerImmediatelyWithInputOverrides(
+				lagertest.NewTestLogger("test"),
+				fakeJob,
+				map[string]int{"some-input": 42},
+				db.Resources{},
+				atc.VersionedResourceTypes{},
+			)
+			if waiter != nil {
+				waiter.Wait()
+			}
+		})
+
+		Context("when creating the build fails", func() {
+			BeforeEach(func() {
+				fakeJob.CreateBuildWithInputOverridesReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(triggerErr).To(Equal(disaster))
+			})
+
+			It("does not try to start pending builds for job", func() {
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when creating the build succeeds", func() {
+			var createdBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				createdBuild = new(dbfakes.FakeBuild)
+				createdBuild.IsManuallyTriggeredReturns(true)
+				fakeJob.CreateBuildWithInputOverridesReturns(createdBuild, nil)
+				fakeJob.GetPendingBuildsReturns([]db.Build{createdBuild}, nil)
+			})
+
+			It("creates the build with the input overrides", func() {
+				Expect(fakeJob.CreateBuildWithInputOverridesCallCount()).To(Equal(1))
+				Expect(fakeJob.CreateBuildWithInputOverridesArgsForCall(0)).To(Equal(map[string]int{"some-input": 42}))
+				Expect(fakeJob.CreateBuildCallCount()).To(BeZero())
+			})
+
+			It("returns the created build", func() {
+				Expect(triggerErr).NotTo(HaveOccurred())
+				Expect(triggeredBuild).To(Equal(createdBuild))
+			})
+
+			It("tries to start the pending builds of the job", func() {
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("RerunImmediately", func() {
+		var (
+			fakeJob           *dbfakes.FakeJob
+			fakeRerunBuild    *dbfakes.FakeBuild
+			rerunBuild        db.Build
+			rerunErr          error
+			nextPendingBuilds []db.Build
+		)
+
+		BeforeEach(func() {
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+
+			fakeRerunBuild = new(dbfakes.FakeBuild)
+			fakeRerunBuild.IDReturns(12)
+		})
+
+		JustBeforeEach(func() {
+			var waiter Waiter
+			rerunBuild, waiter, rerunErr = scheduler.RerunImmediately(
+				lagertest.NewTestLogger("test"),
+				fakeJob,
+				fakeRerunBuild,
+				db.Resources{},
+				atc.VersionedResourceTypes{},
+			)
+			if waiter != nil {
+				waiter.Wait()
+			}
+		})
+
+		Context("when creating the rerun build fails", func() {
+			BeforeEach(func() {
+				fakeJob.RerunBuildReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(rerunErr).To(Equal(disaster))
+			})
+
+			It("does not try to start pending builds for job", func() {
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when creating the rerun build succeeds", func() {
+			var createdBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				createdBuild = new(dbfakes.FakeBuild)
+				createdBuild.RerunOfReturns(12)
+				fakeJob.RerunBuildReturns(createdBuild, nil)
+
+				nextPendingBuilds = []db.Build{createdBuild}
+				fakeJob.GetPendingBuildsReturns(nextPendingBuilds, nil)
+			})
+
+			It("reruns the right build", func() {
+				Expect(fakeJob.RerunBuildCallCount()).To(Equal(1))
+				Expect(fakeJob.RerunBuildArgsForCall(0)).To(Equal(fakeRerunBuild))
+			})
+
+			It("returns the created build", func() {
+				Expect(rerunErr).NotTo(HaveOccurred())
+				Expect(rerunBuild).To(Equal(createdBuild))
+			})
+
+			It("tries to start the pending builds of the job", func() {
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+				_, _, _, _, b := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+				Expect(b).To(Equal(nextPendingBuilds))
+			})
+		})
+	})
+
 	Describe("SaveNextInputMapping", func() {
 		var saveErr error
 		var fakeJob *dbfakes.FakeJob
@@ -406,4 +843,174 @@ var _ = Describe("Scheduler", func() {
 			})
 		})
 	})
+
+	Describe("ExplainJob", func() {
+		var (
+			fakeJob     *dbfakes.FakeJob
+			versionsDB  *algorithm.VersionsDB
+			explanation atc.JobSchedulingExplanation
+			explainErr  error
+		)
+
+		BeforeEach(func() {
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Name: "some-job",
+				Plan: atc.PlanSequence{
+					{Get: "some-input", Resource: "some-resource", Passed: []string{"upstream-job"}, Trigger: true},
+				},
+			})
+
+			versionsDB = &algorithm.VersionsDB{JobIDs: map[string]int{"upstream-job": 11, "some-job": 12}}
+			fakePipeline.LoadVersionsDBReturns(versionsDB, nil)
+
+			fakePipeline.GetResourceVersionsReturns([]db.SavedVersionedResource{
+				{
+					ID:                2,
+					Enabled:           true,
+					VersionedResource: db.VersionedResource{Version: db.ResourceVersion{"ref": "v2"}},
+				},
+				{
+					ID:                1,
+					Enabled:           true,
+					VersionedResource: db.VersionedResource{Version: db.ResourceVersion{"ref": "v1"}},
+				},
+			}, db.Pagination{}, true, nil)
+
+			fakeInputMapper.ExplainNextInputMappingReturns([]algorithm.InputExplanation{
+				{
+					Name:            "some-input",
+					Resolved:        true,
+					VersionID:       1,
+					FirstOccurrence: true,
+					Candidates: []algorithm.CandidateExplanation{
+						{VersionID: 2, Reason: algorithm.ReasonNotPassed, NotPassed: algorithm.JobSet{11: {}}},
+						{VersionID: 1, Selected: true},
+					},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			explanation, explainErr = scheduler.ExplainJob(lagertest.NewTestLogger("test"), fakeJob, 5)
+		})
+
+		It("explains the candidates of each input", func() {
+			Expect(explainErr).NotTo(HaveOccurred())
+
+			Expect(fakePipeline.GetResourceVersionsCallCount()).To(Equal(1))
+			resourceName, page := fakePipeline.GetResourceVersionsArgsForCall(0)
+			Expect(resourceName).To(Equal("some-resource"))
+			Expect(page).To(Equal(db.Page{Limit: 5}))
+
+			Expect(fakeInputMapper.ExplainNextInputMappingCallCount()).To(Equal(1))
+			_, actualVersionsDB, _, candidates := fakeInputMapper.ExplainNextInputMappingArgsForCall(0)
+			Expect(actualVersionsDB).To(Equal(versionsDB))
+			Expect(candidates).To(Equal(map[string][]int{"some-input": {2, 1}}))
+
+			Expect(explanation.InputsSatisfied).To(BeTrue())
+			Expect(explanation.TriggerChanged).To(BeTrue())
+			Expect(explanation.Inputs).To(Equal([]atc.InputSchedulingExplanation{
+				{
+					Name:       "some-input",
+					Resource:   "some-resource",
+					Passed:     []string{"upstream-job"},
+					Trigger:    true,
+					Resolved:   true,
+					VersionID:  1,
+					Version:    atc.Version{"ref": "v1"},
+					NewVersion: true,
+					Candidates: []atc.VersionCandidateExplanation{
+						{
+							ID:        2,
+							Version:   atc.Version{"ref": "v2"},
+							Enabled:   true,
+							Reason:    string(algorithm.ReasonNotPassed),
+							NotPassed: []string{"upstream-job"},
+						},
+						{
+							ID:       1,
+							Version:  atc.Version{"ref": "v1"},
+							Enabled:  true,
+							Selected: true,
+						},
+					},
+				},
+			}))
+		})
+
+		Context("when the input could not be resolved", func() {
+			BeforeEach(func() {
+				fakeInputMapper.ExplainNextInputMappingReturns([]algorithm.InputExplanation{
+					{Name: "some-input"},
+				}, nil)
+			})
+
+			It("reports the inputs as unsatisfied", func() {
+				Expect(explanation.InputsSatisfied).To(BeFalse())
+				Expect(explanation.TriggerChanged).To(BeFalse())
+				Expect(explanation.Inputs[0].Resolved).To(BeFalse())
+			})
+		})
+
+		Context("when the job is serial", func() {
+			var fakeRunningBuild, fakePendingBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job", SerialGroups: []string{"some-group"}})
+
+				fakeRunningBuild = new(dbfakes.FakeBuild)
+				fakeRunningBuild.JobNameReturns("other-job")
+				fakeRunningBuild.NameReturns("3")
+
+				fakePendingBuild = new(dbfakes.FakeBuild)
+				fakePendingBuild.IDReturns(42)
+				fakePendingBuild.NameReturns("7")
+
+				fakeJob.GetPendingBuildsReturns([]db.Build{fakePendingBuild}, nil)
+			})
+
+			Context("when a build in the serial group is running", func() {
+				BeforeEach(func() {
+					fakeJob.GetRunningBuildsBySerialGroupReturns([]db.Build{fakeRunningBuild}, nil)
+				})
+
+				It("reports max in flight as blocking the pending build", func() {
+					Expect(explainErr).NotTo(HaveOccurred())
+					Expect(explanation.NextPendingBuild).To(Equal("7"))
+					Expect(explanation.MaxInFlight).To(Equal(1))
+					Expect(explanation.SerialGroups).To(Equal([]string{"some-group"}))
+					Expect(explanation.RunningBuilds).To(Equal([]string{"other-job/3"}))
+					Expect(explanation.BlockedBy).To(Equal("max in flight of 1 reached"))
+				})
+			})
+
+			Context("when another job's pending build is ahead in the serial group", func() {
+				BeforeEach(func() {
+					fakeAheadBuild := new(dbfakes.FakeBuild)
+					fakeAheadBuild.IDReturns(41)
+					fakeAheadBuild.JobNameReturns("other-job")
+					fakeAheadBuild.NameReturns("4")
+
+					fakeJob.GetNextPendingBuildBySerialGroupReturns(fakeAheadBuild, true, nil)
+				})
+
+				It("reports the serial group as blocking the pending build", func() {
+					Expect(explainErr).NotTo(HaveOccurred())
+					Expect(explanation.BlockedBy).To(Equal("build other-job/4 is ahead in the serial group"))
+				})
+			})
+		})
+
+		Context("when loading the versions DB fails", func() {
+			BeforeEach(func() {
+				fakePipeline.LoadVersionsDBReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
+	})
 })
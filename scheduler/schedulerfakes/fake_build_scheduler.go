@@ -48,6 +48,44 @@ type FakeBuildScheduler struct {
 		result2 scheduler.Waiter
 		result3 error
 	}
+	TriggerImmediatelyWithInputOverridesStub        func(logger lager.Logger, job db.Job, inputOverrides map[string]int, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error)
+	triggerImmediatelyWithInputOverridesMutex       sync.RWMutex
+	triggerImmediatelyWithInputOverridesArgsForCall []struct {
+		logger         lager.Logger
+		job            db.Job
+		inputOverrides map[string]int
+		resources      db.Resources
+		resourceTypes  atc.VersionedResourceTypes
+	}
+	triggerImmediatelyWithInputOverridesReturns struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
+	triggerImmediatelyWithInputOverridesReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
+	RerunImmediatelyStub        func(logger lager.Logger, job db.Job, rerunBuild db.Build, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error)
+	rerunImmediatelyMutex       sync.RWMutex
+	rerunImmediatelyArgsForCall []struct {
+		logger        lager.Logger
+		job           db.Job
+		rerunBuild    db.Build
+		resources     db.Resources
+		resourceTypes atc.VersionedResourceTypes
+	}
+	rerunImmediatelyReturns struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
+	rerunImmediatelyReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
 	SaveNextInputMappingStub        func(logger lager.Logger, job db.Job) error
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
@@ -60,6 +98,21 @@ type FakeBuildScheduler struct {
 	saveNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExplainJobStub        func(logger lager.Logger, job db.Job, candidateLimit int) (atc.JobSchedulingExplanation, error)
+	explainJobMutex       sync.RWMutex
+	explainJobArgsForCall []struct {
+		logger         lager.Logger
+		job            db.Job
+		candidateLimit int
+	}
+	explainJobReturns struct {
+		result1 atc.JobSchedulingExplanation
+		result2 error
+	}
+	explainJobReturnsOnCall map[int]struct {
+		result1 atc.JobSchedulingExplanation
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -181,6 +234,122 @@ func (fake *FakeBuildScheduler) TriggerImmediatelyReturnsOnCall(i int, result1 d
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildScheduler) TriggerImmediatelyWithInputOverrides(logger lager.Logger, job db.Job, inputOverrides map[string]int, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error) {
+	fake.triggerImmediatelyWithInputOverridesMutex.Lock()
+	ret, specificReturn := fake.triggerImmediatelyWithInputOverridesReturnsOnCall[len(fake.triggerImmediatelyWithInputOverridesArgsForCall)]
+	fake.triggerImmediatelyWithInputOverridesArgsForCall = append(fake.triggerImmediatelyWithInputOverridesArgsForCall, struct {
+		logger         lager.Logger
+		job            db.Job
+		inputOverrides map[string]int
+		resources      db.Resources
+		resourceTypes  atc.VersionedResourceTypes
+	}{logger, job, inputOverrides, resources, resourceTypes})
+	fake.recordInvocation("TriggerImmediatelyWithInputOverrides", []interface{}{logger, job, inputOverrides, resources, resourceTypes})
+	fake.triggerImmediatelyWithInputOverridesMutex.Unlock()
+	if fake.TriggerImmediatelyWithInputOverridesStub != nil {
+		return fake.TriggerImmediatelyWithInputOverridesStub(logger, job, inputOverrides, resources, resourceTypes)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.triggerImmediatelyWithInputOverridesReturns.result1, fake.triggerImmediatelyWithInputOverridesReturns.result2, fake.triggerImmediatelyWithInputOverridesReturns.result3
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyWithInputOverridesCallCount() int {
+	fake.triggerImmediatelyWithInputOverridesMutex.RLock()
+	defer fake.triggerImmediatelyWithInputOverridesMutex.RUnlock()
+	return len(fake.triggerImmediatelyWithInputOverridesArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyWithInputOverridesArgsForCall(i int) (lager.Logger, db.Job, map[string]int, db.Resources, atc.VersionedResourceTypes) {
+	fake.triggerImmediatelyWithInputOverridesMutex.RLock()
+	defer fake.triggerImmediatelyWithInputOverridesMutex.RUnlock()
+	return fake.triggerImmediatelyWithInputOverridesArgsForCall[i].logger, fake.triggerImmediatelyWithInputOverridesArgsForCall[i].job, fake.triggerImmediatelyWithInputOverridesArgsForCall[i].inputOverrides, fake.triggerImmediatelyWithInputOverridesArgsForCall[i].resources, fake.triggerImmediatelyWithInputOverridesArgsForCall[i].resourceTypes
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyWithInputOverridesReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.TriggerImmediatelyWithInputOverridesStub = nil
+	fake.triggerImmediatelyWithInputOverridesReturns = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyWithInputOverridesReturnsOnCall(i int, result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.TriggerImmediatelyWithInputOverridesStub = nil
+	if fake.triggerImmediatelyWithInputOverridesReturnsOnCall == nil {
+		fake.triggerImmediatelyWithInputOverridesReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 scheduler.Waiter
+			result3 error
+		})
+	}
+	fake.triggerImmediatelyWithInputOverridesReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildScheduler) RerunImmediately(logger lager.Logger, job db.Job, rerunBuild db.Build, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error) {
+	fake.rerunImmediatelyMutex.Lock()
+	ret, specificReturn := fake.rerunImmediatelyReturnsOnCall[len(fake.rerunImmediatelyArgsForCall)]
+	fake.rerunImmediatelyArgsForCall = append(fake.rerunImmediatelyArgsForCall, struct {
+		logger        lager.Logger
+		job           db.Job
+		rerunBuild    db.Build
+		resources     db.Resources
+		resourceTypes atc.VersionedResourceTypes
+	}{logger, job, rerunBuild, resources, resourceTypes})
+	fake.recordInvocation("RerunImmediately", []interface{}{logger, job, rerunBuild, resources, resourceTypes})
+	fake.rerunImmediatelyMutex.Unlock()
+	if fake.RerunImmediatelyStub != nil {
+		return fake.RerunImmediatelyStub(logger, job, rerunBuild, resources, resourceTypes)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.rerunImmediatelyReturns.result1, fake.rerunImmediatelyReturns.result2, fake.rerunImmediatelyReturns.result3
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyCallCount() int {
+	fake.rerunImmediatelyMutex.RLock()
+	defer fake.rerunImmediatelyMutex.RUnlock()
+	return len(fake.rerunImmediatelyArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyArgsForCall(i int) (lager.Logger, db.Job, db.Build, db.Resources, atc.VersionedResourceTypes) {
+	fake.rerunImmediatelyMutex.RLock()
+	defer fake.rerunImmediatelyMutex.RUnlock()
+	return fake.rerunImmediatelyArgsForCall[i].logger, fake.rerunImmediatelyArgsForCall[i].job, fake.rerunImmediatelyArgsForCall[i].rerunBuild, fake.rerunImmediatelyArgsForCall[i].resources, fake.rerunImmediatelyArgsForCall[i].resourceTypes
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.RerunImmediatelyStub = nil
+	fake.rerunImmediatelyReturns = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyReturnsOnCall(i int, result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.RerunImmediatelyStub = nil
+	if fake.rerunImmediatelyReturnsOnCall == nil {
+		fake.rerunImmediatelyReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 scheduler.Waiter
+			result3 error
+		})
+	}
+	fake.rerunImmediatelyReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuildScheduler) SaveNextInputMapping(logger lager.Logger, job db.Job) error {
 	fake.saveNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
@@ -230,6 +399,59 @@ func (fake *FakeBuildScheduler) SaveNextInputMappingReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeBuildScheduler) ExplainJob(logger lager.Logger, job db.Job, candidateLimit int) (atc.JobSchedulingExplanation, error) {
+	fake.explainJobMutex.Lock()
+	ret, specificReturn := fake.explainJobReturnsOnCall[len(fake.explainJobArgsForCall)]
+	fake.explainJobArgsForCall = append(fake.explainJobArgsForCall, struct {
+		logger         lager.Logger
+		job            db.Job
+		candidateLimit int
+	}{logger, job, candidateLimit})
+	fake.recordInvocation("ExplainJob", []interface{}{logger, job, candidateLimit})
+	fake.explainJobMutex.Unlock()
+	if fake.ExplainJobStub != nil {
+		return fake.ExplainJobStub(logger, job, candidateLimit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.explainJobReturns.result1, fake.explainJobReturns.result2
+}
+
+func (fake *FakeBuildScheduler) ExplainJobCallCount() int {
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
+	return len(fake.explainJobArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) ExplainJobArgsForCall(i int) (lager.Logger, db.Job, int) {
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
+	return fake.explainJobArgsForCall[i].logger, fake.explainJobArgsForCall[i].job, fake.explainJobArgsForCall[i].candidateLimit
+}
+
+func (fake *FakeBuildScheduler) ExplainJobReturns(result1 atc.JobSchedulingExplanation, result2 error) {
+	fake.ExplainJobStub = nil
+	fake.explainJobReturns = struct {
+		result1 atc.JobSchedulingExplanation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildScheduler) ExplainJobReturnsOnCall(i int, result1 atc.JobSchedulingExplanation, result2 error) {
+	fake.ExplainJobStub = nil
+	if fake.explainJobReturnsOnCall == nil {
+		fake.explainJobReturnsOnCall = make(map[int]struct {
+			result1 atc.JobSchedulingExplanation
+			result2 error
+		})
+	}
+	fake.explainJobReturnsOnCall[i] = struct {
+		result1 atc.JobSchedulingExplanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -237,8 +459,14 @@ func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	defer fake.scheduleMutex.RUnlock()
 	fake.triggerImmediatelyMutex.RLock()
 	defer fake.triggerImmediatelyMutex.RUnlock()
+	fake.triggerImmediatelyWithInputOverridesMutex.RLock()
+	defer fake.triggerImmediatelyWithInputOverridesMutex.RUnlock()
+	fake.rerunImmediatelyMutex.RLock()
+	defer fake.rerunImmediatelyMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,242 @@
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/scheduler/inputmapper/inputconfig"
+)
+
+// Simulation resolves the inputs of a pipeline's jobs against a versions DB,
+// e.g. a dump from the GetVersionsDB API, the same way the scheduler does but
+// without a database.
+type Simulation struct {
+	Versions *algorithm.VersionsDB
+	Config   atc.Config
+
+	// CandidateLimit is the number of latest versions of each input's
+	// resource whose selection is explained.
+	CandidateLimit int
+}
+
+type JobResult struct {
+	JobName string
+
+	// Skipped is set when the job's inputs could not be resolved offline.
+	Skipped string
+
+	Resolved bool
+	Inputs   []InputResult
+}
+
+type InputResult struct {
+	Config      atc.JobInput
+	Explanation algorithm.InputExplanation
+}
+
+type UnknownJobError struct {
+	JobName string
+}
+
+func (err UnknownJobError) Error() string {
+	return fmt.Sprintf("job '%s' is not in the pipeline config", err.JobName)
+}
+
+// Run resolves the inputs of the given jobs, or of every job in the config
+// when no names are given.
+func (s Simulation) Run(jobNames []string) ([]JobResult, error) {
+	jobs := s.Config.Jobs
+	if len(jobNames) != 0 {
+		jobs = atc.JobConfigs{}
+		for _, jobName := range jobNames {
+			job, found := s.Config.Jobs.Lookup(jobName)
+			if !found {
+				return nil, UnknownJobError{JobName: jobName}
+			}
+
+			jobs = append(jobs, job)
+		}
+	}
+
+	results := []JobResult{}
+	for _, job := range jobs {
+		results = append(results, s.runJob(job))
+	}
+
+	return results, nil
+}
+
+func (s Simulation) runJob(job atc.JobConfig) JobResult {
+	result := JobResult{
+		JobName: job.Name,
+		Inputs:  []InputResult{},
+	}
+
+	inputs := job.Inputs()
+
+	skipped := s.unsupported(job.Name, inputs)
+	if skipped != "" {
+		result.Skipped = skipped
+		return result
+	}
+
+	// the transformer only needs the pipeline to look up pinned versions,
+	// which are ruled out above
+	inputConfigs, err := inputconfig.NewTransformer(nil).TransformInputConfigs(s.Versions, job.Name, inputs)
+	if err != nil {
+		result.Skipped = err.Error()
+		return result
+	}
+
+	candidates := map[string][]int{}
+	for _, input := range inputs {
+		candidates[input.Name] = s.latestVersions(s.Versions.ResourceIDs[input.Resource])
+	}
+
+	result.Resolved = true
+	for i, explanation := range inputConfigs.Explain(s.Versions, candidates) {
+		result.Resolved = result.Resolved && explanation.Resolved
+
+		result.Inputs = append(result.Inputs, InputResult{
+			Config:      inputs[i],
+			Explanation: explanation,
+		})
+	}
+
+	return result
+}
+
+func (s Simulation) unsupported(jobName string, inputs []atc.JobInput) string {
+	if _, found := s.Versions.JobIDs[jobName]; !found {
+		return fmt.Sprintf("job '%s' is not in the versions DB", jobName)
+	}
+
+	for _, input := range inputs {
+		if input.Version != nil && input.Version.Pinned != nil {
+			return fmt.Sprintf("input '%s' is pinned to a version, which cannot be looked up in a versions DB", input.Name)
+		}
+
+		if _, found := s.Versions.ResourceIDs[input.Resource]; !found {
+			return fmt.Sprintf("resource '%s' of input '%s' is not in the versions DB", input.Resource, input.Name)
+		}
+
+		for _, passedJob := range append(append([]string{}, input.Passed...), input.PassedAny...) {
+			if _, found := s.Versions.JobIDs[passedJob]; !found {
+				return fmt.Sprintf("job '%s' in the passed constraints of input '%s' is not in the versions DB", passedJob, input.Name)
+			}
+		}
+	}
+
+	return ""
+}
+
+func (s Simulation) latestVersions(resourceID int) []int {
+	versions := []algorithm.ResourceVersion{}
+	for _, version := range s.Versions.ResourceVersions {
+		if version.ResourceID == resourceID {
+			versions = append(versions, version)
+		}
+	}
+
+	sort.Sort(byCheckOrderDesc(versions))
+
+	versionIDs := []int{}
+	for i, version := range versions {
+		if i == s.CandidateLimit {
+			break
+		}
+
+		versionIDs = append(versionIDs, version.VersionID)
+	}
+
+	return versionIDs
+}
+
+type byCheckOrderDesc []algorithm.ResourceVersion
+
+func (versions byCheckOrderDesc) Len() int      { return len(versions) }
+func (versions byCheckOrderDesc) Swap(i, j int) { versions[i], versions[j] = versions[j], versions[i] }
+func (versions byCheckOrderDesc) Less(i, j int) bool {
+	return versions[i].CheckOrder > versions[j].CheckOrder
+}
+
+// Report prints the results in a form that can be attached to bug reports.
+// Versions are identified by their versioned resource IDs.
+func (s Simulation) Report(w io.Writer, results []JobResult) {
+	jobNames := map[int]string{}
+	for name, id := range s.Versions.JobIDs {
+		jobNames[id] = name
+	}
+
+	for _, result := range results {
+		fmt.Fprintf(w, "job %s:\n", result.JobName)
+
+		if result.Skipped != "" {
+			fmt.Fprintf(w, "  skipped: %s\n\n", result.Skipped)
+			continue
+		}
+
+		if result.Resolved {
+			fmt.Fprintln(w, "  inputs resolved")
+		} else {
+			fmt.Fprintln(w, "  inputs not resolved")
+		}
+
+		for _, input := range result.Inputs {
+			explanation := input.Explanation
+
+			fmt.Fprintf(w, "  input %s (resource %s):\n", input.Config.Name, input.Config.Resource)
+
+			if len(explanation.Passed) != 0 {
+				fmt.Fprintf(w, "    passed: %s\n", names(explanation.Passed, jobNames))
+			}
+
+			if len(explanation.PassedAny) != 0 {
+				fmt.Fprintf(w, "    passed_any: %s\n", names(explanation.PassedAny, jobNames))
+			}
+
+			if input.Config.Version != nil && input.Config.Version.Every {
+				fmt.Fprintln(w, "    version: every")
+			}
+
+			if explanation.Resolved {
+				firstOccurrence := ""
+				if explanation.FirstOccurrence {
+					firstOccurrence = " (first occurrence)"
+				}
+
+				fmt.Fprintf(w, "    chose version %d%s\n", explanation.VersionID, firstOccurrence)
+			}
+
+			for _, candidate := range explanation.Candidates {
+				switch {
+				case candidate.Selected:
+					fmt.Fprintf(w, "    candidate %d: selected\n", candidate.VersionID)
+				case len(candidate.NotPassed) != 0:
+					fmt.Fprintf(w, "    candidate %d: %s (%s)\n", candidate.VersionID, candidate.Reason, names(candidate.NotPassed, jobNames))
+				case candidate.Reason != "":
+					fmt.Fprintf(w, "    candidate %d: %s\n", candidate.VersionID, candidate.Reason)
+				default:
+					fmt.Fprintf(w, "    candidate %d: eligible\n", candidate.VersionID)
+				}
+			}
+		}
+
+		fmt.Fprintln(w)
+	}
+}
+
+func names(jobs algorithm.JobSet, jobNames map[int]string) string {
+	sorted := []string{}
+	for jobID := range jobs {
+		sorted = append(sorted, jobNames[jobID])
+	}
+
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, ", ")
+}
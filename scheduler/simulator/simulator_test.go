@@ -0,0 +1,170 @@
+package simulator_test
+
+import (
+	"bytes"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/scheduler/simulator"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Simulation", func() {
+	var (
+		simulation simulator.Simulation
+		jobNames   []string
+
+		results []simulator.JobResult
+		runErr  error
+	)
+
+	output := func(jobID, buildID, resourceID, versionID, checkOrder int) algorithm.BuildOutput {
+		return algorithm.BuildOutput{
+			ResourceVersion: algorithm.ResourceVersion{VersionID: versionID, ResourceID: resourceID, CheckOrder: checkOrder},
+			BuildID:         buildID,
+			JobID:           jobID,
+		}
+	}
+
+	BeforeEach(func() {
+		simulation = simulator.Simulation{
+			Versions: &algorithm.VersionsDB{
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					{VersionID: 3, ResourceID: 21, CheckOrder: 3},
+				},
+				BuildOutputs: []algorithm.BuildOutput{
+					output(11, 31, 21, 1, 1),
+					output(11, 32, 21, 2, 2),
+				},
+				BuildInputs: []algorithm.BuildInput{},
+				JobIDs:      map[string]int{"unit": 11, "deploy": 12},
+				ResourceIDs: map[string]int{"repo": 21},
+			},
+			Config: atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "unit",
+						Plan: atc.PlanSequence{
+							{Get: "repo"},
+						},
+					},
+					{
+						Name: "deploy",
+						Plan: atc.PlanSequence{
+							{Get: "repo", Passed: []string{"unit"}},
+						},
+					},
+				},
+			},
+			CandidateLimit: 2,
+		}
+
+		jobNames = nil
+	})
+
+	JustBeforeEach(func() {
+		results, runErr = simulation.Run(jobNames)
+	})
+
+	It("resolves every job", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+
+		Expect(results[0].JobName).To(Equal("unit"))
+		Expect(results[0].Resolved).To(BeTrue())
+		Expect(results[0].Inputs).To(HaveLen(1))
+		Expect(results[0].Inputs[0].Explanation.VersionID).To(Equal(3))
+
+		Expect(results[1].JobName).To(Equal("deploy"))
+		Expect(results[1].Resolved).To(BeTrue())
+		Expect(results[1].Inputs).To(HaveLen(1))
+		Expect(results[1].Inputs[0].Explanation.VersionID).To(Equal(2))
+	})
+
+	It("explains the latest versions up to the candidate limit", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+
+		candidates := results[1].Inputs[0].Explanation.Candidates
+		Expect(candidates).To(Equal([]algorithm.CandidateExplanation{
+			{
+				VersionID: 3,
+				Reason:    algorithm.ReasonNotPassed,
+				NotPassed: algorithm.JobSet{11: struct{}{}},
+			},
+			{
+				VersionID: 2,
+				Selected:  true,
+			},
+		}))
+	})
+
+	It("reports the chosen versions and the constraints", func() {
+		buf := new(bytes.Buffer)
+		simulation.Report(buf, results)
+
+		Expect(buf.String()).To(ContainSubstring("job deploy:\n  inputs resolved\n  input repo (resource repo):\n    passed: unit\n    chose version 2 (first occurrence)\n"))
+		Expect(buf.String()).To(ContainSubstring("    candidate 3: version has not passed all of the required jobs (unit)\n"))
+	})
+
+	Context("when specific jobs are given", func() {
+		BeforeEach(func() {
+			jobNames = []string{"deploy"}
+		})
+
+		It("resolves only those jobs", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].JobName).To(Equal("deploy"))
+		})
+	})
+
+	Context("when an unknown job is given", func() {
+		BeforeEach(func() {
+			jobNames = []string{"bogus"}
+		})
+
+		It("returns an error", func() {
+			Expect(runErr).To(Equal(simulator.UnknownJobError{JobName: "bogus"}))
+		})
+	})
+
+	Context("when no version has passed the constraints", func() {
+		BeforeEach(func() {
+			simulation.Versions.BuildOutputs = []algorithm.BuildOutput{}
+		})
+
+		It("does not resolve the job", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(results[1].Resolved).To(BeFalse())
+			Expect(results[1].Inputs[0].Explanation.Resolved).To(BeFalse())
+		})
+	})
+
+	Context("when an input is pinned to a version", func() {
+		BeforeEach(func() {
+			simulation.Config.Jobs[0].Plan[0].Version = &atc.VersionConfig{Pinned: atc.Version{"ref": "abc"}}
+		})
+
+		It("skips the job", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(results[0].Skipped).To(ContainSubstring("pinned"))
+			Expect(results[0].Inputs).To(BeEmpty())
+		})
+	})
+
+	Context("when a resource is missing from the versions DB", func() {
+		BeforeEach(func() {
+			delete(simulation.Versions.ResourceIDs, "repo")
+		})
+
+		It("skips the jobs using it", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(results[0].Skipped).To(Equal("resource 'repo' of input 'repo' is not in the versions DB"))
+			Expect(results[1].Skipped).To(Equal("resource 'repo' of input 'repo' is not in the versions DB"))
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package syslog
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"time"
+)
+
+// Config configures the syslog server which build output is drained to.
+type Config struct {
+	Address   string `long:"address"   description:"Address of the syslog server to ship build logs to, as host:port."`
+	Transport string `long:"transport" default:"tcp" choice:"tcp" choice:"tls" description:"Transport used to connect to the syslog server."`
+	CACert    string `long:"ca-cert"   description:"File containing the CA certificate used to verify a TLS syslog server. Defaults to the system pool."`
+
+	Hostname         string        `long:"hostname" description:"Hostname sent with each message. Defaults to the ATC's hostname."`
+	AppName          string        `long:"app-name" default:"concourse" description:"App name sent with each message."`
+	StructuredDataID string        `long:"sd-id"    description:"SD-ID of the structured data carrying each message's build metadata, as name@<private enterprise number>. Required along with the address."`
+	Interval         time.Duration `long:"interval" default:"5s" description:"Interval on which to ship newly saved build logs."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.Address != ""
+}
+
+// Validate checks that the SD-ID is a private SD-ID as defined by RFC 5424.
+// It is required, as messages carry their build metadata only as structured
+// data.
+func (config Config) Validate() error {
+	if config.StructuredDataID == "" {
+		return errors.New("a syslog SD-ID must be configured to ship build logs along with their build metadata (--syslog-sd-id)")
+	}
+
+	if !structuredDataIDPattern.MatchString(config.StructuredDataID) || len(config.StructuredDataID) > 32 {
+		return fmt.Errorf("invalid syslog SD-ID '%s': must be name@<private enterprise number>, up to 32 characters", config.StructuredDataID)
+	}
+
+	return nil
+}
+
+// structuredDataIDPattern matches printable ASCII without '=', ' ', ']' or
+// '"', followed by '@' and an enterprise number other than the reserved 0.
+var structuredDataIDPattern = regexp.MustCompile(`^[!#-<>-?A-\\^-~]+@[1-9][0-9]*(\.[0-9]+)*$`)
+
+// TLSConfig returns the configuration for connecting over TLS, or nil for
+// plain TCP.
+func (config Config) TLSConfig() (*tls.Config, error) {
+	if config.Transport != "tls" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if config.CACert != "" {
+		cert, err := ioutil.ReadFile(config.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read syslog CA certificate: %s", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cert) {
+			return nil, errors.New("no certificates found in syslog CA certificate file")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,38 @@
+package syslog_test
+
+import (
+	"github.com/concourse/atc/syslog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	Describe("Validate", func() {
+		It("requires an SD-ID", func() {
+			Expect(syslog.Config{Address: "some-address"}.Validate()).NotTo(Succeed())
+		})
+
+		It("allows a private SD-ID", func() {
+			Expect(syslog.Config{StructuredDataID: "concourse@12345"}.Validate()).To(Succeed())
+			Expect(syslog.Config{StructuredDataID: "concourse@12345.1"}.Validate()).To(Succeed())
+		})
+
+		It("rejects the reserved enterprise number 0", func() {
+			Expect(syslog.Config{StructuredDataID: "concourse@0"}.Validate()).NotTo(Succeed())
+		})
+
+		It("rejects an SD-ID without an enterprise number", func() {
+			Expect(syslog.Config{StructuredDataID: "concourse"}.Validate()).NotTo(Succeed())
+		})
+
+		It("rejects an SD-ID with characters which are not allowed", func() {
+			Expect(syslog.Config{StructuredDataID: "con course@12345"}.Validate()).NotTo(Succeed())
+			Expect(syslog.Config{StructuredDataID: "con=course@12345"}.Validate()).NotTo(Succeed())
+		})
+
+		It("rejects an SD-ID longer than 32 characters", func() {
+			Expect(syslog.Config{StructuredDataID: "some-very-long-structured-data-name@12345"}.Validate()).NotTo(Succeed())
+		})
+	})
+})
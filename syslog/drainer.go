@@ -0,0 +1,262 @@
+package syslog
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+// DefaultBatchSize is the number of build events read from the database at a
+// time.
+const DefaultBatchSize = 500
+
+// Drainer ships the output of builds to a syslog server as it is saved.
+//
+// The position of the next event to ship is saved to each build after every
+// batch, so a drainer picks up where the last one stopped. A batch which was
+// sent but not recorded is sent again after a restart; the message IDs allow
+// the receiver to discard it.
+type Drainer struct {
+	logger       lager.Logger
+	buildFactory db.BuildFactory
+
+	transport string
+	address   string
+	tlsConfig *tls.Config
+
+	hostname         string
+	appName          string
+	structuredDataID string
+	batchSize        int
+}
+
+func NewDrainer(
+	logger lager.Logger,
+	buildFactory db.BuildFactory,
+	transport string,
+	address string,
+	tlsConfig *tls.Config,
+	hostname string,
+	appName string,
+	structuredDataID string,
+	batchSize int,
+) *Drainer {
+	return &Drainer{
+		logger:       logger,
+		buildFactory: buildFactory,
+
+		transport: transport,
+		address:   address,
+		tlsConfig: tlsConfig,
+
+		hostname:         hostname,
+		appName:          appName,
+		structuredDataID: structuredDataID,
+		batchSize:        batchSize,
+	}
+}
+
+func (drainer *Drainer) Run() error {
+	logger := drainer.logger.Session("drain")
+
+	builds, err := drainer.buildFactory.UndrainedBuilds()
+	if err != nil {
+		logger.Error("failed-to-get-undrained-builds", err)
+		return err
+	}
+
+	if len(builds) == 0 {
+		return nil
+	}
+
+	writer, err := Dial(drainer.transport, drainer.address, drainer.tlsConfig)
+	if err != nil {
+		logger.Error("failed-to-connect", err, lager.Data{"address": drainer.address})
+		return err
+	}
+
+	defer writer.Close()
+
+	for _, build := range builds {
+		err := drainer.drainBuild(writer, build)
+		if err != nil {
+			logger.Error("failed-to-drain-build", err, lager.Data{"build-id": build.ID()})
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (drainer *Drainer) drainBuild(writer *Writer, build db.Build) error {
+	// a build which had finished when it was loaded has saved all of its
+	// events, so once they are shipped it need not be drained again
+	finished := !build.IsRunning()
+
+	stepNames := map[string]string{}
+	if build.PublicPlan() != nil {
+		var plan interface{}
+		err := json.Unmarshal(*build.PublicPlan(), &plan)
+		if err != nil {
+			return err
+		}
+
+		collectStepNames(plan, stepNames)
+	}
+
+	for {
+		events, err := build.UndrainedEvents(drainer.batchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			break
+		}
+
+		for _, buildEvent := range events {
+			message, found, err := drainer.message(build, stepNames, buildEvent)
+			if err != nil {
+				return err
+			}
+
+			if !found {
+				continue
+			}
+
+			err = writer.Write(message)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = writer.Flush()
+		if err != nil {
+			return err
+		}
+
+		err = build.SaveDrainOffset(events[len(events)-1].ID + 1)
+		if err != nil {
+			return err
+		}
+
+		if len(events) < drainer.batchSize {
+			break
+		}
+	}
+
+	if finished {
+		return build.MarkDrained()
+	}
+
+	return nil
+}
+
+// message converts a build's log or error event into a syslog message. Other
+// events are not shipped.
+func (drainer *Drainer) message(build db.Build, stepNames map[string]string, buildEvent db.BuildEvent) (Message, bool, error) {
+	envelope := buildEvent.Envelope
+	if envelope.Data == nil {
+		return Message{}, false, nil
+	}
+
+	if envelope.Event != event.EventTypeLog && envelope.Event != event.EventTypeError {
+		return Message{}, false, nil
+	}
+
+	parsed, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+	if err != nil {
+		// events saved by older versions are not shipped
+		return Message{}, false, nil
+	}
+
+	params := map[string]string{
+		"team":     build.TeamName(),
+		"build":    build.Name(),
+		"build_id": strconv.Itoa(build.ID()),
+	}
+
+	if build.PipelineName() != "" {
+		params["pipeline"] = build.PipelineName()
+	}
+
+	if build.JobName() != "" {
+		params["job"] = build.JobName()
+	}
+
+	message := Message{
+		Timestamp: buildEvent.SaveTime,
+		Hostname:  drainer.hostname,
+		AppName:   drainer.appName,
+		MsgID:     fmt.Sprintf("%d/%d", build.ID(), buildEvent.ID),
+
+		StructuredDataID: drainer.structuredDataID,
+		Params:           params,
+	}
+
+	var origin event.Origin
+
+	switch e := parsed.(type) {
+	case event.Log:
+		origin = e.Origin
+		message.Text = strings.TrimSuffix(e.Payload, "\n")
+
+		message.Severity = SeverityInfo
+		if origin.Source == event.OriginSourceStderr {
+			message.Severity = SeverityError
+		}
+	case event.Error:
+		origin = e.Origin
+		message.Text = e.Message
+		message.Severity = SeverityError
+	default:
+		return Message{}, false, nil
+	}
+
+	if origin.Source != "" {
+		params["source"] = string(origin.Source)
+	}
+
+	if name, found := stepNames[string(origin.ID)]; found {
+		params["step"] = name
+	}
+
+	return message, true, nil
+}
+
+// collectStepNames maps the plan IDs of the gets, puts and tasks in a build's
+// public plan to their names.
+func collectStepNames(plan interface{}, names map[string]string) {
+	switch node := plan.(type) {
+	case []interface{}:
+		for _, child := range node {
+			collectStepNames(child, names)
+		}
+	case map[string]interface{}:
+		if id, ok := node["id"].(string); ok {
+			for _, stepType := range []string{"get", "put", "task", "dependent_get"} {
+				step, ok := node[stepType].(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				name, _ := step["name"].(string)
+				if name == "" {
+					name, _ = step["resource"].(string)
+				}
+
+				names[id] = name
+			}
+		}
+
+		for _, child := range node {
+			collectStepNames(child, names)
+		}
+	}
+}
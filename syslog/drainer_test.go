@@ -0,0 +1,182 @@
+package syslog_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/syslog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drainer", func() {
+	var (
+		listener net.Listener
+		received chan string
+
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		fakeBuild        *dbfakes.FakeBuild
+
+		drainer *syslog.Drainer
+		runErr  error
+	)
+
+	buildEvent := func(id int, e atc.Event) db.BuildEvent {
+		payload, err := json.Marshal(e)
+		Expect(err).NotTo(HaveOccurred())
+
+		data := json.RawMessage(payload)
+
+		return db.BuildEvent{
+			ID: id,
+			Envelope: event.Envelope{
+				Data:    &data,
+				Event:   e.EventType(),
+				Version: e.Version(),
+			},
+			SaveTime: time.Date(2018, 1, 2, 3, 4, id, 0, time.UTC),
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		received = make(chan string, 1)
+
+		go func() {
+			defer GinkgoRecover()
+
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			defer conn.Close()
+
+			payload, err := ioutil.ReadAll(conn)
+			Expect(err).NotTo(HaveOccurred())
+
+			received <- string(payload)
+		}()
+
+		plan := json.RawMessage(`{"id":"do-id","do":[{"id":"get-id","get":{"type":"git","name":"repo","resource":"repo"}},{"id":"task-id","task":{"name":"unit","privileged":false}}]}`)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamNameReturns("main")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.PublicPlanReturns(&plan)
+		fakeBuild.UndrainedEventsStub = func(int) ([]db.BuildEvent, error) {
+			switch fakeBuild.UndrainedEventsCallCount() {
+			case 1:
+				return []db.BuildEvent{
+					buildEvent(0, event.Status{Status: atc.StatusStarted}),
+					buildEvent(1, event.Log{
+						Origin:  event.Origin{ID: "task-id", Source: event.OriginSourceStdout},
+						Payload: "hello\n",
+					}),
+				}, nil
+			case 2:
+				return []db.BuildEvent{
+					buildEvent(2, event.Error{
+						Origin:  event.Origin{ID: "get-id"},
+						Message: "oh no",
+					}),
+				}, nil
+			default:
+				return []db.BuildEvent{}, nil
+			}
+		}
+
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeBuildFactory.UndrainedBuildsReturns([]db.Build{fakeBuild}, nil)
+
+		drainer = syslog.NewDrainer(
+			lagertest.NewTestLogger("drainer"),
+			fakeBuildFactory,
+			"tcp",
+			listener.Addr().String(),
+			nil,
+			"some-host",
+			"concourse",
+			"concourse@12345",
+			2,
+		)
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	JustBeforeEach(func() {
+		runErr = drainer.Run()
+	})
+
+	It("ships the logs and errors of the build with its metadata", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		first := `<14>1 2018-01-02T03:04:01.000000Z some-host concourse - 42/1 [concourse@12345 build="7" build_id="42" job="some-job" pipeline="some-pipeline" source="stdout" step="unit" team="main"] hello`
+		second := `<11>1 2018-01-02T03:04:02.000000Z some-host concourse - 42/2 [concourse@12345 build="7" build_id="42" job="some-job" pipeline="some-pipeline" step="repo" team="main"] oh no`
+
+		var payload string
+		Eventually(received).Should(Receive(&payload))
+		Expect(payload).To(Equal(
+			fmt.Sprintf("%d %s%d %s", len(first), first, len(second), second),
+		))
+	})
+
+	It("saves the position of the next event after each batch", func() {
+		Expect(fakeBuild.UndrainedEventsCallCount()).To(Equal(2))
+		Expect(fakeBuild.UndrainedEventsArgsForCall(0)).To(Equal(2))
+
+		Expect(fakeBuild.SaveDrainOffsetCallCount()).To(Equal(2))
+		Expect(fakeBuild.SaveDrainOffsetArgsForCall(0)).To(Equal(2))
+		Expect(fakeBuild.SaveDrainOffsetArgsForCall(1)).To(Equal(3))
+	})
+
+	Context("when the build is running", func() {
+		BeforeEach(func() {
+			fakeBuild.IsRunningReturns(true)
+		})
+
+		It("does not mark it as drained", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeBuild.MarkDrainedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the build has finished", func() {
+		BeforeEach(func() {
+			fakeBuild.IsRunningReturns(false)
+		})
+
+		It("marks it as drained", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeBuild.MarkDrainedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the syslog server cannot be reached", func() {
+		BeforeEach(func() {
+			listener.Close()
+		})
+
+		It("returns an error without saving a position", func() {
+			Expect(runErr).To(HaveOccurred())
+			Expect(fakeBuild.SaveDrainOffsetCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package syslog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// timestampFormat is RFC 3339 with at most the 6 fractional digits allowed
+// by RFC 5424.
+const timestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+type Severity int
+
+const (
+	SeverityError Severity = 3
+	SeverityInfo  Severity = 6
+)
+
+// facilityUser is the facility of every message; build output is user-level.
+const facilityUser = 1
+
+// Message is a single RFC 5424 syslog message.
+type Message struct {
+	Severity Severity
+
+	// Timestamp is sent as the nil value if it is zero.
+	Timestamp time.Time
+
+	Hostname string
+	AppName  string
+
+	// MsgID identifies the message's build event, so that a receiver can
+	// discard any message which is sent again.
+	MsgID string
+
+	// Params are the build metadata sent as structured data, in an element
+	// identified by StructuredDataID. They are not sent without an ID.
+	StructuredDataID string
+	Params           map[string]string
+
+	Text string
+}
+
+// Format renders the message as defined by RFC 5424, without framing.
+func (message Message) Format() string {
+	return fmt.Sprintf(
+		"<%d>1 %s %s %s - %s %s %s",
+		facilityUser*8+int(message.Severity),
+		message.timestamp(),
+		headerField(message.Hostname, 255),
+		headerField(message.AppName, 48),
+		headerField(message.MsgID, 32),
+		message.structuredData(),
+		message.Text,
+	)
+}
+
+func (message Message) timestamp() string {
+	if message.Timestamp.IsZero() {
+		return "-"
+	}
+
+	return message.Timestamp.UTC().Format(timestampFormat)
+}
+
+func (message Message) structuredData() string {
+	if message.StructuredDataID == "" || len(message.Params) == 0 {
+		return "-"
+	}
+
+	names := []string{}
+	for name := range message.Params {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	data := "[" + message.StructuredDataID
+	for _, name := range names {
+		data += fmt.Sprintf(` %s="%s"`, name, paramValueEscaper.Replace(message.Params[name]))
+	}
+
+	return data + "]"
+}
+
+var paramValueEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`, `]`, `\]`)
+
+// headerField replaces an empty header field with the nil value and limits
+// it to printable ASCII of the given length.
+func headerField(value string, maxLength int) string {
+	field := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+
+		return r
+	}, value)
+
+	if len(field) > maxLength {
+		field = field[:maxLength]
+	}
+
+	if field == "" {
+		return "-"
+	}
+
+	return field
+}
@@ -0,0 +1,83 @@
+package syslog_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/syslog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Message", func() {
+	var message syslog.Message
+
+	BeforeEach(func() {
+		message = syslog.Message{
+			Severity:  syslog.SeverityError,
+			Timestamp: time.Date(2018, 1, 2, 3, 4, 5, 600000000, time.UTC),
+			Hostname:  "some-host",
+			AppName:   "concourse",
+			MsgID:     "42/7",
+
+			StructuredDataID: "concourse@12345",
+			Params: map[string]string{
+				"team": "main",
+				"step": `quoted "step" [with] \brackets\`,
+			},
+			Text: "some output",
+		}
+	})
+
+	It("formats the message as RFC 5424 with escaped, sorted structured data", func() {
+		Expect(message.Format()).To(Equal(
+			`<11>1 2018-01-02T03:04:05.600000Z some-host concourse - 42/7 ` +
+				`[concourse@12345 step="quoted \"step\" [with\] \\brackets\\" team="main"] some output`,
+		))
+	})
+
+	Context("when header fields are empty or contain spaces", func() {
+		BeforeEach(func() {
+			message.Severity = syslog.SeverityInfo
+			message.Hostname = ""
+			message.AppName = "some app"
+			message.Params = nil
+		})
+
+		It("uses nil values and strips the spaces", func() {
+			Expect(message.Format()).To(Equal(
+				`<14>1 2018-01-02T03:04:05.600000Z - someapp - 42/7 - some output`,
+			))
+		})
+	})
+
+	Context("when the timestamp has more precision than RFC 5424 allows", func() {
+		BeforeEach(func() {
+			message.Timestamp = time.Date(2018, 1, 2, 3, 4, 5, 123456789, time.UTC)
+		})
+
+		It("truncates it to microseconds", func() {
+			Expect(message.Format()).To(HavePrefix(`<11>1 2018-01-02T03:04:05.123456Z `))
+		})
+	})
+
+	Context("when the timestamp is unknown", func() {
+		BeforeEach(func() {
+			message.Timestamp = time.Time{}
+		})
+
+		It("uses the nil value", func() {
+			Expect(message.Format()).To(HavePrefix(`<11>1 - some-host `))
+		})
+	})
+
+	Context("when there is no SD-ID", func() {
+		BeforeEach(func() {
+			message.StructuredDataID = ""
+		})
+
+		It("does not send the structured data", func() {
+			Expect(message.Format()).To(HaveSuffix(` 42/7 - some output`))
+		})
+	})
+})
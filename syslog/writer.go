@@ -0,0 +1,78 @@
+package syslog
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+)
+
+const dialTimeout = 10 * time.Second
+
+// writeTimeout bounds each write to the server, so that a server which stops
+// reading does not hang the drainer while it holds its lock.
+const writeTimeout = 30 * time.Second
+
+// Writer sends messages to a syslog server over TCP, framed by octet
+// counting as described in RFC 6587.
+type Writer struct {
+	conn   net.Conn
+	buffer *bufio.Writer
+}
+
+// Dial connects to the syslog server over "tcp", or "tls" using the given
+// configuration.
+func Dial(transport string, address string, tlsConfig *tls.Config) (*Writer, error) {
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	dialer := &net.Dialer{Timeout: dialTimeout}
+
+	switch transport {
+	case "tcp":
+		conn, err = dialer.Dial("tcp", address)
+	case "tls":
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	default:
+		return nil, fmt.Errorf("unknown syslog transport: %s", transport)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		conn:   conn,
+		buffer: bufio.NewWriter(conn),
+	}, nil
+}
+
+// Write buffers the message until the next Flush, or writes out the buffer
+// if it is full.
+func (writer *Writer) Write(message Message) error {
+	formatted := message.Format()
+
+	err := writer.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(writer.buffer, "%d %s", len(formatted), formatted)
+	return err
+}
+
+func (writer *Writer) Flush() error {
+	err := writer.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err != nil {
+		return err
+	}
+
+	return writer.buffer.Flush()
+}
+
+func (writer *Writer) Close() error {
+	return writer.conn.Close()
+}
@@ -34,6 +34,10 @@ type TaskConfig struct {
 
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []CacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
+
+	// Test reports written by the task to its outputs, which are summarized
+	// once the task finishes.
+	Reports []TaskReportConfig `json:"reports,omitempty" yaml:"reports,omitempty" mapstructure:"reports"`
 }
 
 type ImageResource struct {
@@ -131,6 +135,7 @@ func (config TaskConfig) Validate() error {
 	}
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
+	messages = append(messages, config.validateReports()...)
 
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
@@ -371,6 +376,67 @@ type MetadataField struct {
 	Value string `json:"value"`
 }
 
+const (
+	TaskReportFormatJUnit  = "junit"
+	TaskReportFormatGoTest = "go-test"
+)
+
+type TaskReportConfig struct {
+	// Path to the report, relative to the task's working directory. It must
+	// be within one of the task's outputs.
+	Path string `json:"path" yaml:"path" mapstructure:"path"`
+
+	// Format of the report, either junit (JUnit XML) or go-test (the output
+	// of go test -json).
+	Format string `json:"format" yaml:"format" mapstructure:"format"`
+}
+
+// ReportOutput returns the output the report is written to, and the report's
+// path within it.
+func (config TaskConfig) ReportOutput(report TaskReportConfig) (TaskOutputConfig, string, bool) {
+	reportPath := filepath.ToSlash(filepath.Clean(report.Path))
+	if filepath.IsAbs(report.Path) || reportPath == ".." || strings.HasPrefix(reportPath, "../") {
+		return TaskOutputConfig{}, "", false
+	}
+
+	for _, output := range config.Outputs {
+		outputPath := filepath.ToSlash(filepath.Clean(output.resolvePath()))
+
+		if outputPath == "." {
+			return output, reportPath, true
+		}
+
+		if strings.HasPrefix(reportPath, outputPath+"/") {
+			return output, strings.TrimPrefix(reportPath, outputPath+"/"), true
+		}
+	}
+
+	return TaskOutputConfig{}, "", false
+}
+
+func (config TaskConfig) validateReports() []string {
+	messages := []string{}
+
+	for i, report := range config.Reports {
+		if report.Path == "" {
+			messages = append(messages, fmt.Sprintf("  report in position %d is missing a path", i))
+			continue
+		}
+
+		switch report.Format {
+		case TaskReportFormatJUnit, TaskReportFormatGoTest:
+		default:
+			messages = append(messages, fmt.Sprintf("  report '%s' has an unknown format '%s' (must be '%s' or '%s')", report.Path, report.Format, TaskReportFormatJUnit, TaskReportFormatGoTest))
+		}
+
+		if _, _, found := config.ReportOutput(report); !found {
+			messages = append(messages, fmt.Sprintf("  report '%s' is not within any of the task's outputs", report.Path))
+		}
+	}
+
+	return messages
+}
+
 type CacheConfig struct {
 	Path string `json:"path,omitempty" yaml:"path,omitempty" mapstructure:"path"`
 }
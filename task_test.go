@@ -234,6 +234,56 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has reports", func() {
+			BeforeEach(func() {
+				validConfig.Outputs = append(validConfig.Outputs, TaskOutputConfig{Name: "results", Path: "out/results"})
+				validConfig.Reports = append(validConfig.Reports, TaskReportConfig{Path: "out/results/junit.xml", Format: "junit"})
+
+				invalidConfig.Outputs = append(invalidConfig.Outputs, TaskOutputConfig{Name: "results", Path: "out/results"})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			It("finds the output the report is written to", func() {
+				output, reportPath, found := validConfig.ReportOutput(validConfig.Reports[0])
+				Expect(found).To(BeTrue())
+				Expect(output.Name).To(Equal("results"))
+				Expect(reportPath).To(Equal("junit.xml"))
+			})
+
+			Context("when report.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = append(invalidConfig.Reports, TaskReportConfig{Format: "junit"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  report in position 0 is missing a path")))
+				})
+			})
+
+			Context("when report.format is unknown", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = append(invalidConfig.Reports, TaskReportConfig{Path: "out/results/report.txt", Format: "tap"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  report 'out/results/report.txt' has an unknown format 'tap' (must be 'junit' or 'go-test')")))
+				})
+			})
+
+			Context("when the report is not within an output", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = append(invalidConfig.Reports, TaskReportConfig{Path: "out/junit.xml", Format: "junit"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  report 'out/junit.xml' is not within any of the task's outputs")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
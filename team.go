@@ -1,6 +1,10 @@
 package atc
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
 
 type Team struct {
 	ID   int    `json:"id,omitempty"`
@@ -9,9 +13,134 @@ type Team struct {
 	BasicAuth *BasicAuth `json:"basic_auth,omitempty"`
 
 	Auth map[string]*json.RawMessage `json:"auth,omitempty"`
+
+	DefaultJobPriority *int `json:"default_job_priority,omitempty"`
+
+	Quota *TeamQuota `json:"quota,omitempty"`
 }
 
 type BasicAuth struct {
 	BasicAuthUsername string `json:"basic_auth_username,omitempty"`
 	BasicAuthPassword string `json:"basic_auth_password,omitempty"`
 }
+
+// TeamQuota limits how many builds a team may run concurrently and how many
+// active containers it may hold. A zero value means there is no limit, except
+// for MaxHeldContainers which then defaults to DefaultMaxHeldContainers.
+type TeamQuota struct {
+	MaxRunningBuilds int `json:"max_running_builds,omitempty"`
+	MaxContainers    int `json:"max_containers,omitempty"`
+
+	// MaxHeldContainers limits the containers of finished builds which are
+	// held for debugging.
+	MaxHeldContainers int `json:"max_held_containers,omitempty"`
+
+	Pipelines map[string]PipelineQuota `json:"pipelines,omitempty"`
+}
+
+type PipelineQuota struct {
+	MaxRunningBuilds int `json:"max_running_builds,omitempty"`
+}
+
+// DefaultMaxHeldContainers is the number of containers a team may hold when
+// its quota does not say otherwise.
+const DefaultMaxHeldContainers = 20
+
+type TeamQuotaUsage struct {
+	RunningBuilds  int `json:"running_builds"`
+	Containers     int `json:"containers"`
+	HeldContainers int `json:"held_containers"`
+
+	Pipelines map[string]PipelineQuotaUsage `json:"pipelines,omitempty"`
+}
+
+type PipelineQuotaUsage struct {
+	RunningBuilds int `json:"running_builds"`
+}
+
+type TeamQuotaStatus struct {
+	Quota TeamQuota      `json:"quota"`
+	Usage TeamQuotaUsage `json:"usage"`
+}
+
+// Reached reports whether starting another build of the given pipeline would
+// exceed the quota.
+func (quota TeamQuota) Reached(pipelineName string, usage TeamQuotaUsage) bool {
+	if quota.MaxRunningBuilds > 0 && usage.RunningBuilds >= quota.MaxRunningBuilds {
+		return true
+	}
+
+	if quota.MaxContainers > 0 && usage.Containers >= quota.MaxContainers {
+		return true
+	}
+
+	pipelineQuota, found := quota.Pipelines[pipelineName]
+	if found && pipelineQuota.MaxRunningBuilds > 0 {
+		if usage.Pipelines[pipelineName].RunningBuilds >= pipelineQuota.MaxRunningBuilds {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Saturated reports whether the quota leaves room for no more than the given
+// number of builds to start, counting each build as at least one container.
+func (quota TeamQuota) Saturated(usage TeamQuotaUsage, builds int) bool {
+	if quota.MaxRunningBuilds > 0 && quota.MaxRunningBuilds-usage.RunningBuilds <= builds {
+		return true
+	}
+
+	if quota.MaxContainers > 0 && quota.MaxContainers-usage.Containers <= builds {
+		return true
+	}
+
+	return false
+}
+
+func (quota TeamQuota) Validate() error {
+	if quota.MaxRunningBuilds < 0 {
+		return errors.New("max_running_builds must not be negative")
+	}
+
+	if quota.MaxContainers < 0 {
+		return errors.New("max_containers must not be negative")
+	}
+
+	if quota.MaxHeldContainers < 0 {
+		return errors.New("max_held_containers must not be negative")
+	}
+
+	for pipelineName, pipelineQuota := range quota.Pipelines {
+		if pipelineQuota.MaxRunningBuilds < 0 {
+			return fmt.Errorf("max_running_builds of pipeline '%s' must not be negative", pipelineName)
+		}
+	}
+
+	return nil
+}
+
+// IsZero reports whether the quota imposes no limits on starting builds. The
+// limit on held containers is checked on its own, when they are held.
+func (quota TeamQuota) IsZero() bool {
+	if quota.MaxRunningBuilds > 0 || quota.MaxContainers > 0 {
+		return false
+	}
+
+	for _, pipelineQuota := range quota.Pipelines {
+		if pipelineQuota.MaxRunningBuilds > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HeldContainersLimit returns the number of containers the team may hold.
+func (quota TeamQuota) HeldContainersLimit() int {
+	if quota.MaxHeldContainers > 0 {
+		return quota.MaxHeldContainers
+	}
+
+	return DefaultMaxHeldContainers
+}
@@ -0,0 +1,167 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamQuota", func() {
+	var quota atc.TeamQuota
+
+	BeforeEach(func() {
+		quota = atc.TeamQuota{}
+	})
+
+	Describe("IsZero", func() {
+		It("is true when nothing is limited", func() {
+			Expect(quota.IsZero()).To(BeTrue())
+		})
+
+		It("is true when only unlimited pipelines are configured", func() {
+			quota.Pipelines = map[string]atc.PipelineQuota{"some-pipeline": {}}
+			Expect(quota.IsZero()).To(BeTrue())
+		})
+
+		It("is false when running builds are limited", func() {
+			quota.MaxRunningBuilds = 1
+			Expect(quota.IsZero()).To(BeFalse())
+		})
+
+		It("is false when containers are limited", func() {
+			quota.MaxContainers = 1
+			Expect(quota.IsZero()).To(BeFalse())
+		})
+
+		It("is true when only held containers are limited", func() {
+			quota.MaxHeldContainers = 1
+			Expect(quota.IsZero()).To(BeTrue())
+		})
+
+		It("is false when a pipeline is limited", func() {
+			quota.Pipelines = map[string]atc.PipelineQuota{
+				"some-pipeline": {MaxRunningBuilds: 1},
+			}
+			Expect(quota.IsZero()).To(BeFalse())
+		})
+	})
+
+	Describe("Validate", func() {
+		It("accepts an empty quota", func() {
+			Expect(quota.Validate()).To(Succeed())
+		})
+
+		It("rejects negative running builds", func() {
+			quota.MaxRunningBuilds = -1
+			Expect(quota.Validate()).NotTo(Succeed())
+		})
+
+		It("rejects negative containers", func() {
+			quota.MaxContainers = -1
+			Expect(quota.Validate()).NotTo(Succeed())
+		})
+
+		It("rejects negative held containers", func() {
+			quota.MaxHeldContainers = -1
+			Expect(quota.Validate()).To(MatchError("max_held_containers must not be negative"))
+		})
+
+		It("rejects negative pipeline running builds", func() {
+			quota.Pipelines = map[string]atc.PipelineQuota{
+				"some-pipeline": {MaxRunningBuilds: -1},
+			}
+			Expect(quota.Validate()).To(MatchError("max_running_builds of pipeline 'some-pipeline' must not be negative"))
+		})
+	})
+
+	Describe("Reached", func() {
+		var usage atc.TeamQuotaUsage
+
+		BeforeEach(func() {
+			usage = atc.TeamQuotaUsage{
+				RunningBuilds: 5,
+				Containers:    20,
+				Pipelines: map[string]atc.PipelineQuotaUsage{
+					"some-pipeline": {RunningBuilds: 3},
+				},
+			}
+		})
+
+		It("is not reached when nothing is limited", func() {
+			Expect(quota.Reached("some-pipeline", usage)).To(BeFalse())
+		})
+
+		It("is reached when the team is running its maximum number of builds", func() {
+			quota.MaxRunningBuilds = 5
+			Expect(quota.Reached("some-pipeline", usage)).To(BeTrue())
+		})
+
+		It("is not reached when the team is under its maximum number of builds", func() {
+			quota.MaxRunningBuilds = 6
+			Expect(quota.Reached("some-pipeline", usage)).To(BeFalse())
+		})
+
+		It("is reached when the team holds its maximum number of containers", func() {
+			quota.MaxContainers = 20
+			Expect(quota.Reached("some-pipeline", usage)).To(BeTrue())
+		})
+
+		Context("when the pipeline is limited", func() {
+			BeforeEach(func() {
+				quota.Pipelines = map[string]atc.PipelineQuota{
+					"some-pipeline": {MaxRunningBuilds: 3},
+				}
+			})
+
+			It("is reached for that pipeline", func() {
+				Expect(quota.Reached("some-pipeline", usage)).To(BeTrue())
+			})
+
+			It("is not reached for other pipelines", func() {
+				Expect(quota.Reached("some-other-pipeline", usage)).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Saturated", func() {
+		var usage atc.TeamQuotaUsage
+
+		BeforeEach(func() {
+			usage = atc.TeamQuotaUsage{
+				RunningBuilds: 5,
+				Containers:    20,
+			}
+		})
+
+		It("is not saturated when nothing is limited", func() {
+			Expect(quota.Saturated(usage, 3)).To(BeFalse())
+		})
+
+		It("is saturated when no more builds than the given number can start", func() {
+			quota.MaxRunningBuilds = 8
+			Expect(quota.Saturated(usage, 3)).To(BeTrue())
+		})
+
+		It("is not saturated when more builds than the given number can start", func() {
+			quota.MaxRunningBuilds = 9
+			Expect(quota.Saturated(usage, 3)).To(BeFalse())
+		})
+
+		It("is saturated when no more containers than the given number are left", func() {
+			quota.MaxContainers = 23
+			Expect(quota.Saturated(usage, 3)).To(BeTrue())
+		})
+	})
+
+	Describe("HeldContainersLimit", func() {
+		It("defaults when held containers are not limited", func() {
+			Expect(quota.HeldContainersLimit()).To(Equal(atc.DefaultMaxHeldContainers))
+		})
+
+		It("returns the configured limit", func() {
+			quota.MaxHeldContainers = 3
+			Expect(quota.HeldContainersLimit()).To(Equal(3))
+		})
+	})
+})
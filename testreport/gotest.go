@@ -0,0 +1,88 @@
+package testreport
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// goTestEvent is a line of the output of go test -json.
+type goTestEvent struct {
+	Action  string
+	Package string
+	Test    string
+}
+
+func (event goTestEvent) name() string {
+	return event.Package + "." + event.Test
+}
+
+// parentOf returns whether the event is of one of the subtests of the given
+// event's test.
+func (event goTestEvent) parentOf(other goTestEvent) bool {
+	return event.Package == other.Package && strings.HasPrefix(other.Test, event.Test+"/")
+}
+
+func parseGoTest(report io.Reader) (Summary, error) {
+	summary := Summary{
+		Passes:   []string{},
+		Failures: []string{},
+	}
+
+	failures := []goTestEvent{}
+
+	decoder := json.NewDecoder(report)
+
+	for {
+		var event goTestEvent
+		err := decoder.Decode(&event)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return Summary{}, err
+		}
+
+		// package-level events have no test
+		if event.Test == "" {
+			continue
+		}
+
+		switch event.Action {
+		case "pass":
+			summary.Total++
+			summary.Passed++
+			summary.Passes = append(summary.Passes, event.name())
+		case "fail":
+			failures = append(failures, event)
+		case "skip":
+			summary.Total++
+			summary.Skipped++
+		}
+	}
+
+	// a test fails along with any of its subtests, in which case only the
+	// subtests are counted
+	for _, failure := range failures {
+		if failedSubtest(failure, failures) {
+			continue
+		}
+
+		summary.Total++
+		summary.Failed++
+		summary.Failures = append(summary.Failures, failure.name())
+	}
+
+	return summary, nil
+}
+
+func failedSubtest(parent goTestEvent, failures []goTestEvent) bool {
+	for _, failure := range failures {
+		if parent.parentOf(failure) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// junitSuite is either a <testsuites> or a <testsuite> element, which may be
+// nested in each other.
+type junitSuite struct {
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string    `xml:"name,attr"`
+	ClassName string    `xml:"classname,attr"`
+	Failure   *struct{} `xml:"failure"`
+	Error     *struct{} `xml:"error"`
+	Skipped   *struct{} `xml:"skipped"`
+}
+
+func parseJUnit(report io.Reader) (Summary, error) {
+	var root junitSuite
+	err := xml.NewDecoder(report).Decode(&root)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	return root.summary(), nil
+}
+
+func (suite junitSuite) summary() Summary {
+	summary := Summary{
+		Passes:   []string{},
+		Failures: []string{},
+	}
+
+	for _, testCase := range suite.Cases {
+		summary.Total++
+
+		switch {
+		case testCase.Failure != nil || testCase.Error != nil:
+			summary.Failed++
+			summary.Failures = append(summary.Failures, testCase.name())
+		case testCase.Skipped != nil:
+			summary.Skipped++
+		default:
+			summary.Passed++
+			summary.Passes = append(summary.Passes, testCase.name())
+		}
+	}
+
+	for _, nested := range suite.Suites {
+		summary = summary.Merge(nested.summary())
+	}
+
+	return summary
+}
+
+func (testCase junitCase) name() string {
+	if testCase.ClassName == "" {
+		return testCase.Name
+	}
+
+	return testCase.ClassName + "." + testCase.Name
+}
@@ -0,0 +1,54 @@
+// Package testreport summarizes the reports written by test runners.
+package testreport
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/concourse/atc"
+)
+
+type Summary struct {
+	Total   int
+	Passed  int
+	Failed  int
+	Skipped int
+
+	// Passes and Failures are the names of the tests which passed and failed.
+	Passes   []string
+	Failures []string
+}
+
+func (summary Summary) Merge(other Summary) Summary {
+	passes := append([]string{}, summary.Passes...)
+	failures := append([]string{}, summary.Failures...)
+
+	return Summary{
+		Total:    summary.Total + other.Total,
+		Passed:   summary.Passed + other.Passed,
+		Failed:   summary.Failed + other.Failed,
+		Skipped:  summary.Skipped + other.Skipped,
+		Passes:   append(passes, other.Passes...),
+		Failures: append(failures, other.Failures...),
+	}
+}
+
+type UnknownFormatError struct {
+	Format string
+}
+
+func (err UnknownFormatError) Error() string {
+	return fmt.Sprintf("unknown test report format: %s", err.Format)
+}
+
+// Parse summarizes a report in one of the formats tasks can declare.
+func Parse(format string, report io.Reader) (Summary, error) {
+	switch format {
+	case atc.TaskReportFormatJUnit:
+		return parseJUnit(report)
+	case atc.TaskReportFormatGoTest:
+		return parseGoTest(report)
+	default:
+		return Summary{}, UnknownFormatError{Format: format}
+	}
+}
@@ -0,0 +1,142 @@
+package testreport_test
+
+import (
+	"strings"
+
+	"github.com/concourse/atc/testreport"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parse", func() {
+	var (
+		format string
+		report string
+
+		summary  testreport.Summary
+		parseErr error
+	)
+
+	JustBeforeEach(func() {
+		summary, parseErr = testreport.Parse(format, strings.NewReader(report))
+	})
+
+	Context("with a JUnit report", func() {
+		BeforeEach(func() {
+			format = "junit"
+			report = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+	<testsuite name="some-suite">
+		<testcase classname="some.Class" name="passes"></testcase>
+		<testcase classname="some.Class" name="fails">
+			<failure message="expected true">stack</failure>
+		</testcase>
+		<testcase classname="some.Class" name="skips">
+			<skipped/>
+		</testcase>
+	</testsuite>
+	<testsuite name="some-other-suite">
+		<testcase name="errors">
+			<error message="panic"/>
+		</testcase>
+	</testsuite>
+</testsuites>`
+		})
+
+		It("summarizes the test cases of every suite", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(summary).To(Equal(testreport.Summary{
+				Total:    4,
+				Passed:   1,
+				Failed:   2,
+				Skipped:  1,
+				Passes:   []string{"some.Class.passes"},
+				Failures: []string{"some.Class.fails", "errors"},
+			}))
+		})
+
+		Context("when the root element is a single suite", func() {
+			BeforeEach(func() {
+				report = `<testsuite name="some-suite"><testcase name="passes"/></testsuite>`
+			})
+
+			It("summarizes its test cases", func() {
+				Expect(parseErr).NotTo(HaveOccurred())
+				Expect(summary.Total).To(Equal(1))
+				Expect(summary.Passed).To(Equal(1))
+			})
+		})
+
+		Context("when the report is not XML", func() {
+			BeforeEach(func() {
+				report = "not xml"
+			})
+
+			It("returns an error", func() {
+				Expect(parseErr).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("with a go test -json report", func() {
+		BeforeEach(func() {
+			format = "go-test"
+			report = `{"Action":"run","Package":"some/pkg","Test":"TestPasses"}
+{"Action":"output","Package":"some/pkg","Test":"TestPasses","Output":"=== RUN   TestPasses\n"}
+{"Action":"pass","Package":"some/pkg","Test":"TestPasses","Elapsed":0.01}
+{"Action":"run","Package":"some/pkg","Test":"TestFails"}
+{"Action":"fail","Package":"some/pkg","Test":"TestFails","Elapsed":0.01}
+{"Action":"skip","Package":"some/pkg","Test":"TestSkips","Elapsed":0}
+{"Action":"fail","Package":"some/pkg","Elapsed":0.02}
+`
+		})
+
+		It("summarizes the test events, ignoring package events", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(summary).To(Equal(testreport.Summary{
+				Total:    3,
+				Passed:   1,
+				Failed:   1,
+				Skipped:  1,
+				Passes:   []string{"some/pkg.TestPasses"},
+				Failures: []string{"some/pkg.TestFails"},
+			}))
+		})
+
+		Context("when only the subtests of a test fail", func() {
+			BeforeEach(func() {
+				report = `{"Action":"run","Package":"some/pkg","Test":"TestParent"}
+{"Action":"run","Package":"some/pkg","Test":"TestParent/passes"}
+{"Action":"run","Package":"some/pkg","Test":"TestParent/fails"}
+{"Action":"pass","Package":"some/pkg","Test":"TestParent/passes","Elapsed":0}
+{"Action":"fail","Package":"some/pkg","Test":"TestParent/fails","Elapsed":0}
+{"Action":"fail","Package":"some/pkg","Test":"TestParent","Elapsed":0.01}
+{"Action":"fail","Package":"some/pkg","Test":"TestParentless","Elapsed":0.01}
+`
+			})
+
+			It("does not count the test as a failure", func() {
+				Expect(parseErr).NotTo(HaveOccurred())
+				Expect(summary).To(Equal(testreport.Summary{
+					Total:    3,
+					Passed:   1,
+					Failed:   2,
+					Passes:   []string{"some/pkg.TestParent/passes"},
+					Failures: []string{"some/pkg.TestParent/fails", "some/pkg.TestParentless"},
+				}))
+			})
+		})
+	})
+
+	Context("with an unknown format", func() {
+		BeforeEach(func() {
+			format = "tap"
+			report = ""
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(Equal(testreport.UnknownFormatError{Format: "tap"}))
+		})
+	})
+})
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"net"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// Config configures the export of spans to an OpenTelemetry collector over
+// OTLP/HTTP.
+type Config struct {
+	OTLPAddress  string            `long:"otlp-address"  description:"host:port of an OTLP/HTTP endpoint to export trace spans to, usually on port 4318."`
+	OTLPInsecure bool              `long:"otlp-insecure" description:"Connect to the OTLP endpoint over plain HTTP rather than HTTPS."`
+	OTLPHeaders  map[string]string `long:"otlp-header"   description:"A header to send to the OTLP endpoint, e.g. for authentication. Can be specified multiple times." value-name:"NAME:VALUE"`
+
+	ServiceName string `long:"service-name" default:"concourse-atc" description:"Service name to attach to exported trace spans."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.OTLPAddress != ""
+}
+
+// Prepare starts exporting spans to the configured endpoint. It does nothing
+// unless an OTLP address is configured.
+func (config Config) Prepare(logger lager.Logger) error {
+	if !config.IsConfigured() {
+		return nil
+	}
+
+	_, _, err := net.SplitHostPort(config.OTLPAddress)
+	if err != nil {
+		return err
+	}
+
+	scheme := "https"
+	if config.OTLPInsecure {
+		scheme = "http"
+	}
+
+	exporter := NewOTLPExporter(
+		logger,
+		scheme+"://"+config.OTLPAddress+"/v1/traces",
+		config.OTLPHeaders,
+		config.ServiceName,
+		DefaultFlushInterval,
+	)
+
+	go exporter.Run()
+
+	SetExporter(exporter)
+
+	return nil
+}
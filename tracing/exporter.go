@@ -0,0 +1,42 @@
+package tracing
+
+import "sync"
+
+// Exporter sends spans on to be collected as they end.
+type Exporter interface {
+	ExportSpan(span *Span)
+}
+
+var (
+	exporter     Exporter
+	exporterLock sync.RWMutex
+)
+
+// SetExporter installs the exporter ended spans are sent to, returning the
+// one it replaces.
+func SetExporter(newExporter Exporter) Exporter {
+	exporterLock.Lock()
+	defer exporterLock.Unlock()
+
+	previous := exporter
+	exporter = newExporter
+
+	return previous
+}
+
+func exporting() bool {
+	exporterLock.RLock()
+	defer exporterLock.RUnlock()
+
+	return exporter != nil
+}
+
+func export(span *Span) {
+	exporterLock.RLock()
+	current := exporter
+	exporterLock.RUnlock()
+
+	if current != nil {
+		current.ExportSpan(span)
+	}
+}
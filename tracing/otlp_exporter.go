@@ -0,0 +1,240 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	// DefaultFlushInterval is how long ended spans are held to be sent in one
+	// batch.
+	DefaultFlushInterval = 5 * time.Second
+
+	otlpBatchSize = 512
+	otlpQueueSize = 2048
+)
+
+// OTLPExporter sends spans in batches to an OTLP/HTTP endpoint, encoded as
+// JSON. Spans which end while the queue is full are dropped rather than
+// holding up the work they trace.
+type OTLPExporter struct {
+	logger        lager.Logger
+	url           string
+	headers       map[string]string
+	serviceName   string
+	flushInterval time.Duration
+
+	client *http.Client
+	spans  chan *Span
+}
+
+// NewOTLPExporter constructs an exporter which sends spans to the traces
+// endpoint at the given URL, e.g. http://collector:4318/v1/traces. Run must be
+// called for them to be sent.
+func NewOTLPExporter(logger lager.Logger, url string, headers map[string]string, serviceName string, flushInterval time.Duration) *OTLPExporter {
+	return &OTLPExporter{
+		logger:        logger,
+		url:           url,
+		headers:       headers,
+		serviceName:   serviceName,
+		flushInterval: flushInterval,
+
+		client: &http.Client{Timeout: 30 * time.Second},
+		spans:  make(chan *Span, otlpQueueSize),
+	}
+}
+
+func (exporter *OTLPExporter) ExportSpan(span *Span) {
+	select {
+	case exporter.spans <- span:
+	default:
+		exporter.logger.Debug("dropped-span", lager.Data{"name": span.Name})
+	}
+}
+
+// Run sends the exported spans until the process exits.
+func (exporter *OTLPExporter) Run() {
+	ticker := time.NewTicker(exporter.flushInterval)
+	defer ticker.Stop()
+
+	batch := []*Span{}
+
+	for {
+		select {
+		case span := <-exporter.spans:
+			batch = append(batch, span)
+			if len(batch) < otlpBatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		err := exporter.send(batch)
+		if err != nil {
+			exporter.logger.Error("failed-to-send-spans", err, lager.Data{"spans": len(batch)})
+		}
+
+		batch = []*Span{}
+	}
+}
+
+func (exporter *OTLPExporter) send(batch []*Span) error {
+	payload, err := json.Marshal(exporter.request(batch))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", exporter.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for name, value := range exporter.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := exporter.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response from OTLP endpoint: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// The types below are the JSON encoding of an OTLP ExportTraceServiceRequest.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    string  `json:"intValue,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+
+	otlpStatusCodeError = 2
+)
+
+func (exporter *OTLPExporter) request(batch []*Span) otlpRequest {
+	spans := []otlpSpan{}
+	for _, span := range batch {
+		encoded := otlpSpan{
+			TraceID:           span.Context.TraceID.String(),
+			SpanID:            span.Context.SpanID.String(),
+			Name:              span.Name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+			Attributes:        []otlpKeyValue{},
+		}
+
+		if span.ParentID != (SpanID{}) {
+			encoded.ParentSpanID = span.ParentID.String()
+		}
+
+		for key, value := range span.Attrs {
+			encoded.Attributes = append(encoded.Attributes, otlpString(key, value))
+		}
+
+		if span.StatusCode != 0 {
+			encoded.Kind = otlpSpanKindServer
+			encoded.Attributes = append(encoded.Attributes, otlpKeyValue{
+				Key:   "http.status_code",
+				Value: otlpAnyValue{IntValue: strconv.Itoa(span.StatusCode)},
+			})
+		}
+
+		if span.Error != "" {
+			encoded.Status = otlpStatus{
+				Code:    otlpStatusCodeError,
+				Message: span.Error,
+			}
+		}
+
+		spans = append(spans, encoded)
+	}
+
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpKeyValue{otlpString("service.name", exporter.serviceName)},
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: "github.com/concourse/atc"},
+						Spans: spans,
+					},
+				},
+			},
+		},
+	}
+}
+
+func otlpString(key string, value string) otlpKeyValue {
+	return otlpKeyValue{
+		Key:   key,
+		Value: otlpAnyValue{StringValue: &value},
+	}
+}
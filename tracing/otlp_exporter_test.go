@@ -0,0 +1,116 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/tracing"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OTLPExporter", func() {
+	type exportedSpan struct {
+		TraceID      string `json:"traceId"`
+		SpanID       string `json:"spanId"`
+		ParentSpanID string `json:"parentSpanId"`
+		Name         string `json:"name"`
+		Attributes   []struct {
+			Key   string `json:"key"`
+			Value struct {
+				StringValue string `json:"stringValue"`
+			} `json:"value"`
+		} `json:"attributes"`
+		Status struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"status"`
+	}
+
+	type exportRequest struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []exportedSpan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+
+	var (
+		server           *ghttp.Server
+		requests         chan exportRequest
+		exporter         *tracing.OTLPExporter
+		previousExporter tracing.Exporter
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		requests = make(chan exportRequest, 10)
+
+		server.RouteToHandler("POST", "/v1/traces", ghttp.CombineHandlers(
+			ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+			ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+			func(w http.ResponseWriter, r *http.Request) {
+				var request exportRequest
+				err := json.NewDecoder(r.Body).Decode(&request)
+				Expect(err).NotTo(HaveOccurred())
+
+				requests <- request
+			},
+		))
+
+		exporter = tracing.NewOTLPExporter(
+			lagertest.NewTestLogger("test"),
+			server.URL()+"/v1/traces",
+			map[string]string{"Authorization": "Bearer some-token"},
+			"some-service",
+			10*time.Millisecond,
+		)
+
+		previousExporter = tracing.SetExporter(exporter)
+	})
+
+	AfterEach(func() {
+		tracing.SetExporter(previousExporter)
+		server.Close()
+	})
+
+	It("sends the ended spans to the endpoint in a batch", func() {
+		ctx, parent := tracing.StartSpan(context.Background(), "some-component", tracing.Attrs{"some": "attr"})
+		_, child := tracing.StartSpan(ctx, "some-other-component", nil)
+
+		tracing.End(child, errors.New("nope"))
+		tracing.End(parent, nil)
+
+		go exporter.Run()
+
+		var request exportRequest
+		Eventually(requests).Should(Receive(&request))
+
+		Expect(request.ResourceSpans).To(HaveLen(1))
+		Expect(request.ResourceSpans[0].ScopeSpans).To(HaveLen(1))
+
+		spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+		Expect(spans).To(HaveLen(2))
+
+		Expect(spans[0].Name).To(Equal("some-other-component"))
+		Expect(spans[0].TraceID).To(Equal(parent.Context.TraceID.String()))
+		Expect(spans[0].ParentSpanID).To(Equal(parent.Context.SpanID.String()))
+		Expect(spans[0].Status.Code).To(Equal(2))
+		Expect(spans[0].Status.Message).To(Equal("nope"))
+
+		Expect(spans[1].Name).To(Equal("some-component"))
+		Expect(spans[1].SpanID).To(Equal(parent.Context.SpanID.String()))
+		Expect(spans[1].ParentSpanID).To(BeEmpty())
+		Expect(spans[1].Attributes).To(HaveLen(1))
+		Expect(spans[1].Attributes[0].Key).To(Equal("some"))
+		Expect(spans[1].Attributes[0].Value.StringValue).To(Equal("attr"))
+		Expect(spans[1].Status.Code).To(BeZero())
+	})
+})
@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// Builds and their steps are run in different places from the work done for
+// them, e.g. creating containers on workers, which is too far down the stack
+// to be handed a context. Their spans are registered here while they run so
+// that such work may still be traced as part of them.
+
+var (
+	contexts     = map[string]context.Context{}
+	contextsLock sync.RWMutex
+)
+
+// RegisterBuild records the context of a running build's span.
+func RegisterBuild(buildID int, ctx context.Context) {
+	register(buildKey(buildID), ctx)
+}
+
+// ForgetBuild removes the context of a build which is no longer running.
+func ForgetBuild(buildID int) {
+	forget(buildKey(buildID))
+}
+
+// BuildContext returns the context of a running build's span, or a background
+// context if the build is not running here.
+func BuildContext(buildID int) context.Context {
+	return lookup(buildKey(buildID))
+}
+
+// RegisterStep records the context of the span of a running build step,
+// identified by the ID of its plan.
+func RegisterStep(buildID int, planID string, ctx context.Context) {
+	register(stepKey(buildID, planID), ctx)
+}
+
+// ForgetStep removes the context of a step which is no longer running.
+func ForgetStep(buildID int, planID string) {
+	forget(stepKey(buildID, planID))
+}
+
+// StepContext returns the context of a running step's span, falling back on
+// that of its build.
+func StepContext(buildID int, planID string) context.Context {
+	contextsLock.RLock()
+	ctx, found := contexts[stepKey(buildID, planID)]
+	contextsLock.RUnlock()
+
+	if !found {
+		return BuildContext(buildID)
+	}
+
+	return ctx
+}
+
+func buildKey(buildID int) string {
+	return strconv.Itoa(buildID)
+}
+
+func stepKey(buildID int, planID string) string {
+	return strconv.Itoa(buildID) + "/" + planID
+}
+
+func register(key string, ctx context.Context) {
+	contextsLock.Lock()
+	contexts[key] = ctx
+	contextsLock.Unlock()
+}
+
+func forget(key string) {
+	contextsLock.Lock()
+	delete(contexts, key)
+	contextsLock.Unlock()
+}
+
+func lookup(key string) context.Context {
+	contextsLock.RLock()
+	defer contextsLock.RUnlock()
+
+	ctx, found := contexts[key]
+	if !found {
+		return context.Background()
+	}
+
+	return ctx
+}
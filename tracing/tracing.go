@@ -0,0 +1,215 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Attrs are the attributes recorded on a span.
+type Attrs map[string]string
+
+type TraceID [16]byte
+
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+
+type SpanID [8]byte
+
+func (id SpanID) String() string { return hex.EncodeToString(id[:]) }
+
+// SpanContext identifies a span within its trace, as propagated between
+// processes.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// Span is the work done by a component for a build, a step or a request.
+type Span struct {
+	Name     string
+	Context  SpanContext
+	ParentID SpanID
+	Attrs    Attrs
+
+	// StatusCode is the status of the response to a request handled by the
+	// API, if the span is of one.
+	StatusCode int
+
+	// Error is the reason the work failed, if it did.
+	Error string
+
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a context in which spans are started as
+// children of the given span.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span in the context, which is invalid
+// if there is none.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	sc, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc
+}
+
+// StartSpan starts a span named after the component doing the work, as a
+// child of any span in the given context.
+//
+// Until an Exporter is installed, e.g. by Prepare, spans are not recorded and
+// only pass on the span in the given context, if any.
+func StartSpan(ctx context.Context, component string, attrs Attrs) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	span := &Span{
+		Name:      component,
+		Attrs:     Attrs{},
+		StartTime: time.Now(),
+	}
+
+	for key, value := range attrs {
+		span.Attrs[key] = value
+	}
+
+	parent := SpanContextFromContext(ctx)
+
+	if !exporting() {
+		span.Context = parent
+		span.Context.Sampled = false
+		return ctx, span
+	}
+
+	if parent.IsValid() {
+		span.Context.TraceID = parent.TraceID
+		span.Context.Sampled = parent.Sampled
+		span.ParentID = parent.SpanID
+	} else {
+		rand.Read(span.Context.TraceID[:])
+		span.Context.Sampled = true
+	}
+
+	rand.Read(span.Context.SpanID[:])
+
+	return ContextWithSpanContext(ctx, span.Context), span
+}
+
+// End ends the span, marking it as failed if err is non-nil.
+func End(span *Span, err error) {
+	if err != nil {
+		span.Error = err.Error()
+	}
+
+	end(span)
+}
+
+// EndRequest ends the span of a request handled by the API, recording the
+// status code of the response and marking it as failed on a server error.
+func EndRequest(span *Span, statusCode int) {
+	span.StatusCode = statusCode
+
+	if statusCode >= http.StatusInternalServerError {
+		span.Error = http.StatusText(statusCode)
+	}
+
+	end(span)
+}
+
+func end(span *Span) {
+	span.EndTime = time.Now()
+
+	if span.Context.Sampled {
+		export(span)
+	}
+}
+
+// Env returns the environment variables which propagate the span in the
+// given context to a process, per the W3C Trace Context spec, so that it may
+// report its own spans as children.
+func Env(ctx context.Context) []string {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+
+	return []string{"TRACEPARENT=" + TraceParent(sc)}
+}
+
+// RequestContext returns the request's context along with any span context
+// propagated by the client in its traceparent header.
+func RequestContext(r *http.Request) context.Context {
+	sc, ok := ParseTraceParent(r.Header.Get("traceparent"))
+	if !ok {
+		return r.Context()
+	}
+
+	return ContextWithSpanContext(r.Context(), sc)
+}
+
+// TraceParent formats the span as a W3C Trace Context traceparent.
+func TraceParent(sc SpanContext) string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceParent parses a W3C Trace Context traceparent, returning false
+// if it is malformed.
+func ParseTraceParent(traceParent string) (SpanContext, bool) {
+	parts := strings.Split(traceParent, "-")
+	if len(parts) < 4 {
+		return SpanContext{}, false
+	}
+
+	version, err := hex.DecodeString(parts[0])
+	if err != nil || len(version) != 1 || version[0] == 0xff {
+		return SpanContext{}, false
+	}
+
+	// only the first version may not have more fields
+	if version[0] == 0 && len(parts) != 4 {
+		return SpanContext{}, false
+	}
+
+	var sc SpanContext
+
+	if !decodeID(parts[1], sc.TraceID[:]) || !decodeID(parts[2], sc.SpanID[:]) {
+		return SpanContext{}, false
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 {
+		return SpanContext{}, false
+	}
+
+	sc.Sampled = flags[0]&1 == 1
+
+	return sc, sc.IsValid()
+}
+
+func decodeID(encoded string, id []byte) bool {
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil || len(decoded) != len(id) || strings.ToLower(encoded) != encoded {
+		return false
+	}
+
+	copy(id, decoded)
+
+	return true
+}
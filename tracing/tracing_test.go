@@ -0,0 +1,230 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/concourse/atc/tracing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordingExporter struct {
+	spans []*tracing.Span
+	lock  sync.Mutex
+}
+
+func (exporter *recordingExporter) ExportSpan(span *tracing.Span) {
+	exporter.lock.Lock()
+	exporter.spans = append(exporter.spans, span)
+	exporter.lock.Unlock()
+}
+
+func (exporter *recordingExporter) Spans() []*tracing.Span {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+
+	return append([]*tracing.Span{}, exporter.spans...)
+}
+
+var _ = Describe("Tracing", func() {
+	var spanCtx context.Context
+
+	BeforeEach(func() {
+		sc, ok := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		Expect(ok).To(BeTrue())
+
+		spanCtx = tracing.ContextWithSpanContext(context.Background(), sc)
+	})
+
+	Describe("Env", func() {
+		It("propagates the span in the context", func() {
+			Expect(tracing.Env(spanCtx)).To(Equal([]string{
+				"TRACEPARENT=00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			}))
+		})
+
+		Context("when the context has no span", func() {
+			It("returns no variables", func() {
+				Expect(tracing.Env(context.Background())).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ParseTraceParent", func() {
+		It("rejects malformed traceparents", func() {
+			for _, traceParent := range []string{
+				"",
+				"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+				"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+				"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+				"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+				"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+				"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902-01",
+			} {
+				_, ok := tracing.ParseTraceParent(traceParent)
+				Expect(ok).To(BeFalse(), traceParent)
+			}
+		})
+
+		It("reads whether the trace is sampled", func() {
+			sc, ok := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+			Expect(ok).To(BeTrue())
+			Expect(sc.Sampled).To(BeFalse())
+		})
+	})
+
+	Describe("RequestContext", func() {
+		It("continues the trace propagated by the client", func() {
+			request, err := http.NewRequest("GET", "/api/v1/info", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+			Expect(tracing.SpanContextFromContext(tracing.RequestContext(request))).To(Equal(tracing.SpanContextFromContext(spanCtx)))
+		})
+	})
+
+	Describe("StartSpan", func() {
+		var (
+			exporter         *recordingExporter
+			previousExporter tracing.Exporter
+		)
+
+		BeforeEach(func() {
+			exporter = &recordingExporter{}
+			previousExporter = tracing.SetExporter(exporter)
+		})
+
+		AfterEach(func() {
+			tracing.SetExporter(previousExporter)
+		})
+
+		It("starts the span within the trace in the context", func() {
+			ctx, span := tracing.StartSpan(spanCtx, "some-component", tracing.Attrs{"some": "attr"})
+
+			sc := tracing.SpanContextFromContext(ctx)
+			Expect(sc.TraceID.String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			Expect(sc.SpanID).To(Equal(span.Context.SpanID))
+			Expect(span.ParentID.String()).To(Equal("00f067aa0ba902b7"))
+			Expect(span.Attrs).To(Equal(tracing.Attrs{"some": "attr"}))
+
+			tracing.End(span, nil)
+			Expect(exporter.Spans()).To(Equal([]*tracing.Span{span}))
+		})
+
+		It("records the error the span ended with", func() {
+			_, span := tracing.StartSpan(spanCtx, "some-component", nil)
+			tracing.End(span, errors.New("nope"))
+
+			Expect(span.Error).To(Equal("nope"))
+		})
+
+		It("records the status of a request, failing the span on a server error", func() {
+			_, span := tracing.StartSpan(spanCtx, "some-component", nil)
+			tracing.EndRequest(span, http.StatusBadGateway)
+
+			Expect(span.StatusCode).To(Equal(http.StatusBadGateway))
+			Expect(span.Error).To(Equal("Bad Gateway"))
+		})
+
+		Context("when the context has no span", func() {
+			It("starts a new trace", func() {
+				ctx, span := tracing.StartSpan(context.Background(), "some-component", nil)
+				defer tracing.End(span, nil)
+
+				Expect(tracing.SpanContextFromContext(ctx).IsValid()).To(BeTrue())
+				Expect(span.ParentID).To(BeZero())
+			})
+		})
+
+		Context("when the trace is not sampled", func() {
+			It("does not export the span", func() {
+				sc, ok := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+				Expect(ok).To(BeTrue())
+
+				_, span := tracing.StartSpan(tracing.ContextWithSpanContext(context.Background(), sc), "some-component", nil)
+				tracing.End(span, nil)
+
+				Expect(exporter.Spans()).To(BeEmpty())
+			})
+		})
+
+		Context("when no exporter is installed", func() {
+			BeforeEach(func() {
+				tracing.SetExporter(nil)
+			})
+
+			It("passes on the span in the context", func() {
+				ctx, span := tracing.StartSpan(spanCtx, "some-component", nil)
+				defer tracing.End(span, nil)
+
+				Expect(ctx).To(Equal(spanCtx))
+			})
+
+			It("does not start a trace", func() {
+				ctx, span := tracing.StartSpan(context.Background(), "some-component", nil)
+				defer tracing.End(span, nil)
+
+				Expect(tracing.SpanContextFromContext(ctx).IsValid()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("registering builds and steps", func() {
+		AfterEach(func() {
+			tracing.ForgetBuild(1)
+			tracing.ForgetStep(1, "some-plan")
+		})
+
+		Context("when the build is registered", func() {
+			BeforeEach(func() {
+				tracing.RegisterBuild(1, spanCtx)
+			})
+
+			It("returns the build's context", func() {
+				Expect(tracing.BuildContext(1)).To(Equal(spanCtx))
+			})
+
+			It("returns the build's context for its unregistered steps", func() {
+				Expect(tracing.StepContext(1, "some-plan")).To(Equal(spanCtx))
+			})
+
+			Context("when the build is forgotten", func() {
+				BeforeEach(func() {
+					tracing.ForgetBuild(1)
+				})
+
+				It("returns a background context", func() {
+					Expect(tracing.BuildContext(1)).To(Equal(context.Background()))
+				})
+			})
+		})
+
+		Context("when the step is registered", func() {
+			var stepCtx context.Context
+
+			BeforeEach(func() {
+				stepCtx = context.WithValue(spanCtx, "some-key", "some-value")
+
+				tracing.RegisterBuild(1, spanCtx)
+				tracing.RegisterStep(1, "some-plan", stepCtx)
+			})
+
+			It("returns the step's context", func() {
+				Expect(tracing.StepContext(1, "some-plan")).To(Equal(stepCtx))
+			})
+		})
+
+		Context("when nothing is registered", func() {
+			It("returns a background context", func() {
+				Expect(tracing.BuildContext(1)).To(Equal(context.Background()))
+				Expect(tracing.StepContext(1, "some-plan")).To(Equal(context.Background()))
+			})
+		})
+	})
+})
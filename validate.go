@@ -6,6 +6,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/robfig/cron"
 )
 
 func formatErr(groupName string, err error) string {
@@ -224,6 +226,13 @@ func validateJobs(c Config) ([]Warning, error) {
 			errorMessages = append(errorMessages, identifier+" has no name")
 		}
 
+		if strings.Contains(job.Name, PassedJobSeparator) {
+			errorMessages = append(
+				errorMessages,
+				identifier+fmt.Sprintf(" has a name containing '%s', which refers to a job of another pipeline in passed constraints", PassedJobSeparator),
+			)
+		}
+
 		if job.BuildLogsToRetain < 0 {
 			errorMessages = append(
 				errorMessages,
@@ -231,6 +240,82 @@ func validateJobs(c Config) ([]Warning, error) {
 			)
 		}
 
+		if job.Approval != nil && job.Approval.Timeout != "" {
+			duration, err := time.ParseDuration(job.Approval.Timeout)
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(".approval.timeout refers to a duration that could not be parsed ('%s')", job.Approval.Timeout),
+				)
+			} else if duration < 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(".approval.timeout must not be negative ('%s')", job.Approval.Timeout),
+				)
+			}
+		}
+
+		if job.HoldContainersOnFailure != "" {
+			duration, err := time.ParseDuration(job.HoldContainersOnFailure)
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(".hold_containers_on_failure refers to a duration that could not be parsed ('%s')", job.HoldContainersOnFailure),
+				)
+			} else if duration < 0 || duration > MaxContainerHold {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(".hold_containers_on_failure must be between 0 and %s ('%s')", MaxContainerHold, job.HoldContainersOnFailure),
+				)
+			}
+		}
+
+		if job.Schedule != nil {
+			if job.Schedule.Cron == "" {
+				errorMessages = append(errorMessages, identifier+".schedule has no cron expression")
+			} else if _, err := cron.ParseStandard(job.Schedule.Cron); err != nil {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(".schedule.cron could not be parsed ('%s'): %s", job.Schedule.Cron, err),
+				)
+			}
+
+			if job.Schedule.Location != "" {
+				if _, err := time.LoadLocation(job.Schedule.Location); err != nil {
+					errorMessages = append(
+						errorMessages,
+						identifier+fmt.Sprintf(".schedule.location is not a known time zone ('%s')", job.Schedule.Location),
+					)
+				}
+			}
+		}
+
+		for i, triggerOn := range job.TriggerOn {
+			triggerOnIdentifier := fmt.Sprintf("%s.trigger_on[%d]", identifier, i)
+
+			if triggerOn.Job == "" {
+				errorMessages = append(errorMessages, triggerOnIdentifier+" has no job")
+			} else if triggerOn.Job == job.Name {
+				errorMessages = append(errorMessages, triggerOnIdentifier+" references the job itself")
+			} else if _, found := c.Jobs.Lookup(triggerOn.Job); !found {
+				errorMessages = append(
+					errorMessages,
+					triggerOnIdentifier+fmt.Sprintf(" references an unknown job ('%s')", triggerOn.Job),
+				)
+			}
+
+			for _, status := range triggerOn.Statuses {
+				switch status {
+				case StatusSucceeded, StatusFailed, StatusErrored, StatusAborted:
+				default:
+					errorMessages = append(
+						errorMessages,
+						triggerOnIdentifier+fmt.Sprintf(" has a status which builds do not finish with ('%s')", status),
+					)
+				}
+			}
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -248,9 +333,74 @@ func validateJobs(c Config) ([]Warning, error) {
 		}
 	}
 
+	errorMessages = append(errorMessages, validateTriggerOnCycles(c)...)
+
 	return warnings, compositeErr(errorMessages)
 }
 
+// validateTriggerOnCycles rejects jobs which end up triggering themselves
+// through the trigger_on of other jobs, as they would keep triggering each
+// other forever. Each cycle is reported once, as the jobs which are triggered
+// on the next one. References to the job itself or to unknown jobs are
+// reported separately.
+func validateTriggerOnCycles(c Config) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	errorMessages := []string{}
+
+	state := map[string]int{}
+	path := []string{}
+
+	var visit func(job JobConfig)
+	visit = func(job JobConfig) {
+		state[job.Name] = visiting
+		path = append(path, job.Name)
+
+		for _, triggerOn := range job.TriggerOn {
+			if triggerOn.Job == job.Name {
+				continue
+			}
+
+			upstream, found := c.Jobs.Lookup(triggerOn.Job)
+			if !found {
+				continue
+			}
+
+			switch state[upstream.Name] {
+			case unvisited:
+				visit(upstream)
+			case visiting:
+				start := 0
+				for path[start] != upstream.Name {
+					start++
+				}
+
+				cycle := append(append([]string{}, path[start:]...), upstream.Name)
+
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("jobs.%s.trigger_on forms a cycle (%s)", upstream.Name, strings.Join(cycle, " <- ")),
+				)
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[job.Name] = visited
+	}
+
+	for _, job := range c.Jobs {
+		if state[job.Name] == unvisited {
+			visit(job)
+		}
+	}
+
+	return errorMessages
+}
+
 type foundTypes struct {
 	identifier string
 	found      map[string]bool
@@ -338,7 +488,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "cache_result", "artifacts"},
 			plan, identifier)...,
 		)
 
@@ -367,53 +517,14 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			}
 		}
 
-		for _, job := range plan.Passed {
-			jobConfig, found := c.Jobs.Lookup(job)
-			if !found {
-				errorMessages = append(
-					errorMessages,
-					fmt.Sprintf(
-						"%s.passed references an unknown job ('%s')",
-						identifier,
-						job,
-					),
-				)
-			} else {
-				foundResource := false
-
-				for _, input := range jobConfig.Inputs() {
-					if input.Resource == plan.ResourceName() {
-						foundResource = true
-						break
-					}
-				}
-
-				for _, output := range jobConfig.Outputs() {
-					if output.Resource == plan.ResourceName() {
-						foundResource = true
-						break
-					}
-				}
-
-				if !foundResource {
-					errorMessages = append(
-						errorMessages,
-						fmt.Sprintf(
-							"%s.passed references a job ('%s') which doesn't interact with the resource ('%s')",
-							identifier,
-							job,
-							plan.Get,
-						),
-					)
-				}
-			}
-		}
+		errorMessages = append(errorMessages, validatePassedJobs(c, plan, "passed", plan.Passed, identifier)...)
+		errorMessages = append(errorMessages, validatePassedJobs(c, plan, "passed_any", plan.PassedAny, identifier)...)
 
 	case plan.Put != "":
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "passed_any", "trigger", "privileged", "config", "file", "cache_result", "artifacts"},
 			plan, identifier)...,
 		)
 
@@ -461,10 +572,26 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "passed_any", "trigger"},
 			plan, identifier)...,
 		)
 
+		// outputs of task configs loaded from files are only known at runtime
+		if plan.TaskConfig != nil && plan.TaskConfigPath == "" {
+			for _, artifact := range plan.Artifacts {
+				if !taskHasOutput(*plan.TaskConfig, artifact) {
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s.artifacts references an output which the task does not have ('%s')",
+							identifier,
+							artifact,
+						),
+					)
+				}
+			}
+		}
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
@@ -509,6 +636,85 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 	return warnings, errorMessages
 }
 
+func validatePassedJobs(c Config, plan PlanConfig, field string, jobs []string, identifier string) []string {
+	errorMessages := []string{}
+
+	for _, job := range jobs {
+		if strings.Contains(job, PassedJobSeparator) {
+			// jobs of other pipelines are checked against the database when
+			// the config is saved
+			pipelineName, jobName := SplitPassedJob(job)
+			if pipelineName == "" || jobName == "" || strings.Contains(jobName, PassedJobSeparator) {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf(
+						"%s.%s references a job of another pipeline which is not of the form 'pipeline/job' ('%s')",
+						identifier,
+						field,
+						job,
+					),
+				)
+			}
+
+			continue
+		}
+
+		jobConfig, found := c.Jobs.Lookup(job)
+		if !found {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s.%s references an unknown job ('%s')",
+					identifier,
+					field,
+					job,
+				),
+			)
+		} else {
+			foundResource := false
+
+			for _, input := range jobConfig.Inputs() {
+				if input.Resource == plan.ResourceName() {
+					foundResource = true
+					break
+				}
+			}
+
+			for _, output := range jobConfig.Outputs() {
+				if output.Resource == plan.ResourceName() {
+					foundResource = true
+					break
+				}
+			}
+
+			if !foundResource {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf(
+						"%s.%s references a job ('%s') which doesn't interact with the resource ('%s')",
+						identifier,
+						field,
+						job,
+						plan.Get,
+					),
+				)
+			}
+		}
+	}
+
+	return errorMessages
+}
+
+func taskHasOutput(config TaskConfig, name string) bool {
+	for _, output := range config.Outputs {
+		if output.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -523,6 +729,10 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if len(plan.Passed) != 0 {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "passed_any":
+			if len(plan.PassedAny) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		case "trigger":
 			if plan.Trigger {
 				foundInapplicableFields = append(foundInapplicableFields, field)
@@ -539,6 +749,14 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "cache_result":
+			if plan.CacheResult {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "artifacts":
+			if len(plan.Artifacts) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
@@ -436,6 +436,19 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job's name contains the passed job separator", func() {
+			BeforeEach(func() {
+				job.Name = "some/job"
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some/job has a name containing '/', which refers to a job of another pipeline in passed constraints"))
+			})
+		})
+
 		Context("when a job has a negative build_logs_to_retain", func() {
 			BeforeEach(func() {
 				job.BuildLogsToRetain = -1
@@ -449,6 +462,188 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job's approval timeout is not a duration", func() {
+			BeforeEach(func() {
+				job.Approval = &ApprovalConfig{Timeout: "nope"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.approval.timeout refers to a duration that could not be parsed ('nope')"))
+			})
+		})
+
+		Context("when a job's approval timeout is a duration", func() {
+			BeforeEach(func() {
+				job.Approval = &ApprovalConfig{Approvers: []string{"release-managers"}, Timeout: "24h"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job holds containers on failure for too long", func() {
+			BeforeEach(func() {
+				job.HoldContainersOnFailure = "48h"
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.hold_containers_on_failure must be between 0 and 24h0m0s ('48h')"))
+			})
+		})
+
+		Context("when a job holds containers on failure", func() {
+			BeforeEach(func() {
+				job.HoldContainersOnFailure = "30m"
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job is triggered on builds of another job", func() {
+			BeforeEach(func() {
+				job.TriggerOn = []TriggerOnConfig{
+					{Job: "some-job", Statuses: []BuildStatus{StatusSucceeded, StatusFailed}},
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job is triggered on builds of an invalid job", func() {
+			BeforeEach(func() {
+				job.TriggerOn = []TriggerOnConfig{
+					{},
+					{Job: "some-other-job"},
+					{Job: "bogus-job"},
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.trigger_on[0] has no job"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.trigger_on[1] references the job itself"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.trigger_on[2] references an unknown job ('bogus-job')"))
+			})
+		})
+
+		Context("when jobs are triggered on builds of each other", func() {
+			BeforeEach(func() {
+				job.TriggerOn = []TriggerOnConfig{
+					{Job: "some-job"},
+				}
+				config.Jobs = append(config.Jobs, job)
+
+				config.Jobs[0].TriggerOn = []TriggerOnConfig{
+					{Job: "some-empty-job"},
+				}
+				config.Jobs[1].TriggerOn = []TriggerOnConfig{
+					{Job: "some-other-job"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.trigger_on forms a cycle (some-job <- some-empty-job <- some-other-job <- some-job)"))
+			})
+		})
+
+		Context("when jobs are triggered on builds of the same job", func() {
+			BeforeEach(func() {
+				job.TriggerOn = []TriggerOnConfig{
+					{Job: "some-job"},
+				}
+				config.Jobs = append(config.Jobs, job)
+
+				config.Jobs[1].TriggerOn = []TriggerOnConfig{
+					{Job: "some-job"},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job is triggered on a status builds do not finish with", func() {
+			BeforeEach(func() {
+				job.TriggerOn = []TriggerOnConfig{
+					{Job: "some-job", Statuses: []BuildStatus{StatusPending}},
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.trigger_on[0] has a status which builds do not finish with ('pending')"))
+			})
+		})
+
+		Context("when a job's schedule has no cron expression", func() {
+			BeforeEach(func() {
+				job.Schedule = &ScheduleConfig{Location: "Europe/Berlin"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule has no cron expression"))
+			})
+		})
+
+		Context("when a job's schedule has an invalid cron expression", func() {
+			BeforeEach(func() {
+				job.Schedule = &ScheduleConfig{Cron: "every tuesday"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule.cron could not be parsed ('every tuesday')"))
+			})
+		})
+
+		Context("when a job's schedule has an unknown location", func() {
+			BeforeEach(func() {
+				job.Schedule = &ScheduleConfig{Cron: "0 9 * * 1-5", Location: "Nowhere/Special"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule.location is not a known time zone ('Nowhere/Special')"))
+			})
+		})
+
+		Context("when a job has a valid schedule", func() {
+			BeforeEach(func() {
+				job.Schedule = &ScheduleConfig{Cron: "0 9 * * 1-5", Location: "Europe/Berlin"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.Plan = append(job.Plan, PlanConfig{
@@ -594,6 +789,7 @@ var _ = Describe("ValidateConfig", func() {
 						Get:            "lol",
 						Privileged:     true,
 						TaskConfigPath: "task.yml",
+						CacheResult:    true,
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -602,7 +798,7 @@ var _ = Describe("ValidateConfig", func() {
 				It("returns an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
 					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.lol has invalid fields specified (privileged, file)"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.lol has invalid fields specified (privileged, file, cache_result)"))
 				})
 			})
 
@@ -625,6 +821,64 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan keeps artifacts which its config has as outputs", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task: "lol",
+						TaskConfig: &TaskConfig{
+							Outputs: []TaskOutputConfig{
+								{Name: "some-output"},
+							},
+						},
+						Artifacts: []string{"some-output"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a task plan keeps artifacts which its config does not have as outputs", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task: "lol",
+						TaskConfig: &TaskConfig{
+							Outputs: []TaskOutputConfig{
+								{Name: "some-output"},
+							},
+						},
+						Artifacts: []string{"some-output", "bogus-output"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol.artifacts references an output which the task does not have ('bogus-output')"))
+				})
+			})
+
+			Context("when a put plan keeps artifacts", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:       "some-resource",
+						Artifacts: []string{"some-output"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource has invalid fields specified (artifacts)"))
+				})
+			})
+
 			Context("when a task plan has neither a config or a path set", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1114,6 +1368,71 @@ var _ = Describe("ValidateConfig", func() {
 					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job ('some-empty-job') which doesn't interact with the resource ('some-resource')"))
 				})
 			})
+
+			Context("when a job's input's passed constraints reference a job of another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/some-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a job of another pipeline incorrectly", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/", "/some-job", "a/b/c"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error for each of them", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job of another pipeline which is not of the form 'pipeline/job' ('other-pipeline/')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job of another pipeline which is not of the form 'pipeline/job' ('/some-job')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job of another pipeline which is not of the form 'pipeline/job' ('a/b/c')"))
+				})
+			})
+
+			Context("when a job's input's passed_any constraints reference a bogus job", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:       "some-resource",
+						PassedAny: []string{"some-job", "bogus-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed_any references an unknown job ('bogus-job')"))
+				})
+			})
+
+			Context("when a job's input's passed_any constraints reference a valid job that does not have the resource as an input or output", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:       "some-resource",
+						PassedAny: []string{"some-empty-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed_any references a job ('some-empty-job') which doesn't interact with the resource ('some-resource')"))
+				})
+			})
 		})
 
 		Context("when two jobs have the same name", func() {
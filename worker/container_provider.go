@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/baggageclaim"
 )
 
@@ -142,6 +144,29 @@ func (p *containerProvider) FindOrCreateContainer(
 	metadata db.ContainerMetadata,
 	spec ContainerSpec,
 	resourceTypes creds.VersionedResourceTypes,
+) (Container, error) {
+	ctx, span := tracing.StartSpan(tracing.BuildContext(metadata.BuildID), "worker.find-or-create-container", tracing.Attrs{
+		"worker":         p.worker.Name(),
+		"container-type": string(metadata.Type),
+		"step":           metadata.StepName,
+	})
+
+	container, err := p.findOrCreateContainer(ctx, logger, cancel, owner, delegate, metadata, spec, resourceTypes)
+
+	tracing.End(span, err)
+
+	return container, err
+}
+
+func (p *containerProvider) findOrCreateContainer(
+	ctx context.Context,
+	logger lager.Logger,
+	cancel <-chan os.Signal,
+	owner db.ContainerOwner,
+	delegate ImageFetchingDelegate,
+	metadata db.ContainerMetadata,
+	spec ContainerSpec,
+	resourceTypes creds.VersionedResourceTypes,
 ) (Container, error) {
 	for {
 		var gardenContainer garden.Container
@@ -236,7 +261,12 @@ func (p *containerProvider) FindOrCreateContainer(
 
 			logger.Debug("fetching-image")
 
+			_, fetchSpan := tracing.StartSpan(ctx, "worker.fetch-image", tracing.Attrs{
+				"container": creatingContainer.Handle(),
+			})
+
 			fetchedImage, err := image.FetchForContainer(logger, cancel, creatingContainer)
+			tracing.End(fetchSpan, err)
 			if err != nil {
 				///TODO : Creating Container - mark as errored in db
 				logger.Error("failed-to-fetch-image-for-container", err)
@@ -246,6 +276,7 @@ func (p *containerProvider) FindOrCreateContainer(
 			logger.Debug("creating-container-in-garden")
 
 			gardenContainer, err = p.createGardenContainer(
+				ctx,
 				logger,
 				creatingContainer,
 				spec,
@@ -353,6 +384,7 @@ func (p *containerProvider) constructGardenWorkerContainer(
 }
 
 func (p *containerProvider) createGardenContainer(
+	ctx context.Context,
 	logger lager.Logger,
 	creatingContainer db.CreatingContainer,
 	spec ContainerSpec,
@@ -438,7 +470,13 @@ func (p *containerProvider) createGardenContainer(
 				return nil, err
 			}
 
+			_, streamSpan := tracing.StartSpan(ctx, "worker.stream-input", tracing.Attrs{
+				"volume":      inputVolume.Handle(),
+				"destination": inputSource.DestinationPath(),
+			})
+
 			err = inputSource.Source().StreamTo(inputVolume)
+			tracing.End(streamSpan, err)
 			if err != nil {
 				return nil, err
 			}
@@ -248,7 +248,7 @@ func (i *imageResourceFetcher) getLatestVersion(
 		return nil, err
 	}
 
-	versions, err := checkingResource.Check(source, nil)
+	versions, err := checkingResource.Check(source, nil, nil)
 	if err != nil {
 		return nil, err
 	}
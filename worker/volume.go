@@ -27,6 +27,7 @@ type Volume interface {
 
 	InitializeResourceCache(*db.UsedResourceCache) error
 	InitializeTaskCache(lager.Logger, int, string, string, bool) error
+	InitializeArtifact(logger lager.Logger, buildID int, name string, privileged bool) error
 
 	CreateChildForContainer(db.CreatingContainer, string) (db.CreatingVolume, error)
 
@@ -127,6 +128,37 @@ func (v *volume) InitializeTaskCache(
 	return importVolume.InitializeTaskCache(logger, jobID, stepName, path, privileged)
 }
 
+func (v *volume) InitializeArtifact(
+	logger lager.Logger,
+	buildID int,
+	name string,
+	privileged bool,
+) error {
+	if v.dbVolume.ParentHandle() == "" {
+		return v.dbVolume.InitializeArtifact(buildID, name)
+	}
+
+	// keeping a copy-on-write volume would keep its parent and the rest of the
+	// container's volumes from being collected
+	logger.Debug("creating-an-import-volume", lager.Data{"path": v.bcVolume.Path()})
+
+	importVolume, err := v.volumeClient.CreateVolumeForArtifact(
+		logger,
+		VolumeSpec{
+			Strategy:   baggageclaim.ImportStrategy{Path: v.bcVolume.Path()},
+			Privileged: privileged,
+		},
+		v.dbVolume.TeamID(),
+		buildID,
+		name,
+	)
+	if err != nil {
+		return err
+	}
+
+	return importVolume.InitializeArtifact(logger, buildID, name, privileged)
+}
+
 func (v *volume) CreateChildForContainer(creatingContainer db.CreatingContainer, mountPath string) (db.CreatingVolume, error) {
 	return v.dbVolume.CreateChildForContainer(creatingContainer, mountPath)
 }
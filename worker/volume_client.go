@@ -58,6 +58,13 @@ type VolumeClient interface {
 		stepName string,
 		path string,
 	) (Volume, error)
+	CreateVolumeForArtifact(
+		logger lager.Logger,
+		volumeSpec VolumeSpec,
+		teamID int,
+		buildID int,
+		name string,
+	) (Volume, error)
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
 }
 
@@ -222,6 +229,25 @@ func (c *volumeClient) CreateVolumeForTaskCache(
 	)
 }
 
+func (c *volumeClient) CreateVolumeForArtifact(
+	logger lager.Logger,
+	volumeSpec VolumeSpec,
+	teamID int,
+	buildID int,
+	name string,
+) (Volume, error) {
+	return c.findOrCreateVolume(
+		logger.Session("create-volume-for-artifact"),
+		volumeSpec,
+		func() (db.CreatingVolume, db.CreatedVolume, error) {
+			return nil, nil, nil
+		},
+		func() (db.CreatingVolume, error) {
+			return c.dbVolumeFactory.CreateArtifactVolume(teamID, c.dbWorker.Name(), buildID, name)
+		},
+	)
+}
+
 func (c *volumeClient) FindVolumeForTaskCache(
 	logger lager.Logger,
 	teamID int,
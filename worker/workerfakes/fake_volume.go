@@ -124,6 +124,20 @@ type FakeVolume struct {
 	initializeTaskCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InitializeArtifactStub        func(logger lager.Logger, buildID int, name string, privileged bool) error
+	initializeArtifactMutex       sync.RWMutex
+	initializeArtifactArgsForCall []struct {
+		logger     lager.Logger
+		buildID    int
+		name       string
+		privileged bool
+	}
+	initializeArtifactReturns struct {
+		result1 error
+	}
+	initializeArtifactReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateChildForContainerStub        func(db.CreatingContainer, string) (db.CreatingVolume, error)
 	createChildForContainerMutex       sync.RWMutex
 	createChildForContainerArgsForCall []struct {
@@ -611,6 +625,57 @@ func (fake *FakeVolume) InitializeTaskCacheReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVolume) InitializeArtifact(logger lager.Logger, buildID int, name string, privileged bool) error {
+	fake.initializeArtifactMutex.Lock()
+	ret, specificReturn := fake.initializeArtifactReturnsOnCall[len(fake.initializeArtifactArgsForCall)]
+	fake.initializeArtifactArgsForCall = append(fake.initializeArtifactArgsForCall, struct {
+		logger     lager.Logger
+		buildID    int
+		name       string
+		privileged bool
+	}{logger, buildID, name, privileged})
+	fake.recordInvocation("InitializeArtifact", []interface{}{logger, buildID, name, privileged})
+	fake.initializeArtifactMutex.Unlock()
+	if fake.InitializeArtifactStub != nil {
+		return fake.InitializeArtifactStub(logger, buildID, name, privileged)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.initializeArtifactReturns.result1
+}
+
+func (fake *FakeVolume) InitializeArtifactCallCount() int {
+	fake.initializeArtifactMutex.RLock()
+	defer fake.initializeArtifactMutex.RUnlock()
+	return len(fake.initializeArtifactArgsForCall)
+}
+
+func (fake *FakeVolume) InitializeArtifactArgsForCall(i int) (lager.Logger, int, string, bool) {
+	fake.initializeArtifactMutex.RLock()
+	defer fake.initializeArtifactMutex.RUnlock()
+	return fake.initializeArtifactArgsForCall[i].logger, fake.initializeArtifactArgsForCall[i].buildID, fake.initializeArtifactArgsForCall[i].name, fake.initializeArtifactArgsForCall[i].privileged
+}
+
+func (fake *FakeVolume) InitializeArtifactReturns(result1 error) {
+	fake.InitializeArtifactStub = nil
+	fake.initializeArtifactReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) InitializeArtifactReturnsOnCall(i int, result1 error) {
+	fake.InitializeArtifactStub = nil
+	if fake.initializeArtifactReturnsOnCall == nil {
+		fake.initializeArtifactReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initializeArtifactReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolume) CreateChildForContainer(arg1 db.CreatingContainer, arg2 string) (db.CreatingVolume, error) {
 	fake.createChildForContainerMutex.Lock()
 	ret, specificReturn := fake.createChildForContainerReturnsOnCall[len(fake.createChildForContainerArgsForCall)]
@@ -726,6 +791,8 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.initializeResourceCacheMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
+	fake.initializeArtifactMutex.RLock()
+	defer fake.initializeArtifactMutex.RUnlock()
 	fake.createChildForContainerMutex.RLock()
 	defer fake.createChildForContainerMutex.RUnlock()
 	fake.destroyMutex.RLock()
@@ -114,6 +114,23 @@ type FakeVolumeClient struct {
 		result1 worker.Volume
 		result2 error
 	}
+	CreateVolumeForArtifactStub        func(logger lager.Logger, volumeSpec worker.VolumeSpec, teamID int, buildID int, name string) (worker.Volume, error)
+	createVolumeForArtifactMutex       sync.RWMutex
+	createVolumeForArtifactArgsForCall []struct {
+		logger     lager.Logger
+		volumeSpec worker.VolumeSpec
+		teamID     int
+		buildID    int
+		name       string
+	}
+	createVolumeForArtifactReturns struct {
+		result1 worker.Volume
+		result2 error
+	}
+	createVolumeForArtifactReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 error
+	}
 	LookupVolumeStub        func(lager.Logger, string) (worker.Volume, bool, error)
 	lookupVolumeMutex       sync.RWMutex
 	lookupVolumeArgsForCall []struct {
@@ -468,6 +485,61 @@ func (fake *FakeVolumeClient) CreateVolumeForTaskCacheReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeClient) CreateVolumeForArtifact(logger lager.Logger, volumeSpec worker.VolumeSpec, teamID int, buildID int, name string) (worker.Volume, error) {
+	fake.createVolumeForArtifactMutex.Lock()
+	ret, specificReturn := fake.createVolumeForArtifactReturnsOnCall[len(fake.createVolumeForArtifactArgsForCall)]
+	fake.createVolumeForArtifactArgsForCall = append(fake.createVolumeForArtifactArgsForCall, struct {
+		logger     lager.Logger
+		volumeSpec worker.VolumeSpec
+		teamID     int
+		buildID    int
+		name       string
+	}{logger, volumeSpec, teamID, buildID, name})
+	fake.recordInvocation("CreateVolumeForArtifact", []interface{}{logger, volumeSpec, teamID, buildID, name})
+	fake.createVolumeForArtifactMutex.Unlock()
+	if fake.CreateVolumeForArtifactStub != nil {
+		return fake.CreateVolumeForArtifactStub(logger, volumeSpec, teamID, buildID, name)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createVolumeForArtifactReturns.result1, fake.createVolumeForArtifactReturns.result2
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForArtifactCallCount() int {
+	fake.createVolumeForArtifactMutex.RLock()
+	defer fake.createVolumeForArtifactMutex.RUnlock()
+	return len(fake.createVolumeForArtifactArgsForCall)
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForArtifactArgsForCall(i int) (lager.Logger, worker.VolumeSpec, int, int, string) {
+	fake.createVolumeForArtifactMutex.RLock()
+	defer fake.createVolumeForArtifactMutex.RUnlock()
+	return fake.createVolumeForArtifactArgsForCall[i].logger, fake.createVolumeForArtifactArgsForCall[i].volumeSpec, fake.createVolumeForArtifactArgsForCall[i].teamID, fake.createVolumeForArtifactArgsForCall[i].buildID, fake.createVolumeForArtifactArgsForCall[i].name
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForArtifactReturns(result1 worker.Volume, result2 error) {
+	fake.CreateVolumeForArtifactStub = nil
+	fake.createVolumeForArtifactReturns = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForArtifactReturnsOnCall(i int, result1 worker.Volume, result2 error) {
+	fake.CreateVolumeForArtifactStub = nil
+	if fake.createVolumeForArtifactReturnsOnCall == nil {
+		fake.createVolumeForArtifactReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 error
+		})
+	}
+	fake.createVolumeForArtifactReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeClient) LookupVolume(arg1 lager.Logger, arg2 string) (worker.Volume, bool, error) {
 	fake.lookupVolumeMutex.Lock()
 	ret, specificReturn := fake.lookupVolumeReturnsOnCall[len(fake.lookupVolumeArgsForCall)]
@@ -538,6 +610,8 @@ func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	defer fake.findVolumeForTaskCacheMutex.RUnlock()
 	fake.createVolumeForTaskCacheMutex.RLock()
 	defer fake.createVolumeForTaskCacheMutex.RUnlock()
+	fake.createVolumeForArtifactMutex.RLock()
+	defer fake.createVolumeForArtifactMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -64,11 +64,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 		// pipeline and job are public or authorized
 		case atc.GetBuildPreparation,
-			atc.BuildEvents:
+			atc.BuildEvents,
+			atc.ListBuildArtifacts,
+			atc.GetBuildArtifact,
+			atc.ListBuildTestReports:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 		// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.HoldBuildContainers:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -84,6 +88,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListJobs,
 			atc.GetJob,
 			atc.ListJobBuilds,
+			atc.GetJobTestHistory,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
@@ -105,6 +110,9 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DeleteWorker,
 			atc.SetTeam,
 			atc.DestroyTeam,
+			atc.GetTeamQuota,
+			atc.ApproveBuild,
+			atc.RejectBuild,
 			atc.WritePipe,
 			atc.ListVolumes,
 			atc.GetUser:
@@ -117,14 +125,18 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		// authorized (requested team matches resource team)
 		case atc.CheckResource,
 			atc.CreateJobBuild,
+			atc.RerunJobBuild,
 			atc.CreatePipelineBuild,
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
+			atc.ExplainJob,
 			atc.GetConfig,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.ListResourceChecks,
 			atc.OrderPipelines,
+			atc.SearchBuildLogs,
 			atc.PauseJob,
 			atc.PausePipeline,
 			atc.PauseResource,
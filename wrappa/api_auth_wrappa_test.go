@@ -224,11 +224,15 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetBuildPlan:   doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
 
 				// authorized or public pipeline and public job
-				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
-				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.BuildEvents:          checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
+				atc.GetBuildPreparation:  checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.ListBuildArtifacts:   checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
+				atc.GetBuildArtifact:     checksIfPrivateJob(inputHandlers[atc.GetBuildArtifact]),
+				atc.ListBuildTestReports: checksIfPrivateJob(inputHandlers[atc.ListBuildTestReports]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild:          checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.HoldBuildContainers: checkWritePermissionForBuild(inputHandlers[atc.HoldBuildContainers]),
 
 				// resource belongs to authorized team
 				atc.PruneWorker:  checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
@@ -242,6 +246,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
 				atc.GetJob:                        openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJob]),
 				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobBuilds]),
+				atc.GetJobTestHistory:             openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobTestHistory]),
 				atc.GetResource:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResource]),
 				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
 				atc.ListBuildsWithVersionAsOutput: openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsOutput]),
@@ -262,10 +267,13 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.HeartbeatWorker: authenticated(inputHandlers[atc.HeartbeatWorker]),
 				atc.DeleteWorker:    authenticated(inputHandlers[atc.DeleteWorker]),
 
-				atc.SetTeam:     authenticated(inputHandlers[atc.SetTeam]),
-				atc.DestroyTeam: authenticated(inputHandlers[atc.DestroyTeam]),
-				atc.WritePipe:   authenticated(inputHandlers[atc.WritePipe]),
-				atc.GetUser:     authenticated(inputHandlers[atc.GetUser]),
+				atc.SetTeam:      authenticated(inputHandlers[atc.SetTeam]),
+				atc.DestroyTeam:  authenticated(inputHandlers[atc.DestroyTeam]),
+				atc.GetTeamQuota: authenticated(inputHandlers[atc.GetTeamQuota]),
+				atc.ApproveBuild: authenticated(inputHandlers[atc.ApproveBuild]),
+				atc.RejectBuild:  authenticated(inputHandlers[atc.RejectBuild]),
+				atc.WritePipe:    authenticated(inputHandlers[atc.WritePipe]),
+				atc.GetUser:      authenticated(inputHandlers[atc.GetUser]),
 
 				// authenticated and is admin
 				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
@@ -274,13 +282,17 @@ var _ = Describe("APIAuthWrappa", func() {
 				// authorized (requested team matches resource team)
 				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
 				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
+				atc.RerunJobBuild:          authorized(inputHandlers[atc.RerunJobBuild]),
 				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
 				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
 				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
 				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
 				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
+				atc.ExplainJob:             authorized(inputHandlers[atc.ExplainJob]),
+				atc.ListResourceChecks:     authorized(inputHandlers[atc.ListResourceChecks]),
 				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
+				atc.SearchBuildLogs:        authorized(inputHandlers[atc.SearchBuildLogs]),
 				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
 				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
 				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
@@ -0,0 +1,31 @@
+package wrappa
+
+import (
+	"github.com/concourse/atc"
+	"github.com/tedsuo/rata"
+)
+
+type APITracingWrappa struct{}
+
+func NewAPITracingWrappa() Wrappa {
+	return APITracingWrappa{}
+}
+
+func (wrappa APITracingWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		switch name {
+		case atc.BuildEvents, atc.WritePipe, atc.ReadPipe, atc.DownloadCLI,
+			atc.HijackContainer:
+			wrapped[name] = handler
+		default:
+			wrapped[name] = TracingHandler{
+				Route:   name,
+				Handler: handler,
+			}
+		}
+	}
+
+	return wrapped
+}
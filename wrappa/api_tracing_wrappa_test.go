@@ -0,0 +1,46 @@
+package wrappa_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APITracingWrappa", func() {
+	Describe("Wrap", func() {
+		var (
+			inputHandlers   rata.Handlers
+			wrappedHandlers rata.Handlers
+		)
+
+		BeforeEach(func() {
+			inputHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				inputHandlers[route.Name] = &stupidHandler{}
+			}
+		})
+
+		JustBeforeEach(func() {
+			wrappedHandlers = wrappa.NewAPITracingWrappa().Wrap(inputHandlers)
+		})
+
+		It("wraps every handler except those which stream with a tracing handler", func() {
+			for name, handler := range inputHandlers {
+				switch name {
+				case atc.BuildEvents, atc.WritePipe, atc.ReadPipe, atc.DownloadCLI,
+					atc.HijackContainer:
+					Expect(wrappedHandlers[name]).To(Equal(handler))
+				default:
+					Expect(wrappedHandlers[name]).To(Equal(wrappa.TracingHandler{
+						Route:   name,
+						Handler: handler,
+					}))
+				}
+			}
+		})
+	})
+})
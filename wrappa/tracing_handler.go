@@ -0,0 +1,47 @@
+package wrappa
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/tracing"
+)
+
+type TracingHandler struct {
+	Route   string
+	Handler http.Handler
+}
+
+func (handler TracingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(tracing.RequestContext(r), "api."+handler.Route, tracing.Attrs{
+		"http.method": r.Method,
+		"http.target": r.URL.Path,
+	})
+
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	defer func() { tracing.EndRequest(span, recorder.status) }()
+
+	handler.Handler.ServeHTTP(recorder, r.WithContext(ctx))
+}
+
+// statusRecorder captures the status code written by a handler. Streaming
+// routes, which would need the writer's other interfaces, are not traced.
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	if !recorder.wroteHeader {
+		recorder.status = status
+		recorder.wroteHeader = true
+	}
+
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Write(b []byte) (int, error) {
+	recorder.wroteHeader = true
+	return recorder.ResponseWriter.Write(b)
+}
@@ -0,0 +1,152 @@
+package wrappa_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/concourse/atc/tracing"
+	"github.com/concourse/atc/wrappa"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordingExporter struct {
+	spans []*tracing.Span
+	lock  sync.Mutex
+}
+
+func (exporter *recordingExporter) ExportSpan(span *tracing.Span) {
+	exporter.lock.Lock()
+	exporter.spans = append(exporter.spans, span)
+	exporter.lock.Unlock()
+}
+
+func (exporter *recordingExporter) Spans() []*tracing.Span {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+
+	return append([]*tracing.Span{}, exporter.spans...)
+}
+
+var _ = Describe("TracingHandler", func() {
+	var (
+		server *httptest.Server
+		client *http.Client
+
+		request  *http.Request
+		response *http.Response
+
+		handledEnv    []string
+		handlerStatus int
+	)
+
+	BeforeEach(func() {
+		handledEnv = nil
+		handlerStatus = 0
+
+		server = httptest.NewServer(wrappa.TracingHandler{
+			Route: "some-route",
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handledEnv = tracing.Env(r.Context())
+
+				if handlerStatus != 0 {
+					w.WriteHeader(handlerStatus)
+				}
+			}),
+		})
+
+		client = &http.Client{}
+
+		var err error
+		request, err = http.NewRequest("GET", server.URL, nil)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		response, err = client.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when the request propagates a trace context", func() {
+		BeforeEach(func() {
+			request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		})
+
+		It("handles the request as part of the trace", func() {
+			Expect(handledEnv).To(HaveLen(1))
+			Expect(handledEnv[0]).To(HavePrefix("TRACEPARENT=00-4bf92f3577b34da6a3ce929d0e0e4736-"))
+		})
+	})
+
+	Context("when the request does not propagate a trace context", func() {
+		It("handles the request", func() {
+			Expect(handledEnv).To(BeEmpty())
+		})
+	})
+
+	Describe("recording the response", func() {
+		var (
+			exporter         *recordingExporter
+			previousExporter tracing.Exporter
+		)
+
+		BeforeEach(func() {
+			exporter = &recordingExporter{}
+			previousExporter = tracing.SetExporter(exporter)
+		})
+
+		AfterEach(func() {
+			tracing.SetExporter(previousExporter)
+		})
+
+		// the span ends after the response has been sent
+		endedSpan := func() *tracing.Span {
+			Eventually(exporter.Spans).Should(HaveLen(1))
+			return exporter.Spans()[0]
+		}
+
+		It("names the span after the route", func() {
+			Expect(endedSpan().Name).To(Equal("api.some-route"))
+		})
+
+		Context("when the handler does not write a status", func() {
+			It("records the implicit 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(endedSpan().StatusCode).To(Equal(http.StatusOK))
+				Expect(endedSpan().Error).To(BeEmpty())
+			})
+		})
+
+		Context("when the handler responds with a client error", func() {
+			BeforeEach(func() {
+				handlerStatus = http.StatusNotFound
+			})
+
+			It("records the status without failing the span", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				Expect(endedSpan().StatusCode).To(Equal(http.StatusNotFound))
+				Expect(endedSpan().Error).To(BeEmpty())
+			})
+		})
+
+		Context("when the handler responds with a server error", func() {
+			BeforeEach(func() {
+				handlerStatus = http.StatusInternalServerError
+			})
+
+			It("records the status and fails the span", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				Expect(endedSpan().StatusCode).To(Equal(http.StatusInternalServerError))
+				Expect(endedSpan().Error).To(Equal("Internal Server Error"))
+			})
+		})
+	})
+})